@@ -92,19 +92,38 @@ type CurrencyPairFormatConfig struct {
 	Index     string `json:"index,omitempty"`
 }
 
+// HTTPRateLimitConfig overrides an exchanges default HTTP request rate limits,
+// allowing the given number of requests per duration
+type HTTPRateLimitConfig struct {
+	Duration   time.Duration `json:"duration"`
+	AuthRate   int           `json:"authRate"`
+	UnauthRate int           `json:"unauthRate"`
+}
+
+// HTTPRequestTimeoutsConfig overrides the HTTP client timeout for individual
+// request types, a zero duration keeps the client timeout
+type HTTPRequestTimeoutsConfig struct {
+	Trade      time.Duration `json:"trade,omitempty"`
+	MarketData time.Duration `json:"marketData,omitempty"`
+	Account    time.Duration `json:"account,omitempty"`
+	History    time.Duration `json:"history,omitempty"`
+}
+
 // Config is the overarching object that holds all the information for
 // prestart management of Portfolio, Communications, Webserver and Enabled
 // Exchanges
 type Config struct {
-	Name              string               `json:"name"`
-	EncryptConfig     int                  `json:"encryptConfig"`
-	GlobalHTTPTimeout time.Duration        `json:"globalHTTPTimeout"`
-	Currency          CurrencyConfig       `json:"currencyConfig"`
-	Communications    CommunicationsConfig `json:"communications"`
-	Portfolio         portfolio.Base       `json:"portfolioAddresses"`
-	Webserver         WebserverConfig      `json:"webserver"`
-	Exchanges         []ExchangeConfig     `json:"exchanges"`
-	BankAccounts      []BankAccount        `json:"bankAccounts"`
+	Name               string               `json:"name"`
+	Version            int                  `json:"version"`
+	EncryptConfig      int                  `json:"encryptConfig"`
+	EncryptCredentials bool                 `json:"encryptCredentials,omitempty"`
+	GlobalHTTPTimeout  time.Duration        `json:"globalHTTPTimeout"`
+	Currency           CurrencyConfig       `json:"currencyConfig"`
+	Communications     CommunicationsConfig `json:"communications"`
+	Portfolio          portfolio.Base       `json:"portfolioAddresses"`
+	Webserver          WebserverConfig      `json:"webserver"`
+	Exchanges          []ExchangeConfig     `json:"exchanges"`
+	BankAccounts       []BankAccount        `json:"bankAccounts"`
 
 	// Deprecated config settings, will be removed at a future date
 	CurrencyPairFormat  *CurrencyPairFormatConfig `json:"currencyPairFormat,omitempty"`
@@ -115,33 +134,43 @@ type Config struct {
 
 // ExchangeConfig holds all the information needed for each enabled Exchange.
 type ExchangeConfig struct {
-	Name                      string                    `json:"name"`
-	Enabled                   bool                      `json:"enabled"`
-	Verbose                   bool                      `json:"verbose"`
-	Websocket                 bool                      `json:"websocket"`
-	UseSandbox                bool                      `json:"useSandbox"`
-	RESTPollingDelay          time.Duration             `json:"restPollingDelay"`
-	HTTPTimeout               time.Duration             `json:"httpTimeout"`
-	HTTPUserAgent             string                    `json:"httpUserAgent"`
-	AuthenticatedAPISupport   bool                      `json:"authenticatedApiSupport"`
-	APIKey                    string                    `json:"apiKey"`
-	APISecret                 string                    `json:"apiSecret"`
-	APIAuthPEMKeySupport      bool                      `json:"apiAuthPemKeySupport,omitempty"`
-	APIAuthPEMKey             string                    `json:"apiAuthPemKey,omitempty"`
-	APIURL                    string                    `json:"apiUrl"`
-	APIURLSecondary           string                    `json:"apiUrlSecondary"`
-	ProxyAddress              string                    `json:"proxyAddress"`
-	WebsocketURL              string                    `json:"websocketUrl"`
-	ClientID                  string                    `json:"clientId,omitempty"`
-	AvailablePairs            string                    `json:"availablePairs"`
-	EnabledPairs              string                    `json:"enabledPairs"`
-	BaseCurrencies            string                    `json:"baseCurrencies"`
-	AssetTypes                string                    `json:"assetTypes"`
-	SupportsAutoPairUpdates   bool                      `json:"supportsAutoPairUpdates"`
-	PairsLastUpdated          int64                     `json:"pairsLastUpdated,omitempty"`
-	ConfigCurrencyPairFormat  *CurrencyPairFormatConfig `json:"configCurrencyPairFormat"`
-	RequestCurrencyPairFormat *CurrencyPairFormatConfig `json:"requestCurrencyPairFormat"`
-	BankAccounts              []BankAccount             `json:"bankAccounts"`
+	Name                          string                     `json:"name"`
+	Enabled                       bool                       `json:"enabled"`
+	Verbose                       bool                       `json:"verbose"`
+	Websocket                     bool                       `json:"websocket"`
+	UseSandbox                    bool                       `json:"useSandbox"`
+	RESTPollingDelay              time.Duration              `json:"restPollingDelay"`
+	HTTPTimeout                   time.Duration              `json:"httpTimeout"`
+	HTTPUserAgent                 string                     `json:"httpUserAgent"`
+	HTTPRateLimiter               *HTTPRateLimitConfig       `json:"httpRateLimiter,omitempty"`
+	HTTPRequestTimeouts           *HTTPRequestTimeoutsConfig `json:"httpRequestTimeouts,omitempty"`
+	AuthenticatedAPISupport       bool                       `json:"authenticatedApiSupport"`
+	APIKey                        string                     `json:"apiKey"`
+	APISecret                     string                     `json:"apiSecret"`
+	APIAuthPEMKeySupport          bool                       `json:"apiAuthPemKeySupport,omitempty"`
+	APIAuthPEMKey                 string                     `json:"apiAuthPemKey,omitempty"`
+	APIURL                        string                     `json:"apiUrl"`
+	APIURLSecondary               string                     `json:"apiUrlSecondary"`
+	ProxyAddress                  string                     `json:"proxyAddress"`
+	WebsocketURL                  string                     `json:"websocketUrl"`
+	WebsocketOrderbookDepth       int                        `json:"websocketOrderbookDepth,omitempty"`
+	OrderbookMaxStoredLevels      int                        `json:"orderbookMaxStoredLevels,omitempty"`
+	WebsocketPersistSubscriptions bool                       `json:"websocketPersistSubscriptions,omitempty"`
+	WebsocketSubscriptions        []string                   `json:"websocketSubscriptions,omitempty"`
+	ClientID                      string                     `json:"clientId,omitempty"`
+	APITradePassword              string                     `json:"apiTradePassword,omitempty"`
+	AvailablePairs                string                     `json:"availablePairs"`
+	EnabledPairs                  string                     `json:"enabledPairs"`
+	BaseCurrencies                string                     `json:"baseCurrencies"`
+	AssetTypes                    string                     `json:"assetTypes"`
+	DefaultAssetType              string                     `json:"defaultAssetType,omitempty"`
+	PriceSources                  []string                   `json:"priceSources,omitempty"`
+	PairsBlacklist                []string                   `json:"pairsBlacklist,omitempty"`
+	SupportsAutoPairUpdates       bool                       `json:"supportsAutoPairUpdates"`
+	PairsLastUpdated              int64                      `json:"pairsLastUpdated,omitempty"`
+	ConfigCurrencyPairFormat      *CurrencyPairFormatConfig  `json:"configCurrencyPairFormat"`
+	RequestCurrencyPairFormat     *CurrencyPairFormatConfig  `json:"requestCurrencyPairFormat"`
+	BankAccounts                  []BankAccount              `json:"bankAccounts"`
 }
 
 // BankAccount holds differing bank account details by supported funding
@@ -499,10 +528,16 @@ func (c *Config) CheckPairConsistency(exchName string) error {
 		return err
 	}
 
+	exchCfg, err := c.GetExchangeConfig(exchName)
+	if err != nil {
+		return err
+	}
+
 	var pairs, pairsRemoved []pair.CurrencyPair
 	update := false
 	for x := range enabledPairs {
-		if !pair.Contains(availPairs, enabledPairs[x], true) {
+		if !pair.Contains(availPairs, enabledPairs[x], true) ||
+			exchCfg.IsPairBlacklisted(enabledPairs[x].Pair().String()) {
 			update = true
 			pairsRemoved = append(pairsRemoved, enabledPairs[x])
 			continue
@@ -514,13 +549,17 @@ func (c *Config) CheckPairConsistency(exchName string) error {
 		return nil
 	}
 
-	exchCfg, err := c.GetExchangeConfig(exchName)
-	if err != nil {
-		return err
-	}
-
 	if len(pairs) == 0 {
-		exchCfg.EnabledPairs = pair.RandomPairFromPairs(availPairs).Pair().String()
+		var allowedPairs []pair.CurrencyPair
+		for x := range availPairs {
+			if !exchCfg.IsPairBlacklisted(availPairs[x].Pair().String()) {
+				allowedPairs = append(allowedPairs, availPairs[x])
+			}
+		}
+		if len(allowedPairs) == 0 {
+			return fmt.Errorf("exchange %s: every available pair is blacklisted", exchName)
+		}
+		exchCfg.EnabledPairs = pair.RandomPairFromPairs(allowedPairs).Pair().String()
 		log.Printf("Exchange %s: No enabled pairs found in available pairs, randomly added %v\n", exchName, exchCfg.EnabledPairs)
 	} else {
 		exchCfg.EnabledPairs = common.JoinStrings(pair.PairsToStringArray(pairs), ",")
@@ -535,6 +574,37 @@ func (c *Config) CheckPairConsistency(exchName string) error {
 	return nil
 }
 
+// IsPairBlacklisted returns whether a pair, in the exchange's config pair
+// format, matches one of the exchange's blacklist patterns. Patterns are
+// matched case insensitively and support globs, for example "*-DOGE"
+func (e *ExchangeConfig) IsPairBlacklisted(p string) bool {
+	p = common.StringToUpper(p)
+	for x := range e.PairsBlacklist {
+		matched, err := path.Match(common.StringToUpper(e.PairsBlacklist[x]), p)
+		if err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterBlacklistedPairs returns the pairs which are not blacklisted and the
+// number of pairs which were removed
+func (e *ExchangeConfig) FilterBlacklistedPairs(pairs []string) ([]string, int) {
+	if len(e.PairsBlacklist) == 0 {
+		return pairs, 0
+	}
+
+	var allowed []string
+	for x := range pairs {
+		if e.IsPairBlacklisted(pairs[x]) {
+			continue
+		}
+		allowed = append(allowed, pairs[x])
+	}
+	return allowed, len(pairs) - len(allowed)
+}
+
 // SupportsPair returns true or not whether the exchange supports the supplied
 // pair
 func (c *Config) SupportsPair(exchName string, p pair.CurrencyPair) (bool, error) {
@@ -545,7 +615,8 @@ func (c *Config) SupportsPair(exchName string, p pair.CurrencyPair) (bool, error
 	return pair.Contains(pairs, p, false), nil
 }
 
-// GetAvailablePairs returns a list of currency pairs for a specifc exchange
+// GetAvailablePairs returns a sorted list of currency pairs for a specifc
+// exchange
 func (c *Config) GetAvailablePairs(exchName string) ([]pair.CurrencyPair, error) {
 	exchCfg, err := c.GetExchangeConfig(exchName)
 	if err != nil {
@@ -555,10 +626,11 @@ func (c *Config) GetAvailablePairs(exchName string) ([]pair.CurrencyPair, error)
 	pairs := pair.FormatPairs(common.SplitStrings(exchCfg.AvailablePairs, ","),
 		exchCfg.ConfigCurrencyPairFormat.Delimiter,
 		exchCfg.ConfigCurrencyPairFormat.Index)
-	return pairs, nil
+	return pair.SortPairs(pairs), nil
 }
 
-// GetEnabledPairs returns a list of currency pairs for a specifc exchange
+// GetEnabledPairs returns a sorted list of currency pairs for a specifc
+// exchange
 func (c *Config) GetEnabledPairs(exchName string) ([]pair.CurrencyPair, error) {
 	exchCfg, err := c.GetExchangeConfig(exchName)
 	if err != nil {
@@ -568,7 +640,7 @@ func (c *Config) GetEnabledPairs(exchName string) ([]pair.CurrencyPair, error) {
 	pairs := pair.FormatPairs(common.SplitStrings(exchCfg.EnabledPairs, ","),
 		exchCfg.ConfigCurrencyPairFormat.Delimiter,
 		exchCfg.ConfigCurrencyPairFormat.Index)
-	return pairs, nil
+	return pair.SortPairs(pairs), nil
 }
 
 // GetEnabledExchanges returns a list of enabled exchanges
@@ -624,6 +696,35 @@ func (c *Config) GetRequestCurrencyPairFormat(exchName string) (*CurrencyPairFor
 	return exchCfg.RequestCurrencyPairFormat, nil
 }
 
+// GetPairFormat returns the resolved currency pair format an exchange uses for
+// an asset type, the request format when requestFormat is true otherwise the
+// config format. Every asset type of an exchange shares the exchange pair
+// formats, an asset type the exchange doesn't support returns an error and an
+// empty asset type matches any
+func (c *Config) GetPairFormat(exchName, assetType string, requestFormat bool) (CurrencyPairFormatConfig, error) {
+	exchCfg, err := c.GetExchangeConfig(exchName)
+	if err != nil {
+		return CurrencyPairFormatConfig{}, err
+	}
+
+	if assetType != "" && exchCfg.AssetTypes != "" &&
+		!common.StringDataCompare(common.SplitStrings(exchCfg.AssetTypes, ","), assetType) {
+		return CurrencyPairFormatConfig{}, fmt.Errorf("exchange %s does not support asset type %s, supported asset types: %s",
+			exchName, assetType, exchCfg.AssetTypes)
+	}
+
+	format, formatName := exchCfg.ConfigCurrencyPairFormat, "config"
+	if requestFormat {
+		format, formatName = exchCfg.RequestCurrencyPairFormat, "request"
+	}
+
+	if format == nil {
+		return CurrencyPairFormatConfig{}, fmt.Errorf("exchange %s has no %s currency pair format set",
+			exchName, formatName)
+	}
+	return *format, nil
+}
+
 // GetCurrencyPairDisplayConfig retrieves the currency pair display preference
 func (c *Config) GetCurrencyPairDisplayConfig() *CurrencyPairFormatConfig {
 	return c.Currency.CurrencyPairFormat
@@ -727,12 +828,19 @@ func (c *Config) CheckExchangeConfigValues() error {
 			if exch.BaseCurrencies == "" {
 				return fmt.Errorf(ErrExchangeBaseCurrenciesEmpty, exch.Name)
 			}
+			for x := range exch.PairsBlacklist {
+				if _, err := path.Match(exch.PairsBlacklist[x], ""); err != nil {
+					return fmt.Errorf("exchange %s: invalid pairs blacklist pattern %q",
+						exch.Name, exch.PairsBlacklist[x])
+				}
+			}
 			if exch.AuthenticatedAPISupport { // non-fatal error
-				if exch.APIKey == "" || exch.APISecret == "" || exch.APIKey == "Key" || exch.APISecret == "Secret" {
+				apiKey, apiSecret, clientID := exch.resolvedCredentials()
+				if apiKey == "" || apiSecret == "" || apiKey == "Key" || apiSecret == "Secret" {
 					c.Exchanges[i].AuthenticatedAPISupport = false
 					log.Printf(WarningExchangeAuthAPIDefaultOrEmptyValues, exch.Name)
 				} else if exch.Name == "ITBIT" || exch.Name == "Bitstamp" || exch.Name == "COINUT" || exch.Name == "CoinbasePro" {
-					if exch.ClientID == "" || exch.ClientID == "ClientID" {
+					if clientID == "" || clientID == "ClientID" {
 						c.Exchanges[i].AuthenticatedAPISupport = false
 						log.Printf(WarningExchangeAuthAPIDefaultOrEmptyValues, exch.Name)
 					}
@@ -746,6 +854,11 @@ func (c *Config) CheckExchangeConfigValues() error {
 				}
 			}
 
+			if exch.OrderbookMaxStoredLevels < 0 {
+				log.Printf("Exchange %s orderbook max stored levels cannot be negative, storing every level.", exch.Name)
+				c.Exchanges[i].OrderbookMaxStoredLevels = 0
+			}
+
 			if exch.HTTPTimeout <= 0 {
 				log.Printf("Exchange %s HTTP Timeout value not set, defaulting to %v.", exch.Name, configDefaultHTTPTimeout)
 				c.Exchanges[i].HTTPTimeout = configDefaultHTTPTimeout
@@ -1058,6 +1171,11 @@ func (c *Config) ReadConfig(configPath string) error {
 			return err
 		}
 
+		err = c.loadCredentials()
+		if err != nil {
+			return err
+		}
+
 		if c.EncryptConfig == configFileEncryptionDisabled {
 			return nil
 		}
@@ -1103,6 +1221,7 @@ func (c *Config) ReadConfig(configPath string) error {
 			}
 			break
 		}
+		return c.loadCredentials()
 	}
 	return nil
 }
@@ -1114,7 +1233,15 @@ func (c *Config) SaveConfig(configPath string) error {
 		return err
 	}
 
-	payload, err := json.MarshalIndent(c, "", " ")
+	cfg := c
+	if c.EncryptCredentials {
+		cfg, err = c.withEncryptedCredentials()
+		if err != nil {
+			return err
+		}
+	}
+
+	payload, err := json.MarshalIndent(cfg, "", " ")
 	if err != nil {
 		return err
 	}
@@ -1187,6 +1314,15 @@ func (c *Config) LoadConfig(configPath string) error {
 		return fmt.Errorf(ErrFailureOpeningConfig, configPath, err)
 	}
 
+	_, err = c.MigrateConfig()
+	if err != nil {
+		return err
+	}
+
+	for _, problem := range c.Validate() {
+		log.Printf("Config validation: %s", problem)
+	}
+
 	return c.CheckConfig()
 }
 
@@ -1198,7 +1334,9 @@ func (c *Config) UpdateConfig(configPath string, newCfg Config) error {
 	}
 
 	c.Name = newCfg.Name
+	c.Version = newCfg.Version
 	c.EncryptConfig = newCfg.EncryptConfig
+	c.EncryptCredentials = newCfg.EncryptCredentials
 	c.Currency = newCfg.Currency
 	c.GlobalHTTPTimeout = newCfg.GlobalHTTPTimeout
 	c.Portfolio = newCfg.Portfolio
@@ -0,0 +1,353 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+const (
+	// CredentialsPassphraseEnv is the environment variable the credentials
+	// passphrase is read from before prompting for it
+	CredentialsPassphraseEnv = "GCT_CREDENTIALS_PASSPHRASE"
+
+	// encryptedCredentialPrefix marks a credential value as encrypted
+	encryptedCredentialPrefix = "ENC:"
+	credentialSaltLength      = 16
+
+	// CredentialEnvPrefix marks a credential value as the name of the
+	// environment variable to read it from e.g. "env:OKEX_API_KEY"
+	CredentialEnvPrefix = "env:"
+)
+
+// CredentialField is an exchange credential which can be resolved from the
+// environment
+type CredentialField string
+
+// Exchange credentials resolvable from the environment, ClientID holds the API
+// passphrase for exchanges which require one
+const (
+	CredentialAPIKey    CredentialField = "API key"
+	CredentialAPISecret CredentialField = "API secret"
+	CredentialClientID  CredentialField = "client ID"
+)
+
+// credentialEnvSuffixes are appended to an exchange's name to give the
+// environment variables an empty credential is read from, in order
+var credentialEnvSuffixes = map[CredentialField][]string{
+	CredentialAPIKey:    {"API_KEY"},
+	CredentialAPISecret: {"API_SECRET"},
+	CredentialClientID:  {"API_PASSPHRASE", "CLIENT_ID"},
+}
+
+var (
+	errCredentialTooShort = errors.New("encrypted credential is too short")
+
+	credentialsPassphrase []byte
+)
+
+// credentialCipher encrypts and decrypts credential values with AES-GCM using
+// keys derived from a passphrase. Every value carries its salt, the cipher
+// reuses one salt when encrypting and caches derived keys by salt so the
+// passphrase is only stretched once per salt
+type credentialCipher struct {
+	passphrase []byte
+	salt       []byte
+	keys       map[string][]byte
+}
+
+func newCredentialCipher(passphrase []byte) (*credentialCipher, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("credentials passphrase is empty")
+	}
+	return &credentialCipher{
+		passphrase: passphrase,
+		keys:       make(map[string][]byte),
+	}, nil
+}
+
+func (c *credentialCipher) key(salt []byte) ([]byte, error) {
+	if k, ok := c.keys[string(salt)]; ok {
+		return k, nil
+	}
+
+	k, err := getScryptDK(c.passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	c.keys[string(salt)] = k
+	return k, nil
+}
+
+func (c *credentialCipher) gcm(salt []byte) (cipher.AEAD, error) {
+	k, err := c.key(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt returns the encrypted form of a credential, empty and already
+// encrypted values are returned unchanged
+func (c *credentialCipher) encrypt(value string) (string, error) {
+	if value == "" || IsEncryptedCredential(value) {
+		return value, nil
+	}
+
+	if c.salt == nil {
+		c.salt = make([]byte, credentialSaltLength)
+		if _, err := io.ReadFull(rand.Reader, c.salt); err != nil {
+			c.salt = nil
+			return "", err
+		}
+	}
+
+	aead, err := c.gcm(c.salt)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	data := append([]byte{}, c.salt...)
+	data = append(data, nonce...)
+	data = aead.Seal(data, nonce, []byte(value), nil)
+	return encryptedCredentialPrefix + common.Base64Encode(data), nil
+}
+
+// decrypt returns the plaintext of a credential, values which are not
+// encrypted are returned unchanged
+func (c *credentialCipher) decrypt(value string) (string, error) {
+	if !IsEncryptedCredential(value) {
+		return value, nil
+	}
+
+	data, err := common.Base64Decode(strings.TrimPrefix(value, encryptedCredentialPrefix))
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < credentialSaltLength {
+		return "", errCredentialTooShort
+	}
+
+	salt := data[:credentialSaltLength]
+	aead, err := c.gcm(salt)
+	if err != nil {
+		return "", err
+	}
+
+	data = data[credentialSaltLength:]
+	if len(data) < aead.NonceSize() {
+		return "", errCredentialTooShort
+	}
+
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return "", errors.New("unable to decrypt credential, invalid passphrase")
+	}
+	return string(plaintext), nil
+}
+
+// IsEncryptedCredential returns whether a credential value is encrypted
+func IsEncryptedCredential(value string) bool {
+	return strings.HasPrefix(value, encryptedCredentialPrefix)
+}
+
+// EncryptCredential encrypts a credential value with AES-GCM using a key
+// derived from the passphrase
+func EncryptCredential(value string, passphrase []byte) (string, error) {
+	c, err := newCredentialCipher(passphrase)
+	if err != nil {
+		return "", err
+	}
+	return c.encrypt(value)
+}
+
+// DecryptCredential decrypts a credential value encrypted by
+// EncryptCredential, plaintext values are returned unchanged
+func DecryptCredential(value string, passphrase []byte) (string, error) {
+	c, err := newCredentialCipher(passphrase)
+	if err != nil {
+		return "", err
+	}
+	return c.decrypt(value)
+}
+
+// CredentialEnvNames returns the environment variables an empty exchange
+// credential is read from, the exchange name uppercased with any other
+// character than a letter or digit replaced by an underscore and suffixed by
+// the credential e.g. OKEX_API_KEY
+func CredentialEnvNames(exchName string, field CredentialField) []string {
+	prefix := strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(exchName))
+
+	var names []string
+	for _, suffix := range credentialEnvSuffixes[field] {
+		names = append(names, prefix+"_"+suffix)
+	}
+	return names
+}
+
+// ResolveCredential returns the value of an exchange credential and where it
+// was loaded from. A value of the form "env:NAME" is read from the environment
+// variable NAME and an empty value from the first of CredentialEnvNames which
+// is set, other values are returned unchanged with the source "config". The
+// source is empty when an empty value isn't set in the environment
+func ResolveCredential(exchName string, field CredentialField, value string) (string, string) {
+	if strings.HasPrefix(value, CredentialEnvPrefix) {
+		name := strings.TrimPrefix(value, CredentialEnvPrefix)
+		return os.Getenv(name), "environment variable " + name
+	}
+
+	if value != "" {
+		return value, "config"
+	}
+
+	for _, name := range CredentialEnvNames(exchName, field) {
+		if env := os.Getenv(name); env != "" {
+			return env, "environment variable " + name
+		}
+	}
+	return "", ""
+}
+
+// resolvedCredentials returns the API key, secret and client ID of an exchange
+// config resolved with ResolveCredential
+func (e *ExchangeConfig) resolvedCredentials() (apiKey, apiSecret, clientID string) {
+	apiKey, _ = ResolveCredential(e.Name, CredentialAPIKey, e.APIKey)
+	apiSecret, _ = ResolveCredential(e.Name, CredentialAPISecret, e.APISecret)
+	clientID, _ = ResolveCredential(e.Name, CredentialClientID, e.ClientID)
+	return
+}
+
+// exchangeCredentials returns pointers to the credential fields of an
+// exchange config
+func exchangeCredentials(exch *ExchangeConfig) []*string {
+	return []*string{
+		&exch.APIKey,
+		&exch.APISecret,
+		&exch.APIAuthPEMKey,
+		&exch.ClientID,
+		&exch.APITradePassword,
+	}
+}
+
+// hasEncryptedCredentials returns whether any exchange credential is
+// encrypted
+func (c *Config) hasEncryptedCredentials() bool {
+	for x := range c.Exchanges {
+		for _, field := range exchangeCredentials(&c.Exchanges[x]) {
+			if IsEncryptedCredential(*field) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EncryptExchangeCredentials encrypts the credentials of every exchange with
+// the passphrase
+func (c *Config) EncryptExchangeCredentials(passphrase []byte) error {
+	return c.transformCredentials(passphrase, (*credentialCipher).encrypt)
+}
+
+// DecryptExchangeCredentials decrypts the credentials of every exchange with
+// the passphrase, plaintext credentials are left unchanged
+func (c *Config) DecryptExchangeCredentials(passphrase []byte) error {
+	return c.transformCredentials(passphrase, (*credentialCipher).decrypt)
+}
+
+func (c *Config) transformCredentials(passphrase []byte, transform func(*credentialCipher, string) (string, error)) error {
+	cc, err := newCredentialCipher(passphrase)
+	if err != nil {
+		return err
+	}
+
+	for x := range c.Exchanges {
+		for _, field := range exchangeCredentials(&c.Exchanges[x]) {
+			value, err := transform(cc, *field)
+			if err != nil {
+				return err
+			}
+			*field = value
+		}
+	}
+	return nil
+}
+
+// getCredentialsPassphrase returns the credentials passphrase, reading it from
+// CredentialsPassphraseEnv or prompting for it the first time it is needed
+func getCredentialsPassphrase(initialSetup bool) ([]byte, error) {
+	if len(credentialsPassphrase) != 0 {
+		return credentialsPassphrase, nil
+	}
+
+	if env := os.Getenv(CredentialsPassphraseEnv); env != "" {
+		credentialsPassphrase = []byte(env)
+		return credentialsPassphrase, nil
+	}
+
+	passphrase, err := PromptForConfigKey(initialSetup)
+	if err != nil {
+		return nil, err
+	}
+	credentialsPassphrase = passphrase
+	return credentialsPassphrase, nil
+}
+
+// loadCredentials decrypts the exchange credentials after the config has been
+// read when any of them are encrypted
+func (c *Config) loadCredentials() error {
+	if !c.hasEncryptedCredentials() {
+		return nil
+	}
+
+	passphrase, err := getCredentialsPassphrase(false)
+	if err != nil {
+		return err
+	}
+
+	err = c.DecryptExchangeCredentials(passphrase)
+	if err != nil {
+		credentialsPassphrase = nil
+		return err
+	}
+	return nil
+}
+
+// withEncryptedCredentials returns a copy of the config with the exchange
+// credentials encrypted, leaving the config itself in plaintext
+func (c *Config) withEncryptedCredentials() (*Config, error) {
+	passphrase, err := getCredentialsPassphrase(true)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := *c
+	cfg.Exchanges = make([]ExchangeConfig, len(c.Exchanges))
+	copy(cfg.Exchanges, c.Exchanges)
+	err = cfg.EncryptExchangeCredentials(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
@@ -126,3 +126,105 @@ func TestMakeNewSessionDK(t *testing.T) {
 		t.Fatal("Test failed. makeNewSessionDK passed with nil key")
 	}
 }
+
+func TestEncryptCredential(t *testing.T) {
+	passphrase := []byte("hunter2")
+	encrypted, err := EncryptCredential("secret", passphrase)
+	if err != nil {
+		t.Fatal("Test failed. EncryptCredential error", err)
+	}
+
+	if !IsEncryptedCredential(encrypted) || encrypted == "secret" {
+		t.Fatalf("Test failed. EncryptCredential returned an unencrypted value %s", encrypted)
+	}
+
+	decrypted, err := DecryptCredential(encrypted, passphrase)
+	if err != nil || decrypted != "secret" {
+		t.Errorf("Test failed. DecryptCredential expected secret, received %s %v", decrypted, err)
+	}
+
+	_, err = DecryptCredential(encrypted, []byte("wrong"))
+	if err == nil {
+		t.Error("Test failed. DecryptCredential expected an error for an invalid passphrase")
+	}
+
+	plaintext, err := DecryptCredential("plaintext", passphrase)
+	if err != nil || plaintext != "plaintext" {
+		t.Errorf("Test failed. DecryptCredential expected plaintext to be unchanged, received %s %v",
+			plaintext, err)
+	}
+
+	_, err = EncryptCredential("secret", nil)
+	if err == nil {
+		t.Error("Test failed. EncryptCredential expected an error without a passphrase")
+	}
+}
+
+func TestEncryptExchangeCredentials(t *testing.T) {
+	c := Config{
+		Exchanges: []ExchangeConfig{
+			{Name: "a", APIKey: "key", APISecret: "secret"},
+			{Name: "b", ClientID: "client"},
+		},
+	}
+
+	credentialsPassphrase = []byte("hunter2")
+	defer func() { credentialsPassphrase = nil }()
+
+	encrypted, err := c.withEncryptedCredentials()
+	if err != nil {
+		t.Fatal("Test failed. withEncryptedCredentials error", err)
+	}
+
+	if c.Exchanges[0].APIKey != "key" {
+		t.Error("Test failed. withEncryptedCredentials modified the original config")
+	}
+
+	if !IsEncryptedCredential(encrypted.Exchanges[0].APIKey) ||
+		!IsEncryptedCredential(encrypted.Exchanges[1].ClientID) ||
+		encrypted.Exchanges[1].APIKey != "" {
+		t.Errorf("Test failed. withEncryptedCredentials unexpected credentials %+v", encrypted.Exchanges)
+	}
+
+	err = encrypted.loadCredentials()
+	if err != nil {
+		t.Fatal("Test failed. loadCredentials error", err)
+	}
+
+	if encrypted.Exchanges[0].APISecret != "secret" || encrypted.Exchanges[1].ClientID != "client" {
+		t.Errorf("Test failed. loadCredentials unexpected credentials %+v", encrypted.Exchanges)
+	}
+}
+
+func TestResolveCredential(t *testing.T) {
+	t.Setenv("OKEX_API_KEY", "envkey")
+	t.Setenv("COINBASE_PRO_CLIENT_ID", "envclient")
+	t.Setenv("GCT_TEST_SECRET", "envsecret")
+
+	names := CredentialEnvNames("Coinbase Pro", CredentialClientID)
+	if len(names) != 2 || names[0] != "COINBASE_PRO_API_PASSPHRASE" || names[1] != "COINBASE_PRO_CLIENT_ID" {
+		t.Errorf("Test failed. CredentialEnvNames unexpected names %v", names)
+	}
+
+	tests := []struct {
+		exch   string
+		field  CredentialField
+		value  string
+		result string
+		source string
+	}{
+		{"OKEX", CredentialAPIKey, "", "envkey", "environment variable OKEX_API_KEY"},
+		{"OKEX", CredentialAPIKey, "configkey", "configkey", "config"},
+		{"OKEX", CredentialAPISecret, "env:GCT_TEST_SECRET", "envsecret", "environment variable GCT_TEST_SECRET"},
+		{"OKEX", CredentialAPISecret, "", "", ""},
+		{"Coinbase Pro", CredentialClientID, "", "envclient", "environment variable COINBASE_PRO_CLIENT_ID"},
+	}
+
+	for _, test := range tests {
+		result, source := ResolveCredential(test.exch, test.field, test.value)
+		if result != test.result || source != test.source {
+			t.Errorf("Test failed. ResolveCredential %s %s %q expected %q from %q, received %q from %q",
+				test.exch, test.field, test.value, test.result, test.source, result, source)
+		}
+	}
+}
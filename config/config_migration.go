@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// CurrentConfigVersion is the config version this build writes, older configs
+// are migrated up to it when loaded
+const CurrentConfigVersion = 1
+
+// configMigration upgrades a config from the previous version to Version,
+// returning a description of each change made
+type configMigration struct {
+	Version int
+	Migrate func(c *Config) []string
+}
+
+// configMigrations holds the migrations in version order
+var configMigrations = []configMigration{
+	{Version: 1, Migrate: migrateExchangeDefaults},
+}
+
+// MigrateConfig upgrades the config to CurrentConfigVersion, logging each
+// change made. It returns whether the config was changed and an error if the
+// config was written by a newer version
+func (c *Config) MigrateConfig() (bool, error) {
+	if c.Version > CurrentConfigVersion {
+		return false, fmt.Errorf("config version %d is newer than the supported version %d",
+			c.Version, CurrentConfigVersion)
+	}
+
+	if c.Version == CurrentConfigVersion {
+		return false, nil
+	}
+
+	for x := range configMigrations {
+		if configMigrations[x].Version <= c.Version {
+			continue
+		}
+
+		changes := configMigrations[x].Migrate(c)
+		for y := range changes {
+			log.Printf("Config migration to version %d: %s", configMigrations[x].Version, changes[y])
+		}
+		c.Version = configMigrations[x].Version
+	}
+	return true, nil
+}
+
+// migrateExchangeDefaults fills the exchange settings added before the config
+// was versioned which an unversioned config may be missing
+func migrateExchangeDefaults(c *Config) []string {
+	var changes []string
+	for x := range c.Exchanges {
+		exch := &c.Exchanges[x]
+		if exch.Name == "GDAX" {
+			exch.Name = "CoinbasePro"
+			changes = append(changes, "renamed exchange GDAX to CoinbasePro")
+		}
+
+		if exch.DefaultAssetType == "" && exch.AssetTypes != "" {
+			exch.DefaultAssetType = common.SplitStrings(exch.AssetTypes, ",")[0]
+			changes = append(changes, fmt.Sprintf("%s default asset type set to %s",
+				exch.Name, exch.DefaultAssetType))
+		}
+
+		if exch.HTTPRequestTimeouts == nil {
+			exch.HTTPRequestTimeouts = &HTTPRequestTimeoutsConfig{}
+			changes = append(changes, fmt.Sprintf("%s HTTP request timeouts added, using the HTTP timeout for every request type",
+				exch.Name))
+		}
+	}
+	return changes
+}
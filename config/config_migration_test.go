@@ -0,0 +1,45 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	c := Config{
+		Exchanges: []ExchangeConfig{
+			{Name: "GDAX", AssetTypes: "SPOT"},
+			{Name: "OKEX", AssetTypes: "SPOT,this_week", DefaultAssetType: "this_week",
+				HTTPRequestTimeouts: &HTTPRequestTimeoutsConfig{Trade: 1}},
+		},
+	}
+
+	migrated, err := c.MigrateConfig()
+	if err != nil {
+		t.Fatal("Test failed. MigrateConfig error", err)
+	}
+
+	if !migrated || c.Version != CurrentConfigVersion {
+		t.Errorf("Test failed. MigrateConfig expected version %d, received %d",
+			CurrentConfigVersion, c.Version)
+	}
+
+	if c.Exchanges[0].Name != "CoinbasePro" || c.Exchanges[0].DefaultAssetType != "SPOT" ||
+		c.Exchanges[0].HTTPRequestTimeouts == nil {
+		t.Errorf("Test failed. MigrateConfig unexpected exchange config %+v", c.Exchanges[0])
+	}
+
+	if c.Exchanges[1].DefaultAssetType != "this_week" || c.Exchanges[1].HTTPRequestTimeouts.Trade != 1 {
+		t.Error("Test failed. MigrateConfig overwrote existing settings")
+	}
+
+	migrated, err = c.MigrateConfig()
+	if err != nil || migrated {
+		t.Error("Test failed. MigrateConfig migrated a current config", err)
+	}
+
+	c.Version = CurrentConfigVersion + 1
+	_, err = c.MigrateConfig()
+	if err == nil {
+		t.Error("Test failed. MigrateConfig expected an error for a newer config version")
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"log"
+	"reflect"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+var errReloadEncryptedConfig = errors.New("encrypted config files can't be reloaded, restart to apply changes")
+
+// ExchangeConfigChanges describes how a reloaded exchange config differs from
+// the running one
+type ExchangeConfigChanges struct {
+	EnabledPairs    bool
+	Verbose         bool
+	HTTPRateLimiter bool
+
+	// RestartRequired lists the changed settings which are only applied when
+	// the bot is restarted
+	RestartRequired []string
+}
+
+// Reloadable returns whether any setting which can be applied to a running
+// exchange changed
+func (e ExchangeConfigChanges) Reloadable() bool {
+	return e.EnabledPairs || e.Verbose || e.HTTPRateLimiter
+}
+
+// CompareExchangeConfigs returns the changes between the running exchange
+// config and a reloaded one
+func CompareExchangeConfigs(current, reloaded *ExchangeConfig) ExchangeConfigChanges {
+	changes := ExchangeConfigChanges{
+		EnabledPairs: current.EnabledPairs != reloaded.EnabledPairs,
+		Verbose:      current.Verbose != reloaded.Verbose,
+	}
+
+	if !reflect.DeepEqual(current.HTTPRateLimiter, reloaded.HTTPRateLimiter) {
+		if reloaded.HTTPRateLimiter == nil {
+			changes.RestartRequired = append(changes.RestartRequired, "httpRateLimiter")
+		} else {
+			changes.HTTPRateLimiter = true
+		}
+	}
+
+	restartSettings := []struct {
+		name    string
+		changed bool
+	}{
+		{"enabled", current.Enabled != reloaded.Enabled},
+		{"websocket", current.Websocket != reloaded.Websocket},
+		{"useSandbox", current.UseSandbox != reloaded.UseSandbox},
+		{"httpTimeout", current.HTTPTimeout != reloaded.HTTPTimeout},
+		{"httpUserAgent", current.HTTPUserAgent != reloaded.HTTPUserAgent},
+		{"authenticatedApiSupport", current.AuthenticatedAPISupport != reloaded.AuthenticatedAPISupport},
+		{"apiKey", current.APIKey != reloaded.APIKey},
+		{"apiSecret", current.APISecret != reloaded.APISecret},
+		{"apiAuthPemKey", current.APIAuthPEMKey != reloaded.APIAuthPEMKey},
+		{"clientId", current.ClientID != reloaded.ClientID},
+		{"apiTradePassword", current.APITradePassword != reloaded.APITradePassword},
+		{"apiUrl", current.APIURL != reloaded.APIURL},
+		{"apiUrlSecondary", current.APIURLSecondary != reloaded.APIURLSecondary},
+		{"websocketUrl", current.WebsocketURL != reloaded.WebsocketURL},
+		{"proxyAddress", current.ProxyAddress != reloaded.ProxyAddress},
+	}
+	for x := range restartSettings {
+		if restartSettings[x].changed {
+			changes.RestartRequired = append(changes.RestartRequired, restartSettings[x].name)
+		}
+	}
+	return changes
+}
+
+// ReadReloadedConfig reads the config file for a reload of the running config.
+// Unlike LoadConfig it never prompts or writes the config file, so encrypted
+// config files can't be reloaded
+func ReadReloadedConfig(configPath string) (*Config, error) {
+	defaultPath, err := GetFilePath(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := common.ReadFile(defaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if ConfirmECS(file) {
+		return nil, errReloadEncryptedConfig
+	}
+
+	var c Config
+	err = ConfirmConfigJSON(file, &c)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.MigrateConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, problem := range c.Validate() {
+		log.Printf("Config validation: %s", problem)
+	}
+
+	err = c.CheckExchangeConfigValues()
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCompareExchangeConfigs(t *testing.T) {
+	current := ExchangeConfig{
+		Name:         "Bitfinex",
+		Verbose:      false,
+		EnabledPairs: "BTCUSD",
+		APIKey:       "key",
+		APIURL:       APIURLNonDefaultMessage,
+	}
+
+	reloaded := current
+	changes := CompareExchangeConfigs(&current, &reloaded)
+	if changes.Reloadable() || len(changes.RestartRequired) != 0 {
+		t.Error("Test failed. CompareExchangeConfigs unexpected changes", changes)
+	}
+
+	reloaded.Verbose = true
+	reloaded.EnabledPairs = "BTCUSD,LTCUSD"
+	reloaded.HTTPRateLimiter = &HTTPRateLimitConfig{Duration: time.Second, AuthRate: 10, UnauthRate: 20}
+	reloaded.APIKey = "newkey"
+	reloaded.APIURL = "https://api.bitfinex.com"
+	changes = CompareExchangeConfigs(&current, &reloaded)
+	if !changes.EnabledPairs || !changes.Verbose || !changes.HTTPRateLimiter {
+		t.Error("Test failed. CompareExchangeConfigs reloadable changes not detected", changes)
+	}
+
+	if len(changes.RestartRequired) != 2 ||
+		changes.RestartRequired[0] != "apiKey" || changes.RestartRequired[1] != "apiUrl" {
+		t.Error("Test failed. CompareExchangeConfigs unexpected restart required settings",
+			changes.RestartRequired)
+	}
+
+	changes = CompareExchangeConfigs(&reloaded, &current)
+	if changes.HTTPRateLimiter || changes.RestartRequired[0] != "httpRateLimiter" {
+		t.Error("Test failed. CompareExchangeConfigs removed rate limiter should require a restart",
+			changes)
+	}
+}
+
+func TestReadReloadedConfig(t *testing.T) {
+	cfg, err := ReadReloadedConfig(ConfigTestFile)
+	if err != nil {
+		t.Fatal("Test failed. ReadReloadedConfig error", err)
+	}
+
+	if cfg == GetConfig() {
+		t.Error("Test failed. ReadReloadedConfig returned the running config")
+	}
+
+	if _, err = cfg.GetExchangeConfig("Bitfinex"); err != nil {
+		t.Error("Test failed. ReadReloadedConfig missing exchange config", err)
+	}
+
+	dir, err := ioutil.TempDir("", "gctreload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	encrypted := filepath.Join(dir, "config.dat")
+	err = ioutil.WriteFile(encrypted, []byte(EncryptConfirmString+"data"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = ReadReloadedConfig(encrypted); err != errReloadEncryptedConfig {
+		t.Error("Test failed. ReadReloadedConfig encrypted config expected error", err)
+	}
+}
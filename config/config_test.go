@@ -315,6 +315,56 @@ func TestCheckPairConsistency(t *testing.T) {
 	}
 }
 
+func TestPairsBlacklist(t *testing.T) {
+	exch := ExchangeConfig{PairsBlacklist: []string{"*_doge", "XRP_USD"}}
+
+	if !exch.IsPairBlacklisted("BTC_DOGE") || !exch.IsPairBlacklisted("xrp_usd") {
+		t.Error("Test failed. IsPairBlacklisted did not match a blacklisted pair")
+	}
+
+	if exch.IsPairBlacklisted("DOGE_BTC") || exch.IsPairBlacklisted("XRP_BTC") {
+		t.Error("Test failed. IsPairBlacklisted matched a pair which isn't blacklisted")
+	}
+
+	allowed, filtered := exch.FilterBlacklistedPairs([]string{"BTC_USD", "LTC_DOGE", "XRP_USD", "DOGE_USD"})
+	if filtered != 2 || len(allowed) != 2 || allowed[0] != "BTC_USD" || allowed[1] != "DOGE_USD" {
+		t.Errorf("Test failed. FilterBlacklistedPairs unexpected result %v, %d filtered", allowed, filtered)
+	}
+
+	cfg := GetConfig()
+	err := cfg.LoadConfig(ConfigTestFile)
+	if err != nil {
+		t.Fatal("Test failed. TestPairsBlacklist LoadConfig error", err)
+	}
+
+	cfg.Exchanges = append(cfg.Exchanges, ExchangeConfig{
+		Name:           "BlacklistExchange",
+		Enabled:        true,
+		AvailablePairs: "DOGE_USD,LTC_USD",
+		EnabledPairs:   "DOGE_USD",
+		PairsBlacklist: []string{"DOGE_*"},
+		ConfigCurrencyPairFormat: &CurrencyPairFormatConfig{
+			Uppercase: true,
+			Delimiter: "_",
+		},
+	})
+
+	err = cfg.CheckPairConsistency("BlacklistExchange")
+	if err != nil {
+		t.Error("Test failed. TestPairsBlacklist CheckPairConsistency error", err)
+	}
+
+	exchCfg, err := cfg.GetExchangeConfig("BlacklistExchange")
+	if err != nil {
+		t.Fatal("Test failed. TestPairsBlacklist GetExchangeConfig error", err)
+	}
+
+	if exchCfg.EnabledPairs != "LTC_USD" {
+		t.Errorf("Test failed. TestPairsBlacklist expected the blacklisted pair to be replaced, received %s",
+			exchCfg.EnabledPairs)
+	}
+}
+
 func TestSupportsPair(t *testing.T) {
 	cfg := GetConfig()
 	err := cfg.LoadConfig(ConfigTestFile)
@@ -504,6 +554,50 @@ func TestGetRequestCurrencyPairFormat(t *testing.T) {
 	}
 }
 
+func TestGetPairFormat(t *testing.T) {
+	cfg := GetConfig()
+	err := cfg.LoadConfig(ConfigTestFile)
+	if err != nil {
+		t.Errorf(
+			"Test failed. TestGetPairFormat. LoadConfig Error: %s", err.Error(),
+		)
+	}
+
+	_, err = cfg.GetPairFormat("asdasdasd", "", true)
+	if err == nil {
+		t.Error(
+			"Test failed. TestGetPairFormat. Non-existent exchange returned nil error",
+		)
+	}
+
+	exchFmt, err := cfg.GetPairFormat("Liqui", "SPOT", true)
+	if err != nil {
+		t.Errorf("Test failed. TestGetPairFormat. Error: %s", err)
+	}
+	if exchFmt.Uppercase || exchFmt.Delimiter != "_" || exchFmt.Separator != "-" {
+		t.Error(
+			"Test failed. TestGetPairFormat. Invalid request format values",
+		)
+	}
+
+	exchFmt, err = cfg.GetPairFormat("Liqui", "", false)
+	if err != nil {
+		t.Errorf("Test failed. TestGetPairFormat. Error: %s", err)
+	}
+	if !exchFmt.Uppercase || exchFmt.Delimiter != "_" {
+		t.Error(
+			"Test failed. TestGetPairFormat. Invalid config format values",
+		)
+	}
+
+	_, err = cfg.GetPairFormat("Liqui", "FUTURES", true)
+	if err == nil {
+		t.Error(
+			"Test failed. TestGetPairFormat. Unsupported asset type returned nil error",
+		)
+	}
+}
+
 func TestGetCurrencyPairDisplayConfig(t *testing.T) {
 	cfg := GetConfig()
 	err := cfg.LoadConfig(ConfigTestFile)
@@ -632,6 +726,13 @@ func TestCheckExchangeConfigValues(t *testing.T) {
 		)
 	}
 
+	checkExchangeConfigValues.Exchanges[0].PairsBlacklist = []string{"[*_DOGE"}
+	err = checkExchangeConfigValues.CheckExchangeConfigValues()
+	if err == nil {
+		t.Error("Test failed. checkExchangeConfigValues.CheckExchangeConfigValues accepted an invalid blacklist pattern")
+	}
+	checkExchangeConfigValues.Exchanges[0].PairsBlacklist = nil
+
 	checkExchangeConfigValues.Exchanges[0].HTTPTimeout = 0
 	checkExchangeConfigValues.CheckExchangeConfigValues()
 	if checkExchangeConfigValues.Exchanges[0].HTTPTimeout == 0 {
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// ValidationErrors holds each problem found when validating a config
+type ValidationErrors []error
+
+// Error implements the error interface, joining each validation error
+func (v ValidationErrors) Error() string {
+	var errs []string
+	for x := range v {
+		errs = append(errs, v[x].Error())
+	}
+	return common.JoinStrings(errs, ", ")
+}
+
+// exchangesRequiringClientID are the exchanges whose authenticated API
+// requires a client ID alongside the API key and secret
+var exchangesRequiringClientID = []string{"ITBIT", "Bitstamp", "COINUT", "CoinbasePro"}
+
+// Validate checks the enabled exchanges for problems which would otherwise
+// only surface at runtime, such as authenticated API support without
+// credentials, pairs which don't match the config pair format and a default
+// asset type the exchange doesn't support. Each problem is returned, nil is
+// returned when the config is valid
+func (c *Config) Validate() ValidationErrors {
+	var errs ValidationErrors
+	for x := range c.Exchanges {
+		exch := &c.Exchanges[x]
+		if !exch.Enabled {
+			continue
+		}
+
+		if exch.Name == "" {
+			errs = append(errs, fmt.Errorf("exchange at index %d is enabled but has no name", x))
+			continue
+		}
+
+		errs = append(errs, validateExchangeCredentials(exch)...)
+		errs = append(errs, validateExchangePairFormat(exch)...)
+		errs = append(errs, validateExchangeAssetTypes(exch)...)
+	}
+	return errs
+}
+
+func validateExchangeCredentials(exch *ExchangeConfig) []error {
+	if !exch.AuthenticatedAPISupport {
+		return nil
+	}
+
+	var errs []error
+	apiKey, apiSecret, clientID := exch.resolvedCredentials()
+	if apiKey == "" || apiKey == "Key" {
+		errs = append(errs, missingCredentialError(exch.Name, "API key", exch.APIKey))
+	}
+
+	if apiSecret == "" || apiSecret == "Secret" {
+		errs = append(errs, missingCredentialError(exch.Name, "API secret", exch.APISecret))
+	}
+
+	if common.StringDataCompare(exchangesRequiringClientID, exch.Name) &&
+		(clientID == "" || clientID == "ClientID") {
+		errs = append(errs, missingCredentialError(exch.Name, "client ID", exch.ClientID))
+	}
+	return errs
+}
+
+// missingCredentialError returns the validation error of a credential which
+// isn't set, naming the environment variable the credential is read from when
+// its config value is of the form "env:NAME"
+func missingCredentialError(exchName, credential, value string) error {
+	if strings.HasPrefix(value, CredentialEnvPrefix) {
+		return fmt.Errorf("exchange %s has authenticated API support enabled but the %s environment variable %s is not set",
+			exchName, credential, strings.TrimPrefix(value, CredentialEnvPrefix))
+	}
+	return fmt.Errorf("exchange %s has authenticated API support enabled but no %s set",
+		exchName, credential)
+}
+
+func validateExchangePairFormat(exch *ExchangeConfig) []error {
+	if exch.ConfigCurrencyPairFormat == nil {
+		return []error{fmt.Errorf("exchange %s has no config currency pair format set", exch.Name)}
+	}
+
+	format := exch.ConfigCurrencyPairFormat
+	if format.Index != "" {
+		return nil
+	}
+
+	var delimiterMismatch, caseMismatch []string
+	pairs := append(common.SplitStrings(exch.AvailablePairs, ","),
+		common.SplitStrings(exch.EnabledPairs, ",")...)
+	for _, p := range pairs {
+		if p == "" || common.StringDataCompare(delimiterMismatch, p) ||
+			common.StringDataCompare(caseMismatch, p) {
+			continue
+		}
+
+		if format.Delimiter != "" && !common.StringContains(p, format.Delimiter) {
+			delimiterMismatch = append(delimiterMismatch, p)
+			continue
+		}
+
+		if format.Uppercase && p != common.StringToUpper(p) ||
+			!format.Uppercase && p != common.StringToLower(p) {
+			caseMismatch = append(caseMismatch, p)
+		}
+	}
+
+	var errs []error
+	if len(delimiterMismatch) > 0 {
+		errs = append(errs, fmt.Errorf("exchange %s pairs %s do not use the config pair format delimiter %q",
+			exch.Name, common.JoinStrings(delimiterMismatch, ","), format.Delimiter))
+	}
+
+	if len(caseMismatch) > 0 {
+		errs = append(errs, fmt.Errorf("exchange %s pairs %s do not match the config pair format uppercase setting %v",
+			exch.Name, common.JoinStrings(caseMismatch, ","), format.Uppercase))
+	}
+	return errs
+}
+
+func validateExchangeAssetTypes(exch *ExchangeConfig) []error {
+	if exch.AssetTypes == "" {
+		return nil
+	}
+
+	var errs []error
+	assetTypes := common.SplitStrings(exch.AssetTypes, ",")
+	for x := range assetTypes {
+		if assetTypes[x] == "" {
+			errs = append(errs, fmt.Errorf("exchange %s has an empty asset type in %q",
+				exch.Name, exch.AssetTypes))
+			break
+		}
+	}
+
+	if exch.DefaultAssetType != "" && !common.StringDataCompare(assetTypes, exch.DefaultAssetType) {
+		errs = append(errs, fmt.Errorf("exchange %s default asset type %s is unknown, supported asset types: %s",
+			exch.Name, exch.DefaultAssetType, exch.AssetTypes))
+	}
+	return errs
+}
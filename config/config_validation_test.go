@@ -0,0 +1,120 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	cfg := Config{
+		Exchanges: []ExchangeConfig{
+			{
+				Name:                    "Bitstamp",
+				Enabled:                 true,
+				AuthenticatedAPISupport: true,
+				APIKey:                  "Key",
+				APISecret:               "",
+				ClientID:                "ClientID",
+				AvailablePairs:          "BTC-USD,btc-eur,LTCUSD",
+				EnabledPairs:            "BTC-USD",
+				ConfigCurrencyPairFormat: &CurrencyPairFormatConfig{
+					Uppercase: true,
+					Delimiter: "-",
+				},
+				AssetTypes:       "SPOT",
+				DefaultAssetType: "FUTURES",
+			},
+			{
+				Name:    "Bitfinex",
+				Enabled: false,
+			},
+			{
+				Enabled: true,
+			},
+		},
+	}
+
+	errs := cfg.Validate()
+	expected := []string{
+		"exchange Bitstamp has authenticated API support enabled but no API key set",
+		"exchange Bitstamp has authenticated API support enabled but no API secret set",
+		"exchange Bitstamp has authenticated API support enabled but no client ID set",
+		"exchange Bitstamp pairs LTCUSD do not use the config pair format delimiter \"-\"",
+		"exchange Bitstamp pairs btc-eur do not match the config pair format uppercase setting true",
+		"exchange Bitstamp default asset type FUTURES is unknown, supported asset types: SPOT",
+		"exchange at index 2 is enabled but has no name",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Test failed. Validate expected %d errors, received %d: %s",
+			len(expected), len(errs), errs)
+	}
+
+	for x := range expected {
+		if errs[x].Error() != expected[x] {
+			t.Errorf("Test failed. Validate expected %q, received %q",
+				expected[x], errs[x])
+		}
+	}
+
+	if !strings.Contains(errs.Error(), expected[0]) {
+		t.Error("Test failed. ValidationErrors Error() missing validation error")
+	}
+}
+
+func TestValidateValidConfig(t *testing.T) {
+	cfg := Config{
+		Exchanges: []ExchangeConfig{
+			{
+				Name:                    "Bitfinex",
+				Enabled:                 true,
+				AuthenticatedAPISupport: true,
+				APIKey:                  "apikey",
+				APISecret:               "apisecret",
+				AvailablePairs:          "BTCUSD,LTCUSD",
+				EnabledPairs:            "BTCUSD",
+				ConfigCurrencyPairFormat: &CurrencyPairFormatConfig{
+					Uppercase: true,
+				},
+				AssetTypes:       "SPOT",
+				DefaultAssetType: "SPOT",
+			},
+		},
+	}
+
+	if errs := cfg.Validate(); errs != nil {
+		t.Error("Test failed. Validate unexpected errors", errs)
+	}
+}
+
+func TestValidateEnvironmentCredentials(t *testing.T) {
+	t.Setenv("OKEX_API_KEY", "envkey")
+	t.Setenv("OKEX_API_SECRET", "envsecret")
+	t.Setenv("GCT_TEST_CLIENT_ID", "envclient")
+
+	exchCfg := ExchangeConfig{
+		Name:                    "OKEX",
+		Enabled:                 true,
+		AuthenticatedAPISupport: true,
+		AvailablePairs:          "BTC_USDT",
+		EnabledPairs:            "BTC_USDT",
+		ConfigCurrencyPairFormat: &CurrencyPairFormatConfig{
+			Uppercase: true,
+			Delimiter: "_",
+		},
+	}
+	cfg := Config{Exchanges: []ExchangeConfig{exchCfg}}
+	if errs := cfg.Validate(); errs != nil {
+		t.Error("Test failed. Validate unexpected errors for environment credentials", errs)
+	}
+
+	exchCfg.Name = "COINUT"
+	exchCfg.APIKey = "env:GCT_TEST_API_KEY"
+	exchCfg.APISecret = "apisecret"
+	exchCfg.ClientID = "env:GCT_TEST_CLIENT_ID"
+	cfg = Config{Exchanges: []ExchangeConfig{exchCfg}}
+	errs := cfg.Validate()
+	expected := "exchange COINUT has authenticated API support enabled but the API key environment variable GCT_TEST_API_KEY is not set"
+	if len(errs) != 1 || errs[0].Error() != expected {
+		t.Errorf("Test failed. Validate expected %q, received %v", expected, errs)
+	}
+}
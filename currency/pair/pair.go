@@ -1,12 +1,23 @@
 package pair
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
 
 	"github.com/thrasher-/gocryptotrader/common"
 )
 
+// DefaultQuoteCurrencies are the quote currencies used to split undelimited
+// symbols when no known quote currencies are supplied
+var DefaultQuoteCurrencies = []string{"USDT", "BTC", "ETH", "USD"}
+
+// ErrUnableToSplitSymbol is returned when a symbol cannot be split into a
+// currency pair
+var ErrUnableToSplitSymbol = errors.New("unable to split symbol into a currency pair")
+
 // CurrencyItem is an exported string with methods to manipulate the data instead
 // of using array/slice access modifiers
 type CurrencyItem string
@@ -81,6 +92,20 @@ func (c CurrencyPair) Swap() CurrencyPair {
 	return p
 }
 
+// Normalize returns the canonical form of the pair, both currencies uppercased
+// with no delimiter, so the same logical pair keys the same regardless of the
+// format it was created with. A pair whose currencies were never split is
+// split first
+func (c CurrencyPair) Normalize() CurrencyPair {
+	if c.SecondCurrency == "" && c.FirstCurrency != "" {
+		c = NewCurrencyPairFromString(c.FirstCurrency.String())
+	}
+	return CurrencyPair{
+		FirstCurrency:  c.FirstCurrency.Upper(),
+		SecondCurrency: c.SecondCurrency.Upper(),
+	}
+}
+
 // Empty returns whether or not the pair is empty
 func (c CurrencyPair) Empty() bool {
 	if c.FirstCurrency == "" || c.SecondCurrency == "" {
@@ -90,9 +115,15 @@ func (c CurrencyPair) Empty() bool {
 }
 
 // NewCurrencyPairDelimiter splits the desired currency string at delimeter,
-// the returns a CurrencyPair struct
+// the returns a CurrencyPair struct. A currency string without the delimiter
+// is split as an undelimited symbol
 func NewCurrencyPairDelimiter(currency, delimiter string) CurrencyPair {
 	result := strings.Split(currency, delimiter)
+	if len(result) < 2 {
+		p := newCurrencyPairFromUndelimited(currency)
+		p.Delimiter = delimiter
+		return p
+	}
 	return CurrencyPair{
 		Delimiter:      delimiter,
 		FirstCurrency:  CurrencyItem(result[0]),
@@ -109,13 +140,45 @@ func NewCurrencyPair(firstCurrency, secondCurrency string) CurrencyPair {
 }
 
 // NewCurrencyPairFromIndex returns a CurrencyPair via a currency string and
-// specific index
+// specific index, the index is matched regardless of case. A currency string
+// without the index is split as an undelimited symbol
 func NewCurrencyPairFromIndex(currency, index string) CurrencyPair {
-	i := strings.Index(currency, index)
-	if i == 0 {
+	i := strings.Index(strings.ToUpper(currency), strings.ToUpper(index))
+	switch {
+	case i < 0 || index == "" || len(currency) == len(index):
+		return newCurrencyPairFromUndelimited(currency)
+	case i == 0:
 		return NewCurrencyPair(currency[0:len(index)], currency[len(index):])
+	default:
+		return NewCurrencyPair(currency[0:i], currency[i:])
 	}
-	return NewCurrencyPair(currency[0:i], currency[i:])
+}
+
+// newCurrencyPairFromUndelimited splits an undelimited symbol into a
+// CurrencyPair. The symbol is split before a known quote currency suffix, then
+// after a known quote currency prefix and otherwise after its first three
+// characters, so symbols such as "DASHBTC" and "USDTUSD" split correctly.
+// Delimiters within symbols such as "XBT7D_U105" are kept as part of the
+// currency rather than split at
+func newCurrencyPairFromUndelimited(currency string) CurrencyPair {
+	if !strings.ContainsAny(currency, "_-/") {
+		p, err := NewCurrencyPairFromSymbol(currency, nil)
+		if err == nil {
+			return p
+		}
+	}
+
+	upper := strings.ToUpper(currency)
+	for _, quote := range DefaultQuoteCurrencies {
+		if len(upper) > len(quote) && strings.HasPrefix(upper, quote) {
+			return NewCurrencyPair(currency[:len(quote)], currency[len(quote):])
+		}
+	}
+
+	if len(currency) <= 3 {
+		return NewCurrencyPair(currency, "")
+	}
+	return NewCurrencyPair(currency[0:3], currency[3:])
 }
 
 // NewCurrencyPairFromString converts currency string into a new CurrencyPair
@@ -129,7 +192,58 @@ func NewCurrencyPairFromString(currency string) CurrencyPair {
 			return NewCurrencyPairDelimiter(currency, delimiter)
 		}
 	}
-	return NewCurrencyPair(currency[0:3], currency[3:])
+	return newCurrencyPairFromUndelimited(currency)
+}
+
+// NewCurrencyPairFromSymbol converts a symbol into a new CurrencyPair. A
+// delimited symbol such as "BTC-USDT" is split at its delimiter, otherwise the
+// symbol such as "ltcbtc" is split by matching its suffix against the known
+// quote currencies. An error is returned if the symbol matches more than one
+// or none of the known quote currencies
+func NewCurrencyPairFromSymbol(symbol string, knownQuotes []string) (CurrencyPair, error) {
+	for _, delimiter := range []string{"_", "-", "/"} {
+		if !strings.Contains(symbol, delimiter) {
+			continue
+		}
+		result := strings.Split(symbol, delimiter)
+		if len(result) != 2 || result[0] == "" || result[1] == "" {
+			return CurrencyPair{}, fmt.Errorf("%s: %s", ErrUnableToSplitSymbol, symbol)
+		}
+		return NewCurrencyPairDelimiter(symbol, delimiter), nil
+	}
+
+	if len(knownQuotes) == 0 {
+		knownQuotes = DefaultQuoteCurrencies
+	}
+
+	var matches []CurrencyPair
+	upper := strings.ToUpper(symbol)
+	for _, quote := range knownQuotes {
+		quote = strings.ToUpper(quote)
+		if len(upper) <= len(quote) || !strings.HasSuffix(upper, quote) {
+			continue
+		}
+		i := len(symbol) - len(quote)
+		matches = append(matches, NewCurrencyPair(symbol[:i], symbol[i:]))
+	}
+
+	switch len(matches) {
+	case 0:
+		return CurrencyPair{}, fmt.Errorf("%s: %s no known quote currency found",
+			ErrUnableToSplitSymbol, symbol)
+	case 1:
+		return matches[0], nil
+	default:
+		var candidates []string
+		for x := range matches {
+			candidates = append(candidates, matches[x].FirstCurrency.String()+"/"+
+				matches[x].SecondCurrency.String())
+		}
+		return CurrencyPair{}, fmt.Errorf("%s: %s is ambiguous, matches %s",
+			ErrUnableToSplitSymbol,
+			symbol,
+			common.JoinStrings(candidates, ", "))
+	}
 }
 
 // Contains checks to see if a specified pair exists inside a currency pair
@@ -177,7 +291,7 @@ func FormatPairs(pairs []string, delimiter, index string) []CurrencyPair {
 			if index != "" {
 				p = NewCurrencyPairFromIndex(pairs[x], index)
 			} else {
-				p = NewCurrencyPair(pairs[x][0:3], pairs[x][3:])
+				p = newCurrencyPairFromUndelimited(pairs[x])
 			}
 		}
 		result = append(result, p)
@@ -185,6 +299,15 @@ func FormatPairs(pairs []string, delimiter, index string) []CurrencyPair {
 	return result
 }
 
+// SortPairs sorts a list of currency pairs in place by their formatted pair
+// string and returns the list
+func SortPairs(pairs []CurrencyPair) []CurrencyPair {
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Pair().String() < pairs[j].Pair().String()
+	})
+	return pairs
+}
+
 // CopyPairFormat copies the pair format from a list of pairs once matched
 func CopyPairFormat(p CurrencyPair, pairs []CurrencyPair, exact bool) CurrencyPair {
 	for x := range pairs {
@@ -1,6 +1,7 @@
 package pair
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -154,6 +155,26 @@ func TestSwap(t *testing.T) {
 	}
 }
 
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+	expected := CurrencyPair{FirstCurrency: "BTC", SecondCurrency: "USD"}
+	pairs := []CurrencyPair{
+		NewCurrencyPair("BTC", "USD"),
+		NewCurrencyPair("btc", "usd"),
+		NewCurrencyPairDelimiter("btc_usd", "_"),
+		NewCurrencyPairDelimiter("BTC-USD", "-"),
+		{FirstCurrency: "btcusd"},
+	}
+
+	for x := range pairs {
+		actual := pairs[x].Normalize()
+		if actual != expected {
+			t.Errorf("Test failed. TestNormalize: %+v normalized to %+v, expected %+v",
+				pairs[x], actual, expected)
+		}
+	}
+}
+
 func TestEmpty(t *testing.T) {
 	t.Parallel()
 	pair := NewCurrencyPair("BTC", "USD")
@@ -320,6 +341,132 @@ func TestFormatPairs(t *testing.T) {
 	}
 }
 
+func TestFormatPairsRoundTrip(t *testing.T) {
+	t.Parallel()
+	pairs := []CurrencyPair{
+		NewCurrencyPair("BTC", "USD"),
+		NewCurrencyPair("DASH", "BTC"),
+		NewCurrencyPair("USDT", "BTC"),
+		NewCurrencyPair("BTC", "USDT"),
+		NewCurrencyPair("USDT", "USD"),
+		NewCurrencyPair("USDT", "SGD"),
+		NewCurrencyPair("ETH", "USDC"),
+		NewCurrencyPair("LTC", "EUR"),
+		NewCurrencyPair("XBT", "7D_U105"),
+	}
+
+	for _, delimiter := range []string{"", "-", "_", "/"} {
+		for _, uppercase := range []bool{true, false} {
+			for x := range pairs {
+				if delimiter != "" && strings.Contains(pairs[x].SecondCurrency.String(), delimiter) {
+					continue
+				}
+
+				formatted := pairs[x].Display(delimiter, uppercase).String()
+				result := FormatPairs([]string{formatted}, delimiter, "")
+				if len(result) != 1 || !result[0].Equal(pairs[x], true) {
+					t.Errorf("Test failed. TestFormatPairsRoundTrip: %s with delimiter %q uppercase %v parsed as %v",
+						formatted, delimiter, uppercase, result)
+				}
+			}
+		}
+	}
+
+	for _, uppercase := range []bool{true, false} {
+		for _, p := range []CurrencyPair{NewCurrencyPair("BTC", "USD"), NewCurrencyPair("DOGE", "BTC")} {
+			formatted := p.Display("", uppercase).String()
+			result := FormatPairs([]string{formatted}, "", "BTC")
+			if len(result) != 1 || !result[0].Equal(p, true) {
+				t.Errorf("Test failed. TestFormatPairsRoundTrip: %s with index BTC uppercase %v parsed as %v",
+					formatted, uppercase, result)
+			}
+		}
+	}
+}
+
+func TestFormatPairsEdgeCases(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		symbol, delimiter, index string
+		first, second            CurrencyItem
+	}{
+		{"BTCUSD", "-", "", "BTC", "USD"},
+		{"ltcbtc", "", "BTC", "ltc", "btc"},
+		{"ETHUSD", "", "BTC", "ETH", "USD"},
+		{"BTC", "", "BTC", "BTC", ""},
+		{"BT", "", "", "BT", ""},
+	}
+
+	for x := range testCases {
+		result := FormatPairs([]string{testCases[x].symbol}, testCases[x].delimiter, testCases[x].index)
+		if len(result) != 1 || result[0].FirstCurrency != testCases[x].first ||
+			result[0].SecondCurrency != testCases[x].second {
+			t.Errorf("Test failed. TestFormatPairsEdgeCases: %s unexpected pair %v",
+				testCases[x].symbol, result)
+		}
+	}
+}
+
+func TestNewCurrencyPairFromSymbol(t *testing.T) {
+	testCases := []struct {
+		symbol        string
+		first, second CurrencyItem
+	}{
+		{"ltcbtc", "ltc", "btc"},
+		{"BTCUSDT", "BTC", "USDT"},
+		{"BTCUSD", "BTC", "USD"},
+		{"ethbtc", "eth", "btc"},
+		{"BTC-USDT", "BTC", "USDT"},
+		{"ltc_btc", "ltc", "btc"},
+	}
+
+	for x := range testCases {
+		p, err := NewCurrencyPairFromSymbol(testCases[x].symbol, nil)
+		if err != nil {
+			t.Errorf("Test failed. TestNewCurrencyPairFromSymbol %s error: %s",
+				testCases[x].symbol, err)
+			continue
+		}
+		if p.FirstCurrency != testCases[x].first || p.SecondCurrency != testCases[x].second {
+			t.Errorf("Test failed. TestNewCurrencyPairFromSymbol %s unexpected pair %s %s",
+				testCases[x].symbol, p.FirstCurrency, p.SecondCurrency)
+		}
+	}
+
+	_, err := NewCurrencyPairFromSymbol("BTCUSDT", []string{"USDT", "SDT"})
+	if err == nil {
+		t.Error("Test failed. TestNewCurrencyPairFromSymbol expected ambiguous error")
+	}
+
+	_, err = NewCurrencyPairFromSymbol("BTCEUR", nil)
+	if err == nil {
+		t.Error("Test failed. TestNewCurrencyPairFromSymbol expected unknown quote error")
+	}
+
+	_, err = NewCurrencyPairFromSymbol("USDT", nil)
+	if err == nil {
+		t.Error("Test failed. TestNewCurrencyPairFromSymbol expected error for quote only symbol")
+	}
+
+	_, err = NewCurrencyPairFromSymbol("BTC-USDT-SWAP", nil)
+	if err == nil {
+		t.Error("Test failed. TestNewCurrencyPairFromSymbol expected error for multiple delimiters")
+	}
+}
+
+func TestSortPairs(t *testing.T) {
+	pairs := FormatPairs([]string{"LTC-USD", "BTC-USD", "ETH-BTC", "BTC-AUD"}, "-", "")
+	expected := []string{"BTC-AUD", "BTC-USD", "ETH-BTC", "LTC-USD"}
+
+	actual := PairsToStringArray(SortPairs(pairs))
+	for x := range expected {
+		if actual[x] != expected[x] {
+			t.Errorf("Test failed. TestSortPairs: Expected %s, received %s",
+				expected[x], actual[x])
+		}
+	}
+}
+
 func TestCopyPairFormat(t *testing.T) {
 	pairOne := NewCurrencyPair("BTC", "USD")
 	pairOne.Delimiter = "-"
@@ -1,6 +1,9 @@
 package symbol
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // Const declarations for individual currencies/tokens/fiat
 // An ever growing list. Cares not for equivalence, just is
@@ -1636,3 +1639,214 @@ func GetSymbolByCurrencyName(currency string) (string, error) {
 	}
 	return result, nil
 }
+
+// fiatCurrencies holds the ISO 4217 fiat currency codes, along with the
+// withdrawn codes and exchange specific aliases still found in exchange data
+var fiatCurrencies = []string{
+	"AED", "AFN", "ALL", "AMD", "ANG", "AOA", "ARS", "AUD", "AWG", "AZN",
+	"BAM", "BBD", "BDT", "BGN", "BHD", "BIF", "BMD", "BND", "BOB", "BRL",
+	"BSD", "BTN", "BWP", "BYN", "BZD", "CAD", "CDF", "CHF", "CLP", "CNY",
+	"COP", "CRC", "CUC", "CUP", "CVE", "CZK", "DJF", "DKK", "DOP", "DZD",
+	"EGP", "ERN", "ETB", "EUR", "FJD", "FKP", "GBP", "GEL", "GGP", "GHS",
+	"GIP", "GMD", "GNF", "GTQ", "GYD", "HKD", "HNL", "HRK", "HTG", "HUF",
+	"IDR", "ILS", "IMP", "INR", "IQD", "IRR", "ISK", "JEP", "JMD", "JOD",
+	"JPY", "KES", "KGS", "KHR", "KMF", "KPW", "KRW", "KWD", "KYD", "KZT",
+	"LAK", "LBP", "LKR", "LRD", "LSL", "LYD", "MAD", "MDL", "MGA", "MKD",
+	"MMK", "MNT", "MOP", "MRU", "MUR", "MVR", "MWK", "MXN", "MYR", "MZN",
+	"NAD", "NGN", "NIO", "NOK", "NPR", "NZD", "OMR", "PAB", "PEN", "PGK",
+	"PHP", "PKR", "PLN", "PYG", "QAR", "RON", "RSD", "RUB", "RUR", "RWF",
+	"SAR", "SBD", "SCR", "SDG", "SEK", "SGD", "SHP", "SLL", "SOS", "SRD",
+	"SSP", "STN", "SVC", "SYP", "SZL", "THB", "TJS", "TMT", "TND", "TOP",
+	"TRY", "TTD", "TVD", "TWD", "TZS", "UAH", "UGX", "USD", "UYU", "UZS",
+	"VEF", "VES", "VND", "VUV", "WST", "XAF", "XCD", "XOF", "XPF", "YER",
+	"ZAR", "ZCAD", "ZEUR", "ZJPY", "ZMW", "ZUSD", "ZWD", "ZWL",
+}
+
+// cryptocurrencies holds the cryptocurrency and token codes declared above.
+// Codes shared with a fiat currency, such as SBD, are classed as fiat
+var cryptocurrencies = []string{
+	BTC, LTC, ETH, XRP, BCH, EOS, XLM, USDT, ADA, XMR,
+	TRX, MIOTA, DASH, BNB, NEO, ETC, XEM, XTZ, VET, DOGE,
+	ZEC, OMG, BTG, MKR, BCN, ONT, ZRX, LSK, DCR, QTUM,
+	BCD, BTS, NANO, ZIL, SC, DGB, ICX, STEEM, AE, XVG,
+	WAVES, NPXS, ETN, BTM, BAT, ETP, HOT, STRAT, GNT, REP,
+	SNT, PPT, KMD, TUSD, CNX, LINK, WTC, ARDR, WAN, MITH,
+	RDD, IOST, IOT, KCS, MAID, XET, MOAC, HC, AION, HT,
+	ELF, LRC, BNT, CMT, DGD, DCN, FUN, GXS, DROP, MANA,
+	PAY, MCO, THETA, NXT, NOAH, LOOM, POWR, WAX, ELA, PIVX,
+	XIN, DAI, BTCP, NEXO, XBT, SAN, GAS, BCC, HCC, OAX,
+	DNT, ICN, LLT, YOYO, SNGLS, BQX, KNC, SNM, CTR, SALT,
+	MDA, IOTA, SUB, MTL, MTH, ENG, AST, CLN, EDG, FIRST,
+	GOLOS, ANT, GBG, HMQ, INCNT, ACE, ACT, AAC, AIDOC, SOC,
+	ATL, AVT, BKX, BEC, VEE, PTOY, CAG, CIC, CBT, CAN,
+	DAT, DNA, INT, IPC, ILA, LIGHT, MAG, AMM, MOF, MGC,
+	OF, LA, LEV, NGC, OKB, MOT, PRA, R, SSC, SHOW,
+	SPF, SNC, SWFTC, TRA, TOPC, TRIO, QVT, UCT, UKG, UTK,
+	VIU, WFEE, WRC, UGC, YEE, YOYOW, ZIP, READ, RCT, REF,
+	XUC, FAIR, GSC, HMC, PLU, PRO, QRL, REN, ROUND, SRN,
+	XID, TAAS, TKN, VEN, VSL, TRST, XXX, IND, LDC, GUP,
+	MGO, MYST, NEU, NET, BMC, BCAP, TIME, CFI, EVX, REQ,
+	VIB, ARK, MOD, ENJ, STORJ, RCN, NULS, RDN, DLT, AMB,
+	BCPT, ARN, GVT, CDT, POE, QSP, XZC, TNT, FUEL, ADX,
+	CND, LEND, WABI, SBTC, BCX, TNB, GTO, OST, CVC, DATA,
+	ETF, BRD, NEBL, VIBE, LUN, CHAT, RLC, INS, VIA, BLZ,
+	SYS, NCASH, POA, STORM, WPR, QLC, GRS, CLOAK, ZEN, SKY,
+	IOTX, QKC, AGI, NXS, EON, KEY, NAS, ADD, MEETONE, ATD,
+	MFT, EOP, DENT, IQ, DOCK, POLY, VTHO, ONG, PHX, GO,
+	PAX, EDO, WINGS, NAV, TRIG, APPC, HSR, ETHOS, CTXC, ITC,
+	TRUE, ABT, RNT, PLY, PST, KICK, BTCZ, DXT, STQ, INK,
+	HBZ, USDT_ETH, QTUM_ETH, BTM_ETH, FIL, STX, BOT, VERI, ZSC, QBT,
+	MED, QASH, MDS, GOD, SMT, BTF, NAS_ETH, TSL, BIFI, BNTY,
+	DRGN, GTC, MDT, QUN, GNX, DDD, BTO, TIO, OCN, RUFF,
+	TNC, SNET, COFI, ZPT, JNT, MTN, GEM, DADI, RFR, MOBI,
+	LEDU, DBC, MKR_OLD, DPY, BCDN, EOSDAC, TIPS, XMC, PPS, BOE,
+	MEDX, SMT_ETH, CS, MAN, REM, LYM, INSTAR, BFT, IHT, SENC,
+	TOMO, ELEC, SHIP, TFD, HAV, HUR, LST, LINO, SWTH, NKN,
+	SOUL, GALA_NEO, LRN, GSE, RATING, HSC, HIT, DX, BXC, GARD,
+	FTI, SOP, LEMO, RED, LBA, KAN, OPEN, SKM, NBAI, UPP,
+	ATMI, BBK, EDR, MET, TCT, EXC, CNC, TIX, XTC, BU,
+	GNO, MLN, XBC, BTCD, BURST, CLAM, XCP, EMC2, EXP, FCT,
+	GAME, GRC, HUC, LBC, NMC, NEOS, OMNI, PASC, PPC, DSH,
+	GML, GSY, POT, XPM, AMP, VRC, VTC, ZERO07, BIT16, TWO015,
+	TWO56, TWOBACCO, TWOGIVE, THIRTY2BIT, THREE65, FOUR04, SEVEN00, EIGHTBIT, ACLR, ACES,
+	ACPR, ACID, ACOIN, ACRN, ADAM, ADT, AIB, ADZ, AECC, AM,
+	AGRI, AGT, AIR, ALEX, AUM, ALIEN, ALIS, ASAFE, AMBER, AMS,
+	ANAL, ACP, ANI, ANTI, ALTC, APT, ARCO, ALC, ARB, ARCT,
+	ARCX, ARGUS, ARH, ARM, ARNA, ARPA, ARTA, ABY, ARTC, AL,
+	ASN, ADCN, ATB, ATM, ATMCHA, ATOM, ADC, ARE, AUR, AV,
+	AXIOM, B2B, B2, B3, BAB, BAN, BamitCoin, NANAS, BBCC, BTA,
+	BSTK, BATL, BBH, BITB, BRDD, XBTS, BVC, CHATX, BEEP, BEEZ,
+	BENJI, BERN, PROFIT, BEST, BGF, BIGUP, BLRY, BILL, BIOB, BIO,
+	BIOS, BPTN, BTCA, BA, BAC, BBT, BOSS, BRONZ, CAT, BTD,
+	XBTC21, BCA, BCP, BTDOLL, LIZA, BTCRED, BTCS, BTU, BUM, LITE,
+	BCM, BCS, BTCU, BM, BTCRY, BTCR, HIRE, STU, BITOK, BITON,
+	BPC, BPOK, BTP, BITCNY, RNTB, BSH, XBS, BITS, BST, BXT,
+	VEG, VOLT, BTV, BITZ, BTZ, BHC, BDC, JACK, BS, BSTAR,
+	BLAZR, BOD, BLUE, BLU, BLUS, BMT, BOLI, BOMB, BON, BOOM,
+	BOSON, BSC, BRH, BRAIN, BRE, BTCM, BTCO, TALK, BUB, BUY,
+	BUZZ, BTH, C0C0, CAB, CF, CLO, CAM, CD, CANN, CNNC,
+	CPC, CST, CAPT, CARBON, CME, CTK, CBD, CCC, CNT, XCE,
+	CHRG, CHEMX, CHESS, CKS, CHILL, CHIP, CHOOF, CRX, CIN, POLL,
+	CLICK, CLINT, CLUB, CLUD, COX, COXST, CFC, CTIC2, COIN, BTTF,
+	C2, CAID, CL, CTIC, CXT, CHP, CV2, COC, COMP, CMS,
+	CONX, CCX, CLR, CORAL, CORG, CSMIC, CMC, COV, COVX, CRAB,
+	CRAFT, CRNK, CRAVE, CRM, XCRE, CREDIT, CREVA, CRIME, CROC, CRW,
+	CRY, CBX, TKTX, CB, CIRC, CCB, CDO, CG, CJ, CJC,
+	CYT, CRPS, PING, CWXT, CCT, CTL, CURVES, CC, CYC, CYG,
+	CYP, FUNK, CZECO, DALC, DLISK, MOOND, DB, DCC, DCYP, DETH,
+	DKC, DISK, DRKT, DTT, DASHS, DBTC, DCT, DBET, DEC, DECR,
+	DEA, DPAY, DCRE, DC, DES, DEM, DXC, DCK, CUBE, DGMS,
+	DBG, DGCS, DBLK, DIME, DIRT, DVD, DMT, NOTE, DGORE, DLC,
+	DRT, DOTA, DOX, DRA, DFT, XDB, DRM, DRZ, DRACO, DBIC,
+	DUB, GUM, DUR, DUST, DUX, DXO, ECN, EDR2, EA, EAGS,
+	EMT, EBONUS, ECCHI, EKO, ECLI, ECOB, ECO, EDIT, EDRC, EDC,
+	EGAME, EGG, EGO, ELC, ELCO, ECA, EPC, ELE, ONE337, EMB,
+	EMC, EPY, EMPC, EMP, ENE, EET, XNG, EGMA, ENTER, ETRUST,
+	EQL, EQM, EQT, ERR, ESC, ESP, ENT, ETCO, DOGETH, ECASH,
+	ELITE, ETHS, ETL, ETZ, EUC, EURC, EUROPE, EVA, EGC, EOC,
+	EVIL, EVO, EXB, EXIT, XT, F16, FADE, FAZZ, FX, FIDEL,
+	FIDGT, FIND, FPC, FIRE, FFC, FRST, FIST, FIT, FLX, FLVR,
+	FLY, FONZ, XFCX, FOREX, FRN, FRK, FRWC, FGZ, FRE, FRDC,
+	FJC, FURY, FSN, FCASH, FTO, FUZZ, GAKH, GBT, UNITS, FOUR20G,
+	GENIUS, GEN, GEO, GER, GSR, SPKTR, GIFT, WTT, GIG, GOT,
+	XGTC, GIZ, GLO, GCR, BSTY, GLC, GSX, GOAT, GB, GFL,
+	MNTP, GP, GLUCK, GOON, GTFO, GOTX, GPU, GRF, GRAM, GRAV,
+	GBIT, GREED, GE, GREENF, GRE, GREXIT, GMCX, GROW, GSM, GT,
+	NLG, HKN, HAC, HALLO, HAMS, HPC, HAWK, HAZE, HZT, HDG,
+	HEDG, HEEL, HMP, PLAY, HXX, XHI, HVCO, HTC, MINH, HODL,
+	HON, HOPE, HQX, HSP, HTML5, HYPERX, HPS, IOC, IBANK, IBITS,
+	ICASH, ICOB, ICON, IETH, ILM, IMPS, NKA, INCP, IN, INC,
+	IMS, IFLT, INFX, INGT, INPAY, INSANE, INXT, IFT, INV, IVZ,
+	ILT, IONX, ISL, ITI, ING, IEC, IW, IXC, IXT, JPC,
+	JANE, JWL, JIF, JOBS, JOCKER, JW, JOK, XJO, KGB, KARMC,
+	KARMA, KASHH, KAT, KC, KIDS, KIN, KISS, KOBO, TP1, KRAK,
+	KGC, KTK, KR, KUBO, KURT, KUSH, LANA, LTH, LAZ, LEA,
+	LEAF, LENIN, LEPEN, LIR, LVG, LGBTQ, LHC, EXT, LBTC, LSD,
+	LIMX, LTD, LINDA, LKC, LBTCX, LCC, LTCU, LTCR, LDOGE, LTS,
+	LIV, LIZI, LOC, LOCX, LOOK, LOOT, XLTCG, BASH, LUCKY, L7S,
+	LDM, LUMI, LUNA, LC, LUX, MCRN, XMG, MMXIV, MAT, MAO,
+	MAPC, MRB, MXT, MARV, MARX, MCAR, MM, MVC, MAVRO, MAX,
+	MAZE, MBIT, MCOIN, MPRO, XMS, MLITE, MLNC, MENTAL, MERGEC, MTLMC3,
+	METAL, MUU, MILO, MND, XMINE, MNM, XNM, MIRO, MIS, MMXVI,
+	MOIN, MOJO, TAB, MONETA, MUE, MONEY, MRP, MOTO, MULTI, MST,
+	MYSTIC, WISH, NKT, NAT, ENAU, NEBU, NEF, NBIT, NETKO, NTM,
+	NETC, NRC, NTK, NTRN, NEVA, NIC, NKC, NYC, NZC, NICE,
+	NDOGE, XTR, N2O, NIXON, NOC, NODC, NODES, NODX, NLC, NLC2,
+	NOO, NVC, NPC, NUBIS, NUKE, N7, NUM, NMR, NXE, OBS,
+	OCEAN, OCOW, EIGHT88, OCC, OK, ODNT, FLAV, OLIT, OLYMP, OMA,
+	OMC, ONEK, ONX, XPO, OPAL, OTN, OP, OPES, OPTION, ORLY,
+	OS76, OZC, P7C, PAC, PAK, PAL, PND, PINKX, POPPY, DUO,
+	PARA, PKB, GENE, PARTY, PYN, XPY, CON, PAYP, GUESS, PTA,
+	PEO, PSB, XPD, PXL, PHR, PIE, PIO, PIPR, SKULL, PLANET,
+	PNC, XPTX, PLNC, XPS, POKE, PLBT, POM, PONZ2, PONZI, XSP,
+	XPC, PEX, TRON, POST, POSW, PWR, POWER, PRE, PRS, PXI,
+	PEXT, PRIMU, PRX, PRM, PRIX, XPRO, PCM, PROC, NANOX, VRP,
+	PTY, PSI, PSY, PULSE, PUPA, PURE, VIDZ, PUTIN, PX, QTM,
+	QTZ, QBC, XQN, RBBT, RAC, RADI, RAD, RAI, XRA, RATIO,
+	REA, RCX, REE, REC, RMS, RBIT, RNC, REV, RH, XRL,
+	RICE, RICHX, RID, RIDE, RBT, RING, RIO, RISE, ROCKET, RPC,
+	ROS, ROYAL, RSGP, RBIES, RUBIT, RBY, RUC, RUPX, RUP, RUST,
+	SFE, SLS, SMSR, RONIN, STV, HIFUN, SANDG, STO, SCAN, SCITW,
+	SCRPT, SCRT, SED, SEEDS, B2X, SEL, SLFI, SMBR, SEN, SENT,
+	SRNT, SEV, SP, SXC, GELD, SHDW, SDC, SAK, SHRP, SHELL,
+	SH, SHORTY, SHREK, SHRM, SIB, SIGT, SLCO, SIGU, SIX, SJW,
+	SKB, SW, SLEEP, SLING, SMART, SMC, SMF, SOCC, SCL, SDAO,
+	SOLAR, SOLO, SCT, SONG, ALTCOM, SPHTX, SPC, SPACE, SBT, SPEC,
+	SPX, SCS, SPORT, SPT, SPR, SPEX, SQL, SBIT, STHR, STALIN,
+	STAR, STA, START, STP, PNK, STEPS, STK, STONK, STS, STRP,
+	STY, XMT, SSTC, SUPER, SRND, STRB, M1, SPM, BUCKS, TOKEN,
+	SWT, SWEET, SWING, CHSB, SIC, SDP, XSY, SYNX, SNRG, TAG,
+	TAGR, TAJ, TAK, TAKE, TAM, XTO, TAP, TLE, TSE, TLEX,
+	TAXI, TCN, TDFB, TEAM, TECH, TEC, TEK, TB, TLX, TELL,
+	TENNET, TES, TGS, XVE, TCR, GCC, MAY, THOM, TIA, TIDE,
+	TIE, TIT, TTC, TODAY, TBX, TDS, TLOSH, TOKC, TMRW, TOOL,
+	TCX, TOT, TX, TRANSF, TRAP, TBCX, TRICK, TPG, TFL, TRUMP,
+	TNG, TUR, TWERK, TWIST, TWO, UCASH, UAE, XBU, UBQ, U,
+	UDOWN, GAIN, USC, UMC, UNF, UNIFY, USDE, UBTC, UIS, UNIT,
+	UNI, UXC, URC, XUP, UFR, URO, UTLE, VAL, VPRC, VAPOR,
+	VCOIN, VEC, VEC2, VLT, VENE, VNTX, VTN, CRED, VERS, VTX,
+	VTY, VIP, VISIO, VK, VOL, VOYA, VPN, XVS, VTL, VULC,
+	VVI, WGR, WAM, WARP, WASH, WGO, WAY, WCASH, WEALTH, WEEK,
+	WHO, WIC, WBB, WINE, WINK, WISC, WITCH, WMC, WOMEN, WOK,
+	WRT, XCO, X2, XNX, XAU, XAV, XDE2, XDE, XIOS, XOC,
+	XSSX, XBY, YAC, YMC, YAY, YBC, YES, YOB2X, YOVI, ZYD,
+	ZECD, ZEIT, ZENI, ZET2, ZET, ZMC, ZIRK, ZLQ, ZNE, ZONTO,
+	ZOOM, ZRC, ZUR, ZB, QC, HLC, SAFE, CDC, DDM, HOTC,
+	BDS, AAA, XWC, PDX, SLT, HPY, XXBT, XDG, LCH,
+}
+
+var (
+	fiatLookup   = newLookup(fiatCurrencies)
+	cryptoLookup = newLookup(cryptocurrencies)
+)
+
+// newLookup returns an upper case set of the supplied codes
+func newLookup(codes []string) map[string]bool {
+	lookup := make(map[string]bool, len(codes))
+	for x := range codes {
+		lookup[strings.ToUpper(codes[x])] = true
+	}
+	return lookup
+}
+
+// GetFiatCurrencies returns the known fiat currency codes
+func GetFiatCurrencies() []string {
+	return append([]string(nil), fiatCurrencies...)
+}
+
+// GetCryptocurrencies returns the known cryptocurrency codes
+func GetCryptocurrencies() []string {
+	return append([]string(nil), cryptocurrencies...)
+}
+
+// IsFiat returns whether a currency code is a known fiat currency, ignoring
+// case
+func IsFiat(currency string) bool {
+	return fiatLookup[strings.ToUpper(currency)]
+}
+
+// IsCryptocurrency returns whether a currency code is a known cryptocurrency,
+// ignoring case
+func IsCryptocurrency(currency string) bool {
+	return cryptoLookup[strings.ToUpper(currency)]
+}
@@ -19,3 +19,50 @@ func TestGetSymbolByCurrencyName(t *testing.T) {
 	}
 
 }
+
+func TestIsFiat(t *testing.T) {
+	for _, currency := range []string{"USD", "eur", "KRW", "PEN", "ZUSD"} {
+		if !IsFiat(currency) {
+			t.Errorf("Test failed. IsFiat expected %s to be fiat", currency)
+		}
+	}
+
+	for _, currency := range []string{"BTC", "usdt", "SBDX", ""} {
+		if IsFiat(currency) {
+			t.Errorf("Test failed. IsFiat expected %s not to be fiat", currency)
+		}
+	}
+}
+
+func TestIsCryptocurrency(t *testing.T) {
+	for _, currency := range []string{"BTC", "eth", "XXBT", "bitCNY"} {
+		if !IsCryptocurrency(currency) {
+			t.Errorf("Test failed. IsCryptocurrency expected %s to be a cryptocurrency", currency)
+		}
+	}
+
+	for _, currency := range []string{"USD", "SBD", "BLAH"} {
+		if IsCryptocurrency(currency) {
+			t.Errorf("Test failed. IsCryptocurrency expected %s not to be a cryptocurrency", currency)
+		}
+	}
+}
+
+func TestGetCurrencyLists(t *testing.T) {
+	fiat := GetFiatCurrencies()
+	crypto := GetCryptocurrencies()
+	if len(fiat) == 0 || len(crypto) == 0 {
+		t.Fatal("Test failed. GetFiatCurrencies and GetCryptocurrencies returned empty lists")
+	}
+
+	for x := range crypto {
+		if IsFiat(crypto[x]) {
+			t.Errorf("Test failed. %s is classed as both fiat and a cryptocurrency", crypto[x])
+		}
+	}
+
+	fiat[0] = "BLAH"
+	if GetFiatCurrencies()[0] == "BLAH" {
+		t.Error("Test failed. GetFiatCurrencies returned the backing list")
+	}
+}
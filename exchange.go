@@ -6,36 +6,38 @@ import (
 	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
-	"github.com/thrasher-/gocryptotrader/exchanges/anx"
-	"github.com/thrasher-/gocryptotrader/exchanges/binance"
-	"github.com/thrasher-/gocryptotrader/exchanges/bitfinex"
-	"github.com/thrasher-/gocryptotrader/exchanges/bitflyer"
-	"github.com/thrasher-/gocryptotrader/exchanges/bithumb"
-	"github.com/thrasher-/gocryptotrader/exchanges/bitmex"
-	"github.com/thrasher-/gocryptotrader/exchanges/bitstamp"
-	"github.com/thrasher-/gocryptotrader/exchanges/bittrex"
-	"github.com/thrasher-/gocryptotrader/exchanges/btcc"
-	"github.com/thrasher-/gocryptotrader/exchanges/btcmarkets"
-	"github.com/thrasher-/gocryptotrader/exchanges/coinbasepro"
-	"github.com/thrasher-/gocryptotrader/exchanges/coinut"
-	"github.com/thrasher-/gocryptotrader/exchanges/exmo"
-	"github.com/thrasher-/gocryptotrader/exchanges/gateio"
-	"github.com/thrasher-/gocryptotrader/exchanges/gemini"
-	"github.com/thrasher-/gocryptotrader/exchanges/hitbtc"
-	"github.com/thrasher-/gocryptotrader/exchanges/huobi"
-	"github.com/thrasher-/gocryptotrader/exchanges/huobihadax"
-	"github.com/thrasher-/gocryptotrader/exchanges/itbit"
-	"github.com/thrasher-/gocryptotrader/exchanges/kraken"
-	"github.com/thrasher-/gocryptotrader/exchanges/lakebtc"
-	"github.com/thrasher-/gocryptotrader/exchanges/liqui"
-	"github.com/thrasher-/gocryptotrader/exchanges/localbitcoins"
-	"github.com/thrasher-/gocryptotrader/exchanges/okcoin"
-	"github.com/thrasher-/gocryptotrader/exchanges/okex"
-	"github.com/thrasher-/gocryptotrader/exchanges/poloniex"
-	"github.com/thrasher-/gocryptotrader/exchanges/wex"
-	"github.com/thrasher-/gocryptotrader/exchanges/yobit"
-	"github.com/thrasher-/gocryptotrader/exchanges/zb"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/anx"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/binance"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bitfinex"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bitflyer"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bithumb"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bitmex"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bitstamp"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bittrex"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/btcc"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/btcmarkets"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/coinbasepro"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/coinut"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/exmo"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/gateio"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/gemini"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/hitbtc"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/huobi"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/huobihadax"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/itbit"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/kraken"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/lakebtc"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/liqui"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/localbitcoins"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/okcoin"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/okex"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/poloniex"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/wex"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/yobit"
+	_ "github.com/thrasher-/gocryptotrader/exchanges/zb"
 )
 
 // vars related to exchange functions
@@ -43,39 +45,26 @@ var (
 	ErrNoExchangesLoaded     = errors.New("no exchanges have been loaded")
 	ErrExchangeNotFound      = errors.New("exchange not found")
 	ErrExchangeAlreadyLoaded = errors.New("exchange already loaded")
-	ErrExchangeFailedToLoad  = errors.New("exchange failed to load")
 )
 
 // CheckExchangeExists returns true whether or not an exchange has already
 // been loaded
 func CheckExchangeExists(exchName string) bool {
-	for x := range bot.exchanges {
-		if common.StringToLower(bot.exchanges[x].GetName()) == common.StringToLower(exchName) {
-			return true
-		}
-	}
-	return false
+	return bot.exchanges.GetExchangeByName(exchName) != nil
 }
 
 // GetExchangeByName returns an exchange given an exchange name
 func GetExchangeByName(exchName string) exchange.IBotExchange {
-	for x := range bot.exchanges {
-		if common.StringToLower(bot.exchanges[x].GetName()) == common.StringToLower(exchName) {
-			return bot.exchanges[x]
-		}
-	}
-	return nil
+	return bot.exchanges.GetExchangeByName(exchName)
 }
 
 // ReloadExchange loads an exchange config by name
 func ReloadExchange(name string) error {
-	nameLower := common.StringToLower(name)
-
-	if len(bot.exchanges) == 0 {
+	if len(bot.exchanges.GetExchanges()) == 0 {
 		return ErrNoExchangesLoaded
 	}
 
-	if !CheckExchangeExists(nameLower) {
+	if !CheckExchangeExists(name) {
 		return ErrExchangeNotFound
 	}
 
@@ -84,21 +73,21 @@ func ReloadExchange(name string) error {
 		return err
 	}
 
-	e := GetExchangeByName(nameLower)
-	e.Setup(exchCfg)
+	err = bot.exchanges.ReloadExchange(exchCfg)
+	if err != nil {
+		return err
+	}
 	log.Printf("%s exchange reloaded successfully.\n", name)
 	return nil
 }
 
 // UnloadExchange unloads an exchange by name
 func UnloadExchange(name string) error {
-	nameLower := common.StringToLower(name)
-
-	if len(bot.exchanges) == 0 {
+	if len(bot.exchanges.GetExchanges()) == 0 {
 		return ErrNoExchangesLoaded
 	}
 
-	if !CheckExchangeExists(nameLower) {
+	if !CheckExchangeExists(name) {
 		return ErrExchangeNotFound
 	}
 
@@ -113,157 +102,154 @@ func UnloadExchange(name string) error {
 		return err
 	}
 
-	for x := range bot.exchanges {
-		if bot.exchanges[x].GetName() == name {
-			bot.exchanges[x].SetEnabled(false)
-			bot.exchanges = append(bot.exchanges[:x], bot.exchanges[x+1:]...)
-			return nil
-		}
+	err = bot.exchanges.UnloadExchange(name)
+	if err == exchange.ErrExchangeNotLoaded {
+		return ErrExchangeNotFound
 	}
-
-	return ErrExchangeNotFound
+	return err
 }
 
 // LoadExchange loads an exchange by name
 func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
-	nameLower := common.StringToLower(name)
-	var exch exchange.IBotExchange
-
-	if len(bot.exchanges) > 0 {
-		if CheckExchangeExists(nameLower) {
-			return ErrExchangeAlreadyLoaded
-		}
+	if CheckExchangeExists(name) {
+		return ErrExchangeAlreadyLoaded
 	}
 
-	switch nameLower {
-	case "anx":
-		exch = new(anx.ANX)
-	case "binance":
-		exch = new(binance.Binance)
-	case "bitfinex":
-		exch = new(bitfinex.Bitfinex)
-	case "bitflyer":
-		exch = new(bitflyer.Bitflyer)
-	case "bithumb":
-		exch = new(bithumb.Bithumb)
-	case "bitmex":
-		exch = new(bitmex.Bitmex)
-	case "bitstamp":
-		exch = new(bitstamp.Bitstamp)
-	case "bittrex":
-		exch = new(bittrex.Bittrex)
-	case "btcc":
-		exch = new(btcc.BTCC)
-	case "btc markets":
-		exch = new(btcmarkets.BTCMarkets)
-	case "coinut":
-		exch = new(coinut.COINUT)
-	case "exmo":
-		exch = new(exmo.EXMO)
-	case "coinbasepro":
-		exch = new(coinbasepro.CoinbasePro)
-	case "gateio":
-		exch = new(gateio.Gateio)
-	case "gemini":
-		exch = new(gemini.Gemini)
-	case "hitbtc":
-		exch = new(hitbtc.HitBTC)
-	case "huobi":
-		exch = new(huobi.HUOBI)
-	case "huobihadax":
-		exch = new(huobihadax.HUOBIHADAX)
-	case "itbit":
-		exch = new(itbit.ItBit)
-	case "kraken":
-		exch = new(kraken.Kraken)
-	case "lakebtc":
-		exch = new(lakebtc.LakeBTC)
-	case "liqui":
-		exch = new(liqui.Liqui)
-	case "localbitcoins":
-		exch = new(localbitcoins.LocalBitcoins)
-	case "okcoin china":
-		exch = new(okcoin.OKCoin)
-	case "okcoin international":
-		exch = new(okcoin.OKCoin)
-	case "okex":
-		exch = new(okex.OKEX)
-	case "poloniex":
-		exch = new(poloniex.Poloniex)
-	case "wex":
-		exch = new(wex.WEX)
-	case "yobit":
-		exch = new(yobit.Yobit)
-	case "zb":
-		exch = new(zb.ZB)
-	default:
+	if !exchange.IsExchangeRegistered(name) {
 		return ErrExchangeNotFound
 	}
 
-	if exch == nil {
-		return ErrExchangeFailedToLoad
-	}
-
-	exch.SetDefaults()
-	bot.exchanges = append(bot.exchanges, exch)
 	exchCfg, err := bot.config.GetExchangeConfig(name)
 	if err != nil {
 		return err
 	}
 
 	exchCfg.Enabled = true
-	exch.Setup(exchCfg)
-
 	if useWG {
-		exch.Start(wg)
-	} else {
-		wg := sync.WaitGroup{}
-		exch.Start(&wg)
-		wg.Wait()
+		return bot.exchanges.LoadExchange(exchCfg, wg)
 	}
-	return nil
+
+	var startWG sync.WaitGroup
+	err = bot.exchanges.LoadExchange(exchCfg, &startWG)
+	startWG.Wait()
+	return err
 }
 
-// SetupExchanges sets up the exchanges used by the bot
+// SetupExchanges sets up the exchanges used by the bot, reloading or
+// unloading exchanges which are already loaded and loading the remaining
+// enabled exchanges
 func SetupExchanges() {
-	var wg sync.WaitGroup
-	for _, exch := range bot.config.Exchanges {
-		if CheckExchangeExists(exch.Name) {
-			e := GetExchangeByName(exch.Name)
-			if e == nil {
-				log.Println(ErrExchangeNotFound)
-				continue
-			}
+	var toLoad []config.ExchangeConfig
+	for x := range bot.config.Exchanges {
+		exchCfg := bot.config.Exchanges[x]
+		e := GetExchangeByName(exchCfg.Name)
+		if e == nil {
+			toLoad = append(toLoad, exchCfg)
+			continue
+		}
+
+		err := ReloadExchange(exchCfg.Name)
+		if err != nil {
+			log.Printf("ReloadExchange %s failed: %s", exchCfg.Name, err)
+			continue
+		}
 
-			err := ReloadExchange(exch.Name)
+		if !e.IsEnabled() {
+			err = UnloadExchange(exchCfg.Name)
 			if err != nil {
-				log.Printf("ReloadExchange %s failed: %s", exch.Name, err)
-				continue
+				log.Printf("UnloadExchange %s failed: %s", exchCfg.Name, err)
 			}
+		}
+	}
 
-			if !e.IsEnabled() {
-				UnloadExchange(exch.Name)
-				continue
-			}
-			return
+	var wg sync.WaitGroup
+	err := bot.exchanges.LoadExchanges(toLoad, &wg)
+	if err != nil {
+		log.Printf("Failed to load exchanges: %s", err)
+	}
+	wg.Wait()
+}
 
+// ApplyExchangeConfigChanges applies the settings of a reloaded exchange config
+// which can change while the exchange is running: enabled pairs, verbosity and
+// HTTP rate limits. Changed settings which need a restart are logged as ignored
+func ApplyExchangeConfigChanges(exch exchange.IBotExchange, reloaded *config.ExchangeConfig) error {
+	name := exch.GetName()
+	current, err := bot.config.GetExchangeConfig(name)
+	if err != nil {
+		return err
+	}
+
+	changes := config.CompareExchangeConfigs(&current, reloaded)
+	for x := range changes.RestartRequired {
+		log.Printf("%s config reload: %s changed, ignored until restart.\n",
+			name, changes.RestartRequired[x])
+	}
+
+	if !changes.Reloadable() {
+		return nil
+	}
+
+	if changes.HTTPRateLimiter {
+		err = exch.SetHTTPRateLimiter(reloaded.HTTPRateLimiter)
+		if err != nil {
+			return err
+		}
+		current.HTTPRateLimiter = reloaded.HTTPRateLimiter
+		log.Printf("%s config reload: HTTP rate limits set to auth %d unauth %d per %v.\n",
+			name, reloaded.HTTPRateLimiter.AuthRate, reloaded.HTTPRateLimiter.UnauthRate,
+			reloaded.HTTPRateLimiter.Duration)
+	}
+
+	if changes.Verbose {
+		exch.SetVerbose(reloaded.Verbose)
+		current.Verbose = reloaded.Verbose
+		log.Printf("%s config reload: verbose set to %v.\n", name, reloaded.Verbose)
+	}
+
+	err = bot.config.UpdateExchangeConfig(current)
+	if err != nil {
+		return err
+	}
+
+	if changes.EnabledPairs {
+		pairs := pair.FormatPairs(common.SplitStrings(reloaded.EnabledPairs, ","),
+			current.ConfigCurrencyPairFormat.Delimiter,
+			current.ConfigCurrencyPairFormat.Index)
+		err = exch.SetCurrencies(pairs, true)
+		if err != nil {
+			return err
 		}
-		if !exch.Enabled {
-			log.Printf("%s: Exchange support: Disabled", exch.Name)
+		log.Printf("%s config reload: enabled pairs set to %s.\n", name, reloaded.EnabledPairs)
+	}
+	return nil
+}
+
+// ReloadConfig reads the config file and applies the settings which can change
+// without a restart to every loaded exchange
+func ReloadConfig() error {
+	reloaded, err := config.ReadReloadedConfig(bot.configFile)
+	if err != nil {
+		return err
+	}
+
+	exchanges := bot.exchanges.GetExchanges()
+	for x := range exchanges {
+		if exchanges[x] == nil {
+			continue
+		}
+
+		name := exchanges[x].GetName()
+		exchCfg, err := reloaded.GetExchangeConfig(name)
+		if err != nil {
+			log.Printf("%s config reload: %s\n", name, err)
 			continue
-		} else {
-			err := LoadExchange(exch.Name, true, &wg)
-			if err != nil {
-				log.Printf("LoadExchange %s failed: %s", exch.Name, err)
-				continue
-			}
 		}
-		log.Printf(
-			"%s: Exchange support: Enabled (Authenticated API support: %s - Verbose mode: %s).\n",
-			exch.Name,
-			common.IsEnabled(exch.AuthenticatedAPISupport),
-			common.IsEnabled(exch.Verbose),
-		)
+
+		err = ApplyExchangeConfigChanges(exchanges[x], &exchCfg)
+		if err != nil {
+			log.Printf("%s config reload failed. Error: %s\n", name, err)
+		}
 	}
-	wg.Wait()
+	return nil
 }
@@ -1,9 +1,15 @@
 package main
 
 import (
+	"errors"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
 )
 
 var testSetup = false
@@ -136,3 +142,184 @@ func TestSetupExchanges(t *testing.T) {
 	SetupExchanges()
 	CleanupTest(t)
 }
+
+func TestRegisteredExchanges(t *testing.T) {
+	SetupTest(t)
+
+	for _, name := range exchange.GetRegisteredExchanges() {
+		exch, err := exchange.NewExchangeByName(name)
+		if err != nil {
+			t.Errorf("Test failed. TestRegisteredExchanges: %s error: %s", name, err)
+			continue
+		}
+
+		if exch == nil {
+			t.Errorf("Test failed. TestRegisteredExchanges: %s returned a nil exchange", name)
+		}
+	}
+
+	for x := range bot.config.Exchanges {
+		if !exchange.IsExchangeRegistered(bot.config.Exchanges[x].Name) {
+			t.Errorf("Test failed. TestRegisteredExchanges: %s is not registered",
+				bot.config.Exchanges[x].Name)
+		}
+	}
+
+	CleanupTest(t)
+}
+
+// callCapabilityMethod calls a wrapper method reported by Capabilities with
+// zero value arguments and returns its error
+func callCapabilityMethod(exch exchange.IBotExchange, method string) error {
+	var err error
+	switch method {
+	case exchange.MethodGetExchangeHistory:
+		_, err = exch.GetExchangeHistory(pair.CurrencyPair{}, "")
+	case exchange.MethodGetFundingHistory:
+		_, err = exch.GetFundingHistory()
+	case exchange.MethodSubmitOrder:
+		_, err = exch.SubmitOrderWithParams(exchange.OrderSubmission{})
+	case exchange.MethodModifyOrder:
+		_, err = exch.ModifyOrder(exchange.ModifyOrder{})
+	case exchange.MethodCancelOrder:
+		err = exch.CancelOrder(exchange.OrderCancellation{})
+	case exchange.MethodCancelAllOrders:
+		_, err = exch.CancelAllOrders(exchange.OrderCancellation{})
+	case exchange.MethodGetOrderInfo:
+		_, err = exch.GetOrderInfo(0)
+	case exchange.MethodGetDepositAddress:
+		_, err = exch.GetDepositAddress("", "")
+	case exchange.MethodWithdrawCryptocurrencyFunds:
+		_, err = exch.WithdrawCryptocurrencyFunds("", "", "", 0)
+	case exchange.MethodWithdrawFiatFunds:
+		_, err = exch.WithdrawFiatFunds("", 0)
+	case exchange.MethodGetWebsocket:
+		_, err = exch.GetWebsocket()
+	}
+	return err
+}
+
+// errCapabilityTransport is returned by capabilityTransport in place of a
+// response
+var errCapabilityTransport = errors.New("capability test request")
+
+// capabilityTransport fails every request so wrapper methods can be called
+// without reaching the exchange API
+type capabilityTransport struct{}
+
+func (capabilityTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errCapabilityTransport
+}
+
+func TestExchangeCapabilities(t *testing.T) {
+	for _, name := range exchange.GetRegisteredExchanges() {
+		exch, err := exchange.NewExchangeByName(name)
+		if err != nil {
+			t.Fatalf("Test failed. TestExchangeCapabilities: %s error: %s", name, err)
+		}
+		exch.SetDefaults()
+
+		client, ok := exch.(interface{ SetHTTPClient(*http.Client) })
+		if !ok {
+			t.Fatalf("Test failed. TestExchangeCapabilities: %s can't set its HTTP client", name)
+		}
+		client.SetHTTPClient(&http.Client{Transport: capabilityTransport{}})
+
+		capabilities := exch.Capabilities()
+		if len(capabilities) != len(exchange.CapabilityMethods) {
+			t.Errorf("Test failed. TestExchangeCapabilities: %s reported %d methods, expected %d",
+				name, len(capabilities), len(exchange.CapabilityMethods))
+		}
+
+		// every method must return the error matching its declared capability
+		// so stubs which aren't declared are caught, methods which aren't
+		// supported must do so without calling the exchange API
+		for method, status := range capabilities {
+			err = callCapabilityMethod(exch, method)
+			if status == exchange.CapabilityUnsupported &&
+				(err == exchange.ErrCryptoWithdrawViaWebsiteOnly || err == exchange.ErrFiatWithdrawViaWebsiteOnly) {
+				continue
+			}
+
+			if exchange.CapabilityFromError(err) != status {
+				t.Errorf("Test failed. TestExchangeCapabilities: %s %s reported %s but returned %v",
+					name, method, status, err)
+			}
+		}
+	}
+}
+
+func TestGetCapabilityMatrix(t *testing.T) {
+	matrix, err := exchange.GetCapabilityMatrix()
+	if err != nil {
+		t.Fatal("Test failed. GetCapabilityMatrix error", err)
+	}
+
+	if len(matrix.Exchanges) != len(exchange.GetRegisteredExchanges()) {
+		t.Fatalf("Test failed. GetCapabilityMatrix expected %d exchanges, received %d",
+			len(exchange.GetRegisteredExchanges()), len(matrix.Exchanges))
+	}
+
+	for x := range matrix.Exchanges {
+		row := matrix.Exchanges[x]
+		if row.Exchange == "" || len(row.AssetTypes) == 0 || row.WithdrawPermissionsText == "" {
+			t.Errorf("Test failed. GetCapabilityMatrix incomplete row %+v", row)
+		}
+	}
+}
+
+func TestApplyExchangeConfigChanges(t *testing.T) {
+	SetupTest(t)
+
+	exch := GetExchangeByName("Bitfinex")
+	original, err := bot.config.GetExchangeConfig("Bitfinex")
+	if err != nil {
+		t.Fatalf("Test failed. TestApplyExchangeConfigChanges: %s", err)
+	}
+
+	reloaded := original
+	reloaded.Verbose = !original.Verbose
+	reloaded.EnabledPairs = common.SplitStrings(original.EnabledPairs, ",")[0]
+	reloaded.HTTPRateLimiter = &config.HTTPRateLimitConfig{
+		Duration:   time.Second,
+		AuthRate:   5,
+		UnauthRate: 10,
+	}
+	reloaded.APIKey = "reloadedkey"
+
+	err = ApplyExchangeConfigChanges(exch, &reloaded)
+	if err != nil {
+		t.Errorf("Test failed. TestApplyExchangeConfigChanges: %s", err)
+	}
+
+	exchCfg, err := bot.config.GetExchangeConfig("Bitfinex")
+	if err != nil {
+		t.Fatalf("Test failed. TestApplyExchangeConfigChanges: %s", err)
+	}
+
+	if exchCfg.Verbose != reloaded.Verbose || exchCfg.EnabledPairs != reloaded.EnabledPairs ||
+		exchCfg.HTTPRateLimiter != reloaded.HTTPRateLimiter {
+		t.Error("Test failed. TestApplyExchangeConfigChanges: reloaded settings not applied to config")
+	}
+
+	if exchCfg.APIKey != original.APIKey {
+		t.Error("Test failed. TestApplyExchangeConfigChanges: API key should require a restart")
+	}
+
+	if len(exch.GetEnabledCurrencies()) != 1 {
+		t.Errorf("Test failed. TestApplyExchangeConfigChanges: expected 1 enabled pair, received %d",
+			len(exch.GetEnabledCurrencies()))
+	}
+
+	reloaded.HTTPRateLimiter = &config.HTTPRateLimitConfig{}
+	err = ApplyExchangeConfigChanges(exch, &reloaded)
+	if err == nil {
+		t.Error("Test failed. TestApplyExchangeConfigChanges: expected invalid rate limiter error")
+	}
+
+	err = bot.config.UpdateExchangeConfig(original)
+	if err != nil {
+		t.Fatalf("Test failed. TestApplyExchangeConfigChanges: %s", err)
+	}
+	CleanupTest(t)
+}
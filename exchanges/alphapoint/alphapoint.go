@@ -60,6 +60,13 @@ func (a *Alphapoint) SetDefaults() {
 		request.NewRateLimit(time.Minute*10, alphapointAuthRate),
 		request.NewRateLimit(time.Minute*10, alphapointUnauthRate),
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	a.WebsocketInit()
+	a.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	a.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnimplemented)
+	a.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnimplemented)
+	a.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	a.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	a.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // GetTicker returns current ticker information from Alphapoint for a selected
@@ -28,7 +28,7 @@ func (a *Alphapoint) WebsocketClient() {
 			log.Printf("%s Connected to Websocket.\n", a.Name)
 		}
 
-		err = a.WebsocketConn.WriteMessage(websocket.TextMessage, []byte(`{"messageType": "logon"}`))
+		err = a.Websocket.SendMessage(a.WebsocketConn, websocket.TextMessage, []byte(`{"messageType": "logon"}`))
 
 		if err != nil {
 			log.Println(err)
@@ -36,7 +36,7 @@ func (a *Alphapoint) WebsocketClient() {
 		}
 
 		for a.Enabled {
-			msgType, resp, err := a.WebsocketConn.ReadMessage()
+			msgType, resp, err := a.Websocket.ReadMessage(a.WebsocketConn)
 			if err != nil {
 				log.Println(err)
 				break
@@ -32,8 +32,23 @@ func (a *Alphapoint) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (a *Alphapoint) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := a.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return a.FilterCurrencyBalance(info, currency)
+}
+
 // UpdateTicker updates and returns the ticker for a currency pair
 func (a *Alphapoint) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := a.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	tick, err := a.GetTicker(p.Pair().String())
 	if err != nil {
@@ -53,6 +68,7 @@ func (a *Alphapoint) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker
 
 // GetTickerPrice returns the ticker for a currency pair
 func (a *Alphapoint) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = a.GetAssetTypeOrDefault(assetType)
 	tick, err := ticker.GetTicker(a.GetName(), p, assetType)
 	if err != nil {
 		return a.UpdateTicker(p, assetType)
@@ -62,6 +78,11 @@ func (a *Alphapoint) GetTickerPrice(p pair.CurrencyPair, assetType string) (tick
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (a *Alphapoint) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := a.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := a.GetOrderbook(p.Pair().String())
 	if err != nil {
@@ -84,6 +105,7 @@ func (a *Alphapoint) UpdateOrderbook(p pair.CurrencyPair, assetType string) (ord
 
 // GetOrderbookEx returns the orderbook for a currency pair
 func (a *Alphapoint) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = a.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(a.GetName(), p, assetType)
 	if err != nil {
 		return a.UpdateOrderbook(p, assetType)
@@ -109,9 +131,16 @@ func (a *Alphapoint) GetExchangeHistory(p pair.CurrencyPair, assetType string) (
 // SubmitOrder submits a new order and returns a true value when
 // successfully submitted
 func (a *Alphapoint) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := a.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (a *Alphapoint) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 
-	response, err := a.CreateOrder(p.Pair().String(), side.ToString(), orderType.ToString(), amount, price)
+	response, err := a.CreateOrder(order.Pair.Pair().String(), order.OrderSide.ToString(), order.OrderType.ToString(), order.Amount, order.Price)
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
 	}
@@ -164,7 +193,11 @@ func (a *Alphapoint) GetOrderInfo(orderID int64) (float64, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (a *Alphapoint) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (a *Alphapoint) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
+	if chain != "" {
+		return "", exchange.ErrChainSelectionNotSupported
+	}
+
 	addreses, err := a.GetDepositAddresses()
 	if err != nil {
 		return "", err
@@ -180,12 +213,20 @@ func (a *Alphapoint) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (a *Alphapoint) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (a *Alphapoint) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := a.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is submitted
 func (a *Alphapoint) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := a.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
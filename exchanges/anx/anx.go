@@ -45,6 +45,10 @@ type ANX struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.RegisterExchange("anx", func() exchange.IBotExchange { return new(ANX) })
+}
+
 // SetDefaults sets current default settings
 func (a *ANX) SetDefaults() {
 	a.Name = "ANX"
@@ -71,6 +75,14 @@ func (a *ANX) SetDefaults() {
 	a.APIUrlDefault = anxAPIURL
 	a.APIUrl = a.APIUrlDefault
 	a.WebsocketInit()
+	a.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	a.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	a.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	a.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	a.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	a.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	a.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	a.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnsupported)
 }
 
 //Setup is run on startup to setup exchange with config values
@@ -80,15 +92,20 @@ func (a *ANX) Setup(exch config.ExchangeConfig) {
 	} else {
 		a.Enabled = true
 		a.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		a.Verbose = exch.Verbose
 		a.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		a.SetHTTPClientTimeout(exch.HTTPTimeout)
+		a.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		a.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := a.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		a.RESTPollingDelay = exch.RESTPollingDelay
-		a.Verbose = exch.Verbose
 		a.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		a.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		a.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := a.SetCurrencyPairFormat()
+		err = a.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -96,6 +113,10 @@ func (a *ANX) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = a.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = a.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
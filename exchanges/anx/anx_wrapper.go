@@ -12,6 +12,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*ANX)(nil)
+
 // Start starts the ANX go routine
 func (a *ANX) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -28,7 +30,11 @@ func (a *ANX) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", a.GetName(), len(a.EnabledPairs), a.EnabledPairs)
 	}
 
-	exchangeProducts, err := a.GetTradablePairs()
+	var exchangeProducts []string
+	err := a.RetryPairUpdate(func() (err error) {
+		exchangeProducts, err = a.GetTradablePairs()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available symbols.\n", a.GetName())
 	} else {
@@ -46,9 +52,11 @@ func (a *ANX) Run() {
 				log.Printf("%s Failed to get config.\n", a.GetName())
 			}
 		}
-		err = a.UpdateCurrencies(exchangeProducts, false, forceUpgrade)
-		if err != nil {
-			log.Printf("%s Failed to get config.\n", a.GetName())
+		if a.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = a.UpdateCurrencies(exchangeProducts, false, forceUpgrade)
+			if err != nil {
+				log.Printf("%s Failed to get config.\n", a.GetName())
+			}
 		}
 	}
 }
@@ -70,6 +78,11 @@ func (a *ANX) GetTradablePairs() ([]string, error) {
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (a *ANX) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := a.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	tick, err := a.GetTicker(exchange.FormatExchangeCurrency(a.GetName(), p).String())
 	if err != nil {
@@ -137,6 +150,7 @@ func (a *ANX) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price,
 
 // GetTickerPrice returns the ticker for a currency pair
 func (a *ANX) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = a.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(a.GetName(), p, assetType)
 	if err != nil {
 		return a.UpdateTicker(p, assetType)
@@ -146,6 +160,7 @@ func (a *ANX) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Pric
 
 // GetOrderbookEx returns the orderbook for a currency pair
 func (a *ANX) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = a.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(a.GetName(), p, assetType)
 	if err != nil {
 		return a.UpdateOrderbook(p, assetType)
@@ -155,6 +170,11 @@ func (a *ANX) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.B
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (a *ANX) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := a.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := a.GetDepth(exchange.FormatExchangeCurrency(a.GetName(), p).String())
 	if err != nil {
@@ -204,6 +224,16 @@ func (a *ANX) GetAccountInfo() (exchange.AccountInfo, error) {
 	return info, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (a *ANX) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := a.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return a.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (a *ANX) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -220,25 +250,32 @@ func (a *ANX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]excha
 
 // SubmitOrder submits a new order
 func (a *ANX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := a.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (a *ANX) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 
 	var isBuying bool
 	var limitPriceInSettlementCurrency float64
 
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		isBuying = true
 	}
 
-	if orderType == exchange.Limit {
-		limitPriceInSettlementCurrency = price
+	if order.OrderType == exchange.Limit {
+		limitPriceInSettlementCurrency = order.Price
 	}
 
-	response, err := a.NewOrder(orderType.ToString(),
+	response, err := a.NewOrder(order.OrderType.ToString(),
 		isBuying,
-		p.FirstCurrency.String(),
-		amount,
-		p.SecondCurrency.String(),
-		amount,
+		order.Pair.FirstCurrency.String(),
+		order.Amount,
+		order.Pair.SecondCurrency.String(),
+		order.Amount,
 		limitPriceInSettlementCurrency,
 		false,
 		"",
@@ -304,19 +341,27 @@ func (a *ANX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (a *ANX) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (a *ANX) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (a *ANX) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (a *ANX) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := a.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (a *ANX) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := a.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
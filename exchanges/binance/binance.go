@@ -57,6 +57,10 @@ const (
 	binanceUnauthRate = 0
 )
 
+func init() {
+	exchange.RegisterExchange("binance", func() exchange.IBotExchange { return new(Binance) })
+}
+
 // SetDefaults sets the basic defaults for Binance
 func (b *Binance) SetDefaults() {
 	b.Name = "Binance"
@@ -79,6 +83,12 @@ func (b *Binance) SetDefaults() {
 	b.APIUrlDefault = apiURL
 	b.APIUrl = b.APIUrlDefault
 	b.WebsocketInit()
+	b.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	b.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	b.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
@@ -88,15 +98,20 @@ func (b *Binance) Setup(exch config.ExchangeConfig) {
 	} else {
 		b.Enabled = true
 		b.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		b.Verbose = exch.Verbose
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
+		b.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := b.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		b.RESTPollingDelay = exch.RESTPollingDelay
-		b.Verbose = exch.Verbose
 		b.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		b.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := b.SetCurrencyPairFormat()
+		err = b.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -104,6 +119,10 @@ func (b *Binance) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -70,6 +70,29 @@ func TestGetRecentTrades(t *testing.T) {
 	}
 }
 
+func TestConvertRecentTrades(t *testing.T) {
+	trades := b.convertRecentTrades([]RecentTrade{
+		{ID: 1, Price: 100, Quantity: 2, Time: 1540000000123, IsBuyerMaker: true},
+		{ID: 2, Price: 101, Quantity: 1, Time: 1540000001456, IsBuyerMaker: false},
+	})
+
+	if len(trades) != 2 {
+		t.Fatalf("Test Failed - Binance convertRecentTrades() expected 2 trades, got %d", len(trades))
+	}
+	if trades[0].Side != exchange.Sell || trades[0].Type != "sell" {
+		t.Errorf("Test Failed - Binance convertRecentTrades() buyer maker trade side %s %s",
+			trades[0].Side, trades[0].Type)
+	}
+	if trades[1].Side != exchange.Buy || trades[1].Type != "buy" {
+		t.Errorf("Test Failed - Binance convertRecentTrades() buyer taker trade side %s %s",
+			trades[1].Side, trades[1].Type)
+	}
+	if trades[0].Timestamp != 1540000000 || trades[0].TID != 1 ||
+		trades[0].Price != 100 || trades[0].Amount != 2 {
+		t.Errorf("Test Failed - Binance convertRecentTrades() unexpected values %+v", trades[0])
+	}
+}
+
 func TestGetHistoricalTrades(t *testing.T) {
 	t.Parallel()
 	_, err := b.GetHistoricalTrades("BTCUSDT", 5, 1337)
@@ -7,7 +7,6 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -21,10 +20,7 @@ const (
 	binanceDefaultWebsocketURL = "wss://stream.binance.com:9443"
 )
 
-var lastUpdateID map[string]int64
-var m sync.Mutex
-
-// SeedLocalCache seeds depth data
+// SeedLocalCache seeds depth data, replacing any existing local orderbook
 func (b *Binance) SeedLocalCache(p pair.CurrencyPair) error {
 	var newOrderBook orderbook.Base
 
@@ -40,14 +36,6 @@ func (b *Binance) SeedLocalCache(p pair.CurrencyPair) error {
 		return err
 	}
 
-	m.Lock()
-	if lastUpdateID == nil {
-		lastUpdateID = make(map[string]int64)
-	}
-
-	lastUpdateID[formattedPair.String()] = orderbookNew.LastUpdateID
-	m.Unlock()
-
 	for _, bids := range orderbookNew.Bids {
 		newOrderBook.Bids = append(newOrderBook.Bids,
 			orderbook.Item{Amount: bids.Quantity, Price: bids.Price})
@@ -62,27 +50,17 @@ func (b *Binance) SeedLocalCache(p pair.CurrencyPair) error {
 	newOrderBook.LastUpdated = time.Now()
 	newOrderBook.AssetType = "SPOT"
 
-	return b.Websocket.Orderbook.LoadSnapshot(newOrderBook, b.GetName())
+	b.Websocket.Orderbook.Invalidate(newOrderBook.Pair, newOrderBook.AssetType)
+	return b.Websocket.Orderbook.LoadSnapshotWithSequence(newOrderBook,
+		b.GetName(),
+		orderbookNew.LastUpdateID)
 }
 
-// UpdateLocalCache updates and returns the most recent iteration of the orderbook
+// UpdateLocalCache updates and returns the most recent iteration of the
+// orderbook. Updates which don't follow on from the last applied update mean
+// depth messages were dropped, the local orderbook is then re-seeded from a
+// fresh snapshot
 func (b *Binance) UpdateLocalCache(ob WebsocketDepthStream) error {
-	m.Lock()
-	ID, ok := lastUpdateID[ob.Pair]
-	if !ok {
-		m.Unlock()
-		return errors.New("binance_websocket.go - Unable to find lastUpdateID")
-	}
-
-	if ob.LastUpdateID+1 <= ID || ID >= ob.LastUpdateID+1 {
-		// Drop update, out of order
-		m.Unlock()
-		return nil
-	}
-
-	lastUpdateID[ob.Pair] = ob.LastUpdateID
-	m.Unlock()
-
 	var updateBid, updateAsk []orderbook.Item
 
 	for _, bidsToUpdate := range ob.UpdateBids {
@@ -108,18 +86,28 @@ func (b *Binance) UpdateLocalCache(ob WebsocketDepthStream) error {
 				priceToBeUpdated.Amount, _ = strconv.ParseFloat(asks.(string), 64)
 			}
 		}
-		updateAsk = append(updateBid, priceToBeUpdated)
+		updateAsk = append(updateAsk, priceToBeUpdated)
 	}
 
-	updatedTime := time.Unix(ob.Timestamp, 0)
+	updatedTime := time.Unix(0, ob.Timestamp*int64(time.Millisecond))
 	currencyPair := pair.NewCurrencyPairFromString(ob.Pair)
 
-	return b.Websocket.Orderbook.Update(updateBid,
+	err := b.Websocket.Orderbook.UpdateWithSequence(updateBid,
 		updateAsk,
 		currencyPair,
 		updatedTime,
 		b.GetName(),
-		"SPOT")
+		"SPOT",
+		ob.FirstUpdateID,
+		ob.LastUpdateID)
+	if err == nil || b.Websocket.Orderbook.IsSynced(currencyPair, "SPOT") {
+		return err
+	}
+
+	if seedErr := b.SeedLocalCache(currencyPair); seedErr != nil {
+		return fmt.Errorf("%s, resync failed: %s", err, seedErr)
+	}
+	return err
 }
 
 // WSConnect intiates a websocket connection
@@ -201,7 +189,7 @@ func (b *Binance) WSReadData() {
 			return
 
 		default:
-			msgType, resp, err := b.WebsocketConn.ReadMessage()
+			msgType, resp, err := b.Websocket.ReadMessage(b.WebsocketConn)
 			if err != nil {
 				b.Websocket.DataHandler <- fmt.Errorf("binance_websocket.go - Websocket Read Data. Error: %s",
 					err)
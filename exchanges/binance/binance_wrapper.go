@@ -14,6 +14,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*Binance)(nil)
+
 // Start starts the OKEX go routine
 func (b *Binance) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -37,7 +39,11 @@ func (b *Binance) Run() {
 			b.EnabledPairs)
 	}
 
-	symbols, err := b.GetExchangeValidCurrencyPairs()
+	var symbols []string
+	err := b.RetryPairUpdate(func() (err error) {
+		symbols, err = b.GetExchangeValidCurrencyPairs()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get exchange info.\n", b.GetName())
 	} else {
@@ -56,15 +62,22 @@ func (b *Binance) Run() {
 				log.Printf("%s Failed to get config.\n", b.GetName())
 			}
 		}
-		err = b.UpdateCurrencies(symbols, false, forceUpgrade)
-		if err != nil {
-			log.Printf("%s Failed to get config.\n", b.GetName())
+		if b.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = b.UpdateCurrencies(symbols, false, forceUpgrade)
+			if err != nil {
+				log.Printf("%s Failed to get config.\n", b.GetName())
+			}
 		}
 	}
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *Binance) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	tick, err := b.GetTickers()
 	if err != nil {
@@ -91,6 +104,7 @@ func (b *Binance) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Pr
 
 // GetTickerPrice returns the ticker for a currency pair
 func (b *Binance) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(b.GetName(), p, assetType)
 	if err != nil {
 		return b.UpdateTicker(p, assetType)
@@ -100,6 +114,7 @@ func (b *Binance) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (b *Binance) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(b.GetName(), currency, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(currency, assetType)
@@ -109,6 +124,11 @@ func (b *Binance) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (b *Binance) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := b.GetOrderBook(OrderBookDataRequestParams{Symbol: exchange.FormatExchangeCurrency(b.Name, p).String(), Limit: 1000})
 	if err != nil {
@@ -162,6 +182,16 @@ func (b *Binance) GetAccountInfo() (exchange.AccountInfo, error) {
 	return info, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (b *Binance) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := b.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return b.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *Binance) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -169,27 +199,60 @@ func (b *Binance) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
+// GetExchangeHistory returns the most recent public trades for a currency pair
 func (b *Binance) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
-	var resp []exchange.TradeHistory
-	return resp, common.ErrNotYetImplemented
+	trades, err := b.GetRecentTrades(RecentTradeRequestParams{
+		Symbol: exchange.FormatExchangeCurrency(b.Name, p).String(),
+		Limit:  500,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.convertRecentTrades(trades), nil
+}
+
+// convertRecentTrades converts Binance recent trades to the exchange trade
+// history format. Binance reports isBuyerMaker rather than a side, so a trade
+// where the buyer was the maker was initiated by a seller
+func (b *Binance) convertRecentTrades(trades []RecentTrade) []exchange.TradeHistory {
+	resp := make([]exchange.TradeHistory, 0, len(trades))
+	for i := range trades {
+		side := exchange.TradeSideFromBuyerMaker(trades[i].IsBuyerMaker)
+		resp = append(resp, exchange.TradeHistory{
+			Timestamp: int64(trades[i].Time) / 1000,
+			TID:       trades[i].ID,
+			Price:     trades[i].Price,
+			Amount:    trades[i].Quantity,
+			Exchange:  b.Name,
+			Type:      common.StringToLower(side.ToString()),
+			Side:      side,
+		})
+	}
+	return resp
 }
 
 // SubmitOrder submits a new order
 func (b *Binance) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := b.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (b *Binance) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 
 	var sideType RequestParamsSideType
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		sideType = BinanceRequestParamsSideBuy
 	} else {
 		sideType = BinanceRequestParamsSideSell
 	}
 
 	var requestParamsOrderType RequestParamsOrderType
-	if orderType == exchange.Market {
+	if order.OrderType == exchange.Market {
 		requestParamsOrderType = BinanceRequestParamsOrderMarket
-	} else if orderType == exchange.Limit {
+	} else if order.OrderType == exchange.Limit {
 		requestParamsOrderType = BinanceRequestParamsOrderLimit
 	} else {
 		submitOrderResponse.IsOrderPlaced = false
@@ -197,10 +260,10 @@ func (b *Binance) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orde
 	}
 
 	var orderRequest = NewOrderRequest{
-		Symbol:    p.FirstCurrency.String() + p.SecondCurrency.String(),
+		Symbol:    order.Pair.FirstCurrency.String() + order.Pair.SecondCurrency.String(),
 		Side:      sideType,
-		Price:     price,
-		Quantity:  amount,
+		Price:     order.Price,
+		Quantity:  order.Amount,
 		TradeType: requestParamsOrderType,
 	}
 
@@ -264,19 +327,27 @@ func (b *Binance) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (b *Binance) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (b *Binance) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (b *Binance) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (b *Binance) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := b.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Binance) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := b.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
@@ -86,6 +86,10 @@ type Bitfinex struct {
 	WebsocketSubdChannels map[int]WebsocketChanInfo
 }
 
+func init() {
+	exchange.RegisterExchange("bitfinex", func() exchange.IBotExchange { return new(Bitfinex) })
+}
+
 // SetDefaults sets the basic defaults for bitfinex
 func (b *Bitfinex) SetDefaults() {
 	b.Name = "Bitfinex"
@@ -108,6 +112,12 @@ func (b *Bitfinex) SetDefaults() {
 	b.APIUrlDefault = bitfinexAPIURLBase
 	b.APIUrl = b.APIUrlDefault
 	b.WebsocketInit()
+	b.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	b.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	b.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
@@ -117,16 +127,21 @@ func (b *Bitfinex) Setup(exch config.ExchangeConfig) {
 	} else {
 		b.Enabled = true
 		b.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		b.Verbose = exch.Verbose
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
+		b.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := b.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		b.RESTPollingDelay = exch.RESTPollingDelay
-		b.Verbose = exch.Verbose
 		b.Websocket.SetEnabled(exch.Websocket)
 		b.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		b.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := b.SetCurrencyPairFormat()
+		err = b.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -134,6 +149,10 @@ func (b *Bitfinex) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
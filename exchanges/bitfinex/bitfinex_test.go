@@ -165,6 +165,28 @@ func TestGetTrades(t *testing.T) {
 	}
 }
 
+func TestConvertTrades(t *testing.T) {
+	var trades []TradeStructure
+	err := common.JSONDecode([]byte(`[{"timestamp":1540000000,"tid":1,"price":"100.5","amount":"2","exchange":"bitfinex","type":"sell"},{"timestamp":1540000001,"tid":2,"price":"101","amount":"1","exchange":"bitfinex","type":"buy"}]`), &trades)
+	if err != nil {
+		t.Fatal("Test Failed - Bitfinex trade decode error", err)
+	}
+
+	resp, err := b.convertTrades(trades)
+	if err != nil {
+		t.Fatal("Test Failed - Bitfinex convertTrades() error", err)
+	}
+
+	if resp[0].Side != exchange.Sell || resp[1].Side != exchange.Buy {
+		t.Errorf("Test Failed - Bitfinex convertTrades() unexpected sides %s %s",
+			resp[0].Side, resp[1].Side)
+	}
+	if resp[0].Timestamp != 1540000000 || resp[0].TID != 1 ||
+		resp[0].Price != 100.5 || resp[0].Amount != 2 {
+		t.Errorf("Test Failed - Bitfinex convertTrades() unexpected values %+v", resp[0])
+	}
+}
+
 func TestGetTradesv2(t *testing.T) {
 	t.Parallel()
 
@@ -66,7 +66,7 @@ func (b *Bitfinex) WsSend(data interface{}) error {
 	if err != nil {
 		return err
 	}
-	return b.WebsocketConn.WriteMessage(websocket.TextMessage, json)
+	return b.Websocket.SendMessage(b.WebsocketConn, websocket.TextMessage, json)
 }
 
 // WsSubscribe subscribes to the websocket channel
@@ -147,7 +147,7 @@ func (b *Bitfinex) WsConnect() error {
 		return fmt.Errorf("Unable to connect to Websocket. Error: %s", err)
 	}
 
-	_, resp, err := b.WebsocketConn.ReadMessage()
+	_, resp, err := b.Websocket.ReadMessage(b.WebsocketConn)
 	if err != nil {
 		return fmt.Errorf("Unable to read from Websocket. Error: %s", err)
 	}
@@ -211,7 +211,7 @@ func (b *Bitfinex) WsReadData() {
 		case <-b.Websocket.ShutdownC:
 			return
 		default:
-			msgType, resp, err := b.WebsocketConn.ReadMessage()
+			msgType, resp, err := b.Websocket.ReadMessage(b.WebsocketConn)
 			if err != nil {
 				b.Websocket.DataHandler <- err
 				return
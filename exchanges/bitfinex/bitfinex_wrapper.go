@@ -14,6 +14,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*Bitfinex)(nil)
+
 // Start starts the Bitfinex go routine
 func (b *Bitfinex) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -31,19 +33,30 @@ func (b *Bitfinex) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
-	exchangeProducts, err := b.GetSymbols()
+	var exchangeProducts []string
+	err := b.RetryPairUpdate(func() (err error) {
+		exchangeProducts, err = b.GetSymbols()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available symbols.\n", b.GetName())
 	} else {
-		err = b.UpdateCurrencies(exchangeProducts, false, false)
-		if err != nil {
-			log.Printf("%s Failed to update available symbols.\n", b.GetName())
+		if b.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = b.UpdateCurrencies(exchangeProducts, false, false)
+			if err != nil {
+				log.Printf("%s Failed to update available symbols.\n", b.GetName())
+			}
 		}
 	}
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *Bitfinex) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	enabledPairs := b.GetEnabledCurrencies()
 
@@ -74,6 +87,7 @@ func (b *Bitfinex) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.P
 
 // GetTickerPrice returns the ticker for a currency pair
 func (b *Bitfinex) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	tick, err := ticker.GetTicker(b.GetName(), p, ticker.Spot)
 	if err != nil {
 		return b.UpdateTicker(p, assetType)
@@ -83,6 +97,7 @@ func (b *Bitfinex) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker
 
 // GetOrderbookEx returns the orderbook for a currency pair
 func (b *Bitfinex) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(b.GetName(), p, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(p, assetType)
@@ -92,6 +107,11 @@ func (b *Bitfinex) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderb
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (b *Bitfinex) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	urlVals := url.Values{}
 	urlVals.Set("limit_bids", "100")
@@ -160,6 +180,16 @@ func (b *Bitfinex) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (b *Bitfinex) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := b.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return b.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *Bitfinex) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -167,23 +197,55 @@ func (b *Bitfinex) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
+// GetExchangeHistory returns the most recent public trades for a currency pair
 func (b *Bitfinex) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
-	var resp []exchange.TradeHistory
+	trades, err := b.GetTrades(p.Pair().String(), url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	return b.convertTrades(trades)
+}
 
-	return resp, common.ErrNotYetImplemented
+// convertTrades converts Bitfinex public trades to the exchange trade history
+// format. Bitfinex reports the taker side directly as "buy" or "sell"
+func (b *Bitfinex) convertTrades(trades []TradeStructure) ([]exchange.TradeHistory, error) {
+	resp := make([]exchange.TradeHistory, 0, len(trades))
+	for i := range trades {
+		side, err := exchange.TradeSideFromString(trades[i].Type)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, exchange.TradeHistory{
+			Timestamp: trades[i].Timestamp,
+			TID:       trades[i].Tid,
+			Price:     trades[i].Price,
+			Amount:    trades[i].Amount,
+			Exchange:  b.Name,
+			Type:      trades[i].Type,
+			Side:      side,
+		})
+	}
+	return resp, nil
 }
 
 // SubmitOrder submits a new order
 func (b *Bitfinex) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := b.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (b *Bitfinex) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 	var isBuying bool
 
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		isBuying = true
 	}
 
-	response, err := b.NewOrder(p.Pair().String(), amount, price, isBuying, orderType.ToString(), false)
+	response, err := b.NewOrder(order.Pair.Pair().String(), order.Amount, order.Price, isBuying, order.OrderType.ToString(), false)
 
 	if response.OrderID > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response.OrderID)
@@ -228,18 +290,26 @@ func (b *Bitfinex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (b *Bitfinex) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (b *Bitfinex) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is submitted
-func (b *Bitfinex) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (b *Bitfinex) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := b.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bitfinex) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := b.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
@@ -75,6 +75,10 @@ type Bitflyer struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.RegisterExchange("bitflyer", func() exchange.IBotExchange { return new(Bitflyer) })
+}
+
 // SetDefaults sets the basic defaults for Bitflyer
 func (b *Bitflyer) SetDefaults() {
 	b.Name = "Bitflyer"
@@ -98,6 +102,17 @@ func (b *Bitflyer) SetDefaults() {
 	b.APIUrlSecondaryDefault = chainAnalysis
 	b.APIUrlSecondary = b.APIUrlSecondaryDefault
 	b.WebsocketInit()
+	b.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	b.SetCapability(exchange.MethodSubmitOrder, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	b.SetCapability(exchange.MethodCancelOrder, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodCancelAllOrders, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
@@ -107,16 +122,21 @@ func (b *Bitflyer) Setup(exch config.ExchangeConfig) {
 	} else {
 		b.Enabled = true
 		b.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		b.Verbose = exch.Verbose
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
+		b.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := b.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		b.RESTPollingDelay = exch.RESTPollingDelay
-		b.Verbose = exch.Verbose
 		b.Websocket.SetEnabled(exch.Websocket)
 		b.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		b.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := b.SetCurrencyPairFormat()
+		err = b.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -124,6 +144,10 @@ func (b *Bitflyer) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -341,3 +341,13 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestSystemStatusFromHealth(t *testing.T) {
+	t.Parallel()
+	if systemStatusFromHealth("NORMAL") != exchange.SystemStatusOperational ||
+		systemStatusFromHealth("SUPER BUSY") != exchange.SystemStatusDegraded ||
+		systemStatusFromHealth("STOP") != exchange.SystemStatusMaintenance ||
+		systemStatusFromHealth("") != exchange.SystemStatusUnknown {
+		t.Error("test failed - Bitflyer - systemStatusFromHealth() incorrect status mapping")
+	}
+}
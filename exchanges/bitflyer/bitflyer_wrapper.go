@@ -12,6 +12,9 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*Bitflyer)(nil)
+var _ exchange.SystemStatusGetter = (*Bitflyer)(nil)
+
 // Start starts the Bitflyer go routine
 func (b *Bitflyer) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -48,8 +51,36 @@ func (b *Bitflyer) Run() {
 	*/
 }
 
+// GetSystemStatus returns the exchange system status from its health status
+func (b *Bitflyer) GetSystemStatus() (exchange.SystemStatus, error) {
+	resp := make(map[string]string)
+	err := b.SendHTTPRequest(b.APIUrl+pubGetHealth, &resp)
+	if err != nil {
+		return exchange.SystemStatusUnknown, err
+	}
+	return systemStatusFromHealth(resp["status"]), nil
+}
+
+// systemStatusFromHealth maps a bitflyer health status to a system status
+func systemStatusFromHealth(health string) exchange.SystemStatus {
+	switch health {
+	case "NORMAL":
+		return exchange.SystemStatusOperational
+	case "BUSY", "VERY BUSY", "SUPER BUSY":
+		return exchange.SystemStatusDegraded
+	case "NO ORDER", "STOP":
+		return exchange.SystemStatusMaintenance
+	}
+	return exchange.SystemStatusUnknown
+}
+
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *Bitflyer) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 
 	p = b.CheckFXString(p)
@@ -72,6 +103,7 @@ func (b *Bitflyer) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.P
 
 // GetTickerPrice returns the ticker for a currency pair
 func (b *Bitflyer) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	tick, err := ticker.GetTicker(b.GetName(), p, ticker.Spot)
 	if err != nil {
 		return b.UpdateTicker(p, assetType)
@@ -90,6 +122,7 @@ func (b *Bitflyer) CheckFXString(p pair.CurrencyPair) pair.CurrencyPair {
 
 // GetOrderbookEx returns the orderbook for a currency pair
 func (b *Bitflyer) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(b.GetName(), p, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(p, assetType)
@@ -99,6 +132,11 @@ func (b *Bitflyer) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderb
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (b *Bitflyer) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 
 	p = b.CheckFXString(p)
@@ -138,6 +176,16 @@ func (b *Bitflyer) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (b *Bitflyer) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := b.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return b.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *Bitflyer) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -154,7 +202,14 @@ func (b *Bitflyer) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]
 
 // SubmitOrder submits a new order
 func (b *Bitflyer) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := b.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (b *Bitflyer) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 
 	return submitOrderResponse, common.ErrNotYetImplemented
 }
@@ -184,19 +239,27 @@ func (b *Bitflyer) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (b *Bitflyer) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (b *Bitflyer) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (b *Bitflyer) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (b *Bitflyer) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := b.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bitflyer) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := b.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
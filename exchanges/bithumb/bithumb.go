@@ -58,6 +58,10 @@ type Bithumb struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.RegisterExchange("bithumb", func() exchange.IBotExchange { return new(Bithumb) })
+}
+
 // SetDefaults sets the basic defaults for Bithumb
 func (b *Bithumb) SetDefaults() {
 	b.Name = "Bithumb"
@@ -80,6 +84,13 @@ func (b *Bithumb) SetDefaults() {
 	b.APIUrlDefault = apiURL
 	b.APIUrl = b.APIUrlDefault
 	b.WebsocketInit()
+	b.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	b.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
@@ -89,16 +100,21 @@ func (b *Bithumb) Setup(exch config.ExchangeConfig) {
 	} else {
 		b.Enabled = true
 		b.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		b.Verbose = exch.Verbose
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
+		b.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := b.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		b.RESTPollingDelay = exch.RESTPollingDelay
-		b.Verbose = exch.Verbose
 		b.Websocket.SetEnabled(exch.Websocket)
 		b.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		b.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := b.SetCurrencyPairFormat()
+		err = b.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -106,6 +122,10 @@ func (b *Bithumb) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -12,6 +12,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*Bithumb)(nil)
+
 // Start starts the OKEX go routine
 func (b *Bithumb) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -29,13 +31,19 @@ func (b *Bithumb) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
-	exchangeProducts, err := b.GetTradingPairs()
+	var exchangeProducts []string
+	err := b.RetryPairUpdate(func() (err error) {
+		exchangeProducts, err = b.GetTradingPairs()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available symbols.\n", b.GetName())
 	} else {
-		err = b.UpdateCurrencies(exchangeProducts, false, false)
-		if err != nil {
-			log.Printf("%s Failed to update available symbols.\n", b.GetName())
+		if b.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = b.UpdateCurrencies(exchangeProducts, false, false)
+			if err != nil {
+				log.Printf("%s Failed to update available symbols.\n", b.GetName())
+			}
 		}
 	}
 }
@@ -56,6 +64,11 @@ func (b *Bithumb) GetTradingPairs() ([]string, error) {
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *Bithumb) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 
 	tickers, err := b.GetAllTickers()
@@ -80,6 +93,7 @@ func (b *Bithumb) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Pr
 
 // GetTickerPrice returns the ticker for a currency pair
 func (b *Bithumb) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(b.GetName(), p, assetType)
 	if err != nil {
 		return b.UpdateTicker(p, assetType)
@@ -89,6 +103,7 @@ func (b *Bithumb) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (b *Bithumb) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(b.GetName(), currency, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(currency, assetType)
@@ -98,6 +113,11 @@ func (b *Bithumb) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (b *Bithumb) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	currency := p.FirstCurrency.String()
 
@@ -147,6 +167,16 @@ func (b *Bithumb) GetAccountInfo() (exchange.AccountInfo, error) {
 	return info, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (b *Bithumb) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := b.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return b.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *Bithumb) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -163,16 +193,23 @@ func (b *Bithumb) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]e
 
 // SubmitOrder submits a new order
 func (b *Bithumb) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := b.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (b *Bithumb) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 	var err error
 	var orderID string
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		var result MarketBuy
-		result, err = b.MarketBuyOrder(p.FirstCurrency.String(), amount)
+		result, err = b.MarketBuyOrder(order.Pair.FirstCurrency.String(), order.Amount)
 		orderID = result.OrderID
-	} else if side == exchange.Sell {
+	} else if order.OrderSide == exchange.Sell {
 		var result MarketSell
-		result, err = b.MarketSellOrder(p.FirstCurrency.String(), amount)
+		result, err = b.MarketSellOrder(order.Pair.FirstCurrency.String(), order.Amount)
 		orderID = result.OrderID
 	}
 
@@ -244,19 +281,27 @@ func (b *Bithumb) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (b *Bithumb) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (b *Bithumb) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (b *Bithumb) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (b *Bithumb) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := b.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bithumb) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := b.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
@@ -108,6 +108,10 @@ const (
 	ContractUpsideProfit
 )
 
+func init() {
+	exchange.RegisterExchange("bitmex", func() exchange.IBotExchange { return new(Bitmex) })
+}
+
 // SetDefaults sets the basic defaults for Bitmex
 func (b *Bitmex) SetDefaults() {
 	b.Name = "Bitmex"
@@ -128,6 +132,12 @@ func (b *Bitmex) SetDefaults() {
 	b.APIUrl = b.APIUrlDefault
 	b.SupportsAutoPairUpdating = true
 	b.WebsocketInit()
+	b.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
@@ -137,14 +147,18 @@ func (b *Bitmex) Setup(exch config.ExchangeConfig) {
 	} else {
 		b.Enabled = true
 		b.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		b.Verbose = exch.Verbose
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
+		err := b.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		b.RESTPollingDelay = exch.RESTPollingDelay
-		b.Verbose = exch.Verbose
 		b.Websocket.SetEnabled(exch.Websocket)
 		b.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		b.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := b.SetCurrencyPairFormat()
+		err = b.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -152,6 +166,10 @@ func (b *Bitmex) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -86,7 +86,7 @@ func (b *Bitmex) WsConnector() error {
 		return err
 	}
 
-	_, p, err := b.WebsocketConn.ReadMessage()
+	_, p, err := b.Websocket.ReadMessage(b.WebsocketConn)
 	if err != nil {
 		return err
 	}
@@ -144,7 +144,7 @@ func (b *Bitmex) wsReadData() {
 			return
 
 		default:
-			_, resp, err := b.WebsocketConn.ReadMessage()
+			_, resp, err := b.Websocket.ReadMessage(b.WebsocketConn)
 			if err != nil {
 				b.Websocket.DataHandler <- fmt.Errorf("bitmex_websocket.go - websocket connection Error: %s",
 					err)
@@ -178,7 +178,7 @@ func (b *Bitmex) wsHandleIncomingData() {
 			}
 
 			if common.StringContains(message, "ping") {
-				err := b.WebsocketConn.WriteJSON("pong")
+				err := b.Websocket.SendJSON(b.WebsocketConn, "pong")
 				if err != nil {
 					b.Websocket.DataHandler <- err
 				}
@@ -417,7 +417,7 @@ func (b *Bitmex) websocketSubscribe() error {
 		// NOTE more added here in future
 	}
 
-	err := b.WebsocketConn.WriteJSON(subscriber)
+	err := b.Websocket.SendJSON(b.WebsocketConn, subscriber)
 	if err != nil {
 		return err
 	}
@@ -441,5 +441,5 @@ func (b *Bitmex) websocketSendAuth() error {
 	sendAuth.Arguments = append(sendAuth.Arguments, timestamp)
 	sendAuth.Arguments = append(sendAuth.Arguments, signature)
 
-	return b.WebsocketConn.WriteJSON(sendAuth)
+	return b.Websocket.SendJSON(b.WebsocketConn, sendAuth)
 }
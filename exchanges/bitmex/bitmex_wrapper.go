@@ -14,6 +14,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*Bitmex)(nil)
+
 // Start starts the Bitmex go routine
 func (b *Bitmex) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -31,7 +33,11 @@ func (b *Bitmex) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
-	marketInfo, err := b.GetActiveInstruments(GenericRequestParams{})
+	var marketInfo []Instrument
+	err := b.RetryPairUpdate(func() (err error) {
+		marketInfo, err = b.GetActiveInstruments(GenericRequestParams{})
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available symbols.\n", b.GetName())
 
@@ -41,15 +47,22 @@ func (b *Bitmex) Run() {
 			exchangeProducts = append(exchangeProducts, info.Symbol)
 		}
 
-		err = b.UpdateCurrencies(exchangeProducts, false, false)
-		if err != nil {
-			log.Printf("%s Failed to update available currencies.\n", b.GetName())
+		if b.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = b.UpdateCurrencies(exchangeProducts, false, false)
+			if err != nil {
+				log.Printf("%s Failed to update available currencies.\n", b.GetName())
+			}
 		}
 	}
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *Bitmex) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	currency := exchange.FormatExchangeCurrency(b.Name, p)
 
@@ -79,6 +92,7 @@ func (b *Bitmex) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Pri
 
 // GetTickerPrice returns the ticker for a currency pair
 func (b *Bitmex) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(b.GetName(), p, assetType)
 	if err != nil {
 		return b.UpdateTicker(p, assetType)
@@ -88,6 +102,7 @@ func (b *Bitmex) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.P
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (b *Bitmex) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(b.GetName(), currency, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(currency, assetType)
@@ -97,6 +112,11 @@ func (b *Bitmex) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (o
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (b *Bitmex) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 
 	orderbookNew, err := b.GetOrderbook(OrderBookGetL2Params{
@@ -147,6 +167,16 @@ func (b *Bitmex) GetAccountInfo() (exchange.AccountInfo, error) {
 	return info, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (b *Bitmex) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := b.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return b.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *Bitmex) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -164,22 +194,29 @@ func (b *Bitmex) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (b *Bitmex) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := b.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (b *Bitmex) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 
-	if math.Mod(amount, 1) != 0 {
+	if math.Mod(order.Amount, 1) != 0 {
 		return submitOrderResponse,
 			errors.New("contract amount can not have decimals")
 	}
 
 	var orderNewParams = OrderNewParams{
-		OrdType:  side.ToString(),
-		Symbol:   p.Pair().String(),
-		OrderQty: amount,
-		Side:     side.ToString(),
+		OrdType:  order.OrderSide.ToString(),
+		Symbol:   order.Pair.Pair().String(),
+		OrderQty: order.Amount,
+		Side:     order.OrderSide.ToString(),
 	}
 
-	if orderType == exchange.Limit {
-		orderNewParams.Price = price
+	if order.OrderType == exchange.Limit {
+		orderNewParams.Price = order.Price
 	}
 
 	response, err := b.CreateOrder(orderNewParams)
@@ -250,19 +287,27 @@ func (b *Bitmex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (b *Bitmex) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (b *Bitmex) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (b *Bitmex) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (b *Bitmex) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := b.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *Bitmex) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := b.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
@@ -61,6 +61,10 @@ type Bitstamp struct {
 	WebsocketConn WebsocketConn
 }
 
+func init() {
+	exchange.RegisterExchange("bitstamp", func() exchange.IBotExchange { return new(Bitstamp) })
+}
+
 // SetDefaults sets default for Bitstamp
 func (b *Bitstamp) SetDefaults() {
 	b.Name = "Bitstamp"
@@ -82,6 +86,13 @@ func (b *Bitstamp) SetDefaults() {
 	b.APIUrlDefault = bitstampAPIURL
 	b.APIUrl = b.APIUrlDefault
 	b.WebsocketInit()
+	b.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	b.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	b.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	b.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets configuration values to bitstamp
@@ -91,11 +102,16 @@ func (b *Bitstamp) Setup(exch config.ExchangeConfig) {
 	} else {
 		b.Enabled = true
 		b.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		b.Verbose = exch.Verbose
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
+		b.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := b.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		b.RESTPollingDelay = exch.RESTPollingDelay
-		b.Verbose = exch.Verbose
 		b.Websocket.SetEnabled(exch.Websocket)
 		b.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		b.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
@@ -104,7 +120,7 @@ func (b *Bitstamp) Setup(exch config.ExchangeConfig) {
 		b.APISecret = exch.APISecret
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, b.ClientID, false)
 		b.AuthenticatedAPISupport = true
-		err := b.SetCurrencyPairFormat()
+		err = b.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -112,6 +128,10 @@ func (b *Bitstamp) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = b.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -170,7 +170,7 @@ func TestGetAccountBalanceByCurrency(t *testing.T) {
 func TestGetDepositAddress(t *testing.T) {
 	t.Parallel()
 
-	_, err := b.GetDepositAddress("btc")
+	_, err := b.GetDepositAddress("btc", "")
 	if err == nil {
 		t.Error("Test Failed - Bittrex - GetDepositAddress() error")
 	}
@@ -427,3 +427,24 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestConvertWithdrawalStatus(t *testing.T) {
+	tests := []struct {
+		entry    WithdrawalHistoryEntry
+		expected exchange.WithdrawalStatus
+	}{
+		{WithdrawalHistoryEntry{}, exchange.WithdrawalPending},
+		{WithdrawalHistoryEntry{Authorized: true}, exchange.WithdrawalProcessing},
+		{WithdrawalHistoryEntry{Authorized: true, PendingPayment: true, TxID: "tx"}, exchange.WithdrawalProcessing},
+		{WithdrawalHistoryEntry{Authorized: true, TxID: "tx"}, exchange.WithdrawalCompleted},
+		{WithdrawalHistoryEntry{Canceled: true}, exchange.WithdrawalFailed},
+		{WithdrawalHistoryEntry{InvalidAddress: true}, exchange.WithdrawalFailed},
+	}
+
+	for i := range tests {
+		if status := convertWithdrawalStatus(&tests[i].entry); status != tests[i].expected {
+			t.Errorf("Test Failed - Bittrex - convertWithdrawalStatus() %d expected %s, received %s",
+				i, tests[i].expected, status)
+		}
+	}
+}
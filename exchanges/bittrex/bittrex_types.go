@@ -188,19 +188,22 @@ type Order struct {
 
 // WithdrawalHistory holds the Withdrawal history data
 type WithdrawalHistory struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Result  []struct {
-		PaymentUUID    string  `json:"PaymentUuid"`
-		Currency       string  `json:"Currency"`
-		Amount         float64 `json:"Amount"`
-		Address        string  `json:"Address"`
-		Opened         string  `json:"Opened"`
-		Authorized     bool    `json:"Authorized"`
-		PendingPayment bool    `json:"PendingPayment"`
-		TxCost         float64 `json:"TxCost"`
-		TxID           string  `json:"TxId"`
-		Canceled       bool    `json:"Canceled"`
-		InvalidAddress bool    `json:"InvalidAddress"`
-	} `json:"result"`
+	Success bool                     `json:"success"`
+	Message string                   `json:"message"`
+	Result  []WithdrawalHistoryEntry `json:"result"`
+}
+
+// WithdrawalHistoryEntry holds a single withdrawal or deposit
+type WithdrawalHistoryEntry struct {
+	PaymentUUID    string  `json:"PaymentUuid"`
+	Currency       string  `json:"Currency"`
+	Amount         float64 `json:"Amount"`
+	Address        string  `json:"Address"`
+	Opened         string  `json:"Opened"`
+	Authorized     bool    `json:"Authorized"`
+	PendingPayment bool    `json:"PendingPayment"`
+	TxCost         float64 `json:"TxCost"`
+	TxID           string  `json:"TxId"`
+	Canceled       bool    `json:"Canceled"`
+	InvalidAddress bool    `json:"InvalidAddress"`
 }
@@ -2,6 +2,7 @@ package bittrex
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"sync"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*Bittrex)(nil)
+
 // Start starts the Bittrex go routine
 func (b *Bittrex) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -28,7 +31,11 @@ func (b *Bittrex) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
-	exchangeProducts, err := b.GetMarkets()
+	var exchangeProducts Market
+	err := b.RetryPairUpdate(func() (err error) {
+		exchangeProducts, err = b.GetMarkets()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available symbols.\n", b.GetName())
 	} else {
@@ -53,9 +60,11 @@ func (b *Bittrex) Run() {
 				log.Printf("%s Failed to get config.\n", b.GetName())
 			}
 		}
-		err = b.UpdateCurrencies(currencies, false, forceUpgrade)
-		if err != nil {
-			log.Printf("%s Failed to get config.\n", b.GetName())
+		if b.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = b.UpdateCurrencies(currencies, false, forceUpgrade)
+			if err != nil {
+				log.Printf("%s Failed to get config.\n", b.GetName())
+			}
 		}
 	}
 }
@@ -80,8 +89,23 @@ func (b *Bittrex) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (b *Bittrex) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := b.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return b.FilterCurrencyBalance(info, currency)
+}
+
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *Bittrex) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	tick, err := b.GetMarketSummaries()
 	if err != nil {
@@ -108,6 +132,7 @@ func (b *Bittrex) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Pr
 
 // GetTickerPrice returns the ticker for a currency pair
 func (b *Bittrex) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	tick, err := ticker.GetTicker(b.GetName(), p, ticker.Spot)
 	if err != nil {
 		return b.UpdateTicker(p, assetType)
@@ -117,6 +142,7 @@ func (b *Bittrex) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.
 
 // GetOrderbookEx returns the orderbook for a currency pair
 func (b *Bittrex) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(b.GetName(), p, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(p, assetType)
@@ -126,6 +152,11 @@ func (b *Bittrex) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbo
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (b *Bittrex) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := b.GetOrderbook(exchange.FormatExchangeCurrency(b.GetName(), p).String())
 	if err != nil {
@@ -170,19 +201,26 @@ func (b *Bittrex) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]e
 
 // SubmitOrder submits a new order
 func (b *Bittrex) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
-	buy := side == exchange.Buy
+	resp, err := b.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (b *Bittrex) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
+	buy := order.OrderSide == exchange.Buy
 	var response UUID
 	var err error
 
-	if orderType != exchange.Limit {
+	if order.OrderType != exchange.Limit {
 		return submitOrderResponse, errors.New("not supported on exchange")
 	}
 
 	if buy {
-		response, err = b.PlaceBuyLimit(p.Pair().String(), amount, price)
+		response, err = b.PlaceBuyLimit(order.Pair.Pair().String(), order.Amount, order.Price)
 	} else {
-		response, err = b.PlaceSellLimit(p.Pair().String(), amount, price)
+		response, err = b.PlaceSellLimit(order.Pair.Pair().String(), order.Amount, order.Price)
 	}
 
 	if response.Result.ID != "" {
@@ -236,19 +274,56 @@ func (b *Bittrex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (b *Bittrex) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (b *Bittrex) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (b *Bittrex) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (b *Bittrex) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := b.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
+// GetWithdrawalStatus returns the status of a withdrawal by its payment UUID
+func (b *Bittrex) GetWithdrawalStatus(withdrawalID string) (exchange.WithdrawalStatus, error) {
+	history, err := b.GetWithdrawalHistory("")
+	if err != nil {
+		return "", err
+	}
+
+	for i := range history.Result {
+		if history.Result[i].PaymentUUID == withdrawalID {
+			return convertWithdrawalStatus(&history.Result[i]), nil
+		}
+	}
+	return "", fmt.Errorf("%s withdrawal %s not found", b.Name, withdrawalID)
+}
+
+// convertWithdrawalStatus derives a withdrawal's status from its history entry
+func convertWithdrawalStatus(w *WithdrawalHistoryEntry) exchange.WithdrawalStatus {
+	switch {
+	case w.Canceled || w.InvalidAddress:
+		return exchange.WithdrawalFailed
+	case w.TxID != "" && !w.PendingPayment:
+		return exchange.WithdrawalCompleted
+	case w.Authorized || w.PendingPayment:
+		return exchange.WithdrawalProcessing
+	default:
+		return exchange.WithdrawalPending
+	}
+}
+
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bittrex) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := b.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
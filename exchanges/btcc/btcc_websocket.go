@@ -96,7 +96,7 @@ func (b *BTCC) WsReadData() {
 
 		default:
 			mtx.Lock()
-			_, resp, err := b.Conn.ReadMessage()
+			_, resp, err := b.Websocket.ReadMessage(b.Conn)
 			mtx.Unlock()
 			if err != nil {
 				b.Websocket.DataHandler <- err
@@ -263,7 +263,7 @@ func (b *BTCC) WsSubscribeAllTickers() error {
 	mtx.Lock()
 	defer mtx.Unlock()
 
-	return b.Conn.WriteJSON(WsOutgoing{
+	return b.Websocket.SendJSON(b.Conn, WsOutgoing{
 		Action: "SubscribeAllTickers",
 	})
 }
@@ -273,7 +273,7 @@ func (b *BTCC) WsUnSubscribeAllTickers() error {
 	mtx.Lock()
 	defer mtx.Unlock()
 
-	return b.Conn.WriteJSON(WsOutgoing{
+	return b.Websocket.SendJSON(b.Conn, WsOutgoing{
 		Action: "UnSubscribeAllTickers",
 	})
 }
@@ -287,7 +287,7 @@ func (b *BTCC) WsUpdateCurrencyPairs() error {
 
 	var currencyResponse WsResponseMain
 	for {
-		_, resp, err := b.Conn.ReadMessage()
+		_, resp, err := b.Websocket.ReadMessage(b.Conn)
 		if err != nil {
 			return err
 		}
@@ -337,7 +337,7 @@ func (b *BTCC) WsSubscribeToOrderbook() error {
 
 	for _, pair := range b.GetEnabledCurrencies() {
 		formattedPair := exchange.FormatExchangeCurrency(b.GetName(), pair)
-		err := b.Conn.WriteJSON(WsOutgoing{
+		err := b.Websocket.SendJSON(b.Conn, WsOutgoing{
 			Action: "SubOrderBook",
 			Symbol: formattedPair.String(),
 			Len:    100})
@@ -355,7 +355,7 @@ func (b *BTCC) WsSubcribeToTicker() error {
 
 	for _, pair := range b.GetEnabledCurrencies() {
 		formattedPair := exchange.FormatExchangeCurrency(b.GetName(), pair)
-		err := b.Conn.WriteJSON(WsOutgoing{
+		err := b.Websocket.SendJSON(b.Conn, WsOutgoing{
 			Action: "Subscribe",
 			Symbol: formattedPair.String(),
 		})
@@ -373,7 +373,7 @@ func (b *BTCC) WsSubcribeToTrades() error {
 
 	for _, pair := range b.GetEnabledCurrencies() {
 		formattedPair := exchange.FormatExchangeCurrency(b.GetName(), pair)
-		err := b.Conn.WriteJSON(WsOutgoing{
+		err := b.Websocket.SendJSON(b.Conn, WsOutgoing{
 			Action: "GetTrades",
 			Symbol: formattedPair.String(),
 			Count:  100,
@@ -13,6 +13,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*BTCC)(nil)
+
 // Start starts the BTCC go routine
 func (b *BTCC) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -45,9 +47,11 @@ func (b *BTCC) Run() {
 		exchCfg.EnabledPairs = pairs[0]
 		b.BaseCurrencies = []string{"USD"}
 
-		err = b.UpdateCurrencies(pairs, false, true)
-		if err != nil {
-			log.Printf("%s failed to update available currencies. %s\n", b.Name, err)
+		if b.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = b.UpdateCurrencies(pairs, false, true)
+			if err != nil {
+				log.Printf("%s failed to update available currencies. %s\n", b.Name, err)
+			}
 		}
 
 		err = b.UpdateCurrencies(pairs, true, true)
@@ -65,6 +69,11 @@ func (b *BTCC) Run() {
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *BTCC) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	// var tickerPrice ticker.Price
 	// tick, err := b.GetTicker(exchange.FormatExchangeCurrency(b.GetName(), p).String())
 	// if err != nil {
@@ -84,6 +93,7 @@ func (b *BTCC) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price
 
 // GetTickerPrice returns the ticker for a currency pair
 func (b *BTCC) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	// tickerNew, err := ticker.GetTicker(b.GetName(), p, assetType)
 	// if err != nil {
 	// 	return b.UpdateTicker(p, assetType)
@@ -94,6 +104,7 @@ func (b *BTCC) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Pri
 
 // GetOrderbookEx returns the orderbook for a currency pair
 func (b *BTCC) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = b.GetAssetTypeOrDefault(assetType)
 	// ob, err := orderbook.GetOrderbook(b.GetName(), p, assetType)
 	// if err != nil {
 	// 	return b.UpdateOrderbook(p, assetType)
@@ -104,6 +115,11 @@ func (b *BTCC) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (b *BTCC) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := b.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	// var orderBook orderbook.Base
 	// orderbookNew, err := b.GetOrderBook(exchange.FormatExchangeCurrency(b.GetName(), p).String(), 100)
 	// if err != nil {
@@ -134,25 +150,37 @@ func (b *BTCC) GetAccountInfo() (exchange.AccountInfo, error) {
 	return exchange.AccountInfo{}, errors.New("REST NOT SUPPORTED")
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (b *BTCC) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := b.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return b.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *BTCC) GetFundingHistory() ([]exchange.FundHistory, error) {
-	// var fundHistory []exchange.FundHistory
-	// return fundHistory, common.ErrFunctionNotSupported
-	return nil, errors.New("REST NOT SUPPORTED")
+	return nil, common.ErrFunctionNotSupported
 }
 
 // GetExchangeHistory returns historic trade data since exchange opening.
 func (b *BTCC) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
-	// var resp []exchange.TradeHistory
-
-	// return resp, common.ErrNotYetImplemented
-	return nil, errors.New("REST NOT SUPPORTED")
+	return nil, common.ErrFunctionNotSupported
 }
 
 // SubmitOrder submits a new order
 func (b *BTCC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := b.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (b *BTCC) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 
 	return submitOrderResponse, common.ErrNotYetImplemented
 }
@@ -180,19 +208,27 @@ func (b *BTCC) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (b *BTCC) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (b *BTCC) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (b *BTCC) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (b *BTCC) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := b.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *BTCC) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := b.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
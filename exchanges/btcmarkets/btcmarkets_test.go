@@ -170,7 +170,7 @@ func TestGetOrderInfo(t *testing.T) {
 }
 
 func TestWithdrawCryptocurrencyFunds(t *testing.T) {
-	_, err := b.WithdrawCryptocurrencyFunds("someaddress", "ltc", 0)
+	_, err := b.WithdrawCryptocurrencyFunds("someaddress", "ltc", "", 0)
 	if err == nil {
 		t.Error("Test failed - WithdrawExchangeFunds() error", err)
 	}
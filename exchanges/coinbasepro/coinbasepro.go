@@ -62,6 +62,10 @@ type CoinbasePro struct {
 	WebsocketConn *websocket.Conn
 }
 
+func init() {
+	exchange.RegisterExchange("coinbasepro", func() exchange.IBotExchange { return new(CoinbasePro) })
+}
+
 // SetDefaults sets default values for the exchange
 func (c *CoinbasePro) SetDefaults() {
 	c.Name = "CoinbasePro"
@@ -85,6 +89,13 @@ func (c *CoinbasePro) SetDefaults() {
 	c.APIUrlDefault = coinbaseproAPIURL
 	c.APIUrl = c.APIUrlDefault
 	c.WebsocketInit()
+	c.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	c.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	c.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	c.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	c.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	c.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	c.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
 }
 
 // Setup initialises the exchange parameters with the current configuration
@@ -94,11 +105,16 @@ func (c *CoinbasePro) Setup(exch config.ExchangeConfig) {
 	} else {
 		c.Enabled = true
 		c.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		c.Verbose = exch.Verbose
 		c.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, true)
 		c.SetHTTPClientTimeout(exch.HTTPTimeout)
+		c.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		c.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := c.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		c.RESTPollingDelay = exch.RESTPollingDelay
-		c.Verbose = exch.Verbose
 		c.Websocket.SetEnabled(exch.Websocket)
 		c.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		c.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
@@ -106,7 +122,7 @@ func (c *CoinbasePro) Setup(exch config.ExchangeConfig) {
 		if exch.UseSandbox {
 			c.APIUrl = coinbaseproSandboxAPIURL
 		}
-		err := c.SetCurrencyPairFormat()
+		err = c.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -114,6 +130,10 @@ func (c *CoinbasePro) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = c.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = c.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
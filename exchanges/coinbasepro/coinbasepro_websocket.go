@@ -52,7 +52,7 @@ func (c *CoinbasePro) WebsocketSubscriber() error {
 		return err
 	}
 
-	return c.WebsocketConn.WriteMessage(websocket.TextMessage, json)
+	return c.Websocket.SendMessage(c.WebsocketConn, websocket.TextMessage, json)
 }
 
 // WsConnect initiates a websocket connection
@@ -111,7 +111,7 @@ func (c *CoinbasePro) WsReadData() {
 			return
 
 		default:
-			_, resp, err := c.WebsocketConn.ReadMessage()
+			_, resp, err := c.Websocket.ReadMessage(c.WebsocketConn)
 			if err != nil {
 				c.Websocket.DataHandler <- err
 				return
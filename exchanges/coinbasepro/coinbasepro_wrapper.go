@@ -12,6 +12,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*CoinbasePro)(nil)
+
 // Start starts the coinbasepro go routine
 func (c *CoinbasePro) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -29,7 +31,11 @@ func (c *CoinbasePro) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", c.GetName(), len(c.EnabledPairs), c.EnabledPairs)
 	}
 
-	exchangeProducts, err := c.GetProducts()
+	var exchangeProducts []Product
+	err := c.RetryPairUpdate(func() (err error) {
+		exchangeProducts, err = c.GetProducts()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available products.\n", c.GetName())
 	} else {
@@ -39,9 +45,11 @@ func (c *CoinbasePro) Run() {
 				currencies = append(currencies, x.ID[0:3]+x.ID[4:])
 			}
 		}
-		err = c.UpdateCurrencies(currencies, false, false)
-		if err != nil {
-			log.Printf("%s Failed to update available currencies.\n", c.GetName())
+		if c.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = c.UpdateCurrencies(currencies, false, false)
+			if err != nil {
+				log.Printf("%s Failed to update available currencies.\n", c.GetName())
+			}
 		}
 	}
 }
@@ -66,8 +74,23 @@ func (c *CoinbasePro) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (c *CoinbasePro) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := c.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return c.FilterCurrencyBalance(info, currency)
+}
+
 // UpdateTicker updates and returns the ticker for a currency pair
 func (c *CoinbasePro) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := c.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	tick, err := c.GetTicker(exchange.FormatExchangeCurrency(c.Name, p).String())
 	if err != nil {
@@ -91,6 +114,7 @@ func (c *CoinbasePro) UpdateTicker(p pair.CurrencyPair, assetType string) (ticke
 
 // GetTickerPrice returns the ticker for a currency pair
 func (c *CoinbasePro) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = c.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(c.GetName(), p, assetType)
 	if err != nil {
 		return c.UpdateTicker(p, assetType)
@@ -100,6 +124,7 @@ func (c *CoinbasePro) GetTickerPrice(p pair.CurrencyPair, assetType string) (tic
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (c *CoinbasePro) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = c.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(c.GetName(), p, assetType)
 	if err != nil {
 		return c.UpdateOrderbook(p, assetType)
@@ -109,6 +134,11 @@ func (c *CoinbasePro) GetOrderbookEx(p pair.CurrencyPair, assetType string) (ord
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (c *CoinbasePro) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := c.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := c.GetOrderbook(exchange.FormatExchangeCurrency(c.Name, p).String(), 2)
 	if err != nil {
@@ -145,14 +175,21 @@ func (c *CoinbasePro) GetExchangeHistory(p pair.CurrencyPair, assetType string)
 
 // SubmitOrder submits a new order
 func (c *CoinbasePro) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := c.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (c *CoinbasePro) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 	var response string
 	var err error
-	if orderType == exchange.Market {
-		response, err = c.PlaceMarginOrder("", amount, amount, side.ToString(), p.Pair().String(), "")
+	if order.OrderType == exchange.Market {
+		response, err = c.PlaceMarginOrder("", order.Amount, order.Amount, order.OrderSide.ToString(), order.Pair.Pair().String(), "")
 
-	} else if orderType == exchange.Limit {
-		response, err = c.PlaceLimitOrder("", price, amount, side.ToString(), "", "", p.Pair().String(), "", false)
+	} else if order.OrderType == exchange.Limit {
+		response, err = c.PlaceLimitOrder("", order.Price, order.Amount, order.OrderSide.ToString(), "", "", order.Pair.Pair().String(), "", false)
 	} else {
 		err = errors.New("not supported")
 	}
@@ -193,19 +230,27 @@ func (c *CoinbasePro) GetOrderInfo(orderID int64) (exchange.OrderDetail, error)
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (c *CoinbasePro) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (c *CoinbasePro) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (c *CoinbasePro) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (c *CoinbasePro) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := c.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (c *CoinbasePro) WithdrawFiatFunds(cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+	if err := c.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
@@ -47,6 +47,10 @@ type COINUT struct {
 	InstrumentMap map[string]int
 }
 
+func init() {
+	exchange.RegisterExchange("coinut", func() exchange.IBotExchange { return new(COINUT) })
+}
+
 // SetDefaults sets current default values
 func (c *COINUT) SetDefaults() {
 	c.Name = "COINUT"
@@ -71,6 +75,13 @@ func (c *COINUT) SetDefaults() {
 	c.APIUrlDefault = coinutAPIURL
 	c.APIUrl = c.APIUrlDefault
 	c.WebsocketInit()
+	c.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	c.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	c.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	c.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	c.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	c.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	c.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets the current exchange configuration
@@ -80,16 +91,21 @@ func (c *COINUT) Setup(exch config.ExchangeConfig) {
 	} else {
 		c.Enabled = true
 		c.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		c.Verbose = exch.Verbose
 		c.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
 		c.SetHTTPClientTimeout(exch.HTTPTimeout)
+		c.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		c.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := c.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		c.RESTPollingDelay = exch.RESTPollingDelay
-		c.Verbose = exch.Verbose
 		c.Websocket.SetEnabled(exch.Websocket)
 		c.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		c.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		c.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := c.SetCurrencyPairFormat()
+		err = c.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -97,6 +113,10 @@ func (c *COINUT) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = c.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = c.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
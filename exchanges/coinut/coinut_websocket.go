@@ -47,7 +47,7 @@ func (c *COINUT) WsReadData() {
 			return
 
 		default:
-			_, resp, err := c.WebsocketConn.ReadMessage()
+			_, resp, err := c.Websocket.ReadMessage(c.WebsocketConn)
 			if err != nil {
 				c.Websocket.DataHandler <- err
 				return
@@ -240,12 +240,12 @@ func (c *COINUT) WsSetInstrumentList() error {
 		return err
 	}
 
-	err = c.WebsocketConn.WriteMessage(websocket.TextMessage, request)
+	err = c.Websocket.SendMessage(c.WebsocketConn, websocket.TextMessage, request)
 	if err != nil {
 		return err
 	}
 
-	_, resp, err := c.WebsocketConn.ReadMessage()
+	_, resp, err := c.Websocket.ReadMessage(c.WebsocketConn)
 	if err != nil {
 		return err
 	}
@@ -287,7 +287,7 @@ func (c *COINUT) WsSubscribe() error {
 			return err
 		}
 
-		err = c.WebsocketConn.WriteMessage(websocket.TextMessage, tickjson)
+		err = c.Websocket.SendMessage(c.WebsocketConn, websocket.TextMessage, tickjson)
 		if err != nil {
 			return err
 		}
@@ -304,7 +304,7 @@ func (c *COINUT) WsSubscribe() error {
 			return err
 		}
 
-		err = c.WebsocketConn.WriteMessage(websocket.TextMessage, objson)
+		err = c.Websocket.SendMessage(c.WebsocketConn, websocket.TextMessage, objson)
 		if err != nil {
 			return err
 		}
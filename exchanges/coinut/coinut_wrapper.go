@@ -16,6 +16,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*COINUT)(nil)
+
 // Start starts the COINUT go routine
 func (c *COINUT) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -33,7 +35,11 @@ func (c *COINUT) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", c.GetName(), len(c.EnabledPairs), c.EnabledPairs)
 	}
 
-	exchangeProducts, err := c.GetInstruments()
+	var exchangeProducts Instruments
+	err := c.RetryPairUpdate(func() (err error) {
+		exchangeProducts, err = c.GetInstruments()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available products.\n", c.GetName())
 		return
@@ -46,9 +52,11 @@ func (c *COINUT) Run() {
 		currencies = append(currencies, x)
 	}
 
-	err = c.UpdateCurrencies(currencies, false, false)
-	if err != nil {
-		log.Printf("%s Failed to update available currencies.\n", c.GetName())
+	if c.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+		err = c.UpdateCurrencies(currencies, false, false)
+		if err != nil {
+			log.Printf("%s Failed to update available currencies.\n", c.GetName())
+		}
 	}
 }
 
@@ -137,8 +145,23 @@ func (c *COINUT) GetAccountInfo() (exchange.AccountInfo, error) {
 	return info, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (c *COINUT) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := c.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return c.FilterCurrencyBalance(info, currency)
+}
+
 // UpdateTicker updates and returns the ticker for a currency pair
 func (c *COINUT) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := c.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	tick, err := c.GetInstrumentTicker(c.InstrumentMap[p.Pair().String()])
 	if err != nil {
@@ -157,6 +180,7 @@ func (c *COINUT) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Pri
 
 // GetTickerPrice returns the ticker for a currency pair
 func (c *COINUT) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = c.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(c.GetName(), p, assetType)
 	if err != nil {
 		return c.UpdateTicker(p, assetType)
@@ -166,6 +190,7 @@ func (c *COINUT) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.P
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (c *COINUT) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = c.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(c.GetName(), p, assetType)
 	if err != nil {
 		return c.UpdateOrderbook(p, assetType)
@@ -175,6 +200,11 @@ func (c *COINUT) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderboo
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (c *COINUT) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := c.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := c.GetInstrumentOrderbook(c.InstrumentMap[p.Pair().String()], 200)
 	if err != nil {
@@ -210,11 +240,18 @@ func (c *COINUT) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (c *COINUT) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := c.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (c *COINUT) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 	var err error
 	var APIresponse interface{}
-	isBuyOrder := side == exchange.Buy
-	clientIDInt, err := strconv.ParseUint(clientID, 0, 32)
+	isBuyOrder := order.OrderSide == exchange.Buy
+	clientIDInt, err := strconv.ParseUint(order.ClientID, 0, 32)
 	clientIDUint := uint32(clientIDInt)
 
 	if err != nil {
@@ -226,13 +263,13 @@ func (c *COINUT) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, order
 		return submitOrderResponse, err
 	}
 
-	currencyArray := instruments.Instruments[p.Pair().String()]
+	currencyArray := instruments.Instruments[order.Pair.Pair().String()]
 	currencyID := currencyArray[0].InstID
 
-	if orderType == exchange.Limit {
-		APIresponse, err = c.NewOrder(currencyID, amount, price, isBuyOrder, clientIDUint)
-	} else if orderType == exchange.Market {
-		APIresponse, err = c.NewOrder(currencyID, amount, 0, isBuyOrder, clientIDUint)
+	if order.OrderType == exchange.Limit {
+		APIresponse, err = c.NewOrder(currencyID, order.Amount, order.Price, isBuyOrder, clientIDUint)
+	} else if order.OrderType == exchange.Market {
+		APIresponse, err = c.NewOrder(currencyID, order.Amount, 0, isBuyOrder, clientIDUint)
 	} else {
 		return submitOrderResponse, errors.New("unsupported order type")
 	}
@@ -346,19 +383,27 @@ func (c *COINUT) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (c *COINUT) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (c *COINUT) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (c *COINUT) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (c *COINUT) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := c.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (c *COINUT) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := c.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
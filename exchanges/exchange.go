@@ -1,6 +1,8 @@
 package exchange
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
@@ -29,6 +31,38 @@ const (
 	DefaultHTTPTimeout = time.Second * 15
 )
 
+// Withdrawal permission errors
+var (
+	ErrCryptoWithdrawViaWebsiteOnly = errors.New("cryptocurrency withdrawals are only supported via the exchange website")
+	ErrFiatWithdrawViaWebsiteOnly   = errors.New("fiat withdrawals are only supported via the exchange website")
+	ErrChainSelectionNotSupported   = errors.New("chain selection is not supported by the exchange")
+)
+
+// ErrAssetTypeNotSupported is returned when a wrapper method is called with an
+// asset type the exchange does not support
+var ErrAssetTypeNotSupported = errors.New("asset type not supported")
+
+// Now returns the current time used for fee rate caching, pair update times and
+// journal entries. It defaults to time.Now and can be replaced in tests so time
+// dependent behaviour is deterministic, nonces use nonce.Now
+var Now = time.Now
+
+// Exchange features which can be enabled or disabled at runtime
+const (
+	FeatureAutoPairUpdates    = "autoPairUpdates"
+	FeatureRESTTickerBatching = "restTickerBatching"
+)
+
+// Withdrawal permissions which allow a withdrawal to be made via the API
+const (
+	cryptoWithdrawAPIPermissions = AutoWithdrawCrypto | AutoWithdrawCryptoWithAPIPermission |
+		AutoWithdrawCryptoWithSetup | WithdrawCryptoWith2FA | WithdrawCryptoWithSMS |
+		WithdrawCryptoWithEmail | WithdrawCryptoWithWebsiteApproval | WithdrawCryptoWithAPIPermission
+	fiatWithdrawAPIPermissions = AutoWithdrawFiat | AutoWithdrawFiatWithAPIPermission |
+		AutoWithdrawFiatWithSetup | WithdrawFiatWith2FA | WithdrawFiatWithSMS |
+		WithdrawFiatWithEmail | WithdrawFiatWithWebsiteApproval | WithdrawFiatWithAPIPermission
+)
+
 // FeeType custom type for calculating fees based on method
 type FeeType string
 
@@ -73,6 +107,67 @@ type SubmitOrderResponse struct {
 	OrderID       string
 }
 
+// OrderSubmission holds the parameters used to submit an order. Fields which
+// aren't supported by an exchange are ignored. ClientOrderID is sent to
+// exchanges which deduplicate orders by a client supplied ID, resubmitting an
+// order with the same ClientOrderID after an ambiguous failure such as a
+// timeout will not place it twice on those exchanges
+type OrderSubmission struct {
+	Pair          pair.CurrencyPair
+	OrderSide     OrderSide
+	OrderType     OrderType
+	Amount        float64
+	Price         float64
+	ClientID      string
+	ClientOrderID string
+	TimeInForce   string
+	TriggerPrice  float64
+	Leverage      float64
+}
+
+// OrderSubmissionResponse is returned after submitting an OrderSubmission,
+// ClientOrderID is the client order ID sent to the exchange if any
+type OrderSubmissionResponse struct {
+	IsOrderPlaced bool
+	OrderID       string
+	ClientOrderID string
+}
+
+// clientOrderIDPrefix starts every generated client order ID, some exchanges
+// require client order IDs to begin with a letter
+const clientOrderIDPrefix = "gct"
+
+// NewClientOrderID returns a random client order ID of 29 alphanumeric
+// characters which is accepted by every exchange supporting client order IDs
+func NewClientOrderID() (string, error) {
+	b := make([]byte, 13)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return clientOrderIDPrefix + hex.EncodeToString(b), nil
+}
+
+// NewOrderSubmission returns an OrderSubmission using the SubmitOrder
+// parameters
+func NewOrderSubmission(p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) OrderSubmission {
+	return OrderSubmission{
+		Pair:      p,
+		OrderSide: side,
+		OrderType: orderType,
+		Amount:    amount,
+		Price:     price,
+		ClientID:  clientID,
+	}
+}
+
+// SubmitOrderResponse converts the response to a SubmitOrderResponse
+func (o OrderSubmissionResponse) SubmitOrderResponse() SubmitOrderResponse {
+	return SubmitOrderResponse{
+		IsOrderPlaced: o.IsOrderPlaced,
+		OrderID:       o.OrderID,
+	}
+}
+
 // FeeBuilder is the type which holds all parameters required to calculate a fee for an exchange
 type FeeBuilder struct {
 	FeeType FeeType
@@ -150,14 +245,20 @@ type AccountInfo struct {
 	Currencies   []AccountCurrencyInfo
 }
 
-// AccountCurrencyInfo is a sub type to store currency name and value
+// AccountCurrencyInfo is a sub type to store currency name and value.
+// AccountType labels the account the balance is held in for exchanges with
+// separate accounts e.g. spot and futures, it is empty otherwise
 type AccountCurrencyInfo struct {
 	CurrencyName string
 	TotalValue   float64
 	Hold         float64
+	AccountType  string
 }
 
-// TradeHistory holds exchange history data
+// TradeHistory holds exchange history data. Timestamp is a unix timestamp in
+// seconds, Type holds the raw side value reported by the exchange and Side is
+// the normalised aggressor (taker) side of the trade, so a Buy means the
+// taker lifted an ask and a Sell means the taker hit a bid
 type TradeHistory struct {
 	Timestamp int64
 	TID       int64
@@ -165,6 +266,7 @@ type TradeHistory struct {
 	Amount    float64
 	Exchange  string
 	Type      string
+	Side      OrderSide
 }
 
 // OrderDetail holds order detail data
@@ -216,9 +318,18 @@ type Base struct {
 	AvailablePairs                             []string
 	EnabledPairs                               []string
 	AssetTypes                                 []string
+	DefaultAssetType                           string
+	PriceSources                               []PriceSource
 	PairsLastUpdated                           int64
 	SupportsAutoPairUpdating                   bool
 	SupportsRESTTickerBatching                 bool
+	disabledFeatures                           map[string]bool
+	featuresMtx                                sync.RWMutex
+	FeeRateCacheTTL                            time.Duration
+	feeRates                                   map[string]cachedFeeRate
+	feeRatesMtx                                sync.Mutex
+	capabilities                               map[string]CapabilityStatus
+	loggedCredentials                          map[config.CredentialField]bool
 	HTTPTimeout                                time.Duration
 	HTTPUserAgent                              string
 	WebsocketURL                               string
@@ -232,8 +343,9 @@ type Base struct {
 	*request.Requester
 }
 
-// IBotExchange enforces standard functions for all exchanges supported in
-// GoCryptoTrader
+// IBotExchange is the canonical interface enforcing standard functions for all
+// exchanges supported in GoCryptoTrader. Each wrapper asserts it satisfies this
+// interface at compile time
 type IBotExchange interface {
 	Setup(exch config.ExchangeConfig)
 	Start(wg *sync.WaitGroup)
@@ -241,20 +353,34 @@ type IBotExchange interface {
 	GetName() string
 	IsEnabled() bool
 	SetEnabled(bool)
+	SetVerbose(bool)
+	SetHTTPRateLimiter(cfg *config.HTTPRateLimitConfig) error
 	GetTickerPrice(currency pair.CurrencyPair, assetType string) (ticker.Price, error)
 	UpdateTicker(currency pair.CurrencyPair, assetType string) (ticker.Price, error)
 	GetOrderbookEx(currency pair.CurrencyPair, assetType string) (orderbook.Base, error)
 	UpdateOrderbook(currency pair.CurrencyPair, assetType string) (orderbook.Base, error)
 	GetEnabledCurrencies() []pair.CurrencyPair
 	GetAvailableCurrencies() []pair.CurrencyPair
+	GetAllEnabledPairs() map[string][]pair.CurrencyPair
+	GetAllEnabledAssetPairs() []AssetPair
+	OnTickerUpdate(handler func(ticker.Update)) (unsubscribe func())
+	OnOrderbookUpdate(handler func(orderbook.Update)) (unsubscribe func())
 	GetAssetTypes() []string
+	GetDefaultAssetType() string
+	GetPriceSources() []PriceSource
+	ValidateConfig(exch config.ExchangeConfig) error
 	GetAccountInfo() (AccountInfo, error)
+	GetCurrencyBalance(currency string) (CurrencyBalance, error)
 	GetAuthenticatedAPISupport() bool
 	SetCurrencies(pairs []pair.CurrencyPair, enabledPairs bool) error
 	GetExchangeHistory(pair.CurrencyPair, string) ([]TradeHistory, error)
 	SupportsAutoPairUpdates() bool
 	GetLastPairsUpdateTime() int64
 	SupportsRESTTickerBatchUpdates() bool
+	SetFeatureEnabled(feature string, enabled bool) error
+	IsFeatureEnabled(feature string) bool
+	RefreshFeeRates()
+	Capabilities() map[string]CapabilityStatus
 
 	GetWithdrawPermissions() uint32
 	FormatWithdrawPermissions() string
@@ -262,13 +388,14 @@ type IBotExchange interface {
 
 	GetFundingHistory() ([]FundHistory, error)
 	SubmitOrder(p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error)
+	SubmitOrderWithParams(order OrderSubmission) (OrderSubmissionResponse, error)
 	ModifyOrder(action ModifyOrder) (string, error)
 	CancelOrder(order OrderCancellation) error
 	CancelAllOrders(orders OrderCancellation) (CancelAllOrdersResponse, error)
 	GetOrderInfo(orderID int64) (OrderDetail, error)
-	GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error)
+	GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error)
 
-	WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error)
+	WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error)
 	WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error)
 
 	GetWebsocket() (*Websocket, error)
@@ -326,6 +453,57 @@ func (e *Base) SetHTTPClientUserAgent(ua string) {
 	e.HTTPUserAgent = ua
 }
 
+// SetHTTPRequestTimeouts sets the per request type timeouts from the supplied
+// config, a nil config keeps the HTTP client timeout for every request type
+func (e *Base) SetHTTPRequestTimeouts(cfg *config.HTTPRequestTimeoutsConfig) {
+	if cfg == nil {
+		return
+	}
+
+	if e.Requester == nil {
+		e.Requester = request.New(e.Name,
+			request.NewRateLimit(time.Second, 0),
+			request.NewRateLimit(time.Second, 0),
+			new(http.Client))
+	}
+	e.Requester.SetTimeout(request.Trade, cfg.Trade)
+	e.Requester.SetTimeout(request.MarketData, cfg.MarketData)
+	e.Requester.SetTimeout(request.Account, cfg.Account)
+	e.Requester.SetTimeout(request.History, cfg.History)
+}
+
+// SetHTTPRateLimiter overrides the exchanges default HTTP request rate limits
+// with the supplied config, a nil config keeps the defaults
+func (e *Base) SetHTTPRateLimiter(cfg *config.HTTPRateLimitConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if err := e.checkHTTPRateLimiter(cfg); err != nil {
+		return err
+	}
+
+	if e.Requester == nil {
+		e.Requester = request.New(e.Name,
+			request.NewRateLimit(time.Second, 0),
+			request.NewRateLimit(time.Second, 0),
+			new(http.Client))
+	}
+	e.Requester.SetRateLimit(true, cfg.Duration, cfg.AuthRate)
+	e.Requester.SetRateLimit(false, cfg.Duration, cfg.UnauthRate)
+	return nil
+}
+
+// checkHTTPRateLimiter returns an error if the HTTP rate limiter config can't
+// be applied, a nil config is valid
+func (e *Base) checkHTTPRateLimiter(cfg *config.HTTPRateLimitConfig) error {
+	if cfg != nil && (cfg.Duration <= 0 || cfg.AuthRate < 0 || cfg.UnauthRate < 0) {
+		return fmt.Errorf("%s invalid HTTP rate limiter config: duration %v auth rate %d unauth rate %d",
+			e.Name, cfg.Duration, cfg.AuthRate, cfg.UnauthRate)
+	}
+	return nil
+}
+
 // GetHTTPClientUserAgent gets the exchanges HTTP user agent
 func (e *Base) GetHTTPClientUserAgent() string {
 	return e.HTTPUserAgent
@@ -374,7 +552,7 @@ func (e *Base) SetAutoPairDefaults() error {
 		}
 	} else {
 		if exch.PairsLastUpdated == 0 {
-			exch.PairsLastUpdated = time.Now().Unix()
+			exch.PairsLastUpdated = Now().Unix()
 			e.PairsLastUpdated = exch.PairsLastUpdated
 			update = true
 		}
@@ -392,6 +570,61 @@ func (e *Base) SupportsAutoPairUpdates() bool {
 	return e.SupportsAutoPairUpdating
 }
 
+// supportsFeature returns whether the exchange supports a runtime feature
+func (e *Base) supportsFeature(feature string) (bool, error) {
+	switch feature {
+	case FeatureAutoPairUpdates:
+		return e.SupportsAutoPairUpdating, nil
+	case FeatureRESTTickerBatching:
+		return e.SupportsRESTTickerBatching, nil
+	}
+	return false, fmt.Errorf("%s unknown feature %s", e.Name, feature)
+}
+
+// SetFeatureEnabled enables or disables a feature at runtime without
+// restarting the exchange. A feature can only be enabled when the exchange
+// supports it
+func (e *Base) SetFeatureEnabled(feature string, enabled bool) error {
+	supported, err := e.supportsFeature(feature)
+	if err != nil {
+		return err
+	}
+
+	if enabled && !supported {
+		return fmt.Errorf("%s does not support feature %s", e.Name, feature)
+	}
+
+	e.featuresMtx.Lock()
+	defer e.featuresMtx.Unlock()
+	if enabled {
+		delete(e.disabledFeatures, feature)
+		return nil
+	}
+
+	if e.disabledFeatures == nil {
+		e.disabledFeatures = make(map[string]bool)
+	}
+	e.disabledFeatures[feature] = true
+	return nil
+}
+
+// IsFeatureEnabled returns whether the exchange supports a feature and it
+// hasn't been disabled at runtime
+func (e *Base) IsFeatureEnabled(feature string) bool {
+	supported, err := e.supportsFeature(feature)
+	if err != nil || !supported {
+		return false
+	}
+	return !e.isFeatureDisabled(feature)
+}
+
+// isFeatureDisabled returns whether a feature has been disabled at runtime
+func (e *Base) isFeatureDisabled(feature string) bool {
+	e.featuresMtx.RLock()
+	defer e.featuresMtx.RUnlock()
+	return e.disabledFeatures[feature]
+}
+
 // GetLastPairsUpdateTime returns the unix timestamp of when the exchanges
 // currency pairs were last updated
 func (e *Base) GetLastPairsUpdateTime() int64 {
@@ -399,7 +632,8 @@ func (e *Base) GetLastPairsUpdateTime() int64 {
 }
 
 // SetAssetTypes checks the exchange asset types (whether it supports SPOT,
-// Binary or Futures) and sets it to a default setting if it doesn't exist
+// Binary or Futures) and sets it to a default setting if it doesn't exist. The
+// configured default asset type is validated against the asset types
 func (e *Base) SetAssetTypes() error {
 	cfg := config.GetConfig()
 	exch, err := cfg.GetExchangeConfig(e.Name)
@@ -407,6 +641,14 @@ func (e *Base) SetAssetTypes() error {
 		return err
 	}
 
+	if exch.DefaultAssetType != "" {
+		if !common.StringDataCompare(e.AssetTypes, exch.DefaultAssetType) {
+			return fmt.Errorf("%s default asset type %s is not a supported asset type %v",
+				e.Name, exch.DefaultAssetType, e.AssetTypes)
+		}
+		e.DefaultAssetType = exch.DefaultAssetType
+	}
+
 	update := false
 	if exch.AssetTypes == "" {
 		exch.AssetTypes = common.JoinStrings(e.AssetTypes, ",")
@@ -428,6 +670,45 @@ func (e *Base) GetAssetTypes() []string {
 	return e.AssetTypes
 }
 
+// GetDefaultAssetType returns the configured default asset type, falling back
+// to the exchanges first asset type or SPOT when none are set
+func (e *Base) GetDefaultAssetType() string {
+	if e.DefaultAssetType != "" {
+		return e.DefaultAssetType
+	}
+
+	if len(e.AssetTypes) > 0 {
+		return e.AssetTypes[0]
+	}
+	return ticker.Spot
+}
+
+// GetAssetTypeOrDefault returns the supplied asset type, or the default asset
+// type when it is empty
+func (e *Base) GetAssetTypeOrDefault(assetType string) string {
+	if assetType == "" {
+		return e.GetDefaultAssetType()
+	}
+	return assetType
+}
+
+// ValidateAssetType returns the supplied asset type, or the default asset type
+// when it is empty, and ErrAssetTypeNotSupported when it is not one of the
+// exchanges asset types
+func (e *Base) ValidateAssetType(assetType string) (string, error) {
+	assetType = e.GetAssetTypeOrDefault(assetType)
+	supported := e.AssetTypes
+	if len(supported) == 0 {
+		supported = []string{e.GetDefaultAssetType()}
+	}
+
+	if !common.StringDataCompare(supported, assetType) {
+		return "", fmt.Errorf("%s %s %w, supported asset types %v",
+			e.Name, assetType, ErrAssetTypeNotSupported, supported)
+	}
+	return assetType, nil
+}
+
 // GetExchangeAssetTypes returns the asset types the exchange supports (SPOT,
 // binary, futures)
 func GetExchangeAssetTypes(exchName string) ([]string, error) {
@@ -529,20 +810,65 @@ func (e *Base) GetName() string {
 	return e.Name
 }
 
-// GetEnabledCurrencies is a method that returns the enabled currency pairs of
-// the exchange base
+// GetEnabledCurrencies is a method that returns the sorted enabled currency
+// pairs of the exchange base
 func (e *Base) GetEnabledCurrencies() []pair.CurrencyPair {
-	return pair.FormatPairs(e.EnabledPairs,
+	return pair.SortPairs(pair.FormatPairs(e.EnabledPairs,
 		e.ConfigCurrencyPairFormat.Delimiter,
-		e.ConfigCurrencyPairFormat.Index)
+		e.ConfigCurrencyPairFormat.Index))
 }
 
-// GetAvailableCurrencies is a method that returns the available currency pairs
-// of the exchange base
+// GetAvailableCurrencies is a method that returns the sorted available currency
+// pairs of the exchange base
 func (e *Base) GetAvailableCurrencies() []pair.CurrencyPair {
-	return pair.FormatPairs(e.AvailablePairs,
+	return pair.SortPairs(pair.FormatPairs(e.AvailablePairs,
 		e.ConfigCurrencyPairFormat.Delimiter,
-		e.ConfigCurrencyPairFormat.Index)
+		e.ConfigCurrencyPairFormat.Index))
+}
+
+// AssetPair holds an enabled currency pair along with one of the asset types
+// it is traded under
+type AssetPair struct {
+	AssetType string
+	Pair      pair.CurrencyPair
+}
+
+// OnTickerUpdate registers a handler called with each ticker processed for the
+// exchange, the returned function unsubscribes the handler
+func (e *Base) OnTickerUpdate(handler func(ticker.Update)) (unsubscribe func()) {
+	return ticker.Subscribe(e.Name, handler)
+}
+
+// OnOrderbookUpdate registers a handler called with each orderbook processed
+// for the exchange, the returned function unsubscribes the handler
+func (e *Base) OnOrderbookUpdate(handler func(orderbook.Update)) (unsubscribe func()) {
+	return orderbook.Subscribe(e.Name, handler)
+}
+
+// GetAllEnabledPairs returns the sorted enabled currency pairs for each of the
+// exchanges asset types, keyed by asset type
+func (e *Base) GetAllEnabledPairs() map[string][]pair.CurrencyPair {
+	enabledPairs := make(map[string][]pair.CurrencyPair)
+	for x := range e.AssetTypes {
+		enabledPairs[e.AssetTypes[x]] = e.GetEnabledCurrencies()
+	}
+	return enabledPairs
+}
+
+// GetAllEnabledAssetPairs returns every enabled currency pair and asset type
+// combination, ordered by asset type then currency pair
+func (e *Base) GetAllEnabledAssetPairs() []AssetPair {
+	enabledPairs := e.GetEnabledCurrencies()
+	var assetPairs []AssetPair
+	for x := range e.AssetTypes {
+		for y := range enabledPairs {
+			assetPairs = append(assetPairs, AssetPair{
+				AssetType: e.AssetTypes[x],
+				Pair:      enabledPairs[y],
+			})
+		}
+	}
+	return assetPairs
 }
 
 // SupportsCurrency returns true or not whether a currency pair exists in the
@@ -557,34 +883,28 @@ func (e *Base) SupportsCurrency(p pair.CurrencyPair, enabledPairs bool) bool {
 // GetExchangeFormatCurrencySeperator returns whether or not a specific
 // exchange contains a separator used for API requests
 func GetExchangeFormatCurrencySeperator(exchName string) bool {
-	cfg := config.GetConfig()
-	exch, err := cfg.GetExchangeConfig(exchName)
+	format, err := config.GetConfig().GetPairFormat(exchName, "", true)
 	if err != nil {
 		return false
 	}
-
-	if exch.RequestCurrencyPairFormat.Separator != "" {
-		return true
-	}
-	return false
+	return format.Separator != ""
 }
 
 // GetAndFormatExchangeCurrencies returns a pair.CurrencyItem string containing
 // the exchanges formatted currency pairs
 func GetAndFormatExchangeCurrencies(exchName string, pairs []pair.CurrencyPair) (pair.CurrencyItem, error) {
 	var currencyItems pair.CurrencyItem
-	cfg := config.GetConfig()
-	exch, err := cfg.GetExchangeConfig(exchName)
+	format, err := config.GetConfig().GetPairFormat(exchName, "", true)
 	if err != nil {
 		return currencyItems, err
 	}
 
 	for x := range pairs {
-		currencyItems += FormatExchangeCurrency(exchName, pairs[x])
+		currencyItems += pairs[x].Display(format.Delimiter, format.Uppercase)
 		if x == len(pairs)-1 {
 			continue
 		}
-		currencyItems += pair.CurrencyItem(exch.RequestCurrencyPairFormat.Separator)
+		currencyItems += pair.CurrencyItem(format.Separator)
 	}
 	return currencyItems, nil
 }
@@ -592,11 +912,64 @@ func GetAndFormatExchangeCurrencies(exchName string, pairs []pair.CurrencyPair)
 // FormatExchangeCurrency is a method that formats and returns a currency pair
 // based on the user currency display preferences
 func FormatExchangeCurrency(exchName string, p pair.CurrencyPair) pair.CurrencyItem {
+	format, _ := config.GetConfig().GetPairFormat(exchName, "", true)
+	return p.Display(format.Delimiter, format.Uppercase)
+}
+
+// ParseExchangeCurrency is the inverse of FormatExchangeCurrency, parsing a
+// symbol in the exchanges request currency pair format back into a currency
+// pair. A symbol matching one of the exchanges available pairs returns that
+// pair, other undelimited symbols are split using the quote currencies of the
+// exchanges available pairs
+func ParseExchangeCurrency(exchName, symbol string) (pair.CurrencyPair, error) {
 	cfg := config.GetConfig()
-	exch, _ := cfg.GetExchangeConfig(exchName)
+	format, err := cfg.GetPairFormat(exchName, "", true)
+	if err != nil {
+		return pair.CurrencyPair{}, err
+	}
+
+	available, err := cfg.GetAvailablePairs(exchName)
+	if err != nil {
+		return pair.CurrencyPair{}, err
+	}
+
+	var matches []pair.CurrencyPair
+	for x := range available {
+		if common.StringToUpper(available[x].Display(format.Delimiter, true).String()) ==
+			common.StringToUpper(symbol) && !pair.Contains(matches, available[x], true) {
+			matches = append(matches, available[x])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+	case 1:
+		return matches[0], nil
+	default:
+		return pair.CurrencyPair{}, fmt.Errorf("%s: %s matches %d %s pairs",
+			pair.ErrUnableToSplitSymbol, symbol, len(matches), exchName)
+	}
+
+	if format.Delimiter != "" {
+		if !common.StringContains(symbol, format.Delimiter) {
+			return pair.CurrencyPair{}, fmt.Errorf("%s: %s is missing the %s delimiter %q",
+				pair.ErrUnableToSplitSymbol, symbol, exchName, format.Delimiter)
+		}
+		return pair.NewCurrencyPairDelimiter(symbol, format.Delimiter), nil
+	}
+
+	if format.Index != "" {
+		return pair.NewCurrencyPairFromIndex(symbol, format.Index), nil
+	}
 
-	return p.Display(exch.RequestCurrencyPairFormat.Delimiter,
-		exch.RequestCurrencyPairFormat.Uppercase)
+	var quotes []string
+	for x := range available {
+		quote := available[x].SecondCurrency.Upper().String()
+		if !common.StringDataCompare(quotes, quote) {
+			quotes = append(quotes, quote)
+		}
+	}
+	return pair.NewCurrencyPairFromSymbol(symbol, quotes)
 }
 
 // FormatCurrency is a method that formats and returns a currency pair
@@ -617,12 +990,22 @@ func (e *Base) IsEnabled() bool {
 	return e.Enabled
 }
 
-// SetAPIKeys is a method that sets the current API keys for the exchange
+// SetVerbose sets whether the exchange logs verbosely
+func (e *Base) SetVerbose(verbose bool) {
+	e.Verbose = verbose
+}
+
+// SetAPIKeys is a method that sets the current API keys for the exchange. Empty
+// credentials and values of the form "env:NAME" are read from the environment
 func (e *Base) SetAPIKeys(APIKey, APISecret, ClientID string, b64Decode bool) {
 	if !e.AuthenticatedAPISupport {
 		return
 	}
 
+	APIKey = e.resolveCredential(config.CredentialAPIKey, APIKey)
+	APISecret = e.resolveCredential(config.CredentialAPISecret, APISecret)
+	ClientID = e.resolveCredential(config.CredentialClientID, ClientID)
+
 	e.APIKey = APIKey
 	e.ClientID = ClientID
 
@@ -638,6 +1021,32 @@ func (e *Base) SetAPIKeys(APIKey, APISecret, ClientID string, b64Decode bool) {
 	}
 }
 
+// resolveCredential returns a credential resolved from the environment when it
+// is empty or refers to an environment variable. Where it was loaded from, but
+// never its value, is logged the first time in verbose mode, a credential
+// which isn't set is always warned about once
+func (e *Base) resolveCredential(field config.CredentialField, value string) string {
+	resolved, source := config.ResolveCredential(e.Name, field, value)
+	if source == "" || e.loggedCredentials[field] {
+		return resolved
+	}
+
+	switch {
+	case resolved == "":
+		log.Printf("WARNING -- Exchange %s %s %s is not set.", e.Name, field, source)
+	case e.Verbose:
+		log.Printf("Exchange %s %s loaded from %s.", e.Name, field, source)
+	default:
+		return resolved
+	}
+
+	if e.loggedCredentials == nil {
+		e.loggedCredentials = make(map[config.CredentialField]bool)
+	}
+	e.loggedCredentials[field] = true
+	return resolved
+}
+
 // SetCurrencies sets the exchange currency pairs for either enabledPairs or
 // availablePairs
 func (e *Base) SetCurrencies(pairs []pair.CurrencyPair, enabledPairs bool) error {
@@ -668,6 +1077,45 @@ func (e *Base) SetCurrencies(pairs []pair.CurrencyPair, enabledPairs bool) error
 	return cfg.UpdateExchangeConfig(exchCfg)
 }
 
+// Pair update retry settings, a failed pair fetch is retried with the delay
+// doubling after each attempt
+const (
+	pairUpdateMaxAttempts  = 4
+	pairUpdateInitialDelay = time.Second * 2
+)
+
+// RetryPairUpdate calls fetch until it succeeds, retrying a bounded number of
+// times with exponential backoff so a transient error doesn't leave the
+// exchange without up to date pairs. Each failed attempt is logged, and the
+// last error is returned once the attempts are exhausted so callers keep the
+// previously known pairs
+func (e *Base) RetryPairUpdate(fetch func() error) error {
+	return retryPairUpdate(e.Name, pairUpdateMaxAttempts, pairUpdateInitialDelay, fetch)
+}
+
+func retryPairUpdate(exchName string, attempts int, delay time.Duration, fetch func() error) error {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = fetch()
+		if err == nil {
+			return nil
+		}
+
+		if attempt == attempts {
+			break
+		}
+
+		log.Printf("%s pair update attempt %d/%d failed, retrying in %s. Err: %s\n",
+			exchName, attempt, attempts, delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+
+	log.Printf("%s pair update failed after %d attempts, keeping previously known pairs. Err: %s\n",
+		exchName, attempts, err)
+	return err
+}
+
 // UpdateCurrencies updates the exchange currency pairs for either enabledPairs or
 // availablePairs
 func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool) error {
@@ -685,45 +1133,80 @@ func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool)
 		products = append(products, exchangeProducts[x])
 	}
 
-	var newPairs, removedPairs []string
+	cfg := config.GetConfig()
+	exch, err := cfg.GetExchangeConfig(e.Name)
+	if err != nil {
+		return err
+	}
+
+	var filtered int
+	products, filtered = exch.FilterBlacklistedPairs(products)
+	if filtered > 0 {
+		log.Printf("%s filtered %d blacklisted pairs.\n", e.Name, filtered)
+	}
+	if len(products) == 0 {
+		return fmt.Errorf("%s UpdateCurrencies error - every pair is blacklisted", e.Name)
+	}
+
+	var current []string
 	var updateType string
 
 	if enabled {
-		newPairs, removedPairs = pair.FindPairDifferences(e.EnabledPairs, products)
+		current = e.EnabledPairs
 		updateType = "enabled"
 	} else {
-		newPairs, removedPairs = pair.FindPairDifferences(e.AvailablePairs, products)
+		current = e.AvailablePairs
 		updateType = "available"
 	}
 
-	if force || len(newPairs) > 0 || len(removedPairs) > 0 {
-		cfg := config.GetConfig()
-		exch, err := cfg.GetExchangeConfig(e.Name)
-		if err != nil {
-			return err
-		}
+	newPairs, removedPairs := pair.FindPairDifferences(current, products)
 
-		if force {
-			log.Printf("%s forced update of %s pairs.", e.Name, updateType)
-		} else {
-			if len(newPairs) > 0 {
-				log.Printf("%s Updating pairs - New: %s.\n", e.Name, newPairs)
-			}
-			if len(removedPairs) > 0 {
-				log.Printf("%s Updating pairs - Removed: %s.\n", e.Name, removedPairs)
-			}
+	// A forced update rewrites pairs which only differ by case or format, but
+	// is skipped along with the config write when the pairs are identical
+	if force && pairsMatch(current, products) ||
+		!force && len(newPairs) == 0 && len(removedPairs) == 0 {
+		return nil
+	}
+
+	if force {
+		log.Printf("%s forced update of %s pairs.", e.Name, updateType)
+	} else {
+		if len(newPairs) > 0 {
+			log.Printf("%s Updating pairs - New: %s.\n", e.Name, newPairs)
+		}
+		if len(removedPairs) > 0 {
+			log.Printf("%s Updating pairs - Removed: %s.\n", e.Name, removedPairs)
 		}
+	}
 
-		if enabled {
-			exch.EnabledPairs = common.JoinStrings(products, ",")
-			e.EnabledPairs = products
-		} else {
-			exch.AvailablePairs = common.JoinStrings(products, ",")
-			e.AvailablePairs = products
+	if enabled {
+		exch.EnabledPairs = common.JoinStrings(products, ",")
+		e.EnabledPairs = products
+	} else {
+		exch.AvailablePairs = common.JoinStrings(products, ",")
+		e.AvailablePairs = products
+	}
+	return cfg.UpdateExchangeConfig(exch)
+}
+
+// pairsMatch returns whether two lists hold exactly the same pairs, ignoring
+// their order
+func pairsMatch(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for x := range a {
+		counts[a[x]]++
+	}
+	for x := range b {
+		if counts[b[x]] == 0 {
+			return false
 		}
-		return cfg.UpdateExchangeConfig(exch)
+		counts[b[x]]--
 	}
-	return nil
+	return true
 }
 
 // ModifyOrder is a an order modifyer
@@ -757,7 +1240,10 @@ type Format struct {
 
 // CancelAllOrdersResponse returns the status from attempting to cancel all orders on an exchagne
 type CancelAllOrdersResponse struct {
-	OrderStatus map[string]string
+	OrderStatus       map[string]string
+	Succeeded         int
+	Failed            int
+	CancelledOrderIDs []string
 }
 
 // Formatting contain a range of exchanges formatting
@@ -792,6 +1278,29 @@ func (o OrderSide) ToString() string {
 	return fmt.Sprintf("%v", o)
 }
 
+// TradeSideFromString returns the aggressor side for an exchange reported
+// trade side of "buy"/"bid" or "sell"/"ask", for exchanges which already
+// report the taker side of a public trade
+func TradeSideFromString(side string) (OrderSide, error) {
+	switch common.StringToLower(side) {
+	case "buy", "bid":
+		return Buy, nil
+	case "sell", "ask":
+		return Sell, nil
+	}
+	return "", fmt.Errorf("unrecognised trade side %q", side)
+}
+
+// TradeSideFromBuyerMaker returns the aggressor side for exchanges which report
+// whether the buyer was the maker of a trade. When the buyer was the maker the
+// seller was the taker, so the aggressor side is Sell
+func TradeSideFromBuyerMaker(isBuyerMaker bool) OrderSide {
+	if isBuyerMaker {
+		return Sell
+	}
+	return Buy
+}
+
 // SetAPIURL sets configuration API URL for an exchange
 func (e *Base) SetAPIURL(ec config.ExchangeConfig) error {
 	if ec.APIURL == "" || ec.APIURLSecondary == "" {
@@ -806,6 +1315,39 @@ func (e *Base) SetAPIURL(ec config.ExchangeConfig) error {
 	return nil
 }
 
+// ValidateConfig checks an exchange config for the problems which would stop
+// Setup, so a misconfigured exchange can be skipped instead of exiting. The
+// exchange defaults must be set before validating
+func (e *Base) ValidateConfig(exch config.ExchangeConfig) error {
+	if _, err := config.GetConfig().GetExchangeConfig(e.Name); err != nil {
+		return err
+	}
+
+	if err := e.checkHTTPRateLimiter(exch.HTTPRateLimiter); err != nil {
+		return err
+	}
+
+	if exch.DefaultAssetType != "" && !common.StringDataCompare(e.AssetTypes, exch.DefaultAssetType) {
+		return fmt.Errorf("%s default asset type %s is not a supported asset type %v",
+			e.Name, exch.DefaultAssetType, e.AssetTypes)
+	}
+
+	if _, err := e.parsePriceSources(exch.PriceSources); err != nil {
+		return err
+	}
+
+	if exch.APIURL == "" || exch.APIURLSecondary == "" {
+		return fmt.Errorf("%s API URL and secondary API URL must be set", e.Name)
+	}
+
+	if exch.ProxyAddress != "" {
+		if _, err := url.Parse(exch.ProxyAddress); err != nil {
+			return fmt.Errorf("%s invalid proxy address %s", e.Name, err)
+		}
+	}
+	return nil
+}
+
 // GetAPIURL returns the set API URL
 func (e *Base) GetAPIURL() string {
 	return e.APIUrl
@@ -840,6 +1382,66 @@ func (e *Base) SupportsWithdrawPermissions(permissions uint32) bool {
 	return false
 }
 
+// CanWithdrawCryptoViaAPI returns whether or not the exchange permits
+// cryptocurrency withdrawals via the API
+func (e *Base) CanWithdrawCryptoViaAPI() bool {
+	return canWithdrawCryptoViaAPI(e.GetWithdrawPermissions())
+}
+
+// CanWithdrawFiatViaAPI returns whether or not the exchange permits fiat
+// withdrawals via the API
+func (e *Base) CanWithdrawFiatViaAPI() bool {
+	return canWithdrawFiatViaAPI(e.GetWithdrawPermissions())
+}
+
+// canWithdrawCryptoViaAPI returns whether the withdrawal permissions permit
+// cryptocurrency withdrawals via the API
+func canWithdrawCryptoViaAPI(permissions uint32) bool {
+	return permissions&WithdrawCryptoViaWebsiteOnly == 0 &&
+		permissions&cryptoWithdrawAPIPermissions != 0
+}
+
+// canWithdrawFiatViaAPI returns whether the withdrawal permissions permit fiat
+// withdrawals via the API
+func canWithdrawFiatViaAPI(permissions uint32) bool {
+	return permissions&WithdrawFiatViaWebsiteOnly == 0 &&
+		permissions&fiatWithdrawAPIPermissions != 0
+}
+
+// CanAutoWithdrawCrypto returns whether or not the exchange processes
+// cryptocurrency withdrawals made via the API without manual confirmation
+func (e *Base) CanAutoWithdrawCrypto() bool {
+	return e.GetWithdrawPermissions()&(AutoWithdrawCrypto|
+		AutoWithdrawCryptoWithAPIPermission|
+		AutoWithdrawCryptoWithSetup) != 0
+}
+
+// CanAutoWithdrawFiat returns whether or not the exchange processes fiat
+// withdrawals made via the API without manual confirmation
+func (e *Base) CanAutoWithdrawFiat() bool {
+	return e.GetWithdrawPermissions()&(AutoWithdrawFiat|
+		AutoWithdrawFiatWithAPIPermission|
+		AutoWithdrawFiatWithSetup) != 0
+}
+
+// CheckCryptoWithdrawPermissions returns an error if the exchange does not
+// permit cryptocurrency withdrawals via the API
+func (e *Base) CheckCryptoWithdrawPermissions() error {
+	if !e.CanWithdrawCryptoViaAPI() {
+		return ErrCryptoWithdrawViaWebsiteOnly
+	}
+	return nil
+}
+
+// CheckFiatWithdrawPermissions returns an error if the exchange does not
+// permit fiat withdrawals via the API
+func (e *Base) CheckFiatWithdrawPermissions() error {
+	if !e.CanWithdrawFiatViaAPI() {
+		return ErrFiatWithdrawViaWebsiteOnly
+	}
+	return nil
+}
+
 // FormatWithdrawPermissions will return each of the exchange's compatible withdrawal methods in readable form
 func (e *Base) FormatWithdrawPermissions() string {
 	services := []string{}
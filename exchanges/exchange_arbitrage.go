@@ -0,0 +1,131 @@
+package exchange
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// ErrInsufficientArbitrageQuotes is returned when fewer than two exchanges
+// could quote a currency pair
+var ErrInsufficientArbitrageQuotes = errors.New("at least two exchanges are required to quote the currency pair")
+
+// ArbitrageSpread holds the most profitable buy low, sell high opportunity for
+// a currency pair across a set of exchanges. Prices and fees are per unit of
+// the base currency and the spread accounts for the taker fee on both legs, a
+// negative spread means no profitable opportunity exists
+type ArbitrageSpread struct {
+	Pair          pair.CurrencyPair
+	BuyExchange   string
+	BuyPrice      float64
+	BuyFee        float64
+	SellExchange  string
+	SellPrice     float64
+	SellFee       float64
+	Spread        float64
+	SpreadPercent float64
+}
+
+// arbitrageQuote holds an exchange's best bid and ask with the taker fee of
+// trading a single unit at each
+type arbitrageQuote struct {
+	BestBidAsk
+	bidFee float64
+	askFee float64
+}
+
+// ScanArbitrageSpread concurrently fetches the best bid and ask of a currency
+// pair from each exchange and returns the largest spread between buying at one
+// exchange's ask and selling at another's bid after taker fees. Exchanges which
+// do not have the pair enabled, or fail to return a quote or fee, are skipped
+func ScanArbitrageSpread(exchanges []IBotExchange, p pair.CurrencyPair, assetType string) (ArbitrageSpread, error) {
+	quotes := make([]*arbitrageQuote, len(exchanges))
+	var wg sync.WaitGroup
+	for x := range exchanges {
+		if !pair.Contains(exchanges[x].GetEnabledCurrencies(), p, true) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, exch IBotExchange) {
+			defer wg.Done()
+			quote, err := getArbitrageQuote(exch, p, assetType)
+			if err != nil {
+				return
+			}
+			quotes[i] = quote
+		}(x, exchanges[x])
+	}
+	wg.Wait()
+
+	var valid []*arbitrageQuote
+	for x := range quotes {
+		if quotes[x] != nil {
+			valid = append(valid, quotes[x])
+		}
+	}
+	return bestArbitrageSpread(p, valid)
+}
+
+// getArbitrageQuote returns the best bid and ask of a currency pair on an
+// exchange along with the taker fee of trading a single unit at each
+func getArbitrageQuote(exch IBotExchange, p pair.CurrencyPair, assetType string) (*arbitrageQuote, error) {
+	bidAsk, err := GetBestBidAsk(exch, p, assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	bidFee, err := GetTradeFee(exch, p, bidAsk.Bid, 1, false)
+	if err != nil {
+		return nil, err
+	}
+
+	askFee, err := GetTradeFee(exch, p, bidAsk.Ask, 1, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arbitrageQuote{BestBidAsk: bidAsk, bidFee: bidFee, askFee: askFee}, nil
+}
+
+// bestArbitrageSpread returns the largest spread between buying at one quote's
+// ask and selling at a different quote's bid
+func bestArbitrageSpread(p pair.CurrencyPair, quotes []*arbitrageQuote) (ArbitrageSpread, error) {
+	if len(quotes) < 2 {
+		return ArbitrageSpread{}, ErrInsufficientArbitrageQuotes
+	}
+
+	var best ArbitrageSpread
+	var found bool
+	for x := range quotes {
+		for y := range quotes {
+			if x == y {
+				continue
+			}
+
+			buy, sell := quotes[x], quotes[y]
+			cost := buy.Ask + buy.askFee
+			spread := (sell.Bid - sell.bidFee) - cost
+			if found && spread <= best.Spread {
+				continue
+			}
+
+			best = ArbitrageSpread{
+				Pair:         p,
+				BuyExchange:  buy.Exchange,
+				BuyPrice:     buy.Ask,
+				BuyFee:       buy.askFee,
+				SellExchange: sell.Exchange,
+				SellPrice:    sell.Bid,
+				SellFee:      sell.bidFee,
+				Spread:       spread,
+			}
+			if cost > 0 {
+				best.SpreadPercent = spread / cost * 100
+			}
+			found = true
+		}
+	}
+	return best, nil
+}
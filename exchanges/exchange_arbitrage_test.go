@@ -0,0 +1,91 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type arbitrageTestExchange struct {
+	IBotExchange
+	name     string
+	pairs    []pair.CurrencyPair
+	bid, ask float64
+	feeRate  float64
+	empty    bool
+}
+
+func (a *arbitrageTestExchange) GetName() string {
+	return a.name
+}
+
+func (a *arbitrageTestExchange) GetEnabledCurrencies() []pair.CurrencyPair {
+	return a.pairs
+}
+
+func (a *arbitrageTestExchange) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	if a.empty {
+		return orderbook.Base{Pair: p}, nil
+	}
+	return orderbook.Base{
+		Pair: p,
+		Bids: []orderbook.Item{{Price: a.bid - 1}, {Price: a.bid}},
+		Asks: []orderbook.Item{{Price: a.ask}, {Price: a.ask + 1}},
+	}, nil
+}
+
+func (a *arbitrageTestExchange) GetFee(feeBuilder FeeBuilder) (float64, error) {
+	return feeBuilder.PurchasePrice * feeBuilder.Amount * a.feeRate, nil
+}
+
+func TestGetBestBidAsk(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	resp, err := GetBestBidAsk(&arbitrageTestExchange{name: "test", bid: 99, ask: 101}, p, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test failed. GetBestBidAsk error", err)
+	}
+
+	if resp.Bid != 99 || resp.Ask != 101 || resp.Exchange != "test" {
+		t.Errorf("Test failed. GetBestBidAsk unexpected result %+v", resp)
+	}
+
+	_, err = GetBestBidAsk(&arbitrageTestExchange{empty: true}, p, ticker.Spot)
+	if err != ErrOrderbookSideEmpty {
+		t.Errorf("Test failed. GetBestBidAsk expected %s, received %v", ErrOrderbookSideEmpty, err)
+	}
+}
+
+func TestScanArbitrageSpread(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	pairs := []pair.CurrencyPair{p}
+	exchanges := []IBotExchange{
+		&arbitrageTestExchange{name: "cheap", pairs: pairs, bid: 99, ask: 100, feeRate: 0.01},
+		&arbitrageTestExchange{name: "dear", pairs: pairs, bid: 110, ask: 111, feeRate: 0.01},
+		&arbitrageTestExchange{name: "unlisted", bid: 1, ask: 2},
+		&arbitrageTestExchange{name: "inverted", pairs: []pair.CurrencyPair{p.Swap()}, bid: 200, ask: 201},
+	}
+
+	spread, err := ScanArbitrageSpread(exchanges, p, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test failed. ScanArbitrageSpread error", err)
+	}
+
+	if spread.BuyExchange != "cheap" || spread.SellExchange != "dear" {
+		t.Errorf("Test failed. ScanArbitrageSpread unexpected venues %s -> %s",
+			spread.BuyExchange, spread.SellExchange)
+	}
+
+	// (110 - 1.1) - (100 + 1)
+	if spread.Spread < 7.89 || spread.Spread > 7.91 {
+		t.Errorf("Test failed. ScanArbitrageSpread expected a spread of 7.9, received %f",
+			spread.Spread)
+	}
+
+	_, err = ScanArbitrageSpread(exchanges[1:], p, ticker.Spot)
+	if err != ErrInsufficientArbitrageQuotes {
+		t.Errorf("Test failed. ScanArbitrageSpread expected %s, received %v",
+			ErrInsufficientArbitrageQuotes, err)
+	}
+}
@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// ErrNoAssetType is returned when a currency pair could not be fetched under
+// any of an exchange's asset types
+var ErrNoAssetType = errors.New("not available under any asset type")
+
+// FetchOrderbookAnyAsset returns the orderbook of a currency pair for callers
+// which don't track the asset type a pair trades under, along with the asset
+// type it was found under. A stored orderbook for any of the exchange's asset
+// types is returned first, otherwise each asset type is updated in the order
+// returned by FetchAssetTypeOrder until one succeeds. Callers which know the
+// asset type should use GetOrderbookEx
+func FetchOrderbookAnyAsset(exch IBotExchange, p pair.CurrencyPair) (orderbook.Base, string, error) {
+	assetTypes := FetchAssetTypeOrder(exch)
+	for x := range assetTypes {
+		ob, err := orderbook.GetOrderbook(exch.GetName(), p, assetTypes[x])
+		if err == nil {
+			return ob, assetTypes[x], nil
+		}
+	}
+
+	var errs []string
+	for x := range assetTypes {
+		ob, err := exch.UpdateOrderbook(p, assetTypes[x])
+		if err == nil {
+			return ob, assetTypes[x], nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", assetTypes[x], err))
+	}
+	return orderbook.Base{}, "", fmt.Errorf("%s %s orderbook %s: %s", exch.GetName(), p.Pair(),
+		ErrNoAssetType, common.JoinStrings(errs, ", "))
+}
+
+// FetchTickerAnyAsset returns the ticker of a currency pair for callers which
+// don't track the asset type a pair trades under, along with the asset type it
+// was found under. Asset types are tried in the same way as
+// FetchOrderbookAnyAsset. Callers which know the asset type should use
+// GetTickerPrice
+func FetchTickerAnyAsset(exch IBotExchange, p pair.CurrencyPair) (ticker.Price, string, error) {
+	assetTypes := FetchAssetTypeOrder(exch)
+	for x := range assetTypes {
+		tick, err := ticker.GetTicker(exch.GetName(), p, assetTypes[x])
+		if err == nil {
+			return tick, assetTypes[x], nil
+		}
+	}
+
+	var errs []string
+	for x := range assetTypes {
+		tick, err := exch.UpdateTicker(p, assetTypes[x])
+		if err == nil {
+			return tick, assetTypes[x], nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", assetTypes[x], err))
+	}
+	return ticker.Price{}, "", fmt.Errorf("%s %s ticker %s: %s", exch.GetName(), p.Pair(),
+		ErrNoAssetType, common.JoinStrings(errs, ", "))
+}
+
+// FetchAssetTypeOrder returns the order asset types are tried in by
+// FetchOrderbookAnyAsset and FetchTickerAnyAsset, the exchange's default asset
+// type followed by its remaining asset types in the order they are configured
+func FetchAssetTypeOrder(exch IBotExchange) []string {
+	var assetTypes []string
+	if defaultAssetType := exch.GetDefaultAssetType(); defaultAssetType != "" {
+		assetTypes = append(assetTypes, defaultAssetType)
+	}
+
+	supported := exch.GetAssetTypes()
+	for x := range supported {
+		if !common.StringDataCompare(assetTypes, supported[x]) {
+			assetTypes = append(assetTypes, supported[x])
+		}
+	}
+	return assetTypes
+}
@@ -0,0 +1,109 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type assetTestExchange struct {
+	IBotExchange
+	name       string
+	assetTypes []string
+	trades     map[string]bool
+	updates    []string
+}
+
+func (a *assetTestExchange) GetName() string {
+	return a.name
+}
+
+func (a *assetTestExchange) GetAssetTypes() []string {
+	return a.assetTypes
+}
+
+func (a *assetTestExchange) GetDefaultAssetType() string {
+	return "this_week"
+}
+
+func (a *assetTestExchange) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	a.updates = append(a.updates, assetType)
+	if !a.trades[assetType] {
+		return orderbook.Base{}, errors.New("pair not traded")
+	}
+	return orderbook.Base{Pair: p, AssetType: assetType}, nil
+}
+
+func (a *assetTestExchange) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	a.updates = append(a.updates, assetType)
+	if !a.trades[assetType] {
+		return ticker.Price{}, errors.New("pair not traded")
+	}
+	return ticker.Price{Pair: p, Last: 1}, nil
+}
+
+func TestFetchAssetTypeOrder(t *testing.T) {
+	exch := &assetTestExchange{assetTypes: []string{ticker.Spot, "this_week", "quarter"}}
+	order := FetchAssetTypeOrder(exch)
+	if len(order) != 3 || order[0] != "this_week" || order[1] != ticker.Spot || order[2] != "quarter" {
+		t.Errorf("Test failed. FetchAssetTypeOrder unexpected order %v", order)
+	}
+}
+
+func TestFetchOrderbookAnyAsset(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	exch := &assetTestExchange{
+		name:       "assetorderbooktest",
+		assetTypes: []string{ticker.Spot, "this_week", "quarter"},
+		trades:     map[string]bool{"quarter": true},
+	}
+
+	ob, assetType, err := FetchOrderbookAnyAsset(exch, p)
+	if err != nil {
+		t.Fatal("Test failed. FetchOrderbookAnyAsset error", err)
+	}
+
+	if assetType != "quarter" || ob.AssetType != "quarter" {
+		t.Errorf("Test failed. FetchOrderbookAnyAsset expected quarter, received %s", assetType)
+	}
+
+	if len(exch.updates) != 3 || exch.updates[0] != "this_week" {
+		t.Errorf("Test failed. FetchOrderbookAnyAsset unexpected updates %v", exch.updates)
+	}
+
+	orderbook.ProcessOrderbook(exch.name, p, orderbook.Base{Pair: p}, ticker.Spot)
+	exch.updates = nil
+	_, assetType, err = FetchOrderbookAnyAsset(exch, p)
+	if err != nil || assetType != ticker.Spot || len(exch.updates) != 0 {
+		t.Errorf("Test failed. FetchOrderbookAnyAsset expected the stored %s orderbook, received %s %v",
+			ticker.Spot, assetType, err)
+	}
+
+	exch.trades = nil
+	_, _, err = FetchOrderbookAnyAsset(exch, pair.NewCurrencyPair("LTC", "USD"))
+	if err == nil {
+		t.Error("Test failed. FetchOrderbookAnyAsset expected error when no asset type trades the pair")
+	}
+}
+
+func TestFetchTickerAnyAsset(t *testing.T) {
+	exch := &assetTestExchange{
+		name:       "assettickertest",
+		assetTypes: []string{ticker.Spot, "this_week"},
+		trades:     map[string]bool{ticker.Spot: true},
+	}
+
+	_, assetType, err := FetchTickerAnyAsset(exch, pair.NewCurrencyPair("BTC", "USD"))
+	if err != nil || assetType != ticker.Spot {
+		t.Errorf("Test failed. FetchTickerAnyAsset expected %s, received %s %v", ticker.Spot, assetType, err)
+	}
+
+	exch.trades = nil
+	_, _, err = FetchTickerAnyAsset(exch, pair.NewCurrencyPair("LTC", "USD"))
+	if err == nil {
+		t.Error("Test failed. FetchTickerAnyAsset expected error when no asset type trades the pair")
+	}
+}
@@ -0,0 +1,42 @@
+package exchange
+
+import (
+	"errors"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// ErrCurrencyNotFound is returned when an account holds no balance for a
+// currency
+var ErrCurrencyNotFound = errors.New("currency not found in account")
+
+// CurrencyBalance holds the available and held balance of a single currency
+type CurrencyBalance struct {
+	Exchange  string
+	Currency  string
+	Available float64
+	Hold      float64
+}
+
+// FilterCurrencyBalance returns the balance of a single currency from the
+// supplied account info, the currency is matched case insensitively. It is used
+// by wrappers whose exchange has no endpoint for a single currency balance
+func (e *Base) FilterCurrencyBalance(info AccountInfo, currency string) (CurrencyBalance, error) {
+	for x := range info.Currencies {
+		if common.StringToUpper(info.Currencies[x].CurrencyName) != common.StringToUpper(currency) {
+			continue
+		}
+
+		if info.Currencies[x].TotalValue == 0 && info.Currencies[x].Hold == 0 {
+			break
+		}
+
+		return CurrencyBalance{
+			Exchange:  e.Name,
+			Currency:  common.StringToUpper(currency),
+			Available: info.Currencies[x].TotalValue - info.Currencies[x].Hold,
+			Hold:      info.Currencies[x].Hold,
+		}, nil
+	}
+	return CurrencyBalance{}, ErrCurrencyNotFound
+}
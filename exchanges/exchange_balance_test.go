@@ -0,0 +1,36 @@
+package exchange
+
+import "testing"
+
+func TestFilterCurrencyBalance(t *testing.T) {
+	b := Base{Name: "test"}
+	info := AccountInfo{
+		ExchangeName: "test",
+		Currencies: []AccountCurrencyInfo{
+			{CurrencyName: "BTC", TotalValue: 2, Hold: 0.5},
+			{CurrencyName: "LTC", TotalValue: 0, Hold: 0},
+		},
+	}
+
+	balance, err := b.FilterCurrencyBalance(info, "btc")
+	if err != nil {
+		t.Fatal("Test failed. FilterCurrencyBalance error", err)
+	}
+
+	if balance.Exchange != "test" || balance.Currency != "BTC" ||
+		balance.Available != 1.5 || balance.Hold != 0.5 {
+		t.Errorf("Test failed. FilterCurrencyBalance unexpected balance %+v", balance)
+	}
+
+	_, err = b.FilterCurrencyBalance(info, "LTC")
+	if err != ErrCurrencyNotFound {
+		t.Errorf("Test failed. FilterCurrencyBalance expected %s, received %v",
+			ErrCurrencyNotFound, err)
+	}
+
+	_, err = b.FilterCurrencyBalance(info, "ETH")
+	if err != ErrCurrencyNotFound {
+		t.Errorf("Test failed. FilterCurrencyBalance expected %s, received %v",
+			ErrCurrencyNotFound, err)
+	}
+}
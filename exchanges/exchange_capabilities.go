@@ -0,0 +1,241 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// CapabilityStatus is whether an exchange wrapper method can be used
+type CapabilityStatus int
+
+// Capability statuses, unsupported methods return
+// common.ErrFunctionNotSupported as the exchange API doesn't offer them and
+// unimplemented methods return common.ErrNotYetImplemented until the wrapper
+// is built
+const (
+	CapabilitySupported CapabilityStatus = iota
+	CapabilityUnsupported
+	CapabilityUnimplemented
+)
+
+// Wrapper methods reported by Capabilities
+const (
+	MethodGetExchangeHistory          = "GetExchangeHistory"
+	MethodGetFundingHistory           = "GetFundingHistory"
+	MethodSubmitOrder                 = "SubmitOrder"
+	MethodModifyOrder                 = "ModifyOrder"
+	MethodCancelOrder                 = "CancelOrder"
+	MethodCancelAllOrders             = "CancelAllOrders"
+	MethodGetOrderInfo                = "GetOrderInfo"
+	MethodGetDepositAddress           = "GetDepositAddress"
+	MethodWithdrawCryptocurrencyFunds = "WithdrawCryptocurrencyFunds"
+	MethodWithdrawFiatFunds           = "WithdrawFiatFunds"
+	MethodGetWebsocket                = "GetWebsocket"
+)
+
+// CapabilityMethods lists the wrapper methods reported by Capabilities
+var CapabilityMethods = []string{
+	MethodGetExchangeHistory,
+	MethodGetFundingHistory,
+	MethodSubmitOrder,
+	MethodModifyOrder,
+	MethodCancelOrder,
+	MethodCancelAllOrders,
+	MethodGetOrderInfo,
+	MethodGetDepositAddress,
+	MethodWithdrawCryptocurrencyFunds,
+	MethodWithdrawFiatFunds,
+	MethodGetWebsocket,
+}
+
+// String returns the capability status in string notation
+func (c CapabilityStatus) String() string {
+	switch c {
+	case CapabilitySupported:
+		return "supported"
+	case CapabilityUnsupported:
+		return "unsupported"
+	case CapabilityUnimplemented:
+		return "unimplemented"
+	}
+	return "unknown"
+}
+
+// CapabilityFromError returns the capability status a wrapper method error
+// reports, any error other than common.ErrFunctionNotSupported or
+// common.ErrNotYetImplemented is a failure of a supported method
+func CapabilityFromError(err error) CapabilityStatus {
+	switch err {
+	case common.ErrFunctionNotSupported:
+		return CapabilityUnsupported
+	case common.ErrNotYetImplemented:
+		return CapabilityUnimplemented
+	}
+	return CapabilitySupported
+}
+
+// SetCapability records a wrapper method as unsupported or unimplemented,
+// exchanges call it from SetDefaults for each method stubbed in their wrapper
+func (e *Base) SetCapability(method string, status CapabilityStatus) {
+	if e.capabilities == nil {
+		e.capabilities = make(map[string]CapabilityStatus)
+	}
+	e.capabilities[method] = status
+}
+
+// Capabilities returns the status of each wrapper method in CapabilityMethods
+// so unsupported features can be hidden and unimplemented ones flagged.
+// Withdrawals the exchange only permits via its website are unsupported
+// regardless of whether the wrapper implements them
+func (e *Base) Capabilities() map[string]CapabilityStatus {
+	capabilities := make(map[string]CapabilityStatus, len(CapabilityMethods))
+	for x := range CapabilityMethods {
+		capabilities[CapabilityMethods[x]] = CapabilitySupported
+	}
+
+	for method, status := range e.capabilities {
+		capabilities[method] = status
+	}
+
+	if !e.CanWithdrawCryptoViaAPI() {
+		capabilities[MethodWithdrawCryptocurrencyFunds] = CapabilityUnsupported
+	}
+	if !e.CanWithdrawFiatViaAPI() {
+		capabilities[MethodWithdrawFiatFunds] = CapabilityUnsupported
+	}
+	return capabilities
+}
+
+// MarshalText encodes the capability status in string notation so JSON
+// encoded capabilities are readable
+func (c CapabilityStatus) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a capability status from string notation
+func (c *CapabilityStatus) UnmarshalText(text []byte) error {
+	for _, status := range []CapabilityStatus{CapabilitySupported, CapabilityUnsupported, CapabilityUnimplemented} {
+		if string(text) == status.String() {
+			*c = status
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid capability status %q", text)
+}
+
+// CapabilityMatrix is the support status of each wrapper method in Methods,
+// along with the asset types and withdrawal permissions, of every exchange
+type CapabilityMatrix struct {
+	Methods   []string              `json:"methods"`
+	Exchanges []CapabilityMatrixRow `json:"exchanges"`
+}
+
+// CapabilityMatrixRow holds the capabilities of a single exchange
+type CapabilityMatrixRow struct {
+	Exchange                string                      `json:"exchange"`
+	Capabilities            map[string]CapabilityStatus `json:"capabilities"`
+	AssetTypes              []string                    `json:"assetTypes"`
+	WithdrawPermissions     uint32                      `json:"withdrawPermissions"`
+	WithdrawPermissionsText string                      `json:"withdrawPermissionsText"`
+	CryptoWithdrawViaAPI    bool                        `json:"cryptoWithdrawViaAPI"`
+	FiatWithdrawViaAPI      bool                        `json:"fiatWithdrawViaAPI"`
+}
+
+// GetCapabilityMatrix returns the capability matrix of every registered
+// exchange, sorted by name, using each exchange's defaults so it doesn't
+// depend on what is configured or enabled. Exchanges which only name
+// themselves during setup are listed under their registered name
+func GetCapabilityMatrix() (CapabilityMatrix, error) {
+	names := GetRegisteredExchanges()
+	exchanges := make([]IBotExchange, 0, len(names))
+	for x := range names {
+		exch, err := NewExchangeByName(names[x])
+		if err != nil {
+			return CapabilityMatrix{}, err
+		}
+		exch.SetDefaults()
+		exchanges = append(exchanges, exch)
+	}
+
+	matrix := NewCapabilityMatrix(exchanges)
+	for x := range matrix.Exchanges {
+		if matrix.Exchanges[x].Exchange == "" {
+			matrix.Exchanges[x].Exchange = names[x]
+		}
+	}
+	return matrix, nil
+}
+
+// NewCapabilityMatrix returns the capability matrix of the supplied
+// exchanges in the order supplied
+func NewCapabilityMatrix(exchanges []IBotExchange) CapabilityMatrix {
+	matrix := CapabilityMatrix{
+		Methods:   append([]string(nil), CapabilityMethods...),
+		Exchanges: make([]CapabilityMatrixRow, 0, len(exchanges)),
+	}
+
+	for x := range exchanges {
+		permissions := exchanges[x].GetWithdrawPermissions()
+		matrix.Exchanges = append(matrix.Exchanges, CapabilityMatrixRow{
+			Exchange:                exchanges[x].GetName(),
+			Capabilities:            exchanges[x].Capabilities(),
+			AssetTypes:              exchanges[x].GetAssetTypes(),
+			WithdrawPermissions:     permissions,
+			WithdrawPermissionsText: exchanges[x].FormatWithdrawPermissions(),
+			CryptoWithdrawViaAPI:    canWithdrawCryptoViaAPI(permissions),
+			FiatWithdrawViaAPI:      canWithdrawFiatViaAPI(permissions),
+		})
+	}
+	return matrix
+}
+
+// Lagging returns the exchanges which haven't implemented a wrapper method
+func (m CapabilityMatrix) Lagging(method string) []string {
+	var exchanges []string
+	for x := range m.Exchanges {
+		if m.Exchanges[x].Capabilities[method] == CapabilityUnimplemented {
+			exchanges = append(exchanges, m.Exchanges[x].Exchange)
+		}
+	}
+	return exchanges
+}
+
+// Markdown returns the capability matrix as a markdown table for
+// documentation, supported methods are marked Yes, unsupported methods No and
+// unimplemented methods NYI
+func (m CapabilityMatrix) Markdown() string {
+	var b strings.Builder
+	b.WriteString("| Exchange | Asset Types | Crypto Withdrawals | Fiat Withdrawals |")
+	for x := range m.Methods {
+		b.WriteString(" " + m.Methods[x] + " |")
+	}
+	b.WriteString("\n|" + strings.Repeat(" --- |", len(m.Methods)+4) + "\n")
+
+	for x := range m.Exchanges {
+		row := m.Exchanges[x]
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |", row.Exchange, strings.Join(row.AssetTypes, ", "),
+			capabilityMarkdown(row.CryptoWithdrawViaAPI), capabilityMarkdown(row.FiatWithdrawViaAPI))
+		for y := range m.Methods {
+			switch row.Capabilities[m.Methods[y]] {
+			case CapabilitySupported:
+				b.WriteString(" Yes |")
+			case CapabilityUnsupported:
+				b.WriteString(" No |")
+			default:
+				b.WriteString(" NYI |")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// capabilityMarkdown returns Yes or No for a markdown table cell
+func capabilityMarkdown(supported bool) string {
+	if supported {
+		return "Yes"
+	}
+	return "No"
+}
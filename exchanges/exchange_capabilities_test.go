@@ -0,0 +1,131 @@
+package exchange
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+func TestCapabilities(t *testing.T) {
+	b := Base{APIWithdrawPermissions: AutoWithdrawCrypto}
+	b.SetCapability(MethodGetOrderInfo, CapabilityUnimplemented)
+	b.SetCapability(MethodModifyOrder, CapabilityUnsupported)
+	b.SetCapability(MethodWithdrawFiatFunds, CapabilityUnimplemented)
+
+	capabilities := b.Capabilities()
+	if len(capabilities) != len(CapabilityMethods) {
+		t.Fatalf("Test failed. Capabilities expected %d methods, received %d",
+			len(CapabilityMethods), len(capabilities))
+	}
+
+	expected := map[string]CapabilityStatus{
+		MethodGetOrderInfo:                CapabilityUnimplemented,
+		MethodModifyOrder:                 CapabilityUnsupported,
+		MethodWithdrawFiatFunds:           CapabilityUnsupported,
+		MethodWithdrawCryptocurrencyFunds: CapabilitySupported,
+		MethodSubmitOrder:                 CapabilitySupported,
+	}
+	for method, status := range expected {
+		if capabilities[method] != status {
+			t.Errorf("Test failed. Capabilities expected %s %s, received %s",
+				method, status, capabilities[method])
+		}
+	}
+}
+
+func TestCapabilityFromError(t *testing.T) {
+	if CapabilityFromError(common.ErrFunctionNotSupported) != CapabilityUnsupported ||
+		CapabilityFromError(common.ErrNotYetImplemented) != CapabilityUnimplemented ||
+		CapabilityFromError(errors.New("request failed")) != CapabilitySupported ||
+		CapabilityFromError(nil) != CapabilitySupported {
+		t.Error("Test failed. CapabilityFromError unexpected capability status")
+	}
+}
+
+type capabilityTestExchange struct {
+	IBotExchange
+	base Base
+}
+
+func (c *capabilityTestExchange) GetName() string {
+	return c.base.Name
+}
+
+func (c *capabilityTestExchange) Capabilities() map[string]CapabilityStatus {
+	return c.base.Capabilities()
+}
+
+func (c *capabilityTestExchange) GetAssetTypes() []string {
+	return c.base.AssetTypes
+}
+
+func (c *capabilityTestExchange) GetWithdrawPermissions() uint32 {
+	return c.base.GetWithdrawPermissions()
+}
+
+func (c *capabilityTestExchange) FormatWithdrawPermissions() string {
+	return c.base.FormatWithdrawPermissions()
+}
+
+func TestCapabilityMatrix(t *testing.T) {
+	spot := &capabilityTestExchange{base: Base{
+		Name:                   "Spot",
+		AssetTypes:             []string{"SPOT"},
+		APIWithdrawPermissions: AutoWithdrawCrypto | AutoWithdrawFiat,
+	}}
+	spot.base.SetCapability(MethodGetWebsocket, CapabilityUnimplemented)
+
+	futures := &capabilityTestExchange{base: Base{
+		Name:                   "Futures",
+		AssetTypes:             []string{"this_week", "quarter"},
+		APIWithdrawPermissions: WithdrawCryptoViaWebsiteOnly,
+	}}
+	futures.base.SetCapability(MethodGetWebsocket, CapabilityUnimplemented)
+	futures.base.SetCapability(MethodModifyOrder, CapabilityUnsupported)
+
+	matrix := NewCapabilityMatrix([]IBotExchange{spot, futures})
+	if len(matrix.Methods) != len(CapabilityMethods) || len(matrix.Exchanges) != 2 {
+		t.Fatalf("Test failed. NewCapabilityMatrix unexpected matrix %+v", matrix)
+	}
+
+	row := matrix.Exchanges[1]
+	if row.Exchange != "Futures" || len(row.AssetTypes) != 2 || row.CryptoWithdrawViaAPI ||
+		row.FiatWithdrawViaAPI || row.WithdrawPermissionsText != WithdrawCryptoViaWebsiteOnlyText ||
+		row.Capabilities[MethodModifyOrder] != CapabilityUnsupported {
+		t.Errorf("Test failed. NewCapabilityMatrix unexpected row %+v", row)
+	}
+
+	if !matrix.Exchanges[0].CryptoWithdrawViaAPI || !matrix.Exchanges[0].FiatWithdrawViaAPI {
+		t.Errorf("Test failed. NewCapabilityMatrix unexpected withdrawal support %+v", matrix.Exchanges[0])
+	}
+
+	if lagging := matrix.Lagging(MethodGetWebsocket); len(lagging) != 2 {
+		t.Errorf("Test failed. Lagging expected both exchanges, received %v", lagging)
+	}
+
+	data, err := json.Marshal(matrix)
+	if err != nil {
+		t.Fatal("Test failed. CapabilityMatrix JSON encode error", err)
+	}
+	if !strings.Contains(string(data), `"ModifyOrder":"unsupported"`) {
+		t.Errorf("Test failed. CapabilityMatrix JSON capabilities not in string notation %s", data)
+	}
+
+	var decoded CapabilityMatrix
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal("Test failed. CapabilityMatrix JSON decode error", err)
+	}
+	if decoded.Exchanges[1].Capabilities[MethodModifyOrder] != CapabilityUnsupported ||
+		decoded.Exchanges[1].Capabilities[MethodGetWebsocket] != CapabilityUnimplemented {
+		t.Errorf("Test failed. CapabilityMatrix JSON decoded unexpected row %+v", decoded.Exchanges[1])
+	}
+
+	markdown := matrix.Markdown()
+	if !strings.Contains(markdown, "| Futures | this_week, quarter | No | No |") ||
+		strings.Count(markdown, "\n") != 4 {
+		t.Errorf("Test failed. Markdown unexpected table\n%s", markdown)
+	}
+}
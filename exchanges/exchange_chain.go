@@ -0,0 +1,34 @@
+package exchange
+
+import (
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// CurrencyChain holds whether a currency can be deposited and withdrawn on a
+// single chain and the chain's withdrawal fee, an empty chain is the
+// currency's default chain
+type CurrencyChain struct {
+	Chain           string
+	DepositEnabled  bool
+	WithdrawEnabled bool
+	WithdrawalFee   float64
+	MinWithdrawal   float64
+}
+
+// ChainsGetter is implemented by exchanges which can return the chains a
+// currency is supported on
+type ChainsGetter interface {
+	GetAvailableChains(cryptocurrency pair.CurrencyItem) ([]CurrencyChain, error)
+}
+
+// GetAvailableChains returns the chains an exchange supports a currency on and
+// whether deposits and withdrawals are currently enabled on each of them.
+// Exchanges which do not implement ChainsGetter return
+// ErrChainSelectionNotSupported
+func GetAvailableChains(exch IBotExchange, cryptocurrency pair.CurrencyItem) ([]CurrencyChain, error) {
+	getter, ok := exch.(ChainsGetter)
+	if !ok {
+		return nil, ErrChainSelectionNotSupported
+	}
+	return getter.GetAvailableChains(cryptocurrency)
+}
@@ -0,0 +1,34 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+type chainsTestExchange struct {
+	IBotExchange
+}
+
+func (c *chainsTestExchange) GetAvailableChains(cryptocurrency pair.CurrencyItem) ([]CurrencyChain, error) {
+	return []CurrencyChain{
+		{Chain: "ERC20", DepositEnabled: true, WithdrawEnabled: true, WithdrawalFee: 5},
+		{Chain: "TRC20", DepositEnabled: true, WithdrawalFee: 1},
+	}, nil
+}
+
+func TestGetAvailableChains(t *testing.T) {
+	_, err := GetAvailableChains(&depositTestExchange{}, "USDT")
+	if err != ErrChainSelectionNotSupported {
+		t.Error("Test failed. GetAvailableChains expected ErrChainSelectionNotSupported", err)
+	}
+
+	chains, err := GetAvailableChains(&chainsTestExchange{}, "USDT")
+	if err != nil {
+		t.Fatal("Test failed. GetAvailableChains error", err)
+	}
+
+	if len(chains) != 2 || chains[1].Chain != "TRC20" || chains[1].WithdrawEnabled {
+		t.Errorf("Test failed. GetAvailableChains unexpected chains %v", chains)
+	}
+}
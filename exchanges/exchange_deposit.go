@@ -0,0 +1,34 @@
+package exchange
+
+import (
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// DepositAddress holds the deposit address of a currency on a single chain,
+// an empty chain is the currency's default chain
+type DepositAddress struct {
+	Chain   string
+	Address string
+	Tag     string
+}
+
+// DepositAddressesGetter is implemented by exchanges which can return the
+// deposit address of a currency on every chain they support it on
+type DepositAddressesGetter interface {
+	GetDepositAddresses(cryptocurrency pair.CurrencyItem) ([]DepositAddress, error)
+}
+
+// GetDepositAddresses returns the deposit addresses of a currency on every
+// chain the exchange supports it on. Exchanges which do not implement
+// DepositAddressesGetter return their default chain's address only
+func GetDepositAddresses(exch IBotExchange, cryptocurrency pair.CurrencyItem) ([]DepositAddress, error) {
+	if getter, ok := exch.(DepositAddressesGetter); ok {
+		return getter.GetDepositAddresses(cryptocurrency)
+	}
+
+	address, err := exch.GetDepositAddress(cryptocurrency, "")
+	if err != nil {
+		return nil, err
+	}
+	return []DepositAddress{{Address: address}}, nil
+}
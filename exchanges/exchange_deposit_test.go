@@ -0,0 +1,46 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+type depositTestExchange struct {
+	IBotExchange
+}
+
+func (d *depositTestExchange) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
+	return "default-" + cryptocurrency.String(), nil
+}
+
+type depositAddressesTestExchange struct {
+	depositTestExchange
+}
+
+func (d *depositAddressesTestExchange) GetDepositAddresses(cryptocurrency pair.CurrencyItem) ([]DepositAddress, error) {
+	return []DepositAddress{
+		{Chain: "ERC20", Address: "0xabc"},
+		{Chain: "TRC20", Address: "Tabc"},
+	}, nil
+}
+
+func TestGetDepositAddresses(t *testing.T) {
+	addresses, err := GetDepositAddresses(&depositTestExchange{}, "BTC")
+	if err != nil {
+		t.Fatal("Test failed. GetDepositAddresses error", err)
+	}
+
+	if len(addresses) != 1 || addresses[0].Address != "default-BTC" || addresses[0].Chain != "" {
+		t.Errorf("Test failed. GetDepositAddresses unexpected fallback addresses %v", addresses)
+	}
+
+	addresses, err = GetDepositAddresses(&depositAddressesTestExchange{}, "USDT")
+	if err != nil {
+		t.Fatal("Test failed. GetDepositAddresses error", err)
+	}
+
+	if len(addresses) != 2 || addresses[1].Chain != "TRC20" {
+		t.Errorf("Test failed. GetDepositAddresses unexpected addresses %v", addresses)
+	}
+}
@@ -0,0 +1,139 @@
+package exchange
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// DefaultFeeRateCacheTTL is how long a fee rate fetched from an exchange API is
+// served from the cache when FeeRateCacheTTL is not set
+const DefaultFeeRateCacheTTL = time.Hour
+
+// cachedFeeRate holds a fee rate fetched from an exchange API and when it was
+// fetched
+type cachedFeeRate struct {
+	rate    float64
+	updated time.Time
+}
+
+// FeeGetter is implemented by exchanges which can estimate the fee of a
+// transaction
+type FeeGetter interface {
+	GetFee(feeBuilder FeeBuilder) (float64, error)
+}
+
+// GetTradeFee returns the estimated fee of trading amount of a currency pair
+// at price, or common.ErrFunctionNotSupported when the exchange cannot
+// estimate fees. Takers pay the fee when isMaker is false
+func GetTradeFee(exch IBotExchange, p pair.CurrencyPair, price, amount float64, isMaker bool) (float64, error) {
+	getter, ok := exch.(FeeGetter)
+	if !ok {
+		return 0, common.ErrFunctionNotSupported
+	}
+
+	return getter.GetFee(FeeBuilder{
+		FeeType:        CryptocurrencyTradeFee,
+		FirstCurrency:  p.FirstCurrency.String(),
+		SecondCurrency: p.SecondCurrency.String(),
+		Delimiter:      p.Delimiter,
+		IsMaker:        isMaker,
+		PurchasePrice:  price,
+		Amount:         amount,
+	})
+}
+
+// IsMakerOrder returns whether an order pays the maker fee. Only post only
+// limit orders are certain not to fill immediately, any other order is treated
+// as a taker so its fee isn't underestimated
+func IsMakerOrder(orderType OrderType, postOnly bool) bool {
+	return orderType == Limit && postOnly
+}
+
+// NetBuyAmount returns the amount of the first currency of a currency pair
+// received for spending grossSpend of the second currency at price, after the
+// trading fee is deducted. The fee is treated as taken from what is spent, see
+// IsMakerOrder for choosing isMaker
+func NetBuyAmount(exch IBotExchange, p pair.CurrencyPair, grossSpend, price float64, isMaker bool) (float64, error) {
+	if grossSpend <= 0 || price <= 0 {
+		return 0, fmt.Errorf("invalid spend %v or price %v", grossSpend, price)
+	}
+
+	fee, err := GetTradeFee(exch, p, price, grossSpend/price, isMaker)
+	if err != nil {
+		return 0, err
+	}
+
+	if fee >= grossSpend {
+		return 0, fmt.Errorf("%s %s fee %v exceeds spend %v", exch.GetName(), p.Pair(), fee, grossSpend)
+	}
+	return (grossSpend - fee) / price, nil
+}
+
+// NetSellProceeds returns the amount of the second currency of a currency pair
+// received for selling amount of the first currency at price, after the
+// trading fee is deducted. See IsMakerOrder for choosing isMaker
+func NetSellProceeds(exch IBotExchange, p pair.CurrencyPair, amount, price float64, isMaker bool) (float64, error) {
+	if amount <= 0 || price <= 0 {
+		return 0, fmt.Errorf("invalid amount %v or price %v", amount, price)
+	}
+
+	fee, err := GetTradeFee(exch, p, price, amount, isMaker)
+	if err != nil {
+		return 0, err
+	}
+
+	proceeds := amount*price - fee
+	if proceeds < 0 {
+		return 0, fmt.Errorf("%s %s fee %v exceeds proceeds %v", exch.GetName(), p.Pair(), fee, amount*price)
+	}
+	return proceeds, nil
+}
+
+// GetCachedFeeRate returns the fee rate for a fee type and currency, calling
+// fetch to refresh the cached rate once FeeRateCacheTTL has elapsed. When fetch
+// fails the last cached rate is returned, or fallback when the rate has never
+// been fetched, so fee estimation keeps working while the API is unreachable
+func (e *Base) GetCachedFeeRate(feeType FeeType, currency string, isMaker bool, fetch func() (float64, error), fallback float64) float64 {
+	key := fmt.Sprintf("%s-%s-%t", feeType, common.StringToUpper(currency), isMaker)
+	ttl := e.FeeRateCacheTTL
+	if ttl <= 0 {
+		ttl = DefaultFeeRateCacheTTL
+	}
+
+	e.feeRatesMtx.Lock()
+	cached, ok := e.feeRates[key]
+	e.feeRatesMtx.Unlock()
+	if ok && Now().Sub(cached.updated) < ttl {
+		return cached.rate
+	}
+
+	rate, err := fetch()
+	if err != nil {
+		log.Printf("%s failed to fetch %s rate for %s, using cached or fallback rate. Err: %s",
+			e.Name, feeType, currency, err)
+		if ok {
+			return cached.rate
+		}
+		return fallback
+	}
+
+	e.feeRatesMtx.Lock()
+	if e.feeRates == nil {
+		e.feeRates = make(map[string]cachedFeeRate)
+	}
+	e.feeRates[key] = cachedFeeRate{rate: rate, updated: Now()}
+	e.feeRatesMtx.Unlock()
+	return rate
+}
+
+// RefreshFeeRates clears the cached fee rates so the next fee lookup fetches
+// them from the API again, for example after the account's fee tier changes
+func (e *Base) RefreshFeeRates() {
+	e.feeRatesMtx.Lock()
+	e.feeRates = nil
+	e.feeRatesMtx.Unlock()
+}
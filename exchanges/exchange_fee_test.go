@@ -0,0 +1,145 @@
+package exchange
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+func TestGetCachedFeeRate(t *testing.T) {
+	var b Base
+	var calls int
+	fetch := func() (float64, error) {
+		calls++
+		return 0.002, nil
+	}
+
+	rate := b.GetCachedFeeRate(CryptocurrencyTradeFee, "btcusd", false, fetch, 0.005)
+	if rate != 0.002 || calls != 1 {
+		t.Errorf("Test failed. GetCachedFeeRate expected 0.002 from 1 fetch, received %v from %d",
+			rate, calls)
+	}
+
+	rate = b.GetCachedFeeRate(CryptocurrencyTradeFee, "BTCUSD", false, fetch, 0.005)
+	if rate != 0.002 || calls != 1 {
+		t.Errorf("Test failed. GetCachedFeeRate expected a cached rate, fetched %d times", calls)
+	}
+
+	b.GetCachedFeeRate(CryptocurrencyTradeFee, "BTCUSD", true, fetch, 0.005)
+	if calls != 2 {
+		t.Error("Test failed. GetCachedFeeRate shared maker and taker rates")
+	}
+
+	b.RefreshFeeRates()
+	b.GetCachedFeeRate(CryptocurrencyTradeFee, "BTCUSD", false, fetch, 0.005)
+	if calls != 3 {
+		t.Error("Test failed. RefreshFeeRates did not force the rate to be fetched")
+	}
+}
+
+func TestGetCachedFeeRateFallback(t *testing.T) {
+	b := Base{Name: "test", FeeRateCacheTTL: time.Nanosecond}
+	failing := func() (float64, error) {
+		return 0, errors.New("api unreachable")
+	}
+
+	rate := b.GetCachedFeeRate(CryptocurrencyTradeFee, "BTCUSD", false, failing, 0.005)
+	if rate != 0.005 {
+		t.Errorf("Test failed. GetCachedFeeRate expected the fallback rate, received %v", rate)
+	}
+
+	b.GetCachedFeeRate(CryptocurrencyTradeFee, "BTCUSD", false, func() (float64, error) {
+		return 0.002, nil
+	}, 0.005)
+
+	time.Sleep(time.Millisecond)
+	rate = b.GetCachedFeeRate(CryptocurrencyTradeFee, "BTCUSD", false, failing, 0.005)
+	if rate != 0.002 {
+		t.Errorf("Test failed. GetCachedFeeRate expected the stale cached rate, received %v", rate)
+	}
+}
+
+func TestGetCachedFeeRateExpiry(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	Now = func() time.Time { return now }
+	defer func() { Now = time.Now }()
+
+	b := Base{Name: "test", FeeRateCacheTTL: time.Minute}
+	var calls int
+	fetch := func() (float64, error) {
+		calls++
+		return 0.002, nil
+	}
+
+	b.GetCachedFeeRate(CryptocurrencyTradeFee, "BTCUSD", false, fetch, 0.005)
+	now = now.Add(time.Minute - time.Nanosecond)
+	b.GetCachedFeeRate(CryptocurrencyTradeFee, "BTCUSD", false, fetch, 0.005)
+	if calls != 1 {
+		t.Errorf("Test failed. GetCachedFeeRate expected a cached rate within the TTL, fetched %d times", calls)
+	}
+
+	now = now.Add(time.Nanosecond)
+	b.GetCachedFeeRate(CryptocurrencyTradeFee, "BTCUSD", false, fetch, 0.005)
+	if calls != 2 {
+		t.Errorf("Test failed. GetCachedFeeRate expected the rate to be fetched after the TTL, fetched %d times", calls)
+	}
+}
+
+type netFeeTestExchange struct {
+	IBotExchange
+	makerRate, takerRate float64
+}
+
+func (n *netFeeTestExchange) GetName() string {
+	return "test"
+}
+
+func (n *netFeeTestExchange) GetFee(feeBuilder FeeBuilder) (float64, error) {
+	rate := n.takerRate
+	if feeBuilder.IsMaker {
+		rate = n.makerRate
+	}
+	return feeBuilder.PurchasePrice * feeBuilder.Amount * rate, nil
+}
+
+func TestNetAmounts(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	exch := &netFeeTestExchange{makerRate: 0.001, takerRate: 0.002}
+
+	amount, err := NetBuyAmount(exch, p, 1000, 100, false)
+	if err != nil || math.Abs(amount-9.98) > 1e-9 {
+		t.Errorf("Test failed. NetBuyAmount expected 9.98, received %v %v", amount, err)
+	}
+
+	amount, err = NetBuyAmount(exch, p, 1000, 100, IsMakerOrder(Limit, true))
+	if err != nil || math.Abs(amount-9.99) > 1e-9 {
+		t.Errorf("Test failed. NetBuyAmount expected the maker fee 9.99, received %v %v", amount, err)
+	}
+
+	proceeds, err := NetSellProceeds(exch, p, 10, 100, IsMakerOrder(Market, true))
+	if err != nil || math.Abs(proceeds-998) > 1e-9 {
+		t.Errorf("Test failed. NetSellProceeds expected the taker fee 998, received %v %v", proceeds, err)
+	}
+
+	if _, err = NetBuyAmount(exch, p, 0, 100, false); err == nil {
+		t.Error("Test failed. NetBuyAmount expected error for no spend")
+	}
+
+	if _, err = NetSellProceeds(exch, p, 10, 0, false); err == nil {
+		t.Error("Test failed. NetSellProceeds expected error for no price")
+	}
+
+	exch.takerRate = 2
+	if _, err = NetSellProceeds(exch, p, 10, 100, false); err == nil {
+		t.Error("Test failed. NetSellProceeds expected error when the fee exceeds the proceeds")
+	}
+
+	_, err = NetBuyAmount(&journalTestExchange{}, p, 1000, 100, false)
+	if err != common.ErrFunctionNotSupported {
+		t.Errorf("Test failed. NetBuyAmount expected %s, received %v", common.ErrFunctionNotSupported, err)
+	}
+}
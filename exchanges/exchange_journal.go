@@ -0,0 +1,306 @@
+package exchange
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// JournalAction is the type of state changing action recorded to the journal
+type JournalAction string
+
+// Journal actions
+const (
+	JournalSubmitOrder     JournalAction = "submitOrder"
+	JournalCancelOrder     JournalAction = "cancelOrder"
+	JournalCancelAllOrders JournalAction = "cancelAllOrders"
+	JournalWithdraw        JournalAction = "withdraw"
+)
+
+// journalRedacted replaces the value of any secret recorded to the journal
+const journalRedacted = "[REDACTED]"
+
+var (
+	journal    Journal
+	journalMtx sync.RWMutex
+
+	// journalSecretKeys are matched against parameter names, case insensitive,
+	// to determine which values are redacted
+	journalSecretKeys = []string{"key", "secret", "password", "passphrase", "pwd", "token", "otp", "sign"}
+
+	// journalSecretPattern matches secrets embedded in error messages such as
+	// request URLs and JSON bodies returned by exchanges, quoted values are
+	// matched up to their closing quote so escaped quotes aren't left exposed
+	journalSecretPattern = regexp.MustCompile(`(?i)((?:api_?key|secret|sign(?:ature)?|password|passphrase|trade_pwd|token)["']?\s*[=:]\s*)("(?:\\.|[^"\\])*"|'[^']*'|[^&\s"',}]+)`)
+)
+
+// JournalEntry is a single state changing action, its parameters and result.
+// Error is empty when the action succeeded
+type JournalEntry struct {
+	Time     time.Time              `json:"time"`
+	Exchange string                 `json:"exchange"`
+	Action   JournalAction          `json:"action"`
+	Params   map[string]interface{} `json:"params,omitempty"`
+	Result   interface{}            `json:"result,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// Journal records state changing actions so what the bot did can be
+// reconstructed after a restart. Implementations must be append only and safe
+// for concurrent use
+type Journal interface {
+	Record(entry JournalEntry) error
+}
+
+// SetJournal sets the journal every order and withdrawal action is recorded
+// to, a nil journal disables journaling
+func SetJournal(j Journal) {
+	journalMtx.Lock()
+	journal = j
+	journalMtx.Unlock()
+}
+
+// GetJournal returns the journal actions are recorded to, or nil when
+// journaling is disabled
+func GetJournal() Journal {
+	journalMtx.RLock()
+	defer journalMtx.RUnlock()
+	return journal
+}
+
+// RecordJournal records an action, its parameters and result to the journal
+// with any secrets redacted. Failures to record are logged rather than
+// returned so the outcome of the action is still reported to the caller
+func RecordJournal(exchName string, action JournalAction, params map[string]interface{}, result interface{}, actionErr error) {
+	j := GetJournal()
+	if j == nil {
+		return
+	}
+
+	entry := JournalEntry{
+		Time:     Now().UTC(),
+		Exchange: exchName,
+		Action:   action,
+		Params:   redactJournalParams(params),
+		Result:   result,
+	}
+	if actionErr != nil {
+		entry.Error = RedactSecrets(actionErr.Error())
+	}
+
+	if err := j.Record(entry); err != nil {
+		log.Printf("%s failed to record %s to journal: %s", exchName, action, err)
+	}
+}
+
+// SubmitOrder submits an order with SubmitOrderWithParams and records it to the
+// journal. A client order ID is generated when the order doesn't have one so
+// the journal entry can be matched to the order, the ID is returned in the
+// response even when the submission fails so it can be safely retried. Orders
+// are rejected with ErrTradingPaused while the exchange is under maintenance
+func SubmitOrder(exch IBotExchange, order OrderSubmission) (OrderSubmissionResponse, error) {
+	if IsTradingPaused(exch.GetName()) {
+		return OrderSubmissionResponse{}, ErrTradingPaused
+	}
+
+	if order.ClientOrderID == "" {
+		id, err := NewClientOrderID()
+		if err != nil {
+			return OrderSubmissionResponse{}, err
+		}
+		order.ClientOrderID = id
+	}
+
+	resp, err := exch.SubmitOrderWithParams(order)
+	if resp.ClientOrderID == "" {
+		resp.ClientOrderID = order.ClientOrderID
+	}
+	if GetJournal() != nil {
+		RecordJournal(exch.GetName(), JournalSubmitOrder, map[string]interface{}{
+			"pair":          order.Pair.Pair().String(),
+			"side":          order.OrderSide,
+			"orderType":     order.OrderType,
+			"amount":        order.Amount,
+			"price":         order.Price,
+			"clientID":      order.ClientID,
+			"clientOrderID": order.ClientOrderID,
+			"timeInForce":   order.TimeInForce,
+			"triggerPrice":  order.TriggerPrice,
+			"leverage":      order.Leverage,
+		}, resp, err)
+	}
+	return resp, err
+}
+
+// CancelOrder cancels an order and records it to the journal
+func CancelOrder(exch IBotExchange, order OrderCancellation) error {
+	err := exch.CancelOrder(order)
+	if GetJournal() != nil {
+		RecordJournal(exch.GetName(), JournalCancelOrder, cancellationParams(order), nil, err)
+	}
+	return err
+}
+
+// CancelAllOrders cancels all orders matching the cancellation and records it
+// to the journal
+func CancelAllOrders(exch IBotExchange, orders OrderCancellation) (CancelAllOrdersResponse, error) {
+	resp, err := exch.CancelAllOrders(orders)
+	if GetJournal() != nil {
+		RecordJournal(exch.GetName(), JournalCancelAllOrders, cancellationParams(orders), resp, err)
+	}
+	return resp, err
+}
+
+// cancellationParams returns the journal parameters of an order cancellation
+func cancellationParams(order OrderCancellation) map[string]interface{} {
+	return map[string]interface{}{
+		"accountID":     order.AccountID,
+		"orderID":       order.OrderID,
+		"pair":          order.CurrencyPair.Pair().String(),
+		"walletAddress": order.WalletAddress,
+		"side":          order.Side,
+	}
+}
+
+// redactJournalParams returns a copy of params with the values of secret
+// parameters, including those of nested maps, replaced
+func redactJournalParams(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		if isJournalSecret(k) {
+			redacted[k] = journalRedacted
+			continue
+		}
+
+		switch val := v.(type) {
+		case map[string]interface{}:
+			redacted[k] = redactJournalParams(val)
+		case string:
+			redacted[k] = RedactSecrets(val)
+		default:
+			redacted[k] = v
+		}
+	}
+	return redacted
+}
+
+// isJournalSecret returns whether a parameter name looks like it holds a
+// secret
+func isJournalSecret(name string) bool {
+	name = strings.ToLower(name)
+	for x := range journalSecretKeys {
+		if strings.Contains(name, journalSecretKeys[x]) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactSecrets replaces secrets embedded in a string, such as an error
+// message containing a request URL or a request body logged in verbose mode
+func RedactSecrets(s string) string {
+	return journalSecretPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := journalSecretPattern.FindStringSubmatch(match)
+		if quote := m[2][0]; quote == '"' || quote == '\'' {
+			return m[1] + string(quote) + journalRedacted + string(quote)
+		}
+		return m[1] + journalRedacted
+	})
+}
+
+// FileJournal is a Journal which appends each entry to a file as a line of
+// JSON
+type FileJournal struct {
+	m    sync.Mutex
+	file *os.File
+}
+
+// NewFileJournal opens the journal file at path for appending, creating it if
+// it does not exist
+func NewFileJournal(path string) (*FileJournal, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileJournal{file: f}, nil
+}
+
+// Record appends an entry to the journal file and syncs it to disk
+func (f *FileJournal) Record(entry JournalEntry) error {
+	data, err := common.JSONEncode(entry)
+	if err != nil {
+		return err
+	}
+
+	f.m.Lock()
+	defer f.m.Unlock()
+	if f.file == nil {
+		return os.ErrClosed
+	}
+
+	if _, err = f.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return f.file.Sync()
+}
+
+// Close closes the journal file, entries recorded afterwards return an error
+func (f *FileJournal) Close() error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// ReadJournal reads every entry from a journal file in the order they were
+// recorded. A final line left incomplete by an interrupted write is ignored
+func ReadJournal(path string) ([]JournalEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []JournalEntry
+	reader := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		data, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, readErr
+		}
+
+		data = bytes.TrimSpace(data)
+		if len(data) > 0 {
+			var entry JournalEntry
+			if err = common.JSONDecode(data, &entry); err != nil {
+				if readErr == io.EOF {
+					break
+				}
+				return nil, fmt.Errorf("journal %s line %d: %s", path, line, err)
+			}
+			entries = append(entries, entry)
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,186 @@
+package exchange
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+type testJournal struct {
+	m       sync.Mutex
+	entries []JournalEntry
+}
+
+func (t *testJournal) Record(entry JournalEntry) error {
+	t.m.Lock()
+	t.entries = append(t.entries, entry)
+	t.m.Unlock()
+	return nil
+}
+
+type journalTestExchange struct {
+	IBotExchange
+	cancelErr     error
+	clientOrderID string
+}
+
+func (j *journalTestExchange) GetName() string {
+	return "test"
+}
+
+func (j *journalTestExchange) SubmitOrderWithParams(order OrderSubmission) (OrderSubmissionResponse, error) {
+	j.clientOrderID = order.ClientOrderID
+	return OrderSubmissionResponse{IsOrderPlaced: true, OrderID: "1337"}, nil
+}
+
+func (j *journalTestExchange) CancelOrder(order OrderCancellation) error {
+	return j.cancelErr
+}
+
+func TestJournalOrderActions(t *testing.T) {
+	j := &testJournal{}
+	SetJournal(j)
+	defer SetJournal(nil)
+
+	exch := &journalTestExchange{
+		cancelErr: errors.New("request failed https://api.test/cancel?apiKey=abc123&orderID=1337"),
+	}
+	resp, err := SubmitOrder(exch, NewOrderSubmission(pair.NewCurrencyPair("BTC", "USD"), Buy, Limit, 1, 1000, "client"))
+	if err != nil {
+		t.Fatal("Test failed. SubmitOrder error", err)
+	}
+
+	if resp.ClientOrderID == "" || exch.clientOrderID != resp.ClientOrderID {
+		t.Errorf("Test failed. SubmitOrder expected a generated client order ID to be submitted, received %q sent %q",
+			resp.ClientOrderID, exch.clientOrderID)
+	}
+
+	err = CancelOrder(exch, OrderCancellation{OrderID: "1337"})
+	if err == nil {
+		t.Error("Test failed. CancelOrder expected the exchange error")
+	}
+
+	if len(j.entries) != 2 {
+		t.Fatalf("Test failed. Expected 2 journal entries, received %d", len(j.entries))
+	}
+
+	submit := j.entries[0]
+	if submit.Action != JournalSubmitOrder || submit.Exchange != "test" ||
+		submit.Params["pair"] != "BTCUSD" || submit.Params["clientOrderID"] != resp.ClientOrderID ||
+		submit.Error != "" {
+		t.Errorf("Test failed. Unexpected submit order journal entry %+v", submit)
+	}
+
+	if result, ok := submit.Result.(OrderSubmissionResponse); !ok || result.OrderID != "1337" {
+		t.Errorf("Test failed. Unexpected submit order journal result %+v", submit.Result)
+	}
+
+	cancel := j.entries[1]
+	if cancel.Action != JournalCancelOrder || cancel.Params["orderID"] != "1337" {
+		t.Errorf("Test failed. Unexpected cancel order journal entry %+v", cancel)
+	}
+
+	if strings.Contains(cancel.Error, "abc123") || !strings.Contains(cancel.Error, "orderID=1337") {
+		t.Errorf("Test failed. Journal error not redacted correctly %s", cancel.Error)
+	}
+}
+
+func TestRedactJournalParams(t *testing.T) {
+	params := redactJournalParams(map[string]interface{}{
+		"amount":    1.5,
+		"apiKey":    "abc",
+		"trade_pwd": "hunter2",
+		"nested": map[string]interface{}{
+			"Secret":  "def",
+			"address": "1F1tAaz5x1HUXrCNLbtMDqcw6o5GNn4xqX",
+		},
+	})
+
+	if params["amount"] != 1.5 || params["apiKey"] != journalRedacted ||
+		params["trade_pwd"] != journalRedacted {
+		t.Errorf("Test failed. redactJournalParams unexpected params %v", params)
+	}
+
+	nested := params["nested"].(map[string]interface{})
+	if nested["Secret"] != journalRedacted || nested["address"] == journalRedacted {
+		t.Errorf("Test failed. redactJournalParams unexpected nested params %v", nested)
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"https://api.test/withdraw?api_key=abc&amount=1&sign=DEF",
+			"https://api.test/withdraw?api_key=[REDACTED]&amount=1&sign=[REDACTED]"},
+		{`{"amount":"1","trade_pwd":"hun\"ter, 2","fee":"0.1"}`,
+			`{"amount":"1","trade_pwd":"[REDACTED]","fee":"0.1"}`},
+		{`{'passphrase': 'a b'}`, `{'passphrase': '[REDACTED]'}`},
+		{"currency=btc&amount=1", "currency=btc&amount=1"},
+	}
+
+	for x := range tests {
+		if result := RedactSecrets(tests[x].input); result != tests[x].expected {
+			t.Errorf("Test failed. RedactSecrets %d expected %s, received %s", x, tests[x].expected, result)
+		}
+	}
+}
+
+func TestFileJournal(t *testing.T) {
+	dir, err := os.MkdirTemp("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "journal.jsonl")
+	j, err := NewFileJournal(path)
+	if err != nil {
+		t.Fatal("Test failed. NewFileJournal error", err)
+	}
+
+	SetJournal(j)
+	defer SetJournal(nil)
+
+	RecordJournal("test", JournalWithdraw, map[string]interface{}{"currency": "BTC"}, "1", nil)
+	RecordJournal("test", JournalCancelAllOrders, nil, nil, errors.New("cancel failed"))
+	if err = j.Close(); err != nil {
+		t.Fatal("Test failed. FileJournal Close error", err)
+	}
+
+	if err = j.Record(JournalEntry{}); err == nil {
+		t.Error("Test failed. FileJournal recorded an entry after being closed")
+	}
+
+	// simulate an interrupted write
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"time":"2018-`)
+	f.Close()
+
+	entries, err := ReadJournal(path)
+	if err != nil {
+		t.Fatal("Test failed. ReadJournal error", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Test failed. ReadJournal expected 2 entries, received %d", len(entries))
+	}
+
+	if entries[0].Action != JournalWithdraw || entries[0].Result != "1" ||
+		entries[0].Params["currency"] != "BTC" {
+		t.Errorf("Test failed. ReadJournal unexpected entry %+v", entries[0])
+	}
+
+	if entries[1].Action != JournalCancelAllOrders || entries[1].Error != "cancel failed" {
+		t.Errorf("Test failed. ReadJournal unexpected entry %+v", entries[1])
+	}
+}
@@ -0,0 +1,148 @@
+package exchange
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// ErrPositionClosingNotSupported is returned when an exchange cannot close
+// open positions
+var ErrPositionClosingNotSupported = errors.New("position closing not supported")
+
+// ClosedPosition holds the reduce only order placed to close a single open
+// position
+type ClosedPosition struct {
+	Pair         pair.CurrencyPair
+	AssetType    string
+	Side         OrderSide
+	Amount       float64
+	CloseOrderID string
+}
+
+// PositionCloser is implemented by exchanges which can flatten their open
+// derivatives positions using reduce only market orders
+type PositionCloser interface {
+	CloseAllPositions() ([]ClosedPosition, error)
+}
+
+// KillSwitchCancellation holds the outcome of cancelling all orders for a
+// single exchange and currency pair
+type KillSwitchCancellation struct {
+	Exchange string
+	Pair     pair.CurrencyPair
+	Response CancelAllOrdersResponse
+	Err      error
+}
+
+// KillSwitchPositions holds the outcome of closing the open positions of a
+// single exchange
+type KillSwitchPositions struct {
+	Exchange string
+	Closed   []ClosedPosition
+	Err      error
+}
+
+// KillSwitchReport holds what was cancelled and closed by KillSwitch and what
+// failed
+type KillSwitchReport struct {
+	Cancellations []KillSwitchCancellation
+	Positions     []KillSwitchPositions
+}
+
+// Succeeded returns whether every cancellation and position close succeeded.
+// Exchanges which do not support closing positions are not treated as failures
+func (k *KillSwitchReport) Succeeded() bool {
+	return len(k.Errors()) == 0
+}
+
+// Errors returns every error encountered by KillSwitch
+func (k *KillSwitchReport) Errors() []error {
+	var errs []error
+	for x := range k.Cancellations {
+		if k.Cancellations[x].Err != nil {
+			errs = append(errs, k.Cancellations[x].Err)
+			continue
+		}
+		if k.Cancellations[x].Response.Failed > 0 {
+			errs = append(errs, errors.New(k.Cancellations[x].Exchange+" "+
+				k.Cancellations[x].Pair.Pair().String()+" failed to cancel orders"))
+		}
+	}
+
+	for x := range k.Positions {
+		if k.Positions[x].Err != nil &&
+			k.Positions[x].Err != ErrPositionClosingNotSupported {
+			errs = append(errs, k.Positions[x].Err)
+		}
+	}
+	return errs
+}
+
+// KillSwitch concurrently cancels all orders for every enabled currency pair
+// of every enabled exchange supplied. When closePositions is set exchanges
+// implementing PositionCloser also have their open positions closed, the
+// remaining exchanges are reported with ErrPositionClosingNotSupported. Every
+// exchange and pair is attempted regardless of earlier failures. Exchanges
+// under maintenance are reported with ErrTradingPaused without being attempted
+func KillSwitch(exchanges []IBotExchange, closePositions bool) KillSwitchReport {
+	var report KillSwitchReport
+	var reportMtx sync.Mutex
+	var wg sync.WaitGroup
+
+	for x := range exchanges {
+		if exchanges[x] == nil || !exchanges[x].IsEnabled() {
+			continue
+		}
+
+		exch := exchanges[x]
+		pairs := exch.GetEnabledCurrencies()
+		for y := range pairs {
+			wg.Add(1)
+			go func(p pair.CurrencyPair) {
+				defer wg.Done()
+				var resp CancelAllOrdersResponse
+				var err error
+				if IsTradingPaused(exch.GetName()) {
+					err = ErrTradingPaused
+				} else {
+					resp, err = CancelAllOrders(exch, OrderCancellation{CurrencyPair: p})
+				}
+				reportMtx.Lock()
+				report.Cancellations = append(report.Cancellations, KillSwitchCancellation{
+					Exchange: exch.GetName(),
+					Pair:     p,
+					Response: resp,
+					Err:      err,
+				})
+				reportMtx.Unlock()
+			}(pairs[y])
+		}
+
+		if !closePositions {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := KillSwitchPositions{Exchange: exch.GetName()}
+			closer, ok := exch.(PositionCloser)
+			switch {
+			case !ok:
+				result.Err = ErrPositionClosingNotSupported
+			case IsTradingPaused(exch.GetName()):
+				result.Err = ErrTradingPaused
+			default:
+				result.Closed, result.Err = closer.CloseAllPositions()
+			}
+			reportMtx.Lock()
+			report.Positions = append(report.Positions, result)
+			reportMtx.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return report
+}
@@ -0,0 +1,140 @@
+package exchange
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+type killSwitchTestExchange struct {
+	IBotExchange
+	name      string
+	enabled   bool
+	pairs     []pair.CurrencyPair
+	cancelErr error
+
+	m         sync.Mutex
+	cancelled []pair.CurrencyPair
+}
+
+func (k *killSwitchTestExchange) GetName() string {
+	return k.name
+}
+
+func (k *killSwitchTestExchange) IsEnabled() bool {
+	return k.enabled
+}
+
+func (k *killSwitchTestExchange) GetEnabledCurrencies() []pair.CurrencyPair {
+	return k.pairs
+}
+
+func (k *killSwitchTestExchange) CancelAllOrders(orders OrderCancellation) (CancelAllOrdersResponse, error) {
+	k.m.Lock()
+	defer k.m.Unlock()
+	k.cancelled = append(k.cancelled, orders.CurrencyPair)
+	if k.cancelErr != nil {
+		return CancelAllOrdersResponse{}, k.cancelErr
+	}
+	return CancelAllOrdersResponse{Succeeded: 1, CancelledOrderIDs: []string{"1"}}, nil
+}
+
+type killSwitchPositionsTestExchange struct {
+	killSwitchTestExchange
+}
+
+func (k *killSwitchPositionsTestExchange) CloseAllPositions() ([]ClosedPosition, error) {
+	return []ClosedPosition{{Pair: pair.NewCurrencyPair("BTC", "USD"), Side: Sell, Amount: 1}}, nil
+}
+
+func TestKillSwitch(t *testing.T) {
+	spot := &killSwitchTestExchange{
+		name:    "spot",
+		enabled: true,
+		pairs: []pair.CurrencyPair{
+			pair.NewCurrencyPair("BTC", "USD"),
+			pair.NewCurrencyPair("LTC", "USD"),
+		},
+	}
+	futures := &killSwitchPositionsTestExchange{killSwitchTestExchange{
+		name:    "futures",
+		enabled: true,
+		pairs:   []pair.CurrencyPair{pair.NewCurrencyPair("BTC", "USD")},
+	}}
+	disabled := &killSwitchTestExchange{
+		name:  "disabled",
+		pairs: []pair.CurrencyPair{pair.NewCurrencyPair("BTC", "USD")},
+	}
+
+	report := KillSwitch([]IBotExchange{spot, futures, disabled, nil}, false)
+	if !report.Succeeded() {
+		t.Errorf("Test failed. KillSwitch unexpected errors %v", report.Errors())
+	}
+
+	if len(report.Cancellations) != 3 || len(spot.cancelled) != 2 || len(futures.cancelled) != 1 {
+		t.Errorf("Test failed. KillSwitch expected 3 cancellations, received %d",
+			len(report.Cancellations))
+	}
+
+	if len(disabled.cancelled) != 0 {
+		t.Error("Test failed. KillSwitch cancelled orders on a disabled exchange")
+	}
+
+	if len(report.Positions) != 0 {
+		t.Error("Test failed. KillSwitch closed positions when not requested")
+	}
+
+	report = KillSwitch([]IBotExchange{spot, futures}, true)
+	if !report.Succeeded() {
+		t.Errorf("Test failed. KillSwitch unexpected errors %v", report.Errors())
+	}
+
+	if len(report.Positions) != 2 {
+		t.Fatalf("Test failed. KillSwitch expected 2 position results, received %d",
+			len(report.Positions))
+	}
+
+	for x := range report.Positions {
+		switch report.Positions[x].Exchange {
+		case "spot":
+			if report.Positions[x].Err != ErrPositionClosingNotSupported {
+				t.Errorf("Test failed. KillSwitch expected %s, received %v",
+					ErrPositionClosingNotSupported, report.Positions[x].Err)
+			}
+		case "futures":
+			if report.Positions[x].Err != nil || len(report.Positions[x].Closed) != 1 {
+				t.Errorf("Test failed. KillSwitch unexpected position result %+v",
+					report.Positions[x])
+			}
+		}
+	}
+}
+
+func TestKillSwitchFailures(t *testing.T) {
+	failing := &killSwitchTestExchange{
+		name:      "failing",
+		enabled:   true,
+		pairs:     []pair.CurrencyPair{pair.NewCurrencyPair("BTC", "USD")},
+		cancelErr: errors.New("cancel failed"),
+	}
+	working := &killSwitchTestExchange{
+		name:    "working",
+		enabled: true,
+		pairs:   []pair.CurrencyPair{pair.NewCurrencyPair("BTC", "USD")},
+	}
+
+	report := KillSwitch([]IBotExchange{failing, working}, false)
+	if report.Succeeded() {
+		t.Error("Test failed. KillSwitch reported success with a failed cancellation")
+	}
+
+	if len(report.Errors()) != 1 {
+		t.Errorf("Test failed. KillSwitch expected 1 error, received %d", len(report.Errors()))
+	}
+
+	if len(working.cancelled) != 1 {
+		t.Error("Test failed. KillSwitch stopped after the first failure")
+	}
+}
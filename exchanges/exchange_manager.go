@@ -0,0 +1,151 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// vars related to the exchange manager
+var (
+	ErrExchangeAlreadyLoaded = errors.New("exchange already loaded")
+	ErrExchangeNotLoaded     = errors.New("exchange not loaded")
+)
+
+// LoadErrors holds the errors returned while loading a set of exchanges
+type LoadErrors []error
+
+// Error implements the error interface, joining each load error
+func (l LoadErrors) Error() string {
+	var errs []string
+	for x := range l {
+		errs = append(errs, l[x].Error())
+	}
+	return common.JoinStrings(errs, ", ")
+}
+
+// Manager instantiates and tracks the exchanges enabled in the config
+type Manager struct {
+	exchanges []IBotExchange
+	m         sync.RWMutex
+}
+
+// LoadExchanges creates each enabled exchange via the registry, then sets its
+// defaults, sets it up and starts it. A failure to load one exchange does not
+// prevent the remaining exchanges from loading, the errors are returned
+// together as LoadErrors
+func (m *Manager) LoadExchanges(exchCfgs []config.ExchangeConfig, wg *sync.WaitGroup) error {
+	var errs LoadErrors
+	for x := range exchCfgs {
+		if !exchCfgs[x].Enabled {
+			log.Printf("%s: Exchange support: Disabled", exchCfgs[x].Name)
+			continue
+		}
+
+		err := m.LoadExchange(exchCfgs[x], wg)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		log.Printf(
+			"%s: Exchange support: Enabled (Authenticated API support: %s - Verbose mode: %s).\n",
+			exchCfgs[x].Name,
+			common.IsEnabled(exchCfgs[x].AuthenticatedAPISupport),
+			common.IsEnabled(exchCfgs[x].Verbose),
+		)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// LoadExchange creates, sets up and starts a single exchange and adds it to
+// the manager. Exchange Setup methods exit on an invalid config, so the config
+// is validated first and an invalid config is returned as an error
+func (m *Manager) LoadExchange(exchCfg config.ExchangeConfig, wg *sync.WaitGroup) error {
+	if m.GetExchangeByName(exchCfg.Name) != nil {
+		return fmt.Errorf("%s: %s", exchCfg.Name, ErrExchangeAlreadyLoaded)
+	}
+
+	exch, err := NewExchangeByName(exchCfg.Name)
+	if err != nil {
+		return fmt.Errorf("%s: %s", exchCfg.Name, err)
+	}
+
+	exch.SetDefaults()
+	err = exch.ValidateConfig(exchCfg)
+	if err != nil {
+		return fmt.Errorf("%s: %s", exchCfg.Name, err)
+	}
+
+	exch.Setup(exchCfg)
+	orderbook.SetMaxStoredLevels(exch.GetName(), exchCfg.OrderbookMaxStoredLevels)
+	exch.Start(wg)
+
+	m.m.Lock()
+	m.exchanges = append(m.exchanges, exch)
+	m.m.Unlock()
+	return nil
+}
+
+// ReloadExchange sets up a loaded exchange again with an updated config, an
+// invalid config is returned as an error and the exchange is left unchanged
+func (m *Manager) ReloadExchange(exchCfg config.ExchangeConfig) error {
+	exch := m.GetExchangeByName(exchCfg.Name)
+	if exch == nil {
+		return ErrExchangeNotLoaded
+	}
+
+	err := exch.ValidateConfig(exchCfg)
+	if err != nil {
+		return fmt.Errorf("%s: %s", exchCfg.Name, err)
+	}
+
+	exch.Setup(exchCfg)
+	orderbook.SetMaxStoredLevels(exch.GetName(), exchCfg.OrderbookMaxStoredLevels)
+	return nil
+}
+
+// GetExchangeByName returns a loaded exchange by name or nil if it hasn't
+// been loaded
+func (m *Manager) GetExchangeByName(name string) IBotExchange {
+	m.m.RLock()
+	defer m.m.RUnlock()
+	for x := range m.exchanges {
+		if common.StringToLower(m.exchanges[x].GetName()) == common.StringToLower(name) {
+			return m.exchanges[x]
+		}
+	}
+	return nil
+}
+
+// GetExchanges returns the loaded exchanges
+func (m *Manager) GetExchanges() []IBotExchange {
+	m.m.RLock()
+	defer m.m.RUnlock()
+	exchanges := make([]IBotExchange, len(m.exchanges))
+	copy(exchanges, m.exchanges)
+	return exchanges
+}
+
+// UnloadExchange disables an exchange and removes it from the manager
+func (m *Manager) UnloadExchange(name string) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	for x := range m.exchanges {
+		if common.StringToLower(m.exchanges[x].GetName()) == common.StringToLower(name) {
+			m.exchanges[x].SetEnabled(false)
+			m.exchanges = append(m.exchanges[:x], m.exchanges[x+1:]...)
+			return nil
+		}
+	}
+	return ErrExchangeNotLoaded
+}
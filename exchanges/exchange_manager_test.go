@@ -0,0 +1,198 @@
+package exchange
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/config"
+)
+
+func TestManagerLoadExchanges(t *testing.T) {
+	var m Manager
+	var wg sync.WaitGroup
+
+	err := m.LoadExchanges([]config.ExchangeConfig{
+		{Name: "ManagerDisabled", Enabled: false},
+		{Name: "ManagerUnknownA", Enabled: true},
+		{Name: "ManagerUnknownB", Enabled: true},
+	}, &wg)
+	wg.Wait()
+
+	loadErrs, ok := err.(LoadErrors)
+	if !ok {
+		t.Fatalf("Test failed. LoadExchanges expected LoadErrors, received %T", err)
+	}
+
+	if len(loadErrs) != 2 {
+		t.Errorf("Test failed. LoadExchanges expected 2 errors, received %d", len(loadErrs))
+	}
+
+	if len(m.GetExchanges()) != 0 {
+		t.Error("Test failed. LoadExchanges loaded an unknown exchange")
+	}
+
+	if m.GetExchangeByName("ManagerUnknownA") != nil {
+		t.Error("Test failed. GetExchangeByName returned an exchange which wasn't loaded")
+	}
+
+	if err = m.UnloadExchange("ManagerUnknownA"); err != ErrExchangeNotLoaded {
+		t.Errorf("Test failed. UnloadExchange expected %s, received %v",
+			ErrExchangeNotLoaded, err)
+	}
+}
+
+func TestManagerLoadExchangesDisabled(t *testing.T) {
+	var m Manager
+	var wg sync.WaitGroup
+
+	err := m.LoadExchanges([]config.ExchangeConfig{
+		{Name: "ManagerDisabled", Enabled: false},
+	}, &wg)
+	if err != nil {
+		t.Error("Test failed. LoadExchanges error", err)
+	}
+}
+
+type managerTestExchange struct {
+	IBotExchange
+	name    string
+	enabled bool
+	started bool
+}
+
+func (e *managerTestExchange) SetDefaults() {}
+
+func (e *managerTestExchange) ValidateConfig(exch config.ExchangeConfig) error {
+	b := Base{Name: exch.Name}
+	return b.ValidateConfig(exch)
+}
+
+func (e *managerTestExchange) Setup(exch config.ExchangeConfig) {
+	e.name = exch.Name
+	e.enabled = exch.Enabled
+}
+
+func (e *managerTestExchange) Start(wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		e.started = true
+		wg.Done()
+	}()
+}
+
+func (e *managerTestExchange) GetName() string {
+	return e.name
+}
+
+func (e *managerTestExchange) SetEnabled(enabled bool) {
+	e.enabled = enabled
+}
+
+// managerTestConfigs adds a valid config for each test exchange to the global
+// config and returns them
+func managerTestConfigs(t *testing.T, names ...string) []config.ExchangeConfig {
+	cfg := config.GetConfig()
+	err := cfg.LoadConfig(config.ConfigTestFile)
+	if err != nil {
+		t.Fatal("Test failed. TestManager failed to load config", err)
+	}
+
+	var exchCfgs []config.ExchangeConfig
+	for x := range names {
+		exchCfgs = append(exchCfgs, config.ExchangeConfig{
+			Name:            names[x],
+			Enabled:         true,
+			APIURL:          config.APIURLNonDefaultMessage,
+			APIURLSecondary: config.APIURLNonDefaultMessage,
+		})
+	}
+	cfg.Exchanges = append(cfg.Exchanges, exchCfgs...)
+	return exchCfgs
+}
+
+func TestManagerLoadUnloadExchange(t *testing.T) {
+	RegisterExchange("ManagerTest", func() IBotExchange {
+		return &managerTestExchange{}
+	})
+	exchCfg := managerTestConfigs(t, "ManagerTest")[0]
+	exchCfg.OrderbookMaxStoredLevels = 5
+
+	var m Manager
+	var wg sync.WaitGroup
+	err := m.LoadExchanges([]config.ExchangeConfig{exchCfg}, &wg)
+	wg.Wait()
+	if err != nil {
+		t.Fatal("Test failed. LoadExchanges error", err)
+	}
+
+	exch, ok := m.GetExchangeByName("managertest").(*managerTestExchange)
+	if !ok {
+		t.Fatal("Test failed. GetExchangeByName did not return the loaded exchange")
+	}
+
+	if !exch.enabled || !exch.started || len(m.GetExchanges()) != 1 {
+		t.Errorf("Test failed. LoadExchanges did not set up and start the exchange %+v", exch)
+	}
+
+	err = m.LoadExchange(exchCfg, &wg)
+	if err == nil {
+		t.Error("Test failed. LoadExchange loaded an exchange twice")
+	}
+
+	invalidCfg := exchCfg
+	invalidCfg.Enabled = false
+	invalidCfg.PriceSources = []string{"guess"}
+	err = m.ReloadExchange(invalidCfg)
+	if err == nil || !exch.enabled {
+		t.Error("Test failed. ReloadExchange set up the exchange with an invalid config")
+	}
+
+	exchCfg.Enabled = false
+	err = m.ReloadExchange(exchCfg)
+	if err != nil || exch.enabled {
+		t.Errorf("Test failed. ReloadExchange did not set up the exchange again %v", err)
+	}
+
+	if err = m.UnloadExchange("MANAGERTEST"); err != nil {
+		t.Fatal("Test failed. UnloadExchange error", err)
+	}
+
+	if m.GetExchangeByName("ManagerTest") != nil || len(m.GetExchanges()) != 0 {
+		t.Error("Test failed. UnloadExchange did not remove the exchange")
+	}
+
+	err = m.ReloadExchange(config.ExchangeConfig{Name: "ManagerTest"})
+	if err != ErrExchangeNotLoaded {
+		t.Errorf("Test failed. ReloadExchange expected %s, received %v", ErrExchangeNotLoaded, err)
+	}
+}
+
+func TestManagerLoadExchangesInvalidConfig(t *testing.T) {
+	RegisterExchange("ManagerValid", func() IBotExchange {
+		return &managerTestExchange{}
+	})
+	RegisterExchange("ManagerInvalid", func() IBotExchange {
+		return &managerTestExchange{}
+	})
+	exchCfgs := managerTestConfigs(t, "ManagerInvalid", "ManagerValid")
+	exchCfgs[0].HTTPRateLimiter = &config.HTTPRateLimitConfig{AuthRate: 1}
+
+	var m Manager
+	var wg sync.WaitGroup
+	err := m.LoadExchanges(exchCfgs, &wg)
+	wg.Wait()
+
+	loadErrs, ok := err.(LoadErrors)
+	if !ok || len(loadErrs) != 1 {
+		t.Fatalf("Test failed. LoadExchanges expected 1 load error, received %v", err)
+	}
+
+	if m.GetExchangeByName("ManagerInvalid") != nil {
+		t.Error("Test failed. LoadExchanges loaded an exchange with an invalid config")
+	}
+
+	exch, ok := m.GetExchangeByName("ManagerValid").(*managerTestExchange)
+	if !ok || !exch.started {
+		t.Error("Test failed. LoadExchanges did not load the valid exchange")
+	}
+}
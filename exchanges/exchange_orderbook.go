@@ -0,0 +1,88 @@
+package exchange
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// DefaultOrderbookUpdateConcurrency is the number of orderbooks
+// UpdateAllOrderbooks refreshes at once when no limit is supplied
+const DefaultOrderbookUpdateConcurrency = 5
+
+// ErrOrderbookSideEmpty is returned when an orderbook has no bids or no asks
+var ErrOrderbookSideEmpty = errors.New("orderbook has no bids or asks")
+
+// BestBidAsk holds the best bid and ask of a currency pair on an exchange
+type BestBidAsk struct {
+	Exchange string
+	Pair     pair.CurrencyPair
+	Bid      float64
+	Ask      float64
+}
+
+// GetBestBidAsk fetches the orderbook of a currency pair and returns its
+// highest bid and lowest ask
+func GetBestBidAsk(exch IBotExchange, p pair.CurrencyPair, assetType string) (BestBidAsk, error) {
+	resp := BestBidAsk{Exchange: exch.GetName(), Pair: p}
+
+	ob, err := exch.UpdateOrderbook(p, assetType)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(ob.Bids) == 0 || len(ob.Asks) == 0 {
+		return resp, ErrOrderbookSideEmpty
+	}
+
+	for x := range ob.Bids {
+		if ob.Bids[x].Price > resp.Bid {
+			resp.Bid = ob.Bids[x].Price
+		}
+	}
+
+	for x := range ob.Asks {
+		if resp.Ask == 0 || ob.Asks[x].Price < resp.Ask {
+			resp.Ask = ob.Asks[x].Price
+		}
+	}
+	return resp, nil
+}
+
+// UpdateAllOrderbooks concurrently refreshes and stores the orderbook of every
+// enabled currency pair for the supplied asset type. At most maxConcurrent
+// requests are in flight at once and each request is still subject to the
+// exchanges rate limiter. The returned map holds the error of each currency
+// pair which failed to update and is empty when every update succeeded
+func UpdateAllOrderbooks(exch IBotExchange, assetType string, maxConcurrent int) map[pair.CurrencyPair]error {
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultOrderbookUpdateConcurrency
+	}
+
+	errs := make(map[pair.CurrencyPair]error)
+	var errMtx sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrent)
+
+	enabledPairs := exch.GetEnabledCurrencies()
+	for x := range enabledPairs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(p pair.CurrencyPair) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			_, err := exch.UpdateOrderbook(p, assetType)
+			if err != nil {
+				errMtx.Lock()
+				errs[p] = err
+				errMtx.Unlock()
+			}
+		}(enabledPairs[x])
+	}
+	wg.Wait()
+	return errs
+}
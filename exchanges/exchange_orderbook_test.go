@@ -0,0 +1,69 @@
+package exchange
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type orderbookTestExchange struct {
+	IBotExchange
+	pairs []pair.CurrencyPair
+
+	m           sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (o *orderbookTestExchange) GetEnabledCurrencies() []pair.CurrencyPair {
+	return o.pairs
+}
+
+func (o *orderbookTestExchange) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	o.m.Lock()
+	o.inFlight++
+	if o.inFlight > o.maxInFlight {
+		o.maxInFlight = o.inFlight
+	}
+	o.m.Unlock()
+
+	defer func() {
+		o.m.Lock()
+		o.inFlight--
+		o.m.Unlock()
+	}()
+
+	if p.FirstCurrency == "LTC" {
+		return orderbook.Base{}, errors.New("update failed")
+	}
+	return orderbook.Base{Pair: p}, nil
+}
+
+func TestUpdateAllOrderbooks(t *testing.T) {
+	exch := &orderbookTestExchange{
+		pairs: []pair.CurrencyPair{
+			pair.NewCurrencyPair("BTC", "USD"),
+			pair.NewCurrencyPair("LTC", "USD"),
+			pair.NewCurrencyPair("ETH", "USD"),
+			pair.NewCurrencyPair("XRP", "USD"),
+		},
+	}
+
+	errs := UpdateAllOrderbooks(exch, ticker.Spot, 2)
+	if len(errs) != 1 {
+		t.Fatalf("Test failed. UpdateAllOrderbooks expected 1 error, received %d", len(errs))
+	}
+
+	if errs[pair.NewCurrencyPair("LTC", "USD")] == nil {
+		t.Error("Test failed. UpdateAllOrderbooks missing LTCUSD error")
+	}
+
+	if exch.maxInFlight > 2 {
+		t.Errorf("Test failed. UpdateAllOrderbooks exceeded the concurrency limit with %d requests",
+			exch.maxInFlight)
+	}
+}
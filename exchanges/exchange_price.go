@@ -0,0 +1,124 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// PriceSource is a source GetPrice can derive the current price of a currency
+// pair from
+type PriceSource string
+
+// Price sources
+const (
+	PriceSourceTicker    PriceSource = "ticker"
+	PriceSourceBookMid   PriceSource = "bookmid"
+	PriceSourceLastTrade PriceSource = "lasttrade"
+)
+
+// DefaultPriceSources is the order GetPrice tries the price sources in when an
+// exchange has none configured: the ticker's last price, then the mid price of
+// the best bid and ask, then the price of the most recent trade
+var DefaultPriceSources = []PriceSource{
+	PriceSourceTicker,
+	PriceSourceBookMid,
+	PriceSourceLastTrade,
+}
+
+// ErrNoPrice is returned when none of an exchange's price sources returned a
+// price
+var ErrNoPrice = errors.New("no price source returned a price")
+
+// SetPriceSources sets the order GetPrice tries the price sources in, an empty
+// list uses DefaultPriceSources
+func (e *Base) SetPriceSources(sources []string) error {
+	priceSources, err := e.parsePriceSources(sources)
+	if err != nil {
+		return err
+	}
+	e.PriceSources = priceSources
+	return nil
+}
+
+// parsePriceSources converts the configured price source names, returning an
+// error on an unknown source
+func (e *Base) parsePriceSources(sources []string) ([]PriceSource, error) {
+	var priceSources []PriceSource
+	for x := range sources {
+		source := PriceSource(common.StringToLower(sources[x]))
+		switch source {
+		case PriceSourceTicker, PriceSourceBookMid, PriceSourceLastTrade:
+		default:
+			return nil, fmt.Errorf("%s invalid price source %s", e.Name, sources[x])
+		}
+		priceSources = append(priceSources, source)
+	}
+	return priceSources, nil
+}
+
+// GetPriceSources returns the order GetPrice tries the price sources in
+func (e *Base) GetPriceSources() []PriceSource {
+	if len(e.PriceSources) == 0 {
+		return DefaultPriceSources
+	}
+	return e.PriceSources
+}
+
+// GetPrice returns the current price of a currency pair from the first of the
+// exchange's price sources which succeeds, along with the source used
+func GetPrice(exch IBotExchange, p pair.CurrencyPair, assetType string) (float64, PriceSource, error) {
+	var errs []string
+	for _, source := range exch.GetPriceSources() {
+		price, err := getPriceFromSource(exch, p, assetType, source)
+		if err == nil && price > 0 {
+			return price, source, nil
+		}
+
+		if err == nil {
+			err = errors.New("zero price")
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", source, err))
+	}
+	return 0, "", fmt.Errorf("%s %s %s: %s", exch.GetName(), p.Pair(), ErrNoPrice,
+		common.JoinStrings(errs, ", "))
+}
+
+// getPriceFromSource returns the current price of a currency pair from a
+// single price source
+func getPriceFromSource(exch IBotExchange, p pair.CurrencyPair, assetType string, source PriceSource) (float64, error) {
+	switch source {
+	case PriceSourceTicker:
+		tick, err := exch.UpdateTicker(p, assetType)
+		if err != nil {
+			return 0, err
+		}
+		return tick.Last, nil
+	case PriceSourceBookMid:
+		bidAsk, err := GetBestBidAsk(exch, p, assetType)
+		if err != nil {
+			return 0, err
+		}
+		return (bidAsk.Bid + bidAsk.Ask) / 2, nil
+	case PriceSourceLastTrade:
+		trades, err := exch.GetExchangeHistory(p, assetType)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(trades) == 0 {
+			return 0, errors.New("no recent trades")
+		}
+
+		latest := trades[0]
+		for x := range trades {
+			if trades[x].Timestamp > latest.Timestamp {
+				latest = trades[x]
+			}
+		}
+		return latest.Price, nil
+	}
+	return 0, fmt.Errorf("unsupported price source %s", source)
+}
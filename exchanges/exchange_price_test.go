@@ -0,0 +1,105 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type priceTestExchange struct {
+	IBotExchange
+	sources []PriceSource
+	last    float64
+	book    bool
+	trades  []TradeHistory
+}
+
+func (p *priceTestExchange) GetName() string {
+	return "test"
+}
+
+func (p *priceTestExchange) GetPriceSources() []PriceSource {
+	return p.sources
+}
+
+func (p *priceTestExchange) UpdateTicker(c pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	if p.last == 0 {
+		return ticker.Price{}, errors.New("ticker unavailable")
+	}
+	return ticker.Price{Pair: c, Last: p.last}, nil
+}
+
+func (p *priceTestExchange) UpdateOrderbook(c pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	if !p.book {
+		return orderbook.Base{}, errors.New("orderbook unavailable")
+	}
+	return orderbook.Base{
+		Pair: c,
+		Bids: []orderbook.Item{{Price: 99}},
+		Asks: []orderbook.Item{{Price: 101}},
+	}, nil
+}
+
+func (p *priceTestExchange) GetExchangeHistory(c pair.CurrencyPair, assetType string) ([]TradeHistory, error) {
+	return p.trades, nil
+}
+
+func TestSetPriceSources(t *testing.T) {
+	b := Base{Name: "test"}
+	if len(b.GetPriceSources()) != len(DefaultPriceSources) {
+		t.Error("Test failed. GetPriceSources expected the default price sources")
+	}
+
+	err := b.SetPriceSources([]string{"LastTrade", "ticker"})
+	if err != nil {
+		t.Fatal("Test failed. SetPriceSources error", err)
+	}
+
+	sources := b.GetPriceSources()
+	if len(sources) != 2 || sources[0] != PriceSourceLastTrade || sources[1] != PriceSourceTicker {
+		t.Errorf("Test failed. GetPriceSources unexpected sources %v", sources)
+	}
+
+	err = b.SetPriceSources([]string{"vwap"})
+	if err == nil {
+		t.Error("Test failed. SetPriceSources expected an error for an invalid source")
+	}
+}
+
+func TestGetPrice(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	exch := &priceTestExchange{
+		sources: DefaultPriceSources,
+		book:    true,
+		trades:  []TradeHistory{{Timestamp: 2, Price: 98}, {Timestamp: 1, Price: 97}},
+	}
+
+	price, source, err := GetPrice(exch, p, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test failed. GetPrice error", err)
+	}
+
+	if price != 100 || source != PriceSourceBookMid {
+		t.Errorf("Test failed. GetPrice expected 100 from %s, received %f from %s",
+			PriceSourceBookMid, price, source)
+	}
+
+	exch.sources = []PriceSource{PriceSourceLastTrade, PriceSourceTicker}
+	exch.last = 105
+	price, source, err = GetPrice(exch, p, ticker.Spot)
+	if err != nil || price != 98 || source != PriceSourceLastTrade {
+		t.Errorf("Test failed. GetPrice expected 98 from %s, received %f from %s %v",
+			PriceSourceLastTrade, price, source, err)
+	}
+
+	exch.sources = []PriceSource{PriceSourceTicker, PriceSourceBookMid}
+	exch.last = 0
+	exch.book = false
+	_, _, err = GetPrice(exch, p, ticker.Spot)
+	if err == nil {
+		t.Error("Test failed. GetPrice expected an error when every source fails")
+	}
+}
@@ -0,0 +1,70 @@
+package exchange
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// Constructor returns a new exchange wrapper which has not yet had its
+// defaults set or been setup
+type Constructor func() IBotExchange
+
+// vars related to the exchange registry
+var (
+	ErrExchangeNotRegistered = errors.New("exchange not registered")
+
+	registry    = make(map[string]Constructor)
+	registryMtx sync.RWMutex
+)
+
+// RegisterExchange registers an exchange constructor by name so it can be
+// created with NewExchangeByName. Exchange packages call this in their init
+// function, registering again under the same name replaces the constructor
+func RegisterExchange(name string, constructor Constructor) {
+	if name == "" || constructor == nil {
+		panic("exchange: RegisterExchange requires a name and constructor")
+	}
+
+	registryMtx.Lock()
+	registry[common.StringToLower(name)] = constructor
+	registryMtx.Unlock()
+}
+
+// NewExchangeByName returns a new exchange wrapper for the supplied exchange
+// name, the name is case insensitive
+func NewExchangeByName(name string) (IBotExchange, error) {
+	registryMtx.RLock()
+	constructor, ok := registry[common.StringToLower(name)]
+	registryMtx.RUnlock()
+
+	if !ok {
+		return nil, ErrExchangeNotRegistered
+	}
+	return constructor(), nil
+}
+
+// IsExchangeRegistered returns whether or not an exchange has been registered
+// under the supplied name
+func IsExchangeRegistered(name string) bool {
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+	_, ok := registry[common.StringToLower(name)]
+	return ok
+}
+
+// GetRegisteredExchanges returns a sorted list of the registered exchange
+// names
+func GetRegisteredExchanges() []string {
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
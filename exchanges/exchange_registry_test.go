@@ -0,0 +1,38 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+func TestRegisterExchange(t *testing.T) {
+	var called bool
+	RegisterExchange("RegistryTest", func() IBotExchange {
+		called = true
+		return nil
+	})
+
+	if !IsExchangeRegistered("registrytest") {
+		t.Fatal("Test failed. IsExchangeRegistered returned false")
+	}
+
+	_, err := NewExchangeByName("REGISTRYTEST")
+	if err != nil {
+		t.Error("Test failed. NewExchangeByName error", err)
+	}
+
+	if !called {
+		t.Error("Test failed. NewExchangeByName did not call the registered constructor")
+	}
+
+	if !common.StringDataCompare(GetRegisteredExchanges(), "registrytest") {
+		t.Error("Test failed. GetRegisteredExchanges did not return the registered exchange")
+	}
+
+	_, err = NewExchangeByName("NotAnExchange")
+	if err != ErrExchangeNotRegistered {
+		t.Errorf("Test failed. NewExchangeByName expected %s, received %v",
+			ErrExchangeNotRegistered, err)
+	}
+}
@@ -0,0 +1,146 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// simulatedOrderIDPrefix prefixes the order IDs of simulated orders
+const simulatedOrderIDPrefix = "simulated-"
+
+var (
+	errSimulateInvalidAmount = errors.New("order amount must be greater than zero")
+	errSimulateInvalidPrice  = errors.New("limit order price must be greater than zero")
+
+	simulatedOrderID int64
+)
+
+// FillLevel holds the amount of an order filled at one orderbook price level
+type FillLevel struct {
+	Price  float64
+	Amount float64
+}
+
+// SimulatedFill describes how an order would fill against the visible
+// liquidity of an orderbook
+type SimulatedFill struct {
+	Levels          []FillLevel
+	FilledAmount    float64
+	RemainingAmount float64
+	AveragePrice    float64
+	BestPrice       float64
+	// Slippage is the fraction the average fill price is worse than the best
+	// price on the book when the order was placed
+	Slippage float64
+}
+
+// SimulatedOrderResponse is returned by SimulateSubmitOrder
+type SimulatedOrderResponse struct {
+	OrderSubmissionResponse
+	Fill SimulatedFill
+}
+
+// SimulateFill computes how an order would execute against an orderbook. Buy
+// orders walk the asks from the lowest price and sell orders walk the bids from
+// the highest price. Market orders consume levels until the amount is filled,
+// other order types only consume levels at or better than the order price. Any
+// amount the visible liquidity can't fill is returned as remaining
+func SimulateFill(order OrderSubmission, ob orderbook.Base) (SimulatedFill, error) {
+	var fill SimulatedFill
+	if order.Amount <= 0 {
+		return fill, errSimulateInvalidAmount
+	}
+
+	if order.OrderType != Market && order.Price <= 0 {
+		return fill, errSimulateInvalidPrice
+	}
+
+	var levels []orderbook.Item
+	var better func(price float64) bool
+	switch order.OrderSide {
+	case Buy:
+		levels = append(levels, ob.Asks...)
+		sort.Slice(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+		better = func(price float64) bool { return price <= order.Price }
+	case Sell:
+		levels = append(levels, ob.Bids...)
+		sort.Slice(levels, func(i, j int) bool { return levels[i].Price > levels[j].Price })
+		better = func(price float64) bool { return price >= order.Price }
+	default:
+		return fill, fmt.Errorf("unsupported order side %s", order.OrderSide)
+	}
+
+	if len(levels) == 0 {
+		return fill, ErrOrderbookSideEmpty
+	}
+
+	fill.BestPrice = levels[0].Price
+	fill.RemainingAmount = order.Amount
+	var cost float64
+	for x := range levels {
+		if fill.RemainingAmount <= 0 {
+			break
+		}
+
+		if order.OrderType != Market && !better(levels[x].Price) {
+			break
+		}
+
+		if levels[x].Amount <= 0 {
+			continue
+		}
+
+		amount := levels[x].Amount
+		if amount > fill.RemainingAmount {
+			amount = fill.RemainingAmount
+		}
+
+		fill.Levels = append(fill.Levels, FillLevel{Price: levels[x].Price, Amount: amount})
+		fill.FilledAmount += amount
+		fill.RemainingAmount -= amount
+		cost += amount * levels[x].Price
+	}
+
+	if fill.FilledAmount == 0 {
+		return fill, nil
+	}
+
+	fill.AveragePrice = cost / fill.FilledAmount
+	if order.OrderSide == Buy {
+		fill.Slippage = (fill.AveragePrice - fill.BestPrice) / fill.BestPrice
+	} else {
+		fill.Slippage = (fill.BestPrice - fill.AveragePrice) / fill.BestPrice
+	}
+	return fill, nil
+}
+
+// SimulateSubmitOrder is the dry-run counterpart of SubmitOrderWithParams,
+// filling the order against the exchanges stored orderbook instead of placing
+// it. The order is reported as placed unless it is a market order which the
+// orderbook can't fill at all
+func SimulateSubmitOrder(exch IBotExchange, order OrderSubmission) (SimulatedOrderResponse, error) {
+	var resp SimulatedOrderResponse
+	ob, err := exch.GetOrderbookEx(order.Pair, exch.GetDefaultAssetType())
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Fill, err = SimulateFill(order, ob)
+	if err != nil {
+		return resp, err
+	}
+
+	if order.OrderType == Market && resp.Fill.FilledAmount == 0 {
+		return resp, nil
+	}
+
+	resp.IsOrderPlaced = true
+	resp.OrderID = simulatedOrderIDPrefix +
+		strconv.FormatInt(atomic.AddInt64(&simulatedOrderID, 1), 10)
+	return resp, nil
+}
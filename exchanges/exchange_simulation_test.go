@@ -0,0 +1,121 @@
+package exchange
+
+import (
+	"math"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+type simulationTestExchange struct {
+	IBotExchange
+	ob orderbook.Base
+}
+
+func (s *simulationTestExchange) GetDefaultAssetType() string {
+	return "SPOT"
+}
+
+func (s *simulationTestExchange) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	return s.ob, nil
+}
+
+func simulationTestOrderbook() orderbook.Base {
+	return orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}, {Price: 100, Amount: 1}, {Price: 98, Amount: 2}},
+		Asks: []orderbook.Item{{Price: 102, Amount: 2}, {Price: 101, Amount: 1}, {Price: 103, Amount: 1}},
+	}
+}
+
+func TestSimulateFill(t *testing.T) {
+	ob := simulationTestOrderbook()
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	fill, err := SimulateFill(NewOrderSubmission(p, Buy, Market, 2, 0, ""), ob)
+	if err != nil {
+		t.Fatal("Test failed. SimulateFill error", err)
+	}
+
+	if len(fill.Levels) != 2 || fill.FilledAmount != 2 || fill.RemainingAmount != 0 ||
+		fill.BestPrice != 101 || fill.AveragePrice != 101.5 ||
+		math.Abs(fill.Slippage-0.5/101) > 1e-12 {
+		t.Error("Test failed. SimulateFill unexpected market buy fill", fill)
+	}
+
+	fill, err = SimulateFill(NewOrderSubmission(p, Sell, Market, 5, 0, ""), ob)
+	if err != nil {
+		t.Fatal("Test failed. SimulateFill error", err)
+	}
+
+	if fill.FilledAmount != 4 || fill.RemainingAmount != 1 || fill.BestPrice != 100 ||
+		fill.AveragePrice != 98.75 {
+		t.Error("Test failed. SimulateFill unexpected market sell fill", fill)
+	}
+
+	fill, err = SimulateFill(NewOrderSubmission(p, Buy, Limit, 3, 102, ""), ob)
+	if err != nil {
+		t.Fatal("Test failed. SimulateFill error", err)
+	}
+
+	if fill.FilledAmount != 3 || fill.Levels[1].Price != 102 || fill.RemainingAmount != 0 {
+		t.Error("Test failed. SimulateFill unexpected limit buy fill", fill)
+	}
+
+	fill, err = SimulateFill(NewOrderSubmission(p, Sell, Limit, 3, 101, ""), ob)
+	if err != nil {
+		t.Fatal("Test failed. SimulateFill error", err)
+	}
+
+	if fill.FilledAmount != 0 || fill.RemainingAmount != 3 || fill.AveragePrice != 0 {
+		t.Error("Test failed. SimulateFill limit sell above the bids should not fill", fill)
+	}
+
+	_, err = SimulateFill(NewOrderSubmission(p, Buy, Market, 0, 0, ""), ob)
+	if err != errSimulateInvalidAmount {
+		t.Errorf("Test failed. SimulateFill expected %s, received %v", errSimulateInvalidAmount, err)
+	}
+
+	_, err = SimulateFill(NewOrderSubmission(p, Buy, Limit, 1, 0, ""), ob)
+	if err != errSimulateInvalidPrice {
+		t.Errorf("Test failed. SimulateFill expected %s, received %v", errSimulateInvalidPrice, err)
+	}
+
+	_, err = SimulateFill(NewOrderSubmission(p, Buy, Market, 1, 0, ""), orderbook.Base{})
+	if err != ErrOrderbookSideEmpty {
+		t.Errorf("Test failed. SimulateFill expected %s, received %v", ErrOrderbookSideEmpty, err)
+	}
+}
+
+func TestSimulateSubmitOrder(t *testing.T) {
+	exch := &simulationTestExchange{ob: simulationTestOrderbook()}
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	resp, err := SimulateSubmitOrder(exch, NewOrderSubmission(p, Buy, Limit, 2, 100, ""))
+	if err != nil {
+		t.Fatal("Test failed. SimulateSubmitOrder error", err)
+	}
+
+	if !resp.IsOrderPlaced || resp.OrderID == "" || resp.Fill.RemainingAmount != 2 {
+		t.Error("Test failed. SimulateSubmitOrder resting limit order should be placed", resp)
+	}
+
+	next, err := SimulateSubmitOrder(exch, NewOrderSubmission(p, Sell, Market, 1, 0, ""))
+	if err != nil {
+		t.Fatal("Test failed. SimulateSubmitOrder error", err)
+	}
+
+	if !next.IsOrderPlaced || next.OrderID == resp.OrderID || next.Fill.AveragePrice != 100 {
+		t.Error("Test failed. SimulateSubmitOrder unexpected market order response", next)
+	}
+
+	exch.ob.Bids = []orderbook.Item{{Price: 100, Amount: 0}}
+	resp, err = SimulateSubmitOrder(exch, NewOrderSubmission(p, Sell, Market, 1, 0, ""))
+	if err != nil {
+		t.Fatal("Test failed. SimulateSubmitOrder error", err)
+	}
+
+	if resp.IsOrderPlaced {
+		t.Error("Test failed. SimulateSubmitOrder unfilled market order should not be placed")
+	}
+}
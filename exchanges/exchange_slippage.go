@@ -0,0 +1,57 @@
+package exchange
+
+import (
+	"errors"
+)
+
+var (
+	// ErrSlippageExceeded is returned when a market order is estimated to fill
+	// further from the top of the book than the tolerated slippage
+	ErrSlippageExceeded = errors.New("order would exceed the maximum slippage")
+
+	errInvalidMaxSlippage = errors.New("maximum slippage must not be negative")
+)
+
+// CheckSlippage estimates the average fill price of an order from the
+// exchanges stored orderbook and returns ErrSlippageExceeded when it is worse
+// than maxSlippage, a fraction of the top of book price e.g. 0.01 for 1%.
+// Orders the visible liquidity can't completely fill are rejected as the
+// price of the remainder can't be estimated. The estimated fill is returned
+// so callers can report the slippage
+func CheckSlippage(exch IBotExchange, order OrderSubmission, maxSlippage float64) (SimulatedFill, error) {
+	if maxSlippage < 0 {
+		return SimulatedFill{}, errInvalidMaxSlippage
+	}
+
+	ob, err := exch.GetOrderbookEx(order.Pair, exch.GetDefaultAssetType())
+	if err != nil {
+		return SimulatedFill{}, err
+	}
+
+	fill, err := SimulateFill(order, ob)
+	if err != nil {
+		return fill, err
+	}
+
+	if fill.RemainingAmount > 0 || fill.Slippage > maxSlippage {
+		return fill, ErrSlippageExceeded
+	}
+	return fill, nil
+}
+
+// SubmitOrderWithSlippage submits an order with SubmitOrder,
+// rejecting market orders estimated to fill worse than maxSlippage from the
+// top of the book before they are placed. Other order types are submitted
+// unchecked as their price already bounds the fill
+func SubmitOrderWithSlippage(exch IBotExchange, order OrderSubmission, maxSlippage float64) (OrderSubmissionResponse, error) {
+	if IsTradingPaused(exch.GetName()) {
+		return OrderSubmissionResponse{}, ErrTradingPaused
+	}
+
+	if order.OrderType == Market {
+		if _, err := CheckSlippage(exch, order, maxSlippage); err != nil {
+			return OrderSubmissionResponse{}, err
+		}
+	}
+	return SubmitOrder(exch, order)
+}
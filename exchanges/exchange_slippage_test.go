@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+type slippageTestExchange struct {
+	simulationTestExchange
+	submitted int
+}
+
+func (s *slippageTestExchange) GetName() string {
+	return "slippagetest"
+}
+
+func (s *slippageTestExchange) SubmitOrderWithParams(order OrderSubmission) (OrderSubmissionResponse, error) {
+	s.submitted++
+	return OrderSubmissionResponse{IsOrderPlaced: true, OrderID: "1"}, nil
+}
+
+func TestCheckSlippage(t *testing.T) {
+	exch := &slippageTestExchange{}
+	exch.ob = simulationTestOrderbook()
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	// fills 1 @ 101 and 1 @ 102, an average of 101.5 or 0.495% from the top
+	order := NewOrderSubmission(p, Buy, Market, 2, 0, "")
+	fill, err := CheckSlippage(exch, order, 0.005)
+	if err != nil {
+		t.Error("Test failed. CheckSlippage error", err)
+	}
+
+	if fill.AveragePrice != 101.5 {
+		t.Errorf("Test failed. CheckSlippage expected average price 101.5, received %v", fill.AveragePrice)
+	}
+
+	_, err = CheckSlippage(exch, order, 0.004)
+	if err != ErrSlippageExceeded {
+		t.Errorf("Test failed. CheckSlippage expected %s, received %v", ErrSlippageExceeded, err)
+	}
+
+	// the book only holds 4 asks
+	_, err = CheckSlippage(exch, NewOrderSubmission(p, Buy, Market, 5, 0, ""), 1)
+	if err != ErrSlippageExceeded {
+		t.Errorf("Test failed. CheckSlippage expected %s for an unfillable order, received %v",
+			ErrSlippageExceeded, err)
+	}
+
+	_, err = CheckSlippage(exch, order, -1)
+	if err == nil {
+		t.Error("Test failed. CheckSlippage expected an error for a negative maximum slippage")
+	}
+}
+
+func TestSubmitOrderWithSlippage(t *testing.T) {
+	exch := &slippageTestExchange{}
+	exch.ob = simulationTestOrderbook()
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	_, err := SubmitOrderWithSlippage(exch, NewOrderSubmission(p, Sell, Market, 4, 0, ""), 0.01)
+	if err != ErrSlippageExceeded {
+		t.Errorf("Test failed. SubmitOrderWithSlippage expected %s, received %v", ErrSlippageExceeded, err)
+	}
+
+	if exch.submitted != 0 {
+		t.Error("Test failed. SubmitOrderWithSlippage submitted an order exceeding the maximum slippage")
+	}
+
+	resp, err := SubmitOrderWithSlippage(exch, NewOrderSubmission(p, Sell, Market, 1, 0, ""), 0.01)
+	if err != nil || !resp.IsOrderPlaced {
+		t.Error("Test failed. SubmitOrderWithSlippage error", err)
+	}
+
+	// limit orders are bounded by their price and aren't checked
+	resp, err = SubmitOrderWithSlippage(exch, NewOrderSubmission(p, Sell, Limit, 4, 90, ""), 0)
+	if err != nil || !resp.IsOrderPlaced {
+		t.Error("Test failed. SubmitOrderWithSlippage limit order error", err)
+	}
+
+	if exch.submitted != 2 {
+		t.Errorf("Test failed. SubmitOrderWithSlippage expected 2 orders submitted, received %d",
+			exch.submitted)
+	}
+}
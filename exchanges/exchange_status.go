@@ -0,0 +1,83 @@
+package exchange
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// SystemStatus is the operational state reported by an exchange
+type SystemStatus int
+
+// Shared system statuses which exchange specific statuses are mapped to
+const (
+	SystemStatusUnknown SystemStatus = iota
+	SystemStatusOperational
+	SystemStatusDegraded
+	SystemStatusMaintenance
+)
+
+// ErrTradingPaused is returned when trading on an exchange whose last reported
+// system status pauses trading
+var ErrTradingPaused = errors.New("trading paused while exchange is under maintenance")
+
+var (
+	systemStatuses    = make(map[string]SystemStatus)
+	systemStatusesMtx sync.RWMutex
+)
+
+// String returns the system status in string notation
+func (s SystemStatus) String() string {
+	switch s {
+	case SystemStatusOperational:
+		return "operational"
+	case SystemStatusDegraded:
+		return "degraded"
+	case SystemStatusMaintenance:
+		return "maintenance"
+	}
+	return "unknown"
+}
+
+// IsTradingPaused returns whether trading should be paused while the exchange
+// is in this state
+func (s SystemStatus) IsTradingPaused() bool {
+	return s == SystemStatusMaintenance
+}
+
+// SystemStatusGetter is implemented by exchanges which expose their system
+// status
+type SystemStatusGetter interface {
+	GetSystemStatus() (SystemStatus, error)
+}
+
+// GetSystemStatus returns the system status of an exchange, or
+// common.ErrFunctionNotSupported when the exchange does not expose one
+func GetSystemStatus(exch IBotExchange) (SystemStatus, error) {
+	getter, ok := exch.(SystemStatusGetter)
+	if !ok {
+		return SystemStatusUnknown, common.ErrFunctionNotSupported
+	}
+	return getter.GetSystemStatus()
+}
+
+// SetSystemStatus stores the latest system status of an exchange and returns
+// whether it changed
+func SetSystemStatus(exchName string, status SystemStatus) bool {
+	systemStatusesMtx.Lock()
+	defer systemStatusesMtx.Unlock()
+	name := common.StringToLower(exchName)
+	previous, ok := systemStatuses[name]
+	systemStatuses[name] = status
+	return !ok || previous != status
+}
+
+// IsTradingPaused returns whether trading on an exchange is paused by its last
+// stored system status, orders are rejected with ErrTradingPaused until the
+// exchange reports it is no longer under maintenance
+func IsTradingPaused(exchName string) bool {
+	systemStatusesMtx.RLock()
+	defer systemStatusesMtx.RUnlock()
+	return systemStatuses[common.StringToLower(exchName)].IsTradingPaused()
+}
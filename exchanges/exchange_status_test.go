@@ -0,0 +1,78 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+type statusTestExchange struct {
+	IBotExchange
+	status SystemStatus
+}
+
+func (s *statusTestExchange) GetSystemStatus() (SystemStatus, error) {
+	return s.status, nil
+}
+
+func TestGetSystemStatus(t *testing.T) {
+	status, err := GetSystemStatus(&orderbookTestExchange{})
+	if err != common.ErrFunctionNotSupported || status != SystemStatusUnknown {
+		t.Errorf("Test failed. GetSystemStatus expected %s, received %v",
+			common.ErrFunctionNotSupported, err)
+	}
+
+	status, err = GetSystemStatus(&statusTestExchange{status: SystemStatusMaintenance})
+	if err != nil {
+		t.Fatal("Test failed. GetSystemStatus error", err)
+	}
+
+	if status != SystemStatusMaintenance || !status.IsTradingPaused() {
+		t.Errorf("Test failed. GetSystemStatus unexpected status %s", status)
+	}
+
+	if SystemStatusDegraded.IsTradingPaused() || SystemStatusDegraded.String() != "degraded" {
+		t.Error("Test failed. SystemStatusDegraded unexpected values")
+	}
+}
+
+func TestTradingPaused(t *testing.T) {
+	exch := &killSwitchPositionsTestExchange{killSwitchTestExchange{
+		name:    "PausedTest",
+		enabled: true,
+		pairs:   []pair.CurrencyPair{pair.NewCurrencyPair("BTC", "USD")},
+	}}
+
+	if !SetSystemStatus("PausedTest", SystemStatusMaintenance) || !IsTradingPaused("pausedtest") {
+		t.Fatal("Test failed. SetSystemStatus did not pause trading during maintenance")
+	}
+	defer SetSystemStatus("PausedTest", SystemStatusOperational)
+
+	if SetSystemStatus("PausedTest", SystemStatusMaintenance) {
+		t.Error("Test failed. SetSystemStatus reported an unchanged status as changed")
+	}
+
+	order := OrderSubmission{Pair: pair.NewCurrencyPair("BTC", "USD"), OrderSide: Buy, OrderType: Limit, Amount: 1, Price: 1}
+	_, err := SubmitOrder(exch, order)
+	if err != ErrTradingPaused {
+		t.Errorf("Test failed. SubmitOrder expected %s, received %v", ErrTradingPaused, err)
+	}
+
+	_, err = SubmitOrderWithSlippage(exch, order, 0.01)
+	if err != ErrTradingPaused {
+		t.Errorf("Test failed. SubmitOrderWithSlippage expected %s, received %v", ErrTradingPaused, err)
+	}
+
+	report := KillSwitch([]IBotExchange{exch}, true)
+	if len(exch.cancelled) != 0 || len(report.Cancellations) != 1 ||
+		report.Cancellations[0].Err != ErrTradingPaused ||
+		len(report.Positions) != 1 || report.Positions[0].Err != ErrTradingPaused {
+		t.Errorf("Test failed. KillSwitch acted on a paused exchange %+v", report)
+	}
+
+	SetSystemStatus("PausedTest", SystemStatusOperational)
+	if IsTradingPaused("PausedTest") {
+		t.Error("Test failed. IsTradingPaused returned true once operational")
+	}
+}
@@ -0,0 +1,129 @@
+package exchange
+
+import (
+	"sort"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// OrderbookStream is the compact JSON shape of an orderbook for streaming to
+// clients. Each level is a [price, amount] pair ordered from the best price,
+// and Timestamp is the time the orderbook was last updated in unix milliseconds
+type OrderbookStream struct {
+	Exchange  string       `json:"exchange"`
+	Pair      string       `json:"pair"`
+	AssetType string       `json:"asset"`
+	Timestamp int64        `json:"ts"`
+	Bids      [][2]float64 `json:"bids"`
+	Asks      [][2]float64 `json:"asks"`
+}
+
+// TickerStream is the compact JSON shape of a ticker for streaming to clients,
+// Timestamp is the time the ticker was last updated in unix milliseconds
+type TickerStream struct {
+	Exchange  string  `json:"exchange"`
+	Pair      string  `json:"pair"`
+	AssetType string  `json:"asset"`
+	Timestamp int64   `json:"ts"`
+	Last      float64 `json:"last"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Bid       float64 `json:"bid"`
+	Ask       float64 `json:"ask"`
+	Volume    float64 `json:"volume"`
+}
+
+// NewOrderbookStream converts an orderbook to its streaming shape, keeping at
+// most maxLevels levels on each side. A maxLevels of 0 or less keeps every
+// level
+func NewOrderbookStream(exchName string, ob *orderbook.Base, maxLevels int) OrderbookStream {
+	return OrderbookStream{
+		Exchange:  exchName,
+		Pair:      ob.Pair.Pair().String(),
+		AssetType: ob.AssetType,
+		Timestamp: streamTimestamp(ob.LastUpdated),
+		Bids:      streamLevels(ob.Bids, true, maxLevels),
+		Asks:      streamLevels(ob.Asks, false, maxLevels),
+	}
+}
+
+// NewTickerStream converts a ticker to its streaming shape
+func NewTickerStream(exchName string, tick *ticker.Price, assetType string) TickerStream {
+	return TickerStream{
+		Exchange:  exchName,
+		Pair:      tick.Pair.Pair().String(),
+		AssetType: assetType,
+		Timestamp: streamTimestamp(tick.LastUpdated),
+		Last:      tick.Last,
+		High:      tick.High,
+		Low:       tick.Low,
+		Bid:       tick.Bid,
+		Ask:       tick.Ask,
+		Volume:    tick.Volume,
+	}
+}
+
+// MarshalOrderbookStream returns the streaming JSON of an orderbook, keeping at
+// most maxLevels levels on each side
+func MarshalOrderbookStream(exchName string, ob *orderbook.Base, maxLevels int) ([]byte, error) {
+	return common.JSONEncode(NewOrderbookStream(exchName, ob, maxLevels))
+}
+
+// MarshalTickerStream returns the streaming JSON of a ticker
+func MarshalTickerStream(exchName string, tick *ticker.Price, assetType string) ([]byte, error) {
+	return common.JSONEncode(NewTickerStream(exchName, tick, assetType))
+}
+
+// MarshalStoredOrderbook returns the streaming JSON of the orderbook stored for
+// an exchange, currency pair and asset type
+func MarshalStoredOrderbook(exchName string, p pair.CurrencyPair, assetType string, maxLevels int) ([]byte, error) {
+	ob, err := orderbook.GetOrderbook(exchName, p, assetType)
+	if err != nil {
+		return nil, err
+	}
+	return MarshalOrderbookStream(exchName, &ob, maxLevels)
+}
+
+// MarshalStoredTicker returns the streaming JSON of the ticker stored for an
+// exchange, currency pair and asset type
+func MarshalStoredTicker(exchName string, p pair.CurrencyPair, assetType string) ([]byte, error) {
+	tick, err := ticker.GetTicker(exchName, p, assetType)
+	if err != nil {
+		return nil, err
+	}
+	return MarshalTickerStream(exchName, &tick, assetType)
+}
+
+// streamLevels converts the best maxLevels orderbook items to [price, amount]
+// pairs, bids ordered by descending price and asks by ascending price. An
+// empty side is encoded as an empty array rather than null
+func streamLevels(items []orderbook.Item, bids bool, maxLevels int) [][2]float64 {
+	levels := make([][2]float64, len(items))
+	for x := range items {
+		levels[x] = [2]float64{items[x].Price, items[x].Amount}
+	}
+
+	sort.SliceStable(levels, func(i, j int) bool {
+		if bids {
+			return levels[i][0] > levels[j][0]
+		}
+		return levels[i][0] < levels[j][0]
+	})
+
+	if maxLevels > 0 && len(levels) > maxLevels {
+		levels = levels[:maxLevels]
+	}
+	return levels
+}
+
+// streamTimestamp returns t in unix milliseconds, or 0 when t is not set
+func streamTimestamp(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,96 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+func TestMarshalOrderbookStream(t *testing.T) {
+	ob := orderbook.Base{
+		Pair:        pair.NewCurrencyPair("BTC", "USD"),
+		Bids:        []orderbook.Item{{Price: 99, Amount: 1}, {Price: 100, Amount: 2}, {Price: 98, Amount: 3}},
+		Asks:        []orderbook.Item{{Price: 102, Amount: 1}, {Price: 101, Amount: 4.5}},
+		LastUpdated: time.Unix(1540000000, 123000000),
+		AssetType:   orderbook.Spot,
+	}
+
+	data, err := MarshalOrderbookStream("Bitstamp", &ob, 2)
+	if err != nil {
+		t.Fatal("Test failed. MarshalOrderbookStream error", err)
+	}
+
+	expected := `{"exchange":"Bitstamp","pair":"BTCUSD","asset":"SPOT","ts":1540000000123,"bids":[[100,2],[99,1]],"asks":[[101,4.5],[102,1]]}`
+	if string(data) != expected {
+		t.Errorf("Test failed. MarshalOrderbookStream expected %s, received %s", expected, data)
+	}
+
+	data, err = MarshalOrderbookStream("Bitstamp", &orderbook.Base{Pair: ob.Pair}, 0)
+	if err != nil {
+		t.Fatal("Test failed. MarshalOrderbookStream error", err)
+	}
+
+	expected = `{"exchange":"Bitstamp","pair":"BTCUSD","asset":"","ts":0,"bids":[],"asks":[]}`
+	if string(data) != expected {
+		t.Errorf("Test failed. MarshalOrderbookStream expected %s, received %s", expected, data)
+	}
+
+	if len(NewOrderbookStream("Bitstamp", &ob, 0).Bids) != 3 {
+		t.Error("Test failed. NewOrderbookStream capped levels without a maximum")
+	}
+}
+
+func TestMarshalTickerStream(t *testing.T) {
+	tick := ticker.Price{
+		Pair:        pair.NewCurrencyPair("BTC", "USD"),
+		LastUpdated: time.Unix(1540000000, 0),
+		Last:        100,
+		High:        110,
+		Low:         90,
+		Bid:         99,
+		Ask:         101,
+		Volume:      1000,
+	}
+
+	data, err := MarshalTickerStream("Bitstamp", &tick, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test failed. MarshalTickerStream error", err)
+	}
+
+	expected := `{"exchange":"Bitstamp","pair":"BTCUSD","asset":"SPOT","ts":1540000000000,"last":100,"high":110,"low":90,"bid":99,"ask":101,"volume":1000}`
+	if string(data) != expected {
+		t.Errorf("Test failed. MarshalTickerStream expected %s, received %s", expected, data)
+	}
+}
+
+func TestMarshalStoredOrderbook(t *testing.T) {
+	p := pair.NewCurrencyPair("LTC", "EUR")
+	_, err := MarshalStoredOrderbook("streamtest", p, orderbook.Spot, 10)
+	if err == nil {
+		t.Error("Test failed. MarshalStoredOrderbook expected an error for a missing orderbook")
+	}
+
+	orderbook.ProcessOrderbook("streamtest", p, orderbook.Base{
+		Pair: p,
+		Bids: []orderbook.Item{{Price: 50, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 51, Amount: 1}},
+	}, orderbook.Spot)
+
+	data, err := MarshalStoredOrderbook("streamtest", p, orderbook.Spot, 10)
+	if err != nil {
+		t.Fatal("Test failed. MarshalStoredOrderbook error", err)
+	}
+
+	var stream OrderbookStream
+	if err = common.JSONDecode(data, &stream); err != nil {
+		t.Fatal("Test failed. MarshalStoredOrderbook returned invalid JSON", err)
+	}
+
+	if stream.Pair != "LTCEUR" || len(stream.Bids) != 1 || stream.Asks[0][0] != 51 {
+		t.Errorf("Test failed. MarshalStoredOrderbook unexpected stream %+v", stream)
+	}
+}
@@ -0,0 +1,90 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// DefaultMarketSummaryMaxAge is the maximum age of cached ticker and orderbook
+// data before GetMarketSummary refreshes it
+const DefaultMarketSummaryMaxAge = time.Second * 30
+
+// MarketSummary combines the ticker and top of the orderbook for a currency
+// pair
+type MarketSummary struct {
+	Exchange         string
+	Pair             pair.CurrencyPair
+	AssetType        string
+	Last             float64
+	Bid              float64
+	Ask              float64
+	High             float64
+	Low              float64
+	Volume           float64
+	BestBid          orderbook.Item
+	BestAsk          orderbook.Item
+	TickerUpdated    time.Time
+	OrderbookUpdated time.Time
+}
+
+// GetMarketSummary returns a market summary for the supplied currency pair
+// and asset type composed from the cached ticker and orderbook. Cached data
+// which is missing or older than maxAge is refreshed from the exchange first
+func GetMarketSummary(exch IBotExchange, p pair.CurrencyPair, assetType string, maxAge time.Duration) (MarketSummary, error) {
+	if maxAge <= 0 {
+		maxAge = DefaultMarketSummaryMaxAge
+	}
+
+	tick, err := ticker.GetTicker(exch.GetName(), p, assetType)
+	if err != nil || tick.IsStale(maxAge) {
+		tick, err = exch.UpdateTicker(p, assetType)
+		if err != nil {
+			return MarketSummary{}, err
+		}
+	}
+
+	ob, err := orderbook.GetOrderbook(exch.GetName(), p, assetType)
+	if err != nil || ob.IsStale(maxAge) {
+		ob, err = exch.UpdateOrderbook(p, assetType)
+		if err != nil {
+			return MarketSummary{}, err
+		}
+	}
+
+	bestBid, bestAsk := topOfBook(&ob)
+	return MarketSummary{
+		Exchange:         exch.GetName(),
+		Pair:             p,
+		AssetType:        assetType,
+		Last:             tick.Last,
+		Bid:              tick.Bid,
+		Ask:              tick.Ask,
+		High:             tick.High,
+		Low:              tick.Low,
+		Volume:           tick.Volume,
+		BestBid:          bestBid,
+		BestAsk:          bestAsk,
+		TickerUpdated:    tick.LastUpdated,
+		OrderbookUpdated: ob.LastUpdated,
+	}, nil
+}
+
+// topOfBook returns the highest bid and lowest ask of an orderbook. Orderbook
+// sides are not guaranteed to be sorted so each side is scanned
+func topOfBook(ob *orderbook.Base) (bestBid, bestAsk orderbook.Item) {
+	for x := range ob.Bids {
+		if x == 0 || ob.Bids[x].Price > bestBid.Price {
+			bestBid = ob.Bids[x]
+		}
+	}
+
+	for x := range ob.Asks {
+		if x == 0 || ob.Asks[x].Price < bestAsk.Price {
+			bestAsk = ob.Asks[x]
+		}
+	}
+	return bestBid, bestAsk
+}
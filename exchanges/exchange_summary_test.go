@@ -0,0 +1,28 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+func TestTopOfBook(t *testing.T) {
+	ob := orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}, {Price: 100, Amount: 2}, {Price: 98, Amount: 3}},
+		Asks: []orderbook.Item{{Price: 102, Amount: 1}, {Price: 101, Amount: 4}, {Price: 103, Amount: 5}},
+	}
+
+	bestBid, bestAsk := topOfBook(&ob)
+	if bestBid.Price != 100 || bestBid.Amount != 2 {
+		t.Errorf("Test failed. topOfBook unexpected best bid %+v", bestBid)
+	}
+
+	if bestAsk.Price != 101 || bestAsk.Amount != 4 {
+		t.Errorf("Test failed. topOfBook unexpected best ask %+v", bestAsk)
+	}
+
+	bestBid, bestAsk = topOfBook(&orderbook.Base{})
+	if bestBid != (orderbook.Item{}) || bestAsk != (orderbook.Item{}) {
+		t.Error("Test failed. topOfBook expected empty items for an empty orderbook")
+	}
+}
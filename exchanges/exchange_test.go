@@ -1,7 +1,12 @@
 package exchange
 
 import (
+	"bytes"
+	"errors"
+	"log"
 	"net/http"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,6 +28,60 @@ func TestSupportsRESTTickerBatchUpdates(t *testing.T) {
 	}
 }
 
+func TestSetHTTPRateLimiter(t *testing.T) {
+	b := Base{Name: "RAWR"}
+	err := b.SetHTTPRateLimiter(nil)
+	if err != nil {
+		t.Error("Test failed. SetHTTPRateLimiter nil config error", err)
+	}
+
+	err = b.SetHTTPRateLimiter(&config.HTTPRateLimitConfig{AuthRate: 1, UnauthRate: 1})
+	if err == nil {
+		t.Error("Test failed. SetHTTPRateLimiter accepted a zero duration")
+	}
+
+	err = b.SetHTTPRateLimiter(&config.HTTPRateLimitConfig{
+		Duration:   time.Second * 2,
+		AuthRate:   3,
+		UnauthRate: 5,
+	})
+	if err != nil {
+		t.Fatal("Test failed. SetHTTPRateLimiter error", err)
+	}
+
+	if b.Requester.AuthLimit.GetRate() != 3 ||
+		b.Requester.UnauthLimit.GetRate() != 5 ||
+		b.Requester.AuthLimit.GetDuration() != time.Second*2 ||
+		b.Requester.UnauthLimit.GetDuration() != time.Second*2 {
+		t.Error("Test failed. SetHTTPRateLimiter unexpected rate limits")
+	}
+}
+
+func TestSetHTTPRequestTimeouts(t *testing.T) {
+	b := Base{Name: "RAWR"}
+	b.SetHTTPRequestTimeouts(nil)
+	if b.Requester != nil {
+		t.Error("Test failed. SetHTTPRequestTimeouts nil config should not create a requester")
+	}
+
+	b.SetHTTPRequestTimeouts(&config.HTTPRequestTimeoutsConfig{
+		Trade:   time.Second * 5,
+		History: time.Minute,
+	})
+
+	if d, ok := b.Requester.GetTimeout(request.Trade); !ok || d != time.Second*5 {
+		t.Error("Test failed. SetHTTPRequestTimeouts unexpected trade timeout")
+	}
+
+	if d, ok := b.Requester.GetTimeout(request.History); !ok || d != time.Minute {
+		t.Error("Test failed. SetHTTPRequestTimeouts unexpected history timeout")
+	}
+
+	if _, ok := b.Requester.GetTimeout(request.MarketData); ok {
+		t.Error("Test failed. SetHTTPRequestTimeouts unset market data timeout was applied")
+	}
+}
+
 func TestHTTPClient(t *testing.T) {
 	r := Base{Name: "asdf"}
 	r.SetHTTPClientTimeout(time.Duration(time.Second * 5))
@@ -181,6 +240,50 @@ func TestSupportsAutoPairUpdates(t *testing.T) {
 	}
 }
 
+func TestSetFeatureEnabled(t *testing.T) {
+	b := Base{
+		Name:                     "TESTNAME",
+		SupportsAutoPairUpdating: true,
+		AvailablePairs:           []string{"BTCUSD"},
+	}
+
+	if !b.IsFeatureEnabled(FeatureAutoPairUpdates) {
+		t.Error("Test failed. TestSetFeatureEnabled auto pair updates should be enabled by default")
+	}
+
+	if b.IsFeatureEnabled(FeatureRESTTickerBatching) {
+		t.Error("Test failed. TestSetFeatureEnabled unsupported feature should not be enabled")
+	}
+
+	err := b.SetFeatureEnabled(FeatureRESTTickerBatching, true)
+	if err == nil {
+		t.Error("Test failed. TestSetFeatureEnabled enabled an unsupported feature")
+	}
+
+	err = b.SetFeatureEnabled("bla", false)
+	if err == nil {
+		t.Error("Test failed. TestSetFeatureEnabled accepted an unknown feature")
+	}
+
+	err = b.SetFeatureEnabled(FeatureAutoPairUpdates, false)
+	if err != nil {
+		t.Error("Test failed. TestSetFeatureEnabled error", err)
+	}
+
+	if b.IsFeatureEnabled(FeatureAutoPairUpdates) {
+		t.Error("Test failed. TestSetFeatureEnabled auto pair updates should be disabled")
+	}
+
+	err = b.SetFeatureEnabled(FeatureAutoPairUpdates, true)
+	if err != nil {
+		t.Error("Test failed. TestSetFeatureEnabled error", err)
+	}
+
+	if !b.IsFeatureEnabled(FeatureAutoPairUpdates) {
+		t.Error("Test failed. TestSetFeatureEnabled auto pair updates should be re-enabled")
+	}
+}
+
 func TestGetLastPairsUpdateTime(t *testing.T) {
 	testTime := time.Now().Unix()
 	b := Base{
@@ -257,6 +360,94 @@ func TestGetAssetTypes(t *testing.T) {
 	}
 }
 
+func TestSetDefaultAssetType(t *testing.T) {
+	cfg := config.GetConfig()
+	err := cfg.LoadConfig(config.ConfigTestFile)
+	if err != nil {
+		t.Fatalf("Test failed. TestSetDefaultAssetType failed to load config file. Error: %s", err)
+	}
+
+	exch, err := cfg.GetExchangeConfig("ANX")
+	if err != nil {
+		t.Fatalf("Test failed. TestSetDefaultAssetType load config failed. Error %s", err)
+	}
+
+	b := Base{
+		Name:       "ANX",
+		AssetTypes: []string{"SPOT", "FUTURES"},
+	}
+
+	exch.DefaultAssetType = "BINARY"
+	err = cfg.UpdateExchangeConfig(exch)
+	if err != nil {
+		t.Fatalf("Test failed. TestSetDefaultAssetType update config failed. Error %s", err)
+	}
+
+	err = b.SetAssetTypes()
+	if err == nil {
+		t.Error("Test failed. SetAssetTypes accepted an unsupported default asset type")
+	}
+
+	exch.DefaultAssetType = "FUTURES"
+	err = cfg.UpdateExchangeConfig(exch)
+	if err != nil {
+		t.Fatalf("Test failed. TestSetDefaultAssetType update config failed. Error %s", err)
+	}
+
+	err = b.SetAssetTypes()
+	if err != nil {
+		t.Fatalf("Test failed. TestSetDefaultAssetType. Error %s", err)
+	}
+
+	if b.GetDefaultAssetType() != "FUTURES" {
+		t.Errorf("Test failed. GetDefaultAssetType expected FUTURES, received %s",
+			b.GetDefaultAssetType())
+	}
+
+	if b.GetAssetTypeOrDefault("") != "FUTURES" || b.GetAssetTypeOrDefault("SPOT") != "SPOT" {
+		t.Error("Test failed. GetAssetTypeOrDefault unexpected asset type")
+	}
+
+	exch.DefaultAssetType = ""
+	err = cfg.UpdateExchangeConfig(exch)
+	if err != nil {
+		t.Fatalf("Test failed. TestSetDefaultAssetType update config failed. Error %s", err)
+	}
+}
+
+func TestGetDefaultAssetType(t *testing.T) {
+	b := Base{}
+	if b.GetDefaultAssetType() != ticker.Spot {
+		t.Error("Test failed. GetDefaultAssetType expected SPOT with no asset types set")
+	}
+
+	b.AssetTypes = []string{"FUTURES", "SPOT"}
+	if b.GetDefaultAssetType() != "FUTURES" {
+		t.Error("Test failed. GetDefaultAssetType expected the first asset type")
+	}
+}
+
+func TestValidateAssetType(t *testing.T) {
+	b := Base{Name: "test"}
+	assetType, err := b.ValidateAssetType("")
+	if err != nil || assetType != ticker.Spot {
+		t.Errorf("Test failed. ValidateAssetType expected SPOT with no asset types set, received %s %v",
+			assetType, err)
+	}
+
+	b.AssetTypes = []string{ticker.Spot, "FUTURES"}
+	assetType, err = b.ValidateAssetType("FUTURES")
+	if err != nil || assetType != "FUTURES" {
+		t.Errorf("Test failed. ValidateAssetType unexpected result %s %v", assetType, err)
+	}
+
+	_, err = b.ValidateAssetType("BINARY")
+	if !errors.Is(err, ErrAssetTypeNotSupported) {
+		t.Errorf("Test failed. ValidateAssetType expected %s, received %v",
+			ErrAssetTypeNotSupported, err)
+	}
+}
+
 func TestGetExchangeAssetTypes(t *testing.T) {
 	cfg := config.GetConfig()
 	err := cfg.LoadConfig(config.ConfigTestFile)
@@ -386,6 +577,33 @@ func TestGetName(t *testing.T) {
 	}
 }
 
+func TestGetAllEnabledPairs(t *testing.T) {
+	b := Base{
+		Name:                     "TESTNAME",
+		AssetTypes:               []string{ticker.Spot, "FUTURES"},
+		EnabledPairs:             []string{"LTC-USD", "BTC-USD"},
+		ConfigCurrencyPairFormat: config.CurrencyPairFormatConfig{Delimiter: "-"},
+	}
+
+	all := b.GetAllEnabledPairs()
+	if len(all) != 2 || len(all[ticker.Spot]) != 2 || len(all["FUTURES"]) != 2 {
+		t.Fatal("Test Failed - Exchange GetAllEnabledPairs() unexpected result", all)
+	}
+
+	assetPairs := b.GetAllEnabledAssetPairs()
+	if len(assetPairs) != 4 {
+		t.Fatalf("Test Failed - Exchange GetAllEnabledAssetPairs() expected 4 pairs, received %d",
+			len(assetPairs))
+	}
+
+	if assetPairs[0].AssetType != ticker.Spot ||
+		assetPairs[0].Pair.Pair().String() != "BTC-USD" ||
+		assetPairs[3].AssetType != "FUTURES" ||
+		assetPairs[3].Pair.Pair().String() != "LTC-USD" {
+		t.Error("Test Failed - Exchange GetAllEnabledAssetPairs() incorrect ordering", assetPairs)
+	}
+}
+
 func TestGetEnabledCurrencies(t *testing.T) {
 	b := Base{
 		Name: "TESTNAME",
@@ -621,6 +839,46 @@ func TestFormatExchangeCurrency(t *testing.T) {
 	}
 }
 
+func TestFormatExchangeCurrencyRoundTrip(t *testing.T) {
+	cfg := config.GetConfig()
+	err := cfg.LoadConfig(config.ConfigTestFile)
+	if err != nil {
+		t.Fatalf("Failed to load config file. Error: %s", err)
+	}
+
+	for _, exch := range cfg.GetAllExchangeConfigs() {
+		pairs, err := cfg.GetAvailablePairs(exch.Name)
+		if err != nil {
+			t.Errorf("Test failed - Exchange TestFormatExchangeCurrencyRoundTrip %s error: %s",
+				exch.Name, err)
+			continue
+		}
+
+		for x := range pairs {
+			formatted := FormatExchangeCurrency(exch.Name, pairs[x]).String()
+			result, err := ParseExchangeCurrency(exch.Name, formatted)
+			if err != nil {
+				t.Errorf("Test failed - Exchange TestFormatExchangeCurrencyRoundTrip %s %s formatted as %s error: %s",
+					exch.Name, pairs[x].Pair(), formatted, err)
+				continue
+			}
+
+			if !result.Equal(pairs[x], true) {
+				t.Errorf("Test failed - Exchange TestFormatExchangeCurrencyRoundTrip %s %s formatted as %s parsed as %s",
+					exch.Name, pairs[x].Pair(), formatted, result.Pair())
+			}
+		}
+	}
+
+	if _, err = ParseExchangeCurrency("CoinbasePro", "BTCUSD"); err == nil {
+		t.Error("Test failed - Exchange ParseExchangeCurrency missing delimiter expected error")
+	}
+
+	if _, err = ParseExchangeCurrency("asdasd", "BTCUSD"); err == nil {
+		t.Error("Test failed - Exchange ParseExchangeCurrency non-existent exchange expected error")
+	}
+}
+
 func TestFormatCurrency(t *testing.T) {
 	cfg := config.GetConfig()
 	err := cfg.LoadConfig(config.ConfigTestFile)
@@ -680,6 +938,55 @@ func TestSetAPIKeys(t *testing.T) {
 	SetAPIKeys.SetAPIKeys("RocketMan", "Digereedoo", "007", true)
 }
 
+func TestSetAPIKeysFromEnvironment(t *testing.T) {
+	t.Setenv("TESTNAME_API_KEY", "RocketMan")
+	t.Setenv("GCT_TEST_SECRET", "Digereedoo")
+	t.Setenv("TESTNAME_API_PASSPHRASE", "007")
+
+	b := Base{Name: "TESTNAME", AuthenticatedAPISupport: true}
+	b.SetAPIKeys("", "env:GCT_TEST_SECRET", "", false)
+	if b.APIKey != "RocketMan" || b.APISecret != "Digereedoo" || b.ClientID != "007" {
+		t.Errorf("Test Failed - SetAPIKeys() did not resolve credentials from the environment %s %s %s",
+			b.APIKey, b.APISecret, b.ClientID)
+	}
+
+	b.SetAPIKeys("Key", "env:GCT_TEST_UNSET", "client", false)
+	if b.APIKey != "Key" || b.APISecret != "" || b.ClientID != "client" {
+		t.Errorf("Test Failed - SetAPIKeys() unexpected credentials %s %s %s",
+			b.APIKey, b.APISecret, b.ClientID)
+	}
+}
+
+func TestSetAPIKeysLogsSourceOnce(t *testing.T) {
+	t.Setenv("TESTLOG_API_KEY", "RocketMan")
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	b := Base{Name: "TESTLOG", AuthenticatedAPISupport: true}
+	b.SetAPIKeys("", "Digereedoo", "007", false)
+	if buf.Len() != 0 {
+		t.Errorf("Test Failed - SetAPIKeys() logged the credential source without verbose mode %s", buf.String())
+	}
+
+	b.Verbose = true
+	b.SetAPIKeys("", "Digereedoo", "007", false)
+	logged := buf.String()
+	if !strings.Contains(logged, "TESTLOG API key loaded from") {
+		t.Errorf("Test Failed - SetAPIKeys() did not log the credential source in verbose mode %s", logged)
+	}
+
+	b.SetAPIKeys("", "Digereedoo", "007", false)
+	if buf.String() != logged {
+		t.Errorf("Test Failed - SetAPIKeys() logged the credential sources again %s", buf.String())
+	}
+
+	if strings.Contains(buf.String(), "RocketMan") {
+		t.Error("Test Failed - SetAPIKeys() logged a credential value")
+	}
+}
+
 func TestSetCurrencies(t *testing.T) {
 	cfg := config.GetConfig()
 	err := cfg.LoadConfig(config.ConfigTestFile)
@@ -802,6 +1109,119 @@ func TestUpdateCurrencies(t *testing.T) {
 	}
 }
 
+func TestUpdateCurrenciesUnchanged(t *testing.T) {
+	cfg := config.GetConfig()
+	err := cfg.LoadConfig(config.ConfigTestFile)
+	if err != nil {
+		t.Fatal("Test failed. TestUpdateCurrenciesUnchanged failed to load config")
+	}
+
+	exch, err := cfg.GetExchangeConfig("ANX")
+	if err != nil {
+		t.Fatal("Test failed. TestUpdateCurrenciesUnchanged failed to get exchange config", err)
+	}
+
+	UAC := Base{Name: "ANX", AvailablePairs: []string{"BTCUSD", "LTCUSD"}}
+	exch.AvailablePairs = "ETHUSD"
+	err = cfg.UpdateExchangeConfig(exch)
+	if err != nil {
+		t.Fatal("Test failed. TestUpdateCurrenciesUnchanged failed to update exchange config", err)
+	}
+
+	for _, force := range []bool{false, true} {
+		err = UAC.UpdateCurrencies([]string{"ltcusd", "btcusd"}, false, force)
+		if err != nil {
+			t.Error("Test failed. TestUpdateCurrenciesUnchanged error", err)
+		}
+
+		exch, err = cfg.GetExchangeConfig("ANX")
+		if err != nil {
+			t.Fatal("Test failed. TestUpdateCurrenciesUnchanged failed to get exchange config", err)
+		}
+
+		if exch.AvailablePairs != "ETHUSD" {
+			t.Errorf("Test failed. TestUpdateCurrenciesUnchanged config written for unchanged pairs, force %v",
+				force)
+		}
+	}
+
+	UAC.AvailablePairs = []string{"btcusd", "LTCUSD"}
+	err = UAC.UpdateCurrencies([]string{"ltcusd", "btcusd"}, false, true)
+	if err != nil {
+		t.Error("Test failed. TestUpdateCurrenciesUnchanged error", err)
+	}
+
+	exch, err = cfg.GetExchangeConfig("ANX")
+	if err != nil {
+		t.Fatal("Test failed. TestUpdateCurrenciesUnchanged failed to get exchange config", err)
+	}
+
+	if exch.AvailablePairs != "LTCUSD,BTCUSD" {
+		t.Errorf("Test failed. TestUpdateCurrenciesUnchanged forced update not written, received %s",
+			exch.AvailablePairs)
+	}
+}
+
+func TestUpdateCurrenciesBlacklist(t *testing.T) {
+	cfg := config.GetConfig()
+	err := cfg.LoadConfig(config.ConfigTestFile)
+	if err != nil {
+		t.Fatal("Test failed. TestUpdateCurrenciesBlacklist failed to load config")
+	}
+
+	exch, err := cfg.GetExchangeConfig("ANX")
+	if err != nil {
+		t.Fatal("Test failed. TestUpdateCurrenciesBlacklist failed to get exchange config", err)
+	}
+
+	exch.PairsBlacklist = []string{"*DOGE"}
+	err = cfg.UpdateExchangeConfig(exch)
+	if err != nil {
+		t.Fatal("Test failed. TestUpdateCurrenciesBlacklist failed to update exchange config", err)
+	}
+
+	UAC := Base{Name: "ANX"}
+	err = UAC.UpdateCurrencies([]string{"BTCUSD", "BTCDOGE", "LTCDOGE"}, false, false)
+	if err != nil {
+		t.Error("Test failed. TestUpdateCurrenciesBlacklist error", err)
+	}
+
+	if len(UAC.AvailablePairs) != 1 || UAC.AvailablePairs[0] != "BTCUSD" {
+		t.Errorf("Test failed. TestUpdateCurrenciesBlacklist blacklisted pairs stored %s",
+			UAC.AvailablePairs)
+	}
+
+	err = UAC.UpdateCurrencies([]string{"BTCDOGE"}, false, false)
+	if err == nil {
+		t.Error("Test failed. TestUpdateCurrenciesBlacklist expected an error when every pair is blacklisted")
+	}
+}
+
+func TestRetryPairUpdate(t *testing.T) {
+	var calls int
+	err := retryPairUpdate("test", 3, time.Millisecond, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("exchange unreachable")
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("Test failed. retryPairUpdate expected success on the third attempt, received %v after %d calls",
+			err, calls)
+	}
+
+	calls = 0
+	err = retryPairUpdate("test", 2, time.Millisecond, func() error {
+		calls++
+		return errors.New("exchange unreachable")
+	})
+	if err == nil || calls != 2 {
+		t.Errorf("Test failed. retryPairUpdate expected an error after 2 attempts, received %v after %d calls",
+			err, calls)
+	}
+}
+
 func TestAPIURL(t *testing.T) {
 	testURL := "https://api.something.com"
 	testURLSecondary := "https://api.somethingelse.com"
@@ -915,6 +1335,106 @@ func TestFormatWithdrawPermissions(t *testing.T) {
 	}
 }
 
+func TestCheckWithdrawPermissions(t *testing.T) {
+	UAC := Base{Name: "ANX"}
+
+	UAC.APIWithdrawPermissions = WithdrawCryptoViaWebsiteOnly | AutoWithdrawFiat
+	if err := UAC.CheckCryptoWithdrawPermissions(); err != ErrCryptoWithdrawViaWebsiteOnly {
+		t.Errorf("Expected: %s, Recieved: %v", ErrCryptoWithdrawViaWebsiteOnly, err)
+	}
+	if err := UAC.CheckFiatWithdrawPermissions(); err != nil {
+		t.Errorf("Expected no error, Recieved: %s", err)
+	}
+
+	UAC.APIWithdrawPermissions = AutoWithdrawCryptoWithSetup | WithdrawFiatViaWebsiteOnly
+	if err := UAC.CheckCryptoWithdrawPermissions(); err != nil {
+		t.Errorf("Expected no error, Recieved: %s", err)
+	}
+	if err := UAC.CheckFiatWithdrawPermissions(); err != ErrFiatWithdrawViaWebsiteOnly {
+		t.Errorf("Expected: %s, Recieved: %v", ErrFiatWithdrawViaWebsiteOnly, err)
+	}
+
+	UAC.APIWithdrawPermissions = NoAPIWithdrawalMethods
+	if err := UAC.CheckCryptoWithdrawPermissions(); err != ErrCryptoWithdrawViaWebsiteOnly {
+		t.Errorf("Expected: %s, Recieved: %v", ErrCryptoWithdrawViaWebsiteOnly, err)
+	}
+	if err := UAC.CheckFiatWithdrawPermissions(); err != ErrFiatWithdrawViaWebsiteOnly {
+		t.Errorf("Expected: %s, Recieved: %v", ErrFiatWithdrawViaWebsiteOnly, err)
+	}
+}
+
+func TestWithdrawPermissionHelpers(t *testing.T) {
+	UAC := Base{Name: "ANX"}
+
+	testCases := []struct {
+		permissions              uint32
+		cryptoViaAPI, fiatViaAPI bool
+		autoCrypto, autoFiat     bool
+	}{
+		{NoAPIWithdrawalMethods, false, false, false, false},
+		{WithdrawCryptoViaWebsiteOnly | WithdrawFiatViaWebsiteOnly, false, false, false, false},
+		{AutoWithdrawCrypto | WithdrawFiatViaWebsiteOnly, true, false, true, false},
+		{WithdrawCryptoViaWebsiteOnly | AutoWithdrawFiat, false, true, false, true},
+		{AutoWithdrawCryptoWithSetup | WithdrawCryptoWith2FA | AutoWithdrawFiatWithSetup | WithdrawFiatWith2FA, true, true, true, true},
+		{WithdrawCryptoWithEmail | WithdrawFiatWithAPIPermission, true, true, false, false},
+		{AutoWithdrawCryptoWithAPIPermission | WithdrawCryptoWithEmail | WithdrawCryptoWith2FA, true, false, true, false},
+	}
+
+	for x := range testCases {
+		UAC.APIWithdrawPermissions = testCases[x].permissions
+		if UAC.CanWithdrawCryptoViaAPI() != testCases[x].cryptoViaAPI {
+			t.Errorf("Test failed. CanWithdrawCryptoViaAPI %s expected %v",
+				UAC.FormatWithdrawPermissions(), testCases[x].cryptoViaAPI)
+		}
+		if UAC.CanWithdrawFiatViaAPI() != testCases[x].fiatViaAPI {
+			t.Errorf("Test failed. CanWithdrawFiatViaAPI %s expected %v",
+				UAC.FormatWithdrawPermissions(), testCases[x].fiatViaAPI)
+		}
+		if UAC.CanAutoWithdrawCrypto() != testCases[x].autoCrypto {
+			t.Errorf("Test failed. CanAutoWithdrawCrypto %s expected %v",
+				UAC.FormatWithdrawPermissions(), testCases[x].autoCrypto)
+		}
+		if UAC.CanAutoWithdrawFiat() != testCases[x].autoFiat {
+			t.Errorf("Test failed. CanAutoWithdrawFiat %s expected %v",
+				UAC.FormatWithdrawPermissions(), testCases[x].autoFiat)
+		}
+	}
+}
+
+func TestNewOrderSubmission(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	order := NewOrderSubmission(p, Buy, Limit, 1.5, 1000, "1337")
+	if order.Pair != p || order.OrderSide != Buy || order.OrderType != Limit ||
+		order.Amount != 1.5 || order.Price != 1000 || order.ClientID != "1337" {
+		t.Errorf("Test failed. NewOrderSubmission unexpected values %+v", order)
+	}
+
+	resp := OrderSubmissionResponse{IsOrderPlaced: true, OrderID: "1"}.SubmitOrderResponse()
+	if !resp.IsOrderPlaced || resp.OrderID != "1" {
+		t.Errorf("Test failed. SubmitOrderResponse unexpected values %+v", resp)
+	}
+}
+
+func TestNewClientOrderID(t *testing.T) {
+	id, err := NewClientOrderID()
+	if err != nil {
+		t.Fatal("Test failed. NewClientOrderID error", err)
+	}
+
+	if len(id) != 29 || id[:len(clientOrderIDPrefix)] != clientOrderIDPrefix {
+		t.Errorf("Test failed. NewClientOrderID unexpected ID %s", id)
+	}
+
+	other, err := NewClientOrderID()
+	if err != nil {
+		t.Fatal("Test failed. NewClientOrderID error", err)
+	}
+
+	if id == other {
+		t.Error("Test failed. NewClientOrderID returned the same ID twice")
+	}
+}
+
 func TestOrderTypes(t *testing.T) {
 	var ot OrderType = "Mo'Money"
 
@@ -928,3 +1448,38 @@ func TestOrderTypes(t *testing.T) {
 		t.Errorf("test failed - unexpected string %s", os.ToString())
 	}
 }
+
+func TestTradeSideFromString(t *testing.T) {
+	tests := map[string]OrderSide{
+		"buy":  Buy,
+		"BUY":  Buy,
+		"bid":  Buy,
+		"sell": Sell,
+		"Sell": Sell,
+		"ask":  Sell,
+	}
+
+	for side, expected := range tests {
+		result, err := TradeSideFromString(side)
+		if err != nil {
+			t.Errorf("Test failed. TradeSideFromString %s error: %s", side, err)
+		}
+		if result != expected {
+			t.Errorf("Test failed. TradeSideFromString %s expected %s, got %s",
+				side, expected, result)
+		}
+	}
+
+	if _, err := TradeSideFromString("hodl"); err == nil {
+		t.Error("Test failed. TradeSideFromString accepted an unknown side")
+	}
+}
+
+func TestTradeSideFromBuyerMaker(t *testing.T) {
+	if TradeSideFromBuyerMaker(true) != Sell {
+		t.Error("Test failed. A maker buyer should give a Sell aggressor side")
+	}
+	if TradeSideFromBuyerMaker(false) != Buy {
+		t.Error("Test failed. A taker buyer should give a Buy aggressor side")
+	}
+}
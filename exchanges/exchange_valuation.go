@@ -0,0 +1,162 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// ErrNoValuationPair is returned when an exchange has no pair which can value
+// a currency in the requested base currency
+var ErrNoValuationPair = errors.New("no pair available to value currency")
+
+// PriceSourceFiat is reported as the price source of balances valued using
+// fiat exchange rates rather than an exchange price
+const PriceSourceFiat PriceSource = "fiat"
+
+// CurrencyValuation holds the value of a single currency balance in the base
+// currency and how its price was derived, Source is empty for a balance held
+// in the base currency
+type CurrencyValuation struct {
+	Currency string
+	Amount   float64
+	Price    float64
+	Value    float64
+	Source   PriceSource
+}
+
+// AccountValuation holds an exchange's balances valued in a base currency.
+// Currencies which could not be priced are excluded from Total and listed in
+// Skipped with the reason
+type AccountValuation struct {
+	Exchange     string
+	BaseCurrency string
+	Total        float64
+	Balances     []CurrencyValuation
+	Skipped      map[string]error
+}
+
+// GetAccountValuation fetches an exchange's account info and values each
+// balance in baseCurrency
+func GetAccountValuation(exch IBotExchange, baseCurrency string) (AccountValuation, error) {
+	info, err := exch.GetAccountInfo()
+	if err != nil {
+		return AccountValuation{}, err
+	}
+	return ValueAccountInfo(exch, info, baseCurrency), nil
+}
+
+// ValueAccountInfo values each balance in the supplied account info in
+// baseCurrency and returns the total. Balances are priced with GetPrice using
+// the exchange's pair against baseCurrency, or its inverse. When baseCurrency
+// is fiat a pair against another fiat currency is also used, converting its
+// price with the fiat exchange rates, and fiat balances are converted directly
+func ValueAccountInfo(exch IBotExchange, info AccountInfo, baseCurrency string) AccountValuation {
+	baseCurrency = common.StringToUpper(baseCurrency)
+	valuation := AccountValuation{
+		Exchange:     info.ExchangeName,
+		BaseCurrency: baseCurrency,
+		Skipped:      make(map[string]error),
+	}
+
+	for x := range info.Currencies {
+		amount := info.Currencies[x].TotalValue
+		if amount == 0 {
+			continue
+		}
+
+		curr := common.StringToUpper(info.Currencies[x].CurrencyName)
+		price, source, err := getValuationPrice(exch, curr, baseCurrency)
+		if err != nil {
+			valuation.Skipped[curr] = err
+			continue
+		}
+
+		valuation.Balances = append(valuation.Balances, CurrencyValuation{
+			Currency: curr,
+			Amount:   amount,
+			Price:    price,
+			Value:    amount * price,
+			Source:   source,
+		})
+		valuation.Total += amount * price
+	}
+	return valuation
+}
+
+// getValuationPrice returns the price of one unit of curr in baseCurrency
+func getValuationPrice(exch IBotExchange, curr, baseCurrency string) (float64, PriceSource, error) {
+	if curr == baseCurrency {
+		return 1, "", nil
+	}
+
+	baseIsFiat := isFiat(baseCurrency)
+	if baseIsFiat && isFiat(curr) {
+		price, err := currency.ConvertCurrency(1, curr, baseCurrency)
+		if err != nil {
+			return 0, "", err
+		}
+		return price, PriceSourceFiat, nil
+	}
+
+	var pairs []pair.CurrencyPair
+	pairs = append(pairs, exch.GetEnabledCurrencies()...)
+	pairs = append(pairs, exch.GetAvailableCurrencies()...)
+	if p, ok := findValuationPair(pairs, curr, baseCurrency); ok {
+		price, source, err := GetPrice(exch, p, ticker.Spot)
+		if err != nil {
+			return 0, "", err
+		}
+		return price, source, nil
+	}
+
+	if p, ok := findValuationPair(pairs, baseCurrency, curr); ok {
+		price, source, err := GetPrice(exch, p, ticker.Spot)
+		if err != nil {
+			return 0, "", err
+		}
+		return 1 / price, source, nil
+	}
+
+	if baseIsFiat {
+		for x := range pairs {
+			quote := common.StringToUpper(pairs[x].SecondCurrency.String())
+			if common.StringToUpper(pairs[x].FirstCurrency.String()) != curr || !isFiat(quote) {
+				continue
+			}
+
+			price, source, err := GetPrice(exch, pairs[x], ticker.Spot)
+			if err != nil {
+				return 0, "", err
+			}
+
+			converted, err := currency.ConvertCurrency(price, quote, baseCurrency)
+			if err != nil {
+				return 0, "", err
+			}
+			return converted, source, nil
+		}
+	}
+
+	return 0, "", fmt.Errorf("%s %s %s", exch.GetName(), curr, ErrNoValuationPair)
+}
+
+// findValuationPair returns the first pair trading first against second
+func findValuationPair(pairs []pair.CurrencyPair, first, second string) (pair.CurrencyPair, bool) {
+	for x := range pairs {
+		if common.StringToUpper(pairs[x].FirstCurrency.String()) == first &&
+			common.StringToUpper(pairs[x].SecondCurrency.String()) == second {
+			return pairs[x], true
+		}
+	}
+	return pair.CurrencyPair{}, false
+}
+
+// isFiat returns whether a currency is an enabled or default fiat currency
+func isFiat(curr string) bool {
+	return currency.IsFiatCurrency(curr) || currency.IsDefaultCurrency(curr)
+}
@@ -0,0 +1,117 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/currency/forexprovider"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type valuationTestExchange struct {
+	IBotExchange
+	prices map[string]float64
+	pairs  []pair.CurrencyPair
+}
+
+func (v *valuationTestExchange) GetName() string {
+	return "test"
+}
+
+func (v *valuationTestExchange) GetPriceSources() []PriceSource {
+	return []PriceSource{PriceSourceTicker}
+}
+
+func (v *valuationTestExchange) GetEnabledCurrencies() []pair.CurrencyPair {
+	return v.pairs
+}
+
+func (v *valuationTestExchange) GetAvailableCurrencies() []pair.CurrencyPair {
+	return nil
+}
+
+func (v *valuationTestExchange) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	price, ok := v.prices[p.Pair().String()]
+	if !ok {
+		return ticker.Price{}, errors.New("ticker unavailable")
+	}
+	return ticker.Price{Pair: p, Last: price}, nil
+}
+
+func (v *valuationTestExchange) GetAccountInfo() (AccountInfo, error) {
+	return AccountInfo{
+		ExchangeName: "test",
+		Currencies: []AccountCurrencyInfo{
+			{CurrencyName: "USD", TotalValue: 100},
+			{CurrencyName: "btc", TotalValue: 2},
+			{CurrencyName: "ETH", TotalValue: 10},
+			{CurrencyName: "LTC", TotalValue: 4},
+			{CurrencyName: "EUR", TotalValue: 50},
+			{CurrencyName: "DOGE", TotalValue: 1000},
+			{CurrencyName: "XRP", TotalValue: 0},
+		},
+	}, nil
+}
+
+func TestGetAccountValuation(t *testing.T) {
+	fiat, rates, providers := currency.FiatCurrencies, currency.FXRates, currency.FXProviders
+	defer func() {
+		currency.FiatCurrencies, currency.FXRates, currency.FXProviders = fiat, rates, providers
+	}()
+	currency.FiatCurrencies = []string{"USD", "EUR"}
+	currency.FXRates = map[string]float64{"USDEUR": 0.5}
+	currency.FXProviders = forexprovider.NewDefaultFXProvider()
+
+	exch := &valuationTestExchange{
+		prices: map[string]float64{
+			"BTCUSD": 1000,
+			"USDETH": 0.01,
+			"LTCEUR": 25,
+		},
+		pairs: []pair.CurrencyPair{
+			pair.NewCurrencyPair("BTC", "USD"),
+			pair.NewCurrencyPair("USD", "ETH"),
+			pair.NewCurrencyPair("LTC", "EUR"),
+		},
+	}
+
+	valuation, err := GetAccountValuation(exch, "usd")
+	if err != nil {
+		t.Fatal("Test failed. GetAccountValuation error", err)
+	}
+
+	// 100 USD + 2 BTC @ 1000 + 10 ETH @ 100 + 4 LTC @ 50 + 50 EUR @ 2
+	if valuation.Total != 3400 {
+		t.Errorf("Test failed. GetAccountValuation expected a total of 3400, received %v", valuation.Total)
+	}
+
+	if len(valuation.Balances) != 5 {
+		t.Errorf("Test failed. GetAccountValuation expected 5 valued balances, received %d",
+			len(valuation.Balances))
+	}
+
+	for x := range valuation.Balances {
+		b := valuation.Balances[x]
+		switch b.Currency {
+		case "USD":
+			if b.Source != "" {
+				t.Errorf("Test failed. GetAccountValuation unexpected base currency source %s", b.Source)
+			}
+		case "ETH":
+			if b.Price != 100 || b.Source != PriceSourceTicker {
+				t.Errorf("Test failed. GetAccountValuation unexpected inverse pair valuation %+v", b)
+			}
+		case "EUR":
+			if b.Value != 100 || b.Source != PriceSourceFiat {
+				t.Errorf("Test failed. GetAccountValuation unexpected fiat valuation %+v", b)
+			}
+		}
+	}
+
+	if len(valuation.Skipped) != 1 || valuation.Skipped["DOGE"] == nil {
+		t.Errorf("Test failed. GetAccountValuation expected DOGE to be skipped, received %v",
+			valuation.Skipped)
+	}
+}
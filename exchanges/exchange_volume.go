@@ -0,0 +1,84 @@
+package exchange
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// TradeVolumeDays is the trailing period fee tiers are assessed over
+	TradeVolumeDays = 30
+
+	// TradeVolumeCacheTTL is how long a trade volume fetched from an exchange
+	// API is served from the cache, volumes only move fee tiers daily
+	TradeVolumeCacheTTL = 24 * time.Hour
+)
+
+var (
+	tradeVolumes    = make(map[string]TradeVolume)
+	tradeVolumesMtx sync.Mutex
+)
+
+// TradeVolume is an account's trailing trade volume as defined by the exchange
+// for assessing its fee tier. Volume is zero and Note explains why when the
+// exchange doesn't expose the volume
+type TradeVolume struct {
+	Exchange string
+	Volume   float64
+	Currency string
+	Days     int
+	Note     string
+	Updated  time.Time
+}
+
+// TradeVolumeGetter is implemented by exchanges which expose the account's
+// trailing trade volume
+type TradeVolumeGetter interface {
+	FetchTradeVolume() (TradeVolume, error)
+}
+
+// GetTradeVolume returns the account's trailing trade volume used to assess
+// the exchange's fee tier, fetching it once TradeVolumeCacheTTL has elapsed.
+// When the fetch fails the last cached volume is returned if there is one.
+// Exchanges which don't expose the volume return a zero volume with a note
+// rather than an error
+func GetTradeVolume(exch IBotExchange) (TradeVolume, error) {
+	getter, ok := exch.(TradeVolumeGetter)
+	if !ok {
+		return TradeVolume{
+			Exchange: exch.GetName(),
+			Days:     TradeVolumeDays,
+			Note:     fmt.Sprintf("%s does not expose trailing trade volume", exch.GetName()),
+		}, nil
+	}
+
+	tradeVolumesMtx.Lock()
+	cached, ok := tradeVolumes[exch.GetName()]
+	tradeVolumesMtx.Unlock()
+	if ok && Now().Sub(cached.Updated) < TradeVolumeCacheTTL {
+		return cached, nil
+	}
+
+	volume, err := getter.FetchTradeVolume()
+	if err != nil {
+		if ok {
+			log.Printf("%s failed to fetch trade volume, using volume cached at %s. Err: %s",
+				exch.GetName(), cached.Updated, err)
+			return cached, nil
+		}
+		return TradeVolume{}, err
+	}
+
+	volume.Exchange = exch.GetName()
+	if volume.Days == 0 {
+		volume.Days = TradeVolumeDays
+	}
+	volume.Updated = Now()
+
+	tradeVolumesMtx.Lock()
+	tradeVolumes[exch.GetName()] = volume
+	tradeVolumesMtx.Unlock()
+	return volume, nil
+}
@@ -0,0 +1,74 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type volumeTestExchange struct {
+	IBotExchange
+	volume float64
+	err    error
+	calls  int
+}
+
+func (v *volumeTestExchange) GetName() string {
+	return "volumetest"
+}
+
+func (v *volumeTestExchange) FetchTradeVolume() (TradeVolume, error) {
+	v.calls++
+	return TradeVolume{Volume: v.volume, Currency: "USDT"}, v.err
+}
+
+func TestGetTradeVolume(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	Now = func() time.Time { return now }
+	defer func() { Now = time.Now }()
+
+	exch := &volumeTestExchange{volume: 1000}
+	volume, err := GetTradeVolume(exch)
+	if err != nil {
+		t.Fatal("Test failed. GetTradeVolume error", err)
+	}
+
+	if volume.Exchange != "volumetest" || volume.Volume != 1000 || volume.Currency != "USDT" ||
+		volume.Days != TradeVolumeDays || !volume.Updated.Equal(now) {
+		t.Errorf("Test failed. GetTradeVolume unexpected volume %+v", volume)
+	}
+
+	exch.volume = 2000
+	now = now.Add(TradeVolumeCacheTTL - time.Nanosecond)
+	if volume, _ = GetTradeVolume(exch); volume.Volume != 1000 || exch.calls != 1 {
+		t.Errorf("Test failed. GetTradeVolume expected the cached volume within the TTL, received %v fetched %d times",
+			volume.Volume, exch.calls)
+	}
+
+	now = now.Add(time.Nanosecond)
+	if volume, _ = GetTradeVolume(exch); volume.Volume != 2000 || exch.calls != 2 {
+		t.Errorf("Test failed. GetTradeVolume expected the volume to be fetched after the TTL, received %v fetched %d times",
+			volume.Volume, exch.calls)
+	}
+
+	exch.err = errors.New("volume unavailable")
+	now = now.Add(TradeVolumeCacheTTL)
+	volume, err = GetTradeVolume(exch)
+	if err != nil || volume.Volume != 2000 {
+		t.Errorf("Test failed. GetTradeVolume expected the stale volume when the fetch fails, received %v %v",
+			volume.Volume, err)
+	}
+
+	tradeVolumesMtx.Lock()
+	delete(tradeVolumes, exch.GetName())
+	tradeVolumesMtx.Unlock()
+	if _, err = GetTradeVolume(exch); err == nil {
+		t.Error("Test failed. GetTradeVolume expected an error when the volume was never fetched")
+	}
+
+	volume, err = GetTradeVolume(&journalTestExchange{})
+	if err != nil || volume.Volume != 0 || volume.Note == "" {
+		t.Errorf("Test failed. GetTradeVolume expected a zero volume with a note for an unsupported exchange, received %+v %v",
+			volume, err)
+	}
+}
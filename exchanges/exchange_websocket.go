@@ -1,11 +1,21 @@
 package exchange
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
@@ -22,8 +32,67 @@ const (
 	WebsocketStateTimeout = "TIMEOUT"
 
 	websocketRestablishConnection = 1 * time.Second
+
+	// websocketWriteQueueSize is the number of outbound messages which can be
+	// queued before senders block
+	websocketWriteQueueSize = 100
+
+	// websocketWriteTimeout is how long a single outbound message write may
+	// take before it fails, so a stalled connection can't block every sender
+	websocketWriteTimeout = 10 * time.Second
+
+	// DefaultSubscribeAckTimeout is how long Subscribe waits for an exchange
+	// to acknowledge a subscription before sending it again
+	DefaultSubscribeAckTimeout = 10 * time.Second
+
+	// websocketSubscribeAttempts is the number of times an unacknowledged
+	// subscription is sent before giving up
+	websocketSubscribeAttempts = 2
+)
+
+// WebsocketCompression is the compression scheme an exchange applies to
+// binary websocket frames
+type WebsocketCompression string
+
+// Websocket compression schemes, deflate is raw DEFLATE without a zlib header
+const (
+	WebsocketCompressionNone    WebsocketCompression = ""
+	WebsocketCompressionGzip    WebsocketCompression = "gzip"
+	WebsocketCompressionDeflate WebsocketCompression = "deflate"
 )
 
+// ErrOrderbookSequenceGap is returned when a websocket orderbook update
+// doesn't follow on from the last applied update
+var ErrOrderbookSequenceGap = errors.New("orderbook update sequence gap detected")
+
+// Websocket subscription errors
+var (
+	ErrSubscribeAckTimeout  = errors.New("websocket subscription not acknowledged")
+	ErrSubscriptionRejected = errors.New("websocket subscription rejected")
+)
+
+// SubscriptionError is returned when an exchange rejects a websocket
+// subscription or never acknowledges it. Err is ErrSubscriptionRejected or
+// ErrSubscribeAckTimeout and Reason holds the exchange's rejection message
+type SubscriptionError struct {
+	Exchange string
+	Channel  string
+	Err      error
+	Reason   string
+}
+
+func (e *SubscriptionError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%s websocket %s %s", e.Exchange, e.Channel, e.Err)
+	}
+	return fmt.Sprintf("%s websocket %s %s: %s", e.Exchange, e.Channel, e.Err, e.Reason)
+}
+
+// Unwrap returns the underlying subscription error
+func (e *SubscriptionError) Unwrap() error {
+	return e.Err
+}
+
 // WebsocketInit initialises the websocket struct
 func (e *Base) WebsocketInit() {
 	e.Websocket = &Websocket{
@@ -76,6 +145,35 @@ type Websocket struct {
 	connector    func() error
 	m            sync.Mutex
 
+	// orderbookDepth is the number of orderbook levels subscribed to
+	orderbookDepth int
+
+	// subscriptions holds the active channels, which are saved to the
+	// exchange config when persistSubscriptions is enabled
+	subscriptions        []string
+	persistSubscriptions bool
+	subscriptionsMtx     sync.Mutex
+
+	// pendingAcks holds a channel for each subscription awaiting an
+	// acknowledgement from the exchange
+	pendingAcks    map[string]chan error
+	pendingAcksMtx sync.Mutex
+	ackTimeout     time.Duration
+
+	// writeQueue serialises outbound messages through a single writer
+	// routine as concurrent writes to a websocket connection panic, it is
+	// created on the first send and closed on shutdown to stop the writer
+	writeQueue    chan websocketWrite
+	writeQueueMtx sync.Mutex
+
+	// Compression scheme used to decompress inbound binary frames
+	compression WebsocketCompression
+
+	// rawTap holds the *websocketRawTap inbound messages are copied to, it is
+	// loaded for every message so an unused tap costs a single atomic load
+	rawTap    atomic.Value
+	rawTapMtx sync.Mutex
+
 	// Connected denotes a channel switch for diversion of request flow
 	Connected chan struct{}
 
@@ -221,6 +319,7 @@ func (w *Websocket) Shutdown() error {
 	select {
 	case <-c:
 		w.connected = false
+		w.stopWriter()
 		return nil
 	case <-timer.C:
 		return fmt.Errorf("%s - Websocket routines failed to shutdown",
@@ -326,12 +425,549 @@ func (w *Websocket) GetName() string {
 	return w.exchangeName
 }
 
+// WebsocketConnection is a connection outbound websocket messages are written
+// to e.g. a gorilla websocket.Conn
+type WebsocketConnection interface {
+	WriteMessage(messageType int, data []byte) error
+}
+
+// websocketWriteDeadliner is implemented by connections which support write
+// deadlines e.g. a gorilla websocket.Conn
+type websocketWriteDeadliner interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+// WebsocketReader is a connection inbound websocket messages are read from
+// e.g. a gorilla websocket.Conn
+type WebsocketReader interface {
+	ReadMessage() (messageType int, data []byte, err error)
+}
+
+// SetCompression sets the compression scheme the exchange applies to binary
+// websocket frames
+func (w *Websocket) SetCompression(compression WebsocketCompression) {
+	w.compression = compression
+}
+
+// GetCompression returns the compression scheme the exchange applies to
+// binary websocket frames
+func (w *Websocket) GetCompression() WebsocketCompression {
+	return w.compression
+}
+
+// ReadMessage reads the next message from conn, decompressing binary frames
+// using the websocket's compression scheme. The message is copied to the raw
+// message tap when one is registered, undecompressed if decompression fails
+func (w *Websocket) ReadMessage(conn WebsocketReader) (int, []byte, error) {
+	messageType, data, err := conn.ReadMessage()
+	if err != nil {
+		return messageType, nil, err
+	}
+
+	decompressed, err := w.Decompress(messageType, data)
+	if tap, _ := w.rawTap.Load().(*websocketRawTap); tap != nil {
+		if err == nil {
+			w.tapRawMessage(tap, messageType, decompressed)
+		} else {
+			w.tapRawMessage(tap, messageType, data)
+		}
+	}
+	return messageType, decompressed, err
+}
+
+// WebsocketRawMessage is an inbound websocket message exactly as received,
+// after decompression, delivered to a raw message tap before it is parsed
+type WebsocketRawMessage struct {
+	Exchange    string
+	Timestamp   time.Time
+	MessageType int
+	Data        []byte
+}
+
+// websocketRawTap holds the destinations of the raw message tap
+type websocketRawTap struct {
+	writer  io.Writer
+	channel chan<- WebsocketRawMessage
+}
+
+// SetRawMessageWriter registers a tap which writes every inbound message read
+// with ReadMessage to out, one line per message prefixed with the time
+// received and exchange name, for debugging message parsing. A nil writer
+// removes the tap. The tap is removed if a write fails
+func (w *Websocket) SetRawMessageWriter(out io.Writer) {
+	w.updateRawTap(func(tap *websocketRawTap) {
+		tap.writer = out
+	})
+}
+
+// SetRawMessageChannel registers a tap which sends every inbound message read
+// with ReadMessage to ch, for debugging message parsing. Messages are dropped
+// when ch is full so a slow consumer doesn't stall the connection. A nil
+// channel removes the tap
+func (w *Websocket) SetRawMessageChannel(ch chan<- WebsocketRawMessage) {
+	w.updateRawTap(func(tap *websocketRawTap) {
+		tap.channel = ch
+	})
+}
+
+// updateRawTap replaces the raw message tap with a copy changed by update
+func (w *Websocket) updateRawTap(update func(tap *websocketRawTap)) {
+	w.rawTapMtx.Lock()
+	defer w.rawTapMtx.Unlock()
+
+	var tap websocketRawTap
+	if current, _ := w.rawTap.Load().(*websocketRawTap); current != nil {
+		tap = *current
+	}
+	update(&tap)
+
+	if tap.writer == nil && tap.channel == nil {
+		w.rawTap.Store((*websocketRawTap)(nil))
+		return
+	}
+	w.rawTap.Store(&tap)
+}
+
+// tapRawMessage copies an inbound message to the registered tap
+func (w *Websocket) tapRawMessage(tap *websocketRawTap, messageType int, data []byte) {
+	now := Now()
+	if tap.writer != nil {
+		line := make([]byte, 0, len(data)+64)
+		line = append(line, now.UTC().Format(time.RFC3339Nano)...)
+		line = append(line, ' ')
+		line = append(line, w.GetName()...)
+		line = append(line, ' ')
+		line = append(line, data...)
+		line = append(line, '\n')
+		if _, err := tap.writer.Write(line); err != nil {
+			log.Printf("%s websocket raw message tap write failed, removing writer. Err: %s\n",
+				w.GetName(), err)
+			w.SetRawMessageWriter(nil)
+		}
+	}
+
+	if tap.channel != nil {
+		msg := WebsocketRawMessage{
+			Exchange:    w.GetName(),
+			Timestamp:   now,
+			MessageType: messageType,
+			Data:        append([]byte(nil), data...),
+		}
+		select {
+		case tap.channel <- msg:
+		default:
+		}
+	}
+}
+
+// Decompress returns the decompressed data of a binary frame using the
+// websocket's compression scheme, text frames are returned unchanged
+func (w *Websocket) Decompress(messageType int, data []byte) ([]byte, error) {
+	if messageType != websocket.BinaryMessage {
+		return data, nil
+	}
+
+	switch w.compression {
+	case WebsocketCompressionNone:
+		return data, nil
+	case WebsocketCompressionGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return ioutil.ReadAll(reader)
+	case WebsocketCompressionDeflate:
+		reader := flate.NewReader(bytes.NewReader(data))
+		defer reader.Close()
+		return ioutil.ReadAll(reader)
+	}
+	return nil, fmt.Errorf("%s websocket unsupported compression %s", w.exchangeName, w.compression)
+}
+
+// websocketWrite is an outbound message queued for the writer routine
+type websocketWrite struct {
+	conn        WebsocketConnection
+	messageType int
+	data        []byte
+	result      chan error
+}
+
+// writer writes queued messages one at a time until the queue is closed,
+// each write is given websocketWriteTimeout to complete
+func writer(queue <-chan websocketWrite) {
+	for write := range queue {
+		if deadliner, ok := write.conn.(websocketWriteDeadliner); ok {
+			if err := deadliner.SetWriteDeadline(time.Now().Add(websocketWriteTimeout)); err != nil {
+				write.result <- err
+				continue
+			}
+		}
+		write.result <- write.conn.WriteMessage(write.messageType, write.data)
+	}
+}
+
+// stopWriter closes the write queue, the writer routine exits once the
+// messages already queued are written
+func (w *Websocket) stopWriter() {
+	w.writeQueueMtx.Lock()
+	if w.writeQueue != nil {
+		close(w.writeQueue)
+		w.writeQueue = nil
+	}
+	w.writeQueueMtx.Unlock()
+}
+
+// isNilConnection returns whether conn is nil, including a nil pointer such as
+// an unconnected *websocket.Conn stored in the interface
+func isNilConnection(conn WebsocketConnection) bool {
+	if conn == nil {
+		return true
+	}
+	v := reflect.ValueOf(conn)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Chan, reflect.Func, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+// SendMessage queues a message to be written to conn by the websocket's single
+// writer routine and returns the result of the write. Every outbound message
+// must be sent through here as concurrent writes to a connection panic. When
+// the queue is full the caller blocks until there is room
+func (w *Websocket) SendMessage(conn WebsocketConnection, messageType int, data []byte) error {
+	if isNilConnection(conn) {
+		return errors.New("exchange_websocket.go - cannot send message, connection not established")
+	}
+
+	result := make(chan error, 1)
+	w.writeQueueMtx.Lock()
+	if w.writeQueue == nil {
+		w.writeQueue = make(chan websocketWrite, websocketWriteQueueSize)
+		go writer(w.writeQueue)
+	}
+	w.writeQueue <- websocketWrite{
+		conn:        conn,
+		messageType: messageType,
+		data:        data,
+		result:      result,
+	}
+	w.writeQueueMtx.Unlock()
+	return <-result
+}
+
+// SendJSON encodes v to JSON and sends it as a text message via SendMessage
+func (w *Websocket) SendJSON(conn WebsocketConnection, v interface{}) error {
+	data, err := common.JSONEncode(v)
+	if err != nil {
+		return err
+	}
+	return w.SendMessage(conn, websocket.TextMessage, data)
+}
+
+// IsOrderbookSynced returns whether the websocket orderbook for a currency
+// pair and asset type is synced with the exchange
+func (w *Websocket) IsOrderbookSynced(p pair.CurrencyPair, assetType string) bool {
+	return w.Orderbook.IsSynced(p, assetType)
+}
+
+// SetOrderbookDepth sets the number of orderbook levels to subscribe to. A zero
+// depth selects the exchange default, and a depth the exchange does not support
+// falls back to the default with a logged warning
+func (w *Websocket) SetOrderbookDepth(depth, defaultDepth int, supported []int) {
+	if depth == 0 {
+		w.orderbookDepth = defaultDepth
+		return
+	}
+
+	for i := range supported {
+		if supported[i] == depth {
+			w.orderbookDepth = depth
+			return
+		}
+	}
+
+	log.Printf("WARNING -- %s websocket orderbook depth %d not supported, supported depths: %v. Falling back to %d.\n",
+		w.GetName(),
+		depth,
+		supported,
+		defaultDepth)
+	w.orderbookDepth = defaultDepth
+}
+
+// GetOrderbookDepth returns the number of orderbook levels subscribed to
+func (w *Websocket) GetOrderbookDepth() int {
+	return w.orderbookDepth
+}
+
+// SetSubscriptionPersistence sets whether the active subscriptions are saved
+// to the exchange config so they can be restored after a restart
+func (w *Websocket) SetSubscriptionPersistence(enabled bool) {
+	w.subscriptionsMtx.Lock()
+	w.persistSubscriptions = enabled
+	w.subscriptionsMtx.Unlock()
+}
+
+// AddSubscription records a channel as subscribed
+func (w *Websocket) AddSubscription(channel string) error {
+	w.subscriptionsMtx.Lock()
+	defer w.subscriptionsMtx.Unlock()
+	for x := range w.subscriptions {
+		if w.subscriptions[x] == channel {
+			return nil
+		}
+	}
+	w.subscriptions = append(w.subscriptions, channel)
+	return w.saveSubscriptions()
+}
+
+// SetSubscribeAckTimeout sets how long Subscribe waits for each
+// acknowledgement, a timeout of 0 or less uses DefaultSubscribeAckTimeout
+func (w *Websocket) SetSubscribeAckTimeout(timeout time.Duration) {
+	w.pendingAcksMtx.Lock()
+	w.ackTimeout = timeout
+	w.pendingAcksMtx.Unlock()
+}
+
+// Subscribe sends a subscription to channel with send and waits for the
+// exchange's websocket handler to pass the response to AcknowledgeSubscription,
+// recording the channel with AddSubscription once it is acknowledged. An
+// unacknowledged subscription is sent again before a SubscriptionError is
+// returned, a rejection is returned straight away. The exchange's read and
+// handler routines must be running and must not be blocked on Subscribe
+func (w *Websocket) Subscribe(channel string, send func() error) error {
+	return w.SubscribeBatch([]string{channel}, func([]string) error {
+		return send()
+	})[channel]
+}
+
+// SubscribeBatch sends the subscriptions to channels together with send and
+// waits for each to be acknowledged as Subscribe does, the channels which
+// aren't acknowledged are sent again as a smaller batch. The result of each
+// subscription is returned by channel, nil when it succeeded, so a rejected
+// channel doesn't stop the rest from being subscribed
+func (w *Websocket) SubscribeBatch(channels []string, send func(channels []string) error) map[string]error {
+	acks := make(map[string]chan error, len(channels))
+	w.pendingAcksMtx.Lock()
+	if w.pendingAcks == nil {
+		w.pendingAcks = make(map[string]chan error)
+	}
+	for _, channel := range channels {
+		acks[channel] = make(chan error, 1)
+		w.pendingAcks[channel] = acks[channel]
+	}
+	timeout := w.ackTimeout
+	w.pendingAcksMtx.Unlock()
+
+	defer func() {
+		w.pendingAcksMtx.Lock()
+		for channel, ack := range acks {
+			if w.pendingAcks[channel] == ack {
+				delete(w.pendingAcks, channel)
+			}
+		}
+		w.pendingAcksMtx.Unlock()
+	}()
+
+	if timeout <= 0 {
+		timeout = DefaultSubscribeAckTimeout
+	}
+
+	results := make(map[string]error, len(channels))
+	pending := channels
+	for attempt := 1; attempt <= websocketSubscribeAttempts && len(pending) > 0; attempt++ {
+		err := send(pending)
+		if err != nil {
+			for _, channel := range pending {
+				results[channel] = err
+			}
+			return results
+		}
+
+		expired := make(chan struct{})
+		timer := time.AfterFunc(timeout, func() { close(expired) })
+		var unacknowledged []string
+		for _, channel := range pending {
+			select {
+			case err = <-acks[channel]:
+				results[channel] = w.subscriptionResult(channel, err)
+			case <-expired:
+				unacknowledged = append(unacknowledged, channel)
+			}
+		}
+		timer.Stop()
+
+		if len(unacknowledged) > 0 {
+			log.Printf("%s websocket %s subscription not acknowledged within %s, attempt %d/%d\n",
+				w.GetName(), common.JoinStrings(unacknowledged, ", "), timeout, attempt,
+				websocketSubscribeAttempts)
+		}
+		pending = unacknowledged
+	}
+
+	for _, channel := range pending {
+		results[channel] = &SubscriptionError{
+			Exchange: w.GetName(),
+			Channel:  channel,
+			Err:      ErrSubscribeAckTimeout,
+		}
+	}
+	return results
+}
+
+// subscriptionResult records an acknowledged subscription, or returns the
+// exchange's rejection of it as a SubscriptionError
+func (w *Websocket) subscriptionResult(channel string, ackErr error) error {
+	if ackErr != nil {
+		return &SubscriptionError{
+			Exchange: w.GetName(),
+			Channel:  channel,
+			Err:      ErrSubscriptionRejected,
+			Reason:   ackErr.Error(),
+		}
+	}
+	return w.AddSubscription(channel)
+}
+
+// AcknowledgeSubscription passes an exchange's response to a subscription to
+// the waiting Subscribe call, err is nil when the subscription succeeded or
+// the exchange's rejection. It returns false when no subscription to channel
+// is awaiting a response
+func (w *Websocket) AcknowledgeSubscription(channel string, err error) bool {
+	w.pendingAcksMtx.Lock()
+	ack, ok := w.pendingAcks[channel]
+	w.pendingAcksMtx.Unlock()
+	if !ok {
+		return false
+	}
+
+	select {
+	case ack <- err:
+	default:
+	}
+	return true
+}
+
+// RemoveSubscription records a channel as unsubscribed
+func (w *Websocket) RemoveSubscription(channel string) error {
+	w.subscriptionsMtx.Lock()
+	defer w.subscriptionsMtx.Unlock()
+	for x := range w.subscriptions {
+		if w.subscriptions[x] == channel {
+			w.subscriptions = append(w.subscriptions[:x], w.subscriptions[x+1:]...)
+			return w.saveSubscriptions()
+		}
+	}
+	return nil
+}
+
+// GetSubscriptions returns the active channels
+func (w *Websocket) GetSubscriptions() []string {
+	w.subscriptionsMtx.Lock()
+	defer w.subscriptionsMtx.Unlock()
+	subscriptions := make([]string, len(w.subscriptions))
+	copy(subscriptions, w.subscriptions)
+	return subscriptions
+}
+
+// GetPersistedSubscriptions returns the channels saved to the exchange config,
+// or nil when subscription persistence is disabled
+func (w *Websocket) GetPersistedSubscriptions() []string {
+	w.subscriptionsMtx.Lock()
+	persist := w.persistSubscriptions
+	w.subscriptionsMtx.Unlock()
+	if !persist {
+		return nil
+	}
+
+	exch, err := config.GetConfig().GetExchangeConfig(w.GetName())
+	if err != nil {
+		return nil
+	}
+	return exch.WebsocketSubscriptions
+}
+
+// saveSubscriptions saves the active channels to the exchange config when
+// persistence is enabled, the lock must be held by the caller
+func (w *Websocket) saveSubscriptions() error {
+	if !w.persistSubscriptions {
+		return nil
+	}
+
+	cfg := config.GetConfig()
+	exch, err := cfg.GetExchangeConfig(w.GetName())
+	if err != nil {
+		return err
+	}
+
+	exch.WebsocketSubscriptions = make([]string, len(w.subscriptions))
+	copy(exch.WebsocketSubscriptions, w.subscriptions)
+	return cfg.UpdateExchangeConfig(exch)
+}
+
 // WebsocketOrderbookLocal defines a local cache of orderbooks for ammending,
 // appending and deleting changes and updates the main store in orderbook.go
 type WebsocketOrderbookLocal struct {
-	ob          []orderbook.Base
-	lastUpdated time.Time
-	m           sync.Mutex
+	ob           []orderbook.Base
+	synced       map[string]bool
+	sequences    map[string]int64
+	sequenceGaps int64
+	lastUpdated  time.Time
+	m            sync.Mutex
+}
+
+// syncKey returns the key used to track the sync state of an orderbook
+func syncKey(p pair.CurrencyPair, assetType string) string {
+	return p.Pair().Upper().String() + ":" + assetType
+}
+
+// setSynced sets the sync state of an orderbook, must be called with the lock
+// held
+func (w *WebsocketOrderbookLocal) setSynced(p pair.CurrencyPair, assetType string, synced bool) {
+	if w.synced == nil {
+		w.synced = make(map[string]bool)
+	}
+	w.synced[syncKey(p, assetType)] = synced
+}
+
+// IsSynced returns whether the local orderbook for a currency pair and asset
+// type has been loaded from a snapshot since the last connection. Consumers
+// should not rely on an orderbook that is not synced as it may be mid resync
+func (w *WebsocketOrderbookLocal) IsSynced(p pair.CurrencyPair, assetType string) bool {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.synced[syncKey(p, assetType)]
+}
+
+// Invalidate marks the local orderbook for a currency pair and asset type as
+// stale and drops it, so that no further updates are applied until a fresh
+// snapshot is loaded
+func (w *WebsocketOrderbookLocal) Invalidate(p pair.CurrencyPair, assetType string) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.invalidate(p, assetType)
+}
+
+// invalidate drops the local orderbook and its sequence, must be called with
+// the lock held
+func (w *WebsocketOrderbookLocal) invalidate(p pair.CurrencyPair, assetType string) {
+	for i := range w.ob {
+		if w.ob[i].Pair == p && w.ob[i].AssetType == assetType {
+			w.ob = append(w.ob[:i], w.ob[i+1:]...)
+			break
+		}
+	}
+	delete(w.sequences, syncKey(p, assetType))
+	w.setSynced(p, assetType, false)
+}
+
+// SequenceGaps returns the number of sequence gaps detected by
+// UpdateWithSequence since the websocket was set up
+func (w *WebsocketOrderbookLocal) SequenceGaps() int64 {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.sequenceGaps
 }
 
 // Update updates a local cache using bid targets and ask targets then updates
@@ -353,7 +989,67 @@ func (w *WebsocketOrderbookLocal) Update(bidTargets, askTargets []orderbook.Item
 
 	w.m.Lock()
 	defer w.m.Unlock()
+	return w.update(bidTargets, askTargets, p, updated, exchName, assetType)
+}
+
+// UpdateWithSequence applies an update covering the sequence numbers
+// firstSequence to lastSequence to an orderbook loaded with
+// LoadSnapshotWithSequence. Updates already covered by the last applied
+// sequence are dropped. An update starting after the next expected sequence
+// means messages were missed, rather than applying it the local orderbook is
+// invalidated, the gap counted and ErrOrderbookSequenceGap returned so the
+// caller can load a fresh snapshot
+func (w *WebsocketOrderbookLocal) UpdateWithSequence(bidTargets, askTargets []orderbook.Item,
+	p pair.CurrencyPair,
+	updated time.Time,
+	exchName, assetType string,
+	firstSequence, lastSequence int64) error {
+	if bidTargets == nil && askTargets == nil {
+		return errors.New("exchange.go websocket orderbook cache UpdateWithSequence() error - cannot have bids and ask targets both nil")
+	}
+
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	last, ok := w.sequences[syncKey(p, assetType)]
+	if !ok {
+		return fmt.Errorf("exchange.go WebsocketOrderbookLocal UpdateWithSequence() - no sequence loaded for Exchange %s CurrencyPair: %s AssetType: %s",
+			exchName,
+			p.Pair().String(),
+			assetType)
+	}
+
+	if lastSequence <= last {
+		// already applied
+		return nil
+	}
 
+	if firstSequence > last+1 {
+		w.invalidate(p, assetType)
+		w.sequenceGaps++
+		return fmt.Errorf("%s %s %s: %s, expected %d received %d",
+			exchName,
+			p.Pair().String(),
+			assetType,
+			ErrOrderbookSequenceGap,
+			last+1,
+			firstSequence)
+	}
+
+	err := w.update(bidTargets, askTargets, p, updated, exchName, assetType)
+	if err != nil {
+		return err
+	}
+	w.sequences[syncKey(p, assetType)] = lastSequence
+	return nil
+}
+
+// update applies bid and ask targets to the local orderbook, must be called
+// with the lock held
+func (w *WebsocketOrderbookLocal) update(bidTargets, askTargets []orderbook.Item,
+	p pair.CurrencyPair,
+	updated time.Time,
+	exchName, assetType string) error {
 	var orderbookAddress *orderbook.Base
 	for i := range w.ob {
 		if w.ob[i].Pair == p && w.ob[i].AssetType == assetType {
@@ -437,6 +1133,7 @@ func (w *WebsocketOrderbookLocal) Update(bidTargets, askTargets []orderbook.Item
 		}()
 	}
 
+	orderbookAddress.LastUpdated = updated
 	orderbook.ProcessOrderbook(exchName, p, *orderbookAddress, assetType)
 	return nil
 }
@@ -449,7 +1146,34 @@ func (w *WebsocketOrderbookLocal) LoadSnapshot(newOrderbook orderbook.Base, exch
 
 	w.m.Lock()
 	defer w.m.Unlock()
+	return w.loadSnapshot(newOrderbook, exchName)
+}
 
+// LoadSnapshotWithSequence loads an initial snapshot of orderbook data along
+// with the sequence number of the last update included in it, subsequent
+// updates are applied with UpdateWithSequence
+func (w *WebsocketOrderbookLocal) LoadSnapshotWithSequence(newOrderbook orderbook.Base, exchName string, sequence int64) error {
+	if len(newOrderbook.Asks) == 0 || len(newOrderbook.Bids) == 0 {
+		return errors.New("exchange.go websocket orderbook cache LoadSnapshotWithSequence() error - snapshot ask and bids are nil")
+	}
+
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	err := w.loadSnapshot(newOrderbook, exchName)
+	if err != nil {
+		return err
+	}
+
+	if w.sequences == nil {
+		w.sequences = make(map[string]int64)
+	}
+	w.sequences[syncKey(newOrderbook.Pair, newOrderbook.AssetType)] = sequence
+	return nil
+}
+
+// loadSnapshot stores a snapshot, must be called with the lock held
+func (w *WebsocketOrderbookLocal) loadSnapshot(newOrderbook orderbook.Base, exchName string) error {
 	for i := range w.ob {
 		if w.ob[i].Pair == newOrderbook.Pair && w.ob[i].AssetType == newOrderbook.AssetType {
 			return errors.New("exchange.go websocket orderbook cache LoadSnapshot() error - Snapshot instance already found")
@@ -458,6 +1182,7 @@ func (w *WebsocketOrderbookLocal) LoadSnapshot(newOrderbook orderbook.Base, exch
 
 	w.ob = append(w.ob, newOrderbook)
 	w.lastUpdated = newOrderbook.LastUpdated
+	w.setSynced(newOrderbook.Pair, newOrderbook.AssetType, true)
 
 	orderbook.ProcessOrderbook(exchName,
 		newOrderbook.Pair,
@@ -540,16 +1265,24 @@ func (w *WebsocketOrderbookLocal) UpdateUsingID(bidTargets, askTargets []orderbo
 		}
 	}
 
+	orderbookAddress.LastUpdated = updated
 	orderbook.ProcessOrderbook(exchName, p, *orderbookAddress, assetType)
 
 	return nil
 }
 
 // FlushCache flushes w.ob data to be garbage collected and refreshed when a
-// connection is lost and reconnected
+// connection is lost and reconnected. Every orderbook is marked as not synced
+// so diffs received after a reconnect are rejected until a fresh snapshot is
+// loaded
 func (w *WebsocketOrderbookLocal) FlushCache() {
 	w.m.Lock()
 	w.ob = nil
+	w.sequences = nil
+	for key := range w.synced {
+		w.synced[key] = false
+	}
+	w.lastUpdated = time.Time{}
 	w.m.Unlock()
 }
 
@@ -616,3 +1349,14 @@ type WebsocketPositionUpdated struct {
 	AssetType string
 	Exchange  string
 }
+
+// WebsocketBalanceUpdate reflects a change in an account balance. Snapshot is
+// set when the balance was fetched in full rather than pushed as a change
+type WebsocketBalanceUpdate struct {
+	Timestamp time.Time
+	Exchange  string
+	Currency  string
+	Available float64
+	Frozen    float64
+	Snapshot  bool
+}
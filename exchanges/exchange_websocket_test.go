@@ -1,9 +1,17 @@
 package exchange
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 )
@@ -309,3 +317,543 @@ func TestUpdate(t *testing.T) {
 		t.Error("test failed - OrderbookUpdate error", err)
 	}
 }
+
+func TestSetOrderbookDepth(t *testing.T) {
+	var w Websocket
+	supported := []int{5, 10, 20}
+
+	w.SetOrderbookDepth(0, 20, supported)
+	if w.GetOrderbookDepth() != 20 {
+		t.Errorf("test failed - SetOrderbookDepth() expected default 20, received %d",
+			w.GetOrderbookDepth())
+	}
+
+	w.SetOrderbookDepth(5, 20, supported)
+	if w.GetOrderbookDepth() != 5 {
+		t.Errorf("test failed - SetOrderbookDepth() expected 5, received %d",
+			w.GetOrderbookDepth())
+	}
+
+	w.SetOrderbookDepth(400, 20, supported)
+	if w.GetOrderbookDepth() != 20 {
+		t.Errorf("test failed - SetOrderbookDepth() expected fallback 20, received %d",
+			w.GetOrderbookDepth())
+	}
+}
+
+func TestOrderbookSync(t *testing.T) {
+	var w Websocket
+	p := pair.NewCurrencyPairFromString("BTCUSD")
+
+	if w.IsOrderbookSynced(p, "SPOT") {
+		t.Error("test failed - IsOrderbookSynced() should not be synced before snapshot")
+	}
+
+	snapShot := orderbook.Base{
+		Asks:        []orderbook.Item{{Price: 6001, Amount: 1}},
+		Bids:        []orderbook.Item{{Price: 5999, Amount: 1}},
+		AssetType:   "SPOT",
+		LastUpdated: time.Now(),
+		Pair:        p,
+	}
+
+	err := w.Orderbook.LoadSnapshot(snapShot, "SyncTest")
+	if err != nil {
+		t.Fatal("test failed - LoadSnapshot() error", err)
+	}
+
+	if !w.IsOrderbookSynced(p, "SPOT") {
+		t.Error("test failed - IsOrderbookSynced() should be synced after snapshot")
+	}
+
+	w.Orderbook.FlushCache()
+	if w.IsOrderbookSynced(p, "SPOT") {
+		t.Error("test failed - IsOrderbookSynced() should not be synced after flush")
+	}
+
+	err = w.Orderbook.Update([]orderbook.Item{{Price: 5999, Amount: 2}},
+		nil,
+		p,
+		time.Now(),
+		"SyncTest",
+		"SPOT")
+	if err == nil {
+		t.Error("test failed - Update() should not apply diffs before resync")
+	}
+
+	snapShot.LastUpdated = time.Now()
+	err = w.Orderbook.LoadSnapshot(snapShot, "SyncTest")
+	if err != nil {
+		t.Fatal("test failed - LoadSnapshot() resync error", err)
+	}
+
+	w.Orderbook.Invalidate(p, "SPOT")
+	if w.IsOrderbookSynced(p, "SPOT") {
+		t.Error("test failed - IsOrderbookSynced() should not be synced after Invalidate")
+	}
+}
+
+func TestUpdateWithSequence(t *testing.T) {
+	var w Websocket
+	p := pair.NewCurrencyPairFromString("BTCUSD")
+	snapShot := orderbook.Base{
+		Asks:        []orderbook.Item{{Price: 6001, Amount: 1}},
+		Bids:        []orderbook.Item{{Price: 5999, Amount: 1}},
+		AssetType:   "SPOT",
+		LastUpdated: time.Now(),
+		Pair:        p,
+	}
+
+	bids := []orderbook.Item{{Price: 5999, Amount: 2}}
+	err := w.Orderbook.UpdateWithSequence(bids, nil, p, time.Now(), "SequenceTest", "SPOT", 1, 1)
+	if err == nil {
+		t.Error("test failed - UpdateWithSequence() should not apply diffs before a snapshot")
+	}
+
+	err = w.Orderbook.LoadSnapshotWithSequence(snapShot, "SequenceTest", 10)
+	if err != nil {
+		t.Fatal("test failed - LoadSnapshotWithSequence() error", err)
+	}
+
+	// update overlapping the snapshot sequence is applied
+	err = w.Orderbook.UpdateWithSequence(bids, nil, p, time.Now(), "SequenceTest", "SPOT", 8, 12)
+	if err != nil {
+		t.Fatal("test failed - UpdateWithSequence() error", err)
+	}
+
+	ob, err := orderbook.GetOrderbook("SequenceTest", p, "SPOT")
+	if err != nil {
+		t.Fatal("test failed - GetOrderbook() error", err)
+	}
+
+	if ob.Bids[0].Amount != 2 {
+		t.Error("test failed - UpdateWithSequence() update was not applied")
+	}
+
+	// update already covered by the last applied sequence is dropped
+	err = w.Orderbook.UpdateWithSequence([]orderbook.Item{{Price: 5999, Amount: 3}},
+		nil, p, time.Now(), "SequenceTest", "SPOT", 11, 12)
+	if err != nil {
+		t.Error("test failed - UpdateWithSequence() stale update error", err)
+	}
+
+	ob, err = orderbook.GetOrderbook("SequenceTest", p, "SPOT")
+	if err != nil {
+		t.Fatal("test failed - GetOrderbook() error", err)
+	}
+
+	if ob.Bids[0].Amount != 2 {
+		t.Error("test failed - UpdateWithSequence() stale update was applied")
+	}
+
+	if w.Orderbook.SequenceGaps() != 0 {
+		t.Error("test failed - SequenceGaps() expected no gaps")
+	}
+
+	// update skipping sequence 13 is a gap
+	err = w.Orderbook.UpdateWithSequence(bids, nil, p, time.Now(), "SequenceTest", "SPOT", 14, 15)
+	if err == nil {
+		t.Error("test failed - UpdateWithSequence() expected a sequence gap error")
+	}
+
+	if w.Orderbook.SequenceGaps() != 1 {
+		t.Errorf("test failed - SequenceGaps() expected 1 gap, received %d",
+			w.Orderbook.SequenceGaps())
+	}
+
+	if w.IsOrderbookSynced(p, "SPOT") {
+		t.Error("test failed - IsOrderbookSynced() should not be synced after a sequence gap")
+	}
+
+	err = w.Orderbook.LoadSnapshotWithSequence(snapShot, "SequenceTest", 20)
+	if err != nil {
+		t.Fatal("test failed - LoadSnapshotWithSequence() resync error", err)
+	}
+
+	err = w.Orderbook.UpdateWithSequence(bids, nil, p, time.Now(), "SequenceTest", "SPOT", 21, 21)
+	if err != nil {
+		t.Error("test failed - UpdateWithSequence() error after resync", err)
+	}
+}
+
+func TestWebsocketSubscriptions(t *testing.T) {
+	cfg := config.GetConfig()
+	err := cfg.LoadConfig(config.ConfigTestFile)
+	if err != nil {
+		t.Fatal("test failed - unable to load config", err)
+	}
+
+	var b Base
+	b.WebsocketInit()
+	b.Websocket.SetExchangeName("Bitstamp")
+
+	err = b.Websocket.AddSubscription("ticker")
+	if err != nil {
+		t.Fatal("test failed - AddSubscription error", err)
+	}
+
+	if b.Websocket.GetPersistedSubscriptions() != nil {
+		t.Error("test failed - GetPersistedSubscriptions returned channels with persistence disabled")
+	}
+
+	b.Websocket.SetSubscriptionPersistence(true)
+	for _, channel := range []string{"ticker", "depth", "balance"} {
+		err = b.Websocket.AddSubscription(channel)
+		if err != nil {
+			t.Fatal("test failed - AddSubscription error", err)
+		}
+	}
+
+	err = b.Websocket.RemoveSubscription("depth")
+	if err != nil {
+		t.Fatal("test failed - RemoveSubscription error", err)
+	}
+
+	subs := b.Websocket.GetSubscriptions()
+	if len(subs) != 2 || subs[0] != "ticker" || subs[1] != "balance" {
+		t.Errorf("test failed - GetSubscriptions unexpected channels %v", subs)
+	}
+
+	var restarted Base
+	restarted.WebsocketInit()
+	restarted.Websocket.SetExchangeName("Bitstamp")
+	restarted.Websocket.SetSubscriptionPersistence(true)
+	persisted := restarted.Websocket.GetPersistedSubscriptions()
+	if len(persisted) != 2 || persisted[0] != "ticker" || persisted[1] != "balance" {
+		t.Errorf("test failed - GetPersistedSubscriptions unexpected channels %v", persisted)
+	}
+}
+
+func TestWebsocketSubscribe(t *testing.T) {
+	var w Websocket
+	w.SetExchangeName("test")
+	w.SetSubscribeAckTimeout(time.Millisecond * 20)
+
+	var sends int32
+	err := w.Subscribe("ticker", func() error {
+		atomic.AddInt32(&sends, 1)
+		go w.AcknowledgeSubscription("ticker", nil)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("test failed - Subscribe error", err)
+	}
+
+	subs := w.GetSubscriptions()
+	if len(subs) != 1 || subs[0] != "ticker" {
+		t.Errorf("test failed - Subscribe did not record the channel %v", subs)
+	}
+
+	err = w.Subscribe("depth", func() error {
+		go w.AcknowledgeSubscription("depth", errors.New("invalid channel"))
+		return nil
+	})
+	if !errors.Is(err, ErrSubscriptionRejected) {
+		t.Errorf("test failed - Subscribe expected a rejection, received %v", err)
+	}
+
+	atomic.StoreInt32(&sends, 0)
+	err = w.Subscribe("trades", func() error {
+		atomic.AddInt32(&sends, 1)
+		return nil
+	})
+	if !errors.Is(err, ErrSubscribeAckTimeout) {
+		t.Errorf("test failed - Subscribe expected an acknowledgement timeout, received %v", err)
+	}
+
+	if atomic.LoadInt32(&sends) != websocketSubscribeAttempts {
+		t.Errorf("test failed - Subscribe expected %d attempts, received %d",
+			websocketSubscribeAttempts, sends)
+	}
+
+	if len(w.GetSubscriptions()) != 1 {
+		t.Errorf("test failed - Subscribe recorded a failed subscription %v", w.GetSubscriptions())
+	}
+
+	sendErr := errors.New("connection closed")
+	err = w.Subscribe("kline", func() error {
+		return sendErr
+	})
+	if err != sendErr {
+		t.Errorf("test failed - Subscribe expected the send error, received %v", err)
+	}
+
+	if w.AcknowledgeSubscription("ticker", nil) {
+		t.Error("test failed - AcknowledgeSubscription accepted a channel not awaiting a response")
+	}
+}
+
+func TestWebsocketSubscribeBatch(t *testing.T) {
+	var w Websocket
+	w.SetExchangeName("test")
+	w.SetSubscribeAckTimeout(time.Millisecond * 20)
+
+	var batches [][]string
+	results := w.SubscribeBatch([]string{"ticker", "depth", "trades"}, func(channels []string) error {
+		batches = append(batches, channels)
+		for _, channel := range channels {
+			switch channel {
+			case "ticker":
+				go w.AcknowledgeSubscription(channel, nil)
+			case "depth":
+				go w.AcknowledgeSubscription(channel, errors.New("invalid channel"))
+			}
+		}
+		return nil
+	})
+
+	if len(batches) != websocketSubscribeAttempts || len(batches[0]) != 3 ||
+		len(batches[1]) != 1 || batches[1][0] != "trades" {
+		t.Errorf("test failed - SubscribeBatch unexpected batches sent %v", batches)
+	}
+
+	if results["ticker"] != nil {
+		t.Error("test failed - SubscribeBatch ticker error", results["ticker"])
+	}
+
+	if !errors.Is(results["depth"], ErrSubscriptionRejected) {
+		t.Errorf("test failed - SubscribeBatch expected a rejection, received %v", results["depth"])
+	}
+
+	if !errors.Is(results["trades"], ErrSubscribeAckTimeout) {
+		t.Errorf("test failed - SubscribeBatch expected an acknowledgement timeout, received %v",
+			results["trades"])
+	}
+
+	subs := w.GetSubscriptions()
+	if len(subs) != 1 || subs[0] != "ticker" {
+		t.Errorf("test failed - SubscribeBatch recorded unexpected channels %v", subs)
+	}
+}
+
+type writeTestConnection struct {
+	writing  int32
+	messages int32
+	failed   int32
+}
+
+func (c *writeTestConnection) WriteMessage(messageType int, data []byte) error {
+	if !atomic.CompareAndSwapInt32(&c.writing, 0, 1) {
+		atomic.StoreInt32(&c.failed, 1)
+		return errors.New("concurrent write to websocket connection")
+	}
+	time.Sleep(time.Microsecond)
+	atomic.AddInt32(&c.messages, 1)
+	atomic.StoreInt32(&c.writing, 0)
+	return nil
+}
+
+func TestSendMessage(t *testing.T) {
+	var w Websocket
+	conn := &writeTestConnection{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < websocketWriteQueueSize*2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			if i%2 == 0 {
+				err = w.SendMessage(conn, websocket.TextMessage, []byte("test"))
+			} else {
+				err = w.SendJSON(conn, map[string]int{"id": i})
+			}
+			if err != nil {
+				t.Error("test failed - SendMessage() error", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if atomic.LoadInt32(&conn.failed) != 0 {
+		t.Error("test failed - SendMessage() wrote to the connection concurrently")
+	}
+
+	if atomic.LoadInt32(&conn.messages) != websocketWriteQueueSize*2 {
+		t.Errorf("test failed - SendMessage() expected %d messages written, received %d",
+			websocketWriteQueueSize*2, conn.messages)
+	}
+
+	err := w.SendMessage(nil, websocket.TextMessage, []byte("test"))
+	if err == nil {
+		t.Error("test failed - SendMessage() expected an error without a connection")
+	}
+
+	var unconnected *websocket.Conn
+	err = w.SendMessage(unconnected, websocket.TextMessage, []byte("test"))
+	if err == nil {
+		t.Error("test failed - SendMessage() expected an error with a nil connection")
+	}
+
+	w.stopWriter()
+	if w.writeQueue != nil {
+		t.Error("test failed - stopWriter() did not close the write queue")
+	}
+
+	deadlineConn := &deadlineTestConnection{}
+	err = w.SendMessage(deadlineConn, websocket.TextMessage, []byte("test"))
+	if err != nil {
+		t.Error("test failed - SendMessage() error after the writer was stopped", err)
+	}
+
+	if deadlineConn.deadline.IsZero() || time.Until(deadlineConn.deadline) > websocketWriteTimeout {
+		t.Errorf("test failed - SendMessage() unexpected write deadline %s", deadlineConn.deadline)
+	}
+	w.stopWriter()
+}
+
+type deadlineTestConnection struct {
+	writeTestConnection
+	deadline time.Time
+}
+
+func (c *deadlineTestConnection) SetWriteDeadline(t time.Time) error {
+	c.deadline = t
+	return nil
+}
+
+type compressedTestConn struct {
+	messageType int
+	data        []byte
+}
+
+func (c *compressedTestConn) ReadMessage() (int, []byte, error) {
+	return c.messageType, c.data, nil
+}
+
+func TestReadMessageCompression(t *testing.T) {
+	payload := []byte(`{"ch":"market.btcusdt.depth.step0"}`)
+
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write(payload)
+	gw.Close()
+
+	var deflated bytes.Buffer
+	fw, err := flate.NewWriter(&deflated, flate.DefaultCompression)
+	if err != nil {
+		t.Fatal("test failed - flate.NewWriter() error", err)
+	}
+	fw.Write(payload)
+	fw.Close()
+
+	tests := []struct {
+		compression WebsocketCompression
+		messageType int
+		data        []byte
+	}{
+		{WebsocketCompressionNone, websocket.BinaryMessage, payload},
+		{WebsocketCompressionGzip, websocket.BinaryMessage, gzipped.Bytes()},
+		{WebsocketCompressionDeflate, websocket.BinaryMessage, deflated.Bytes()},
+		{WebsocketCompressionDeflate, websocket.TextMessage, payload},
+	}
+
+	var w Websocket
+	for _, test := range tests {
+		w.SetCompression(test.compression)
+		_, data, err := w.ReadMessage(&compressedTestConn{test.messageType, test.data})
+		if err != nil {
+			t.Errorf("test failed - ReadMessage() %q error %s", test.compression, err)
+		}
+		if !bytes.Equal(data, payload) {
+			t.Errorf("test failed - ReadMessage() %q unexpected data %s", test.compression, data)
+		}
+	}
+
+	w.SetCompression(WebsocketCompressionGzip)
+	_, _, err = w.ReadMessage(&compressedTestConn{websocket.BinaryMessage, payload})
+	if err == nil {
+		t.Error("test failed - ReadMessage() expected an error for an uncompressed gzip frame")
+	}
+
+	w.SetCompression("brotli")
+	_, err = w.Decompress(websocket.BinaryMessage, payload)
+	if err == nil {
+		t.Error("test failed - Decompress() expected an error for an unsupported compression")
+	}
+}
+
+type failingTestWriter struct {
+	writes int
+}
+
+func (f *failingTestWriter) Write(p []byte) (int, error) {
+	f.writes++
+	return 0, errors.New("disk full")
+}
+
+func TestRawMessageTap(t *testing.T) {
+	defer func(now func() time.Time) { Now = now }(Now)
+	received := time.Date(2018, 10, 16, 1, 2, 3, 0, time.UTC)
+	Now = func() time.Time { return received }
+
+	payload := []byte(`{"ch":"market.btcusdt.depth.step0"}`)
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write(payload)
+	gw.Close()
+
+	w := Websocket{exchangeName: "tap"}
+	w.SetCompression(WebsocketCompressionGzip)
+
+	var out bytes.Buffer
+	ch := make(chan WebsocketRawMessage, 1)
+	w.SetRawMessageWriter(&out)
+	w.SetRawMessageChannel(ch)
+
+	conn := &compressedTestConn{websocket.BinaryMessage, gzipped.Bytes()}
+	_, data, err := w.ReadMessage(conn)
+	if err != nil {
+		t.Fatal("test failed - ReadMessage() error", err)
+	}
+
+	if out.String() != "2018-10-16T01:02:03Z tap "+string(payload)+"\n" {
+		t.Errorf("test failed - raw message writer unexpected output %q", out.String())
+	}
+
+	msg := <-ch
+	if msg.Exchange != "tap" || !msg.Timestamp.Equal(received) ||
+		msg.MessageType != websocket.BinaryMessage || !bytes.Equal(msg.Data, payload) {
+		t.Errorf("test failed - raw message channel unexpected message %+v", msg)
+	}
+
+	data[0] = '['
+	if msg.Data[0] != '{' {
+		t.Error("test failed - raw message channel data shares memory with the parsed message")
+	}
+
+	// a full channel drops the message rather than blocking the reader
+	ch <- WebsocketRawMessage{}
+	if _, _, err = w.ReadMessage(conn); err != nil {
+		t.Fatal("test failed - ReadMessage() error", err)
+	}
+	<-ch
+
+	// undecompressable frames are delivered as received
+	_, _, err = w.ReadMessage(&compressedTestConn{websocket.BinaryMessage, payload})
+	if err == nil {
+		t.Error("test failed - ReadMessage() expected an error for an uncompressed gzip frame")
+	}
+	if msg = <-ch; !bytes.Equal(msg.Data, payload) {
+		t.Errorf("test failed - raw message channel unexpected data %s", msg.Data)
+	}
+
+	w.SetRawMessageWriter(nil)
+	w.SetRawMessageChannel(nil)
+	out.Reset()
+	if _, _, err = w.ReadMessage(conn); err != nil {
+		t.Fatal("test failed - ReadMessage() error", err)
+	}
+	if out.Len() != 0 || len(ch) != 0 {
+		t.Error("test failed - raw message tap received a message after being removed")
+	}
+
+	failing := &failingTestWriter{}
+	w.SetRawMessageWriter(failing)
+	w.ReadMessage(conn)
+	w.ReadMessage(conn)
+	if failing.writes != 1 {
+		t.Errorf("test failed - expected a failing raw message writer to be removed, received %d writes",
+			failing.writes)
+	}
+}
@@ -0,0 +1,198 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+)
+
+// Withdrawal errors
+var (
+	// ErrUnsupported is returned by Withdraw when the exchange cannot withdraw
+	// the type of currency requested via the API
+	ErrUnsupported = errors.New("withdrawal type is not supported by the exchange")
+	// ErrWithdrawalStatusNotSupported is returned when the exchange cannot
+	// report the status of a withdrawal
+	ErrWithdrawalStatusNotSupported = errors.New("withdrawal status is not supported by the exchange")
+)
+
+// WithdrawalStatus is the progress of a submitted withdrawal
+type WithdrawalStatus string
+
+// Withdrawal statuses, a withdrawal moves from pending to processing and ends
+// completed or failed
+const (
+	WithdrawalPending    WithdrawalStatus = "PENDING"
+	WithdrawalProcessing WithdrawalStatus = "PROCESSING"
+	WithdrawalCompleted  WithdrawalStatus = "COMPLETED"
+	WithdrawalFailed     WithdrawalStatus = "FAILED"
+)
+
+// IsTerminal returns whether the withdrawal has completed or failed
+func (w WithdrawalStatus) IsTerminal() bool {
+	return w == WithdrawalCompleted || w == WithdrawalFailed
+}
+
+// WithdrawalStatusGetter is implemented by exchanges which can return the
+// status of a withdrawal by its ID
+type WithdrawalStatusGetter interface {
+	GetWithdrawalStatus(withdrawalID string) (WithdrawalStatus, error)
+}
+
+// GetWithdrawalStatus returns the status of a withdrawal. Exchanges which do
+// not implement WithdrawalStatusGetter return ErrWithdrawalStatusNotSupported
+func GetWithdrawalStatus(exch IBotExchange, withdrawalID string) (WithdrawalStatus, error) {
+	getter, ok := exch.(WithdrawalStatusGetter)
+	if !ok {
+		return "", ErrWithdrawalStatusNotSupported
+	}
+	return getter.GetWithdrawalStatus(withdrawalID)
+}
+
+// WithdrawalStatusChange is sent by WatchWithdrawals when a withdrawal's
+// status changes, Previous is empty for the first status received. Err is set
+// when the status could not be polled, the withdrawal is polled again on the
+// next interval
+type WithdrawalStatusChange struct {
+	Exchange     string
+	WithdrawalID string
+	Previous     WithdrawalStatus
+	Status       WithdrawalStatus
+	Err          error
+}
+
+// Withdraw withdraws a currency with WithdrawFiatFunds when it is a fiat
+// currency and WithdrawCryptocurrencyFunds otherwise. The address and chain
+// are only used for cryptocurrency withdrawals, fiat withdrawals are sent to
+// the bank account configured for the exchange. Every withdrawal attempt is
+// recorded to the journal
+func Withdraw(exch IBotExchange, c pair.CurrencyItem, address, chain string, amount float64) (string, error) {
+	id, err := withdraw(exch, c, address, chain, amount)
+	if GetJournal() != nil {
+		RecordJournal(exch.GetName(), JournalWithdraw, map[string]interface{}{
+			"currency": c.String(),
+			"address":  address,
+			"chain":    chain,
+			"amount":   amount,
+		}, id, err)
+	}
+	return id, err
+}
+
+// withdraw routes a withdrawal to WithdrawFiatFunds or
+// WithdrawCryptocurrencyFunds
+func withdraw(exch IBotExchange, c pair.CurrencyItem, address, chain string, amount float64) (string, error) {
+	if amount <= 0 {
+		return "", fmt.Errorf("%s invalid withdrawal amount %v", exch.GetName(), amount)
+	}
+
+	if symbol.IsFiat(c.String()) {
+		if !canWithdrawFiatViaAPI(exch.GetWithdrawPermissions()) {
+			return "", withdrawUnsupported(exch, "fiat", ErrFiatWithdrawViaWebsiteOnly)
+		}
+
+		id, err := exch.WithdrawFiatFunds(c, amount)
+		if err == common.ErrFunctionNotSupported || err == common.ErrNotYetImplemented {
+			return "", withdrawUnsupported(exch, "fiat", err)
+		}
+		return id, err
+	}
+
+	if address == "" {
+		return "", fmt.Errorf("%s %s withdrawal address not set", exch.GetName(), c)
+	}
+
+	if !canWithdrawCryptoViaAPI(exch.GetWithdrawPermissions()) {
+		return "", withdrawUnsupported(exch, "cryptocurrency", ErrCryptoWithdrawViaWebsiteOnly)
+	}
+
+	id, err := exch.WithdrawCryptocurrencyFunds(address, c, chain, amount)
+	if err == common.ErrFunctionNotSupported || err == common.ErrNotYetImplemented {
+		return "", withdrawUnsupported(exch, "cryptocurrency", err)
+	}
+	return id, err
+}
+
+// withdrawUnsupported returns ErrUnsupported with the exchange, withdrawal type
+// and underlying reason
+func withdrawUnsupported(exch IBotExchange, withdrawalType string, reason error) error {
+	return fmt.Errorf("%s %s %w: %s", exch.GetName(), withdrawalType, ErrUnsupported, reason)
+}
+
+// WatchWithdrawals polls the status of each withdrawal every interval and sends
+// a WithdrawalStatusChange when a status changes. The returned channel is
+// closed once every withdrawal has completed or failed, or when stop is
+// closed. Exchanges which do not implement WithdrawalStatusGetter return
+// ErrWithdrawalStatusNotSupported
+func WatchWithdrawals(exch IBotExchange, withdrawalIDs []string, interval time.Duration, stop <-chan struct{}) (<-chan WithdrawalStatusChange, error) {
+	getter, ok := exch.(WithdrawalStatusGetter)
+	if !ok {
+		return nil, ErrWithdrawalStatusNotSupported
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("%s invalid withdrawal poll interval %v", exch.GetName(), interval)
+	}
+
+	changes := make(chan WithdrawalStatusChange)
+	go func() {
+		defer close(changes)
+
+		statuses := make(map[string]WithdrawalStatus, len(withdrawalIDs))
+		poll := time.NewTicker(interval)
+		defer poll.Stop()
+		for {
+			pending := 0
+			for _, id := range withdrawalIDs {
+				previous, seen := statuses[id]
+				if seen && previous.IsTerminal() {
+					continue
+				}
+
+				status, err := getter.GetWithdrawalStatus(id)
+				if err == nil && seen && status == previous {
+					pending++
+					continue
+				}
+
+				change := WithdrawalStatusChange{
+					Exchange:     exch.GetName(),
+					WithdrawalID: id,
+					Previous:     previous,
+					Status:       status,
+					Err:          err,
+				}
+				if err != nil {
+					change.Status = previous
+				} else {
+					statuses[id] = status
+				}
+
+				if !change.Status.IsTerminal() {
+					pending++
+				}
+
+				select {
+				case changes <- change:
+				case <-stop:
+					return
+				}
+			}
+
+			if pending == 0 {
+				return
+			}
+
+			select {
+			case <-poll.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return changes, nil
+}
@@ -0,0 +1,176 @@
+package exchange
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+type withdrawTestExchange struct {
+	IBotExchange
+	permissions uint32
+	fiatErr     error
+}
+
+func (w *withdrawTestExchange) GetName() string {
+	return "test"
+}
+
+func (w *withdrawTestExchange) GetWithdrawPermissions() uint32 {
+	return w.permissions
+}
+
+func (w *withdrawTestExchange) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	return "crypto", nil
+}
+
+func (w *withdrawTestExchange) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if w.fiatErr != nil {
+		return "", w.fiatErr
+	}
+	return "fiat", nil
+}
+
+func TestWithdraw(t *testing.T) {
+	exch := &withdrawTestExchange{permissions: AutoWithdrawCrypto | AutoWithdrawFiat}
+	id, err := Withdraw(exch, pair.CurrencyItem("aud"), "", "", 100)
+	if err != nil || id != "fiat" {
+		t.Errorf("Test failed. Withdraw expected a fiat withdrawal, received %s %v", id, err)
+	}
+
+	id, err = Withdraw(exch, pair.CurrencyItem("BTC"), "address", "", 1)
+	if err != nil || id != "crypto" {
+		t.Errorf("Test failed. Withdraw expected a cryptocurrency withdrawal, received %s %v", id, err)
+	}
+
+	if _, err = Withdraw(exch, pair.CurrencyItem("BTC"), "", "", 1); err == nil {
+		t.Error("Test failed. Withdraw expected an error without an address")
+	}
+
+	if _, err = Withdraw(exch, pair.CurrencyItem("BTC"), "address", "", 0); err == nil {
+		t.Error("Test failed. Withdraw expected an error with a zero amount")
+	}
+}
+
+func TestWithdrawUnsupported(t *testing.T) {
+	exch := &withdrawTestExchange{permissions: AutoWithdrawCrypto | WithdrawFiatViaWebsiteOnly}
+	if _, err := Withdraw(exch, pair.CurrencyItem("USD"), "", "", 100); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Test failed. Withdraw expected %s, received %v", ErrUnsupported, err)
+	}
+
+	exch = &withdrawTestExchange{permissions: WithdrawCryptoViaWebsiteOnly | AutoWithdrawFiat}
+	if _, err := Withdraw(exch, pair.CurrencyItem("BTC"), "address", "", 1); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Test failed. Withdraw expected %s, received %v", ErrUnsupported, err)
+	}
+
+	exch = &withdrawTestExchange{
+		permissions: AutoWithdrawFiat,
+		fiatErr:     common.ErrFunctionNotSupported,
+	}
+	if _, err := Withdraw(exch, pair.CurrencyItem("USD"), "", "", 100); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Test failed. Withdraw expected %s, received %v", ErrUnsupported, err)
+	}
+}
+
+type withdrawalStatusTestExchange struct {
+	IBotExchange
+	m        sync.Mutex
+	statuses map[string][]WithdrawalStatus
+}
+
+func (w *withdrawalStatusTestExchange) GetName() string {
+	return "test"
+}
+
+// GetWithdrawalStatus returns the next status queued for a withdrawal, the
+// last status is repeated once the queue is exhausted
+func (w *withdrawalStatusTestExchange) GetWithdrawalStatus(withdrawalID string) (WithdrawalStatus, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	queue := w.statuses[withdrawalID]
+	if len(queue) == 0 {
+		return "", errors.New("withdrawal not found")
+	}
+
+	status := queue[0]
+	if len(queue) > 1 {
+		w.statuses[withdrawalID] = queue[1:]
+	}
+	return status, nil
+}
+
+func TestWatchWithdrawals(t *testing.T) {
+	exch := &withdrawalStatusTestExchange{statuses: map[string][]WithdrawalStatus{
+		"1": {WithdrawalPending, WithdrawalPending, WithdrawalProcessing, WithdrawalCompleted},
+		"2": {WithdrawalProcessing, WithdrawalFailed},
+	}}
+
+	changes, err := WatchWithdrawals(exch, []string{"1", "2"}, time.Millisecond, nil)
+	if err != nil {
+		t.Fatal("Test failed. WatchWithdrawals error", err)
+	}
+
+	received := make(map[string][]WithdrawalStatus)
+	timeout := time.After(time.Second * 5)
+	for done := false; !done; {
+		select {
+		case change, ok := <-changes:
+			if !ok {
+				done = true
+				break
+			}
+			if change.Err != nil {
+				t.Fatal("Test failed. WatchWithdrawals unexpected error", change.Err)
+			}
+			received[change.WithdrawalID] = append(received[change.WithdrawalID], change.Status)
+		case <-timeout:
+			t.Fatal("Test failed. WatchWithdrawals did not stop once every withdrawal finished")
+		}
+	}
+
+	expected := map[string][]WithdrawalStatus{
+		"1": {WithdrawalPending, WithdrawalProcessing, WithdrawalCompleted},
+		"2": {WithdrawalProcessing, WithdrawalFailed},
+	}
+	for id, statuses := range expected {
+		if !reflect.DeepEqual(received[id], statuses) {
+			t.Errorf("Test failed. WatchWithdrawals withdrawal %s expected %v, received %v",
+				id, statuses, received[id])
+		}
+	}
+
+	if _, err = WatchWithdrawals(&withdrawTestExchange{}, []string{"1"}, time.Second, nil); err != ErrWithdrawalStatusNotSupported {
+		t.Errorf("Test failed. WatchWithdrawals expected %s, received %v", ErrWithdrawalStatusNotSupported, err)
+	}
+}
+
+func TestWatchWithdrawalsStop(t *testing.T) {
+	exch := &withdrawalStatusTestExchange{statuses: map[string][]WithdrawalStatus{
+		"1": {WithdrawalPending},
+	}}
+
+	stop := make(chan struct{})
+	changes, err := WatchWithdrawals(exch, []string{"1"}, time.Millisecond, stop)
+	if err != nil {
+		t.Fatal("Test failed. WatchWithdrawals error", err)
+	}
+
+	if change := <-changes; change.Status != WithdrawalPending {
+		t.Errorf("Test failed. WatchWithdrawals expected %s, received %s", WithdrawalPending, change.Status)
+	}
+
+	close(stop)
+	select {
+	case _, ok := <-changes:
+		if ok {
+			t.Error("Test failed. WatchWithdrawals sent a change after being stopped")
+		}
+	case <-time.After(time.Second * 5):
+		t.Error("Test failed. WatchWithdrawals did not stop")
+	}
+}
@@ -51,6 +51,10 @@ type EXMO struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.RegisterExchange("exmo", func() exchange.IBotExchange { return new(EXMO) })
+}
+
 // SetDefaults sets the basic defaults for exmo
 func (e *EXMO) SetDefaults() {
 	e.Name = "EXMO"
@@ -73,6 +77,14 @@ func (e *EXMO) SetDefaults() {
 	e.APIUrlDefault = exmoAPIURL
 	e.APIUrl = e.APIUrlDefault
 	e.WebsocketInit()
+	e.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	e.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	e.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	e.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	e.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	e.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	e.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	e.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
@@ -82,15 +94,20 @@ func (e *EXMO) Setup(exch config.ExchangeConfig) {
 	} else {
 		e.Enabled = true
 		e.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		e.Verbose = exch.Verbose
 		e.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		e.SetHTTPClientTimeout(exch.HTTPTimeout)
+		e.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		e.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := e.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		e.RESTPollingDelay = exch.RESTPollingDelay
-		e.Verbose = exch.Verbose
 		e.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		e.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		e.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := e.SetCurrencyPairFormat()
+		err = e.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -98,6 +115,10 @@ func (e *EXMO) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = e.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = e.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -42,6 +42,10 @@ type Gateio struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.RegisterExchange("gateio", func() exchange.IBotExchange { return new(Gateio) })
+}
+
 // SetDefaults sets default values for the exchange
 func (g *Gateio) SetDefaults() {
 	g.Name = "GateIO"
@@ -65,6 +69,14 @@ func (g *Gateio) SetDefaults() {
 	g.APIUrlSecondaryDefault = gateioMarketURL
 	g.APIUrlSecondary = g.APIUrlSecondaryDefault
 	g.WebsocketInit()
+	g.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	g.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	g.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	g.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	g.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	g.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	g.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	g.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets user configuration
@@ -74,16 +86,21 @@ func (g *Gateio) Setup(exch config.ExchangeConfig) {
 	} else {
 		g.Enabled = true
 		g.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		g.Verbose = exch.Verbose
 		g.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		g.APIAuthPEMKey = exch.APIAuthPEMKey
 		g.SetHTTPClientTimeout(exch.HTTPTimeout)
+		g.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		g.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := g.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		g.RESTPollingDelay = exch.RESTPollingDelay
-		g.Verbose = exch.Verbose
 		g.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		g.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		g.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := g.SetCurrencyPairFormat()
+		err = g.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -91,6 +108,10 @@ func (g *Gateio) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = g.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = g.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
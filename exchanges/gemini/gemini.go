@@ -96,6 +96,10 @@ func AddSession(g *Gemini, sessionID int, apiKey, apiSecret, role string, needsH
 	return nil
 }
 
+func init() {
+	exchange.RegisterExchange("gemini", func() exchange.IBotExchange { return new(Gemini) })
+}
+
 // SetDefaults sets package defaults for gemini exchange
 func (g *Gemini) SetDefaults() {
 	g.Name = "Gemini"
@@ -117,6 +121,14 @@ func (g *Gemini) SetDefaults() {
 	g.APIUrlDefault = geminiAPIURL
 	g.APIUrl = g.APIUrlDefault
 	g.WebsocketInit()
+	g.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	g.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	g.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	g.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	g.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	g.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	g.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	g.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets exchange configuration parameters
@@ -126,16 +138,21 @@ func (g *Gemini) Setup(exch config.ExchangeConfig) {
 	} else {
 		g.Enabled = true
 		g.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		g.Verbose = exch.Verbose
 		g.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		g.SetHTTPClientTimeout(exch.HTTPTimeout)
+		g.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		g.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := g.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		g.RESTPollingDelay = exch.RESTPollingDelay
-		g.Verbose = exch.Verbose
 		g.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		g.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		g.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 
-		err := g.SetCurrencyPairFormat()
+		err = g.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -143,6 +160,10 @@ func (g *Gemini) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = g.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = g.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -53,6 +53,10 @@ type HitBTC struct {
 	WebsocketConn *websocket.Conn
 }
 
+func init() {
+	exchange.RegisterExchange("hitbtc", func() exchange.IBotExchange { return new(HitBTC) })
+}
+
 // SetDefaults sets default settings for hitbtc
 func (h *HitBTC) SetDefaults() {
 	h.Name = "HitBTC"
@@ -75,6 +79,13 @@ func (h *HitBTC) SetDefaults() {
 	h.APIUrlDefault = apiURL
 	h.APIUrl = h.APIUrlDefault
 	h.WebsocketInit()
+	h.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	h.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	h.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets user exchange configuration settings
@@ -84,16 +95,21 @@ func (h *HitBTC) Setup(exch config.ExchangeConfig) {
 	} else {
 		h.Enabled = true
 		h.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		h.Verbose = exch.Verbose
 		h.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		h.SetHTTPClientTimeout(exch.HTTPTimeout)
+		h.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		h.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := h.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		h.RESTPollingDelay = exch.RESTPollingDelay // Max 60000ms
-		h.Verbose = exch.Verbose
 		h.Websocket.SetEnabled(exch.Websocket)
 		h.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		h.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		h.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := h.SetCurrencyPairFormat()
+		err = h.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -101,6 +117,10 @@ func (h *HitBTC) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = h.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = h.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
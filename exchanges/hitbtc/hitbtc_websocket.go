@@ -69,7 +69,7 @@ func (h *HitBTC) WsSubscribe() error {
 			return err
 		}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, tickerSubReq)
+		err = h.Websocket.SendMessage(h.WebsocketConn, websocket.TextMessage, tickerSubReq)
 		if err != nil {
 			return nil
 		}
@@ -83,7 +83,7 @@ func (h *HitBTC) WsSubscribe() error {
 			return err
 		}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, orderbookSubReq)
+		err = h.Websocket.SendMessage(h.WebsocketConn, websocket.TextMessage, orderbookSubReq)
 		if err != nil {
 			return nil
 		}
@@ -97,7 +97,7 @@ func (h *HitBTC) WsSubscribe() error {
 			return err
 		}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, tradeSubReq)
+		err = h.Websocket.SendMessage(h.WebsocketConn, websocket.TextMessage, tradeSubReq)
 		if err != nil {
 			return nil
 		}
@@ -124,7 +124,7 @@ func (h *HitBTC) WsReadData() {
 			return
 
 		default:
-			_, resp, err := h.WebsocketConn.ReadMessage()
+			_, resp, err := h.Websocket.ReadMessage(h.WebsocketConn)
 			if err != nil {
 				h.Websocket.DataHandler <- err
 				return
@@ -13,6 +13,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*HitBTC)(nil)
+
 // Start starts the HitBTC go routine
 func (h *HitBTC) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -30,7 +32,11 @@ func (h *HitBTC) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", h.GetName(), len(h.EnabledPairs), h.EnabledPairs)
 	}
 
-	exchangeProducts, err := h.GetSymbolsDetailed()
+	var exchangeProducts []Symbol
+	err := h.RetryPairUpdate(func() (err error) {
+		exchangeProducts, err = h.GetSymbolsDetailed()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available symbols.\n", h.GetName())
 	} else {
@@ -52,15 +58,22 @@ func (h *HitBTC) Run() {
 				log.Printf("%s Failed to update enabled currencies.\n", h.GetName())
 			}
 		}
-		err = h.UpdateCurrencies(currencies, false, forceUpgrade)
-		if err != nil {
-			log.Printf("%s Failed to update available currencies.\n", h.GetName())
+		if h.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = h.UpdateCurrencies(currencies, false, forceUpgrade)
+			if err != nil {
+				log.Printf("%s Failed to update available currencies.\n", h.GetName())
+			}
 		}
 	}
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (h *HitBTC) UpdateTicker(currencyPair pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := h.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	tick, err := h.GetTicker("")
 	if err != nil {
 		return ticker.Price{}, err
@@ -83,6 +96,7 @@ func (h *HitBTC) UpdateTicker(currencyPair pair.CurrencyPair, assetType string)
 
 // GetTickerPrice returns the ticker for a currency pair
 func (h *HitBTC) GetTickerPrice(currencyPair pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = h.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(h.GetName(), currencyPair, assetType)
 	if err != nil {
 		return h.UpdateTicker(currencyPair, assetType)
@@ -92,6 +106,7 @@ func (h *HitBTC) GetTickerPrice(currencyPair pair.CurrencyPair, assetType string
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (h *HitBTC) GetOrderbookEx(currencyPair pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = h.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(h.GetName(), currencyPair, assetType)
 	if err != nil {
 		return h.UpdateOrderbook(currencyPair, assetType)
@@ -101,6 +116,11 @@ func (h *HitBTC) GetOrderbookEx(currencyPair pair.CurrencyPair, assetType string
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (h *HitBTC) UpdateOrderbook(currencyPair pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := h.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := h.GetOrderbook(exchange.FormatExchangeCurrency(h.GetName(), currencyPair).String(), 1000)
 	if err != nil {
@@ -141,6 +161,16 @@ func (h *HitBTC) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (h *HitBTC) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := h.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return h.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (h *HitBTC) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -157,8 +187,15 @@ func (h *HitBTC) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (h *HitBTC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
-	response, err := h.PlaceOrder(p.Pair().String(), price, amount, common.StringToLower(orderType.ToString()), common.StringToLower(side.ToString()))
+	resp, err := h.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (h *HitBTC) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
+	response, err := h.PlaceOrder(order.Pair.Pair().String(), order.Price, order.Amount, common.StringToLower(order.OrderType.ToString()), common.StringToLower(order.OrderSide.ToString()))
 
 	if response.OrderNumber > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response.OrderNumber)
@@ -214,19 +251,27 @@ func (h *HitBTC) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (h *HitBTC) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (h *HitBTC) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (h *HitBTC) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (h *HitBTC) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := h.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (h *HitBTC) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := h.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
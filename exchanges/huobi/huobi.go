@@ -57,6 +57,20 @@ const (
 	huobiMarginAccountBalance  = "margin/accounts/balance"
 	huobiWithdrawCreate        = "dw/withdraw/api/create"
 	huobiWithdrawCancel        = "dw/withdraw-virtual/%s/cancel"
+	huobiDepositWithdrawals    = "query/deposit-withdraw"
+
+	// v2 endpoints, these include their version
+	huobiReferenceCurrencies = "/v2/reference/currencies"
+	huobiDepositAddress      = "/v2/account/deposit/address"
+
+	// huobiMatchResultsLimit is the most match results returned per request
+	huobiMatchResultsLimit = 100
+	// huobiTradeVolumeCurrency is the currency Huobi assesses fee tiers in
+	huobiTradeVolumeCurrency = "usdt"
+
+	// huobiWithdrawalType selects withdrawals from the deposit and withdrawal
+	// history
+	huobiWithdrawalType = "withdraw"
 
 	huobiAuthRate   = 100
 	huobiUnauthRate = 100
@@ -69,6 +83,10 @@ type HUOBI struct {
 	WebsocketConn *websocket.Conn
 }
 
+func init() {
+	exchange.RegisterExchange("huobi", func() exchange.IBotExchange { return new(HUOBI) })
+}
+
 // SetDefaults sets default values for the exchange
 func (h *HUOBI) SetDefaults() {
 	h.Name = "Huobi"
@@ -91,6 +109,11 @@ func (h *HUOBI) SetDefaults() {
 	h.APIUrlDefault = huobiAPIURL
 	h.APIUrl = h.APIUrlDefault
 	h.WebsocketInit()
+	h.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	h.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnsupported)
 }
 
 // Setup sets user configuration
@@ -100,18 +123,23 @@ func (h *HUOBI) Setup(exch config.ExchangeConfig) {
 	} else {
 		h.Enabled = true
 		h.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		h.Verbose = exch.Verbose
 		h.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		h.APIAuthPEMKeySupport = exch.APIAuthPEMKeySupport
 		h.APIAuthPEMKey = exch.APIAuthPEMKey
 		h.SetHTTPClientTimeout(exch.HTTPTimeout)
+		h.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		h.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := h.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		h.RESTPollingDelay = exch.RESTPollingDelay
-		h.Verbose = exch.Verbose
 		h.Websocket.SetEnabled(exch.Websocket)
 		h.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		h.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		h.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := h.SetCurrencyPairFormat()
+		err = h.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -119,6 +147,10 @@ func (h *HUOBI) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = h.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = h.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -140,6 +172,10 @@ func (h *HUOBI) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		h.Websocket.SetOrderbookDepth(exch.WebsocketOrderbookDepth,
+			huobiWsOrderbookDepthDefault,
+			huobiWsOrderbookDepths)
+		h.Websocket.SetCompression(exchange.WebsocketCompressionGzip)
 	}
 }
 
@@ -386,17 +422,19 @@ func (h *HUOBI) GetAccountBalance(accountID string) ([]AccountBalanceDetail, err
 // SpotNewOrder submits an order to Huobi
 func (h *HUOBI) SpotNewOrder(arg SpotNewOrderRequestParams) (int64, error) {
 	data := struct {
-		AccountID int    `json:"account-id,string"`
-		Amount    string `json:"amount"`
-		Price     string `json:"price"`
-		Source    string `json:"source"`
-		Symbol    string `json:"symbol"`
-		Type      string `json:"type"`
+		AccountID     int    `json:"account-id,string"`
+		Amount        string `json:"amount"`
+		Price         string `json:"price"`
+		Source        string `json:"source"`
+		Symbol        string `json:"symbol"`
+		Type          string `json:"type"`
+		ClientOrderID string `json:"client-order-id,omitempty"`
 	}{
-		AccountID: arg.AccountID,
-		Amount:    strconv.FormatFloat(arg.Amount, 'f', -1, 64),
-		Symbol:    arg.Symbol,
-		Type:      string(arg.Type),
+		AccountID:     arg.AccountID,
+		Amount:        strconv.FormatFloat(arg.Amount, 'f', -1, 64),
+		Symbol:        arg.Symbol,
+		Type:          string(arg.Type),
+		ClientOrderID: arg.ClientOrderID,
 	}
 
 	// Only set price if order type is not equal to buy-market or sell-market
@@ -408,12 +446,7 @@ func (h *HUOBI) SpotNewOrder(arg SpotNewOrderRequestParams) (int64, error) {
 		data.Source = arg.Source
 	}
 
-	type response struct {
-		Response
-		OrderID int64 `json:"data,string"`
-	}
-
-	var result response
+	var result OrderIDResponse
 	err := h.SendAuthenticatedHTTPRequest("POST", huobiOrderPlace, nil, data, &result)
 
 	if result.ErrorMessage != "" {
@@ -424,12 +457,7 @@ func (h *HUOBI) SpotNewOrder(arg SpotNewOrderRequestParams) (int64, error) {
 
 // CancelExistingOrder cancels an order on Huobi
 func (h *HUOBI) CancelExistingOrder(orderID int64) (int64, error) {
-	type response struct {
-		Response
-		OrderID int64 `json:"data,string"`
-	}
-
-	var result response
+	var result OrderIDResponse
 	endpoint := fmt.Sprintf(huobiOrderCancel, strconv.FormatInt(orderID, 10))
 	err := h.SendAuthenticatedHTTPRequest("POST", endpoint, url.Values{}, nil, &result)
 
@@ -622,6 +650,66 @@ func (h *HUOBI) GetOrdersMatch(symbol, types, start, end, from, direct, size str
 	return result.Orders, err
 }
 
+// GetTradeVolume returns the value traded on a symbol since start in its quote
+// currency, summed from the account's match results
+func (h *HUOBI) GetTradeVolume(symbol string, start time.Time) (float64, error) {
+	var volume float64
+	var from int64
+	for {
+		var fromID, direct string
+		if from != 0 {
+			fromID = strconv.FormatInt(from, 10)
+			direct = "next"
+		}
+
+		matches, err := h.GetOrdersMatch(symbol, "", start.UTC().Format("2006-01-02"), "",
+			fromID, direct, strconv.Itoa(huobiMatchResultsLimit))
+		if err != nil {
+			return 0, err
+		}
+
+		pageVolume, oldest, err := matchResultsVolume(matches, start, from)
+		if err != nil {
+			return 0, err
+		}
+		volume += pageVolume
+		if oldest == 0 || len(matches) < huobiMatchResultsLimit {
+			return volume, nil
+		}
+		from = oldest
+	}
+}
+
+// matchResultsVolume sums the value of a page of match results, newest first,
+// made since start. Results at or after the before ID, which paging repeats,
+// are skipped when before is set. The ID of the oldest result summed is
+// returned to page from, or zero when the page reached results older than
+// start or held no new results
+func matchResultsVolume(matches []OrderMatchInfo, start time.Time, before int64) (float64, int64, error) {
+	var volume float64
+	var oldest int64
+	for x := range matches {
+		if before != 0 && matches[x].ID >= before {
+			continue
+		}
+		if time.Unix(0, matches[x].CreatedAt*int64(time.Millisecond)).Before(start) {
+			return volume, 0, nil
+		}
+
+		price, err := strconv.ParseFloat(matches[x].Price, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		amount, err := strconv.ParseFloat(matches[x].FilledAmount, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		volume += price * amount
+		oldest = matches[x].ID
+	}
+	return volume, oldest, nil
+}
+
 // MarginTransfer transfers assets into or out of the margin account
 func (h *HUOBI) MarginTransfer(symbol, currency string, amount float64, in bool) (int64, error) {
 	data := struct {
@@ -767,8 +855,101 @@ func (h *HUOBI) GetMarginAccountBalance(symbol string) ([]MarginAccountBalance,
 	return result.Balances, err
 }
 
-// Withdraw withdraws the desired amount and currency
-func (h *HUOBI) Withdraw(address, currency, addrTag string, amount, fee float64) (int64, error) {
+// GetCurrencyChains returns the chains a currency can be deposited and
+// withdrawn on
+func (h *HUOBI) GetCurrencyChains(currency string) ([]CurrencyChain, error) {
+	type response struct {
+		ResponseV2
+		Data []struct {
+			Currency string          `json:"currency"`
+			Chains   []CurrencyChain `json:"chains"`
+		} `json:"data"`
+	}
+
+	vals := url.Values{}
+	vals.Set("currency", common.StringToLower(currency))
+
+	var result response
+	url := common.EncodeURLValues(h.APIUrl+huobiReferenceCurrencies, vals)
+
+	err := h.SendHTTPRequest(url, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Code != http.StatusOK {
+		return nil, fmt.Errorf("%d %s", result.Code, result.Message)
+	}
+
+	for x := range result.Data {
+		if common.StringToLower(result.Data[x].Currency) == common.StringToLower(currency) {
+			return result.Data[x].Chains, nil
+		}
+	}
+	return nil, fmt.Errorf("currency %s not found", currency)
+}
+
+// findChain validates a chain against the chains supported for a currency and
+// returns it. The chain can be supplied either by its name e.g. "trc20usdt" or
+// its display name e.g. "TRC20". When no chain is supplied the currency's
+// default chain is returned, an error listing the chains is returned rather
+// than guessing one if the currency has no default
+func findChain(currency, chain string, chains []CurrencyChain) (CurrencyChain, error) {
+	var names []string
+	for x := range chains {
+		names = append(names, chains[x].DisplayName)
+		if chain == "" {
+			if len(chains) == 1 ||
+				common.StringToLower(chains[x].Chain) == common.StringToLower(currency) {
+				return chains[x], nil
+			}
+			continue
+		}
+
+		if common.StringToLower(chains[x].Chain) == common.StringToLower(chain) ||
+			common.StringToUpper(chains[x].DisplayName) == common.StringToUpper(chain) {
+			return chains[x], nil
+		}
+	}
+
+	if len(chains) == 0 {
+		return CurrencyChain{}, fmt.Errorf("no chains found for currency %s", currency)
+	}
+
+	if chain == "" {
+		return CurrencyChain{}, fmt.Errorf("currency %s requires a chain, available chains: %s",
+			currency, common.JoinStrings(names, ", "))
+	}
+	return CurrencyChain{}, fmt.Errorf("chain %s not supported for currency %s, available chains: %s",
+		chain, currency, common.JoinStrings(names, ", "))
+}
+
+// GetDepositAddressList returns the deposit addresses of a currency, one per
+// chain the currency is supported on
+func (h *HUOBI) GetDepositAddressList(currency string) ([]DepositAddress, error) {
+	type response struct {
+		ResponseV2
+		Data []DepositAddress `json:"data"`
+	}
+
+	vals := url.Values{}
+	vals.Set("currency", common.StringToLower(currency))
+
+	var result response
+	err := h.SendAuthenticatedHTTPRequest("GET", huobiDepositAddress, vals, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Code != http.StatusOK {
+		return nil, fmt.Errorf("%d %s", result.Code, result.Message)
+	}
+	return result.Data, nil
+}
+
+// Withdraw withdraws the desired amount and currency on the supplied chain, an
+// empty chain uses the currency's default chain
+func (h *HUOBI) Withdraw(address, currency, chain, addrTag string, amount, fee float64) (int64, error) {
 	type response struct {
 		Response
 		WithdrawID int64 `json:"data"`
@@ -780,10 +961,12 @@ func (h *HUOBI) Withdraw(address, currency, addrTag string, amount, fee float64)
 		Currency string `json:"currency"`
 		Fee      string `json:"fee"`
 		AddrTag  string `json:"addr-tag"`
+		Chain    string `json:"chain,omitempty"`
 	}{
 		Address:  address,
 		Currency: currency,
 		Amount:   strconv.FormatFloat(amount, 'f', -1, 64),
+		Chain:    chain,
 	}
 
 	if fee != 0 {
@@ -823,6 +1006,35 @@ func (h *HUOBI) CancelWithdraw(withdrawID int64) (int64, error) {
 	return result.WithdrawID, err
 }
 
+// QueryDepositWithdrawals returns up to size deposit or withdrawal records,
+// recordType is "deposit" or "withdraw". A non zero from returns records
+// starting from that record ID
+func (h *HUOBI) QueryDepositWithdrawals(recordType string, from int64, size int) ([]DepositWithdrawal, error) {
+	type response struct {
+		Response
+		Records []DepositWithdrawal `json:"data"`
+	}
+
+	vals := url.Values{}
+	vals.Set("type", recordType)
+
+	if from != 0 {
+		vals.Set("from", strconv.FormatInt(from, 10))
+	}
+
+	if size != 0 {
+		vals.Set("size", strconv.Itoa(size))
+	}
+
+	var result response
+	err := h.SendAuthenticatedHTTPRequest("GET", huobiDepositWithdrawals, vals, nil, &result)
+
+	if result.ErrorMessage != "" {
+		return nil, errors.New(result.ErrorMessage)
+	}
+	return result.Records, err
+}
+
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (h *HUOBI) SendHTTPRequest(path string, result interface{}) error {
 	return h.SendPayload("GET", path, nil, nil, result, false, h.Verbose)
@@ -843,7 +1055,9 @@ func (h *HUOBI) SendAuthenticatedHTTPRequest(method, endpoint string, values url
 	values.Set("SignatureVersion", "2")
 	values.Set("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05"))
 
-	endpoint = fmt.Sprintf("/v%s/%s", huobiAPIVersion, endpoint)
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = fmt.Sprintf("/v%s/%s", huobiAPIVersion, endpoint)
+	}
 	payload := fmt.Sprintf("%s\napi.huobi.pro\n%s\n%s",
 		method, endpoint, values.Encode())
 
@@ -9,12 +9,15 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 // Please supply you own test keys here for due diligence testing.
@@ -428,6 +431,40 @@ func TestSubmitOrder(t *testing.T) {
 	}
 }
 
+func TestSubmitOrderInvalidClientID(t *testing.T) {
+	h.SetDefaults()
+
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USDT)
+	_, err := h.SubmitOrder(p, exchange.Buy, exchange.Limit, 1, 10, "notanumber")
+	if err == nil {
+		t.Error("Test failed - Huobi SubmitOrder() expected error for non-numeric clientID")
+	}
+}
+
+func TestFilterAccountBalance(t *testing.T) {
+	details := []AccountBalanceDetail{
+		{Currency: "btc", Type: "trade", Balance: 1.5},
+		{Currency: "btc", Type: "frozen", Balance: 0.5},
+		{Currency: "ltc", Type: "trade", Balance: 3},
+		{Currency: "eth", Type: "trade", Balance: 0},
+	}
+
+	balance, err := filterAccountBalance(details, "BTC")
+	if err != nil {
+		t.Fatal("Test failed - Huobi filterAccountBalance() error", err)
+	}
+
+	if balance.Currency != "BTC" || balance.Available != 1.5 || balance.Hold != 0.5 {
+		t.Errorf("Test failed - Huobi filterAccountBalance() unexpected balance %+v", balance)
+	}
+
+	_, err = filterAccountBalance(details, "ETH")
+	if err != exchange.ErrCurrencyNotFound {
+		t.Errorf("Test failed - Huobi filterAccountBalance() expected %s, received %v",
+			exchange.ErrCurrencyNotFound, err)
+	}
+}
+
 func TestCancelExchangeOrder(t *testing.T) {
 	// Arrange
 	h.SetDefaults()
@@ -484,6 +521,10 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 	if len(resp.OrderStatus) > 0 {
 		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
 	}
+
+	if resp.Failed > 0 {
+		t.Errorf("%v orders failed to cancel", resp.Failed)
+	}
 }
 
 func TestGetAccountInfo(t *testing.T) {
@@ -506,3 +547,276 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestFindChain(t *testing.T) {
+	t.Parallel()
+	chains := []CurrencyChain{
+		{Chain: "usdt", DisplayName: "OMNI"},
+		{Chain: "usdterc20", DisplayName: "ERC20"},
+		{Chain: "trc20usdt", DisplayName: "TRC20"},
+	}
+
+	c, err := findChain("USDT", "trc20", chains)
+	if err != nil || c.Chain != "trc20usdt" {
+		t.Error("Test Failed - findChain() display name error", err, c)
+	}
+
+	c, err = findChain("USDT", "usdterc20", chains)
+	if err != nil || c.DisplayName != "ERC20" {
+		t.Error("Test Failed - findChain() chain name error", err, c)
+	}
+
+	c, err = findChain("USDT", "", chains)
+	if err != nil || c.Chain != "usdt" {
+		t.Error("Test Failed - findChain() default chain error", err, c)
+	}
+
+	_, err = findChain("USDT", "BEP20", chains)
+	if err == nil {
+		t.Error("Test Failed - findChain() expected an error for an unsupported chain")
+	}
+
+	_, err = findChain("USDT", "", chains[1:])
+	if err == nil {
+		t.Error("Test Failed - findChain() expected an error without a chain when there is no default")
+	}
+
+	c, err = findChain("BTC", "", []CurrencyChain{{Chain: "btc", DisplayName: "BTC"}})
+	if err != nil || c.Chain != "btc" {
+		t.Error("Test Failed - findChain() single chain error", err, c)
+	}
+}
+
+func TestDepositAddressesByChain(t *testing.T) {
+	t.Parallel()
+	chains := []CurrencyChain{
+		{Chain: "usdterc20", DisplayName: "ERC20"},
+		{Chain: "trc20usdt", DisplayName: "TRC20"},
+	}
+	addresses := []DepositAddress{
+		{Currency: "usdt", Address: "0xabc", Chain: "usdterc20"},
+		{Currency: "usdt", Address: "Tabc", Chain: "trc20usdt", AddressTag: "1"},
+		{Currency: "usdt", Address: "1abc", Chain: "usdt"},
+	}
+
+	resp := depositAddressesByChain(chains, addresses)
+	if len(resp) != 3 {
+		t.Fatal("Test Failed - depositAddressesByChain() expected 3 addresses, received", resp)
+	}
+
+	if resp[0].Chain != "ERC20" || resp[1].Chain != "TRC20" || resp[1].Tag != "1" {
+		t.Error("Test Failed - depositAddressesByChain() incorrect chains", resp)
+	}
+
+	if resp[2].Chain != "usdt" {
+		t.Error("Test Failed - depositAddressesByChain() expected unknown chain name to be kept", resp[2])
+	}
+}
+
+func TestAvailableChains(t *testing.T) {
+	t.Parallel()
+	chains := []CurrencyChain{
+		{Chain: "usdterc20", DisplayName: "ERC20", DepositStatus: "allowed",
+			WithdrawStatus: "allowed", TransactFeeWithdraw: "5", MinWithdrawAmt: "2"},
+		{Chain: "trc20usdt", DisplayName: "TRC20", DepositStatus: "allowed",
+			WithdrawStatus: "prohibited", TransactFeeWithdraw: "1"},
+		{Chain: "usdt", DepositStatus: "prohibited", WithdrawStatus: "prohibited"},
+	}
+
+	resp, err := availableChains(chains)
+	if err != nil {
+		t.Fatal("Test Failed - availableChains() error", err)
+	}
+
+	if len(resp) != 3 {
+		t.Fatal("Test Failed - availableChains() expected 3 chains, received", resp)
+	}
+
+	if resp[0].Chain != "ERC20" || !resp[0].DepositEnabled || !resp[0].WithdrawEnabled ||
+		resp[0].WithdrawalFee != 5 || resp[0].MinWithdrawal != 2 {
+		t.Error("Test Failed - availableChains() incorrect ERC20 chain", resp[0])
+	}
+
+	if resp[1].Chain != "TRC20" || resp[1].WithdrawEnabled || resp[1].WithdrawalFee != 1 {
+		t.Error("Test Failed - availableChains() incorrect TRC20 chain", resp[1])
+	}
+
+	if resp[2].Chain != "usdt" || resp[2].DepositEnabled {
+		t.Error("Test Failed - availableChains() incorrect default chain", resp[2])
+	}
+
+	chains[0].TransactFeeWithdraw = "bad"
+	_, err = availableChains(chains)
+	if err == nil {
+		t.Error("Test Failed - availableChains() expected an error for an invalid fee")
+	}
+}
+
+func TestWsProcessTicker(t *testing.T) {
+	var ws HUOBI
+	ws.SetDefaults()
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	hConfig, err := cfg.GetExchangeConfig("Huobi")
+	if err != nil {
+		t.Fatal("Test Failed - Huobi Setup() init error", err)
+	}
+	ws.Setup(hConfig)
+
+	var depth WsDepth
+	depth.Tick.Bids = []interface{}{[]interface{}{100.0, 2.0}, []interface{}{99.0, 1.0}}
+	depth.Tick.Asks = []interface{}{[]interface{}{101.0, 3.0}}
+	err = ws.WsProcessOrderbook(depth, "btcusdt")
+	if err != nil {
+		t.Fatal("Test failed - Huobi WsProcessOrderbook() error", err)
+	}
+	<-ws.Websocket.DataHandler
+
+	err = ws.WsProcessOrderbook(depth, "btcusdt")
+	if err != nil {
+		t.Error("Test failed - Huobi WsProcessOrderbook() repeated snapshot error", err)
+	}
+	<-ws.Websocket.DataHandler
+
+	ws.WsProcessTicker(WsMarketDetail{
+		Open:   95,
+		Close:  100.5,
+		Low:    90,
+		High:   110,
+		Amount: 12,
+		Volume: 1200,
+	}, "btcusdt", 1546300800000)
+
+	data := (<-ws.Websocket.DataHandler).(exchange.TickerData)
+	p := pair.NewCurrencyPairDelimiter("BTC-USDT", "-")
+	if !data.Pair.Equal(p, true) || data.ClosePrice != 100.5 ||
+		data.Timestamp.Unix() != 1546300800 {
+		t.Error("Test failed - Huobi WsProcessTicker() unexpected ticker data", data)
+	}
+
+	tick, err := ticker.GetTicker(ws.Name, p, "SPOT")
+	if err != nil {
+		t.Fatal("Test failed - Huobi WsProcessTicker() ticker not processed", err)
+	}
+
+	if tick.Last != 100.5 || tick.Bid != 100 || tick.Ask != 101 || tick.Volume != 1200 {
+		t.Error("Test failed - Huobi WsProcessTicker() unexpected ticker", tick)
+	}
+
+	ob, err := orderbook.GetOrderbook(ws.Name, p, "SPOT")
+	if err != nil {
+		t.Fatal("Test failed - Huobi WsProcessOrderbook() orderbook not processed", err)
+	}
+
+	if len(ob.Bids) != 2 || ob.Bids[0].Amount != 2 || ob.Asks[0].Amount != 3 {
+		t.Error("Test failed - Huobi WsProcessOrderbook() unexpected orderbook", ob)
+	}
+}
+
+func TestWsProcessKline(t *testing.T) {
+	var ws HUOBI
+	ws.SetDefaults()
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	hConfig, err := cfg.GetExchangeConfig("Huobi")
+	if err != nil {
+		t.Fatal("Test Failed - Huobi Setup() init error", err)
+	}
+	ws.Setup(hConfig)
+
+	var kline WsKline
+	kline.Timestamp = 1546300830000
+	kline.Tick.ID = 1546300800
+	kline.Tick.Close = 100
+	ws.WsProcessKline(kline, "btcusdt")
+
+	data := (<-ws.Websocket.DataHandler).(exchange.KlineData)
+	if data.StartTime.Unix() != 1546300800 || data.CloseTime.Unix() != 1546300860 ||
+		data.Timestamp.Unix() != 1546300830 || data.Interval != wsKlineInterval ||
+		data.ClosePrice != 100 {
+		t.Error("Test failed - Huobi WsProcessKline() unexpected kline data", data)
+	}
+}
+
+func TestOrderIDPrecision(t *testing.T) {
+	t.Parallel()
+	// 2^53 + 1 can't be held exactly by a float64
+	const orderID = 9007199254740993
+
+	var placed OrderIDResponse
+	err := common.JSONDecode([]byte(`{"status":"ok","data":"9007199254740993"}`), &placed)
+	if err != nil || placed.OrderID != orderID {
+		t.Errorf("Test Failed - OrderIDResponse expected order ID %d, received %d %v", orderID, placed.OrderID, err)
+	}
+
+	var order OrderInfo
+	err = common.JSONDecode([]byte(`{"id":9007199254740993,"symbol":"btcusdt"}`), &order)
+	if err != nil || order.ID != orderID {
+		t.Errorf("Test Failed - OrderInfo expected order ID %d, received %d %v", orderID, order.ID, err)
+	}
+
+	var match OrderMatchInfo
+	err = common.JSONDecode([]byte(`{"id":9007199254740995,"order-id":9007199254740993,"match-id":9007199254740997}`), &match)
+	if err != nil || match.OrderID != orderID || match.ID != 9007199254740995 || match.MatchID != 9007199254740997 {
+		t.Errorf("Test Failed - OrderMatchInfo unexpected IDs %+v %v", match, err)
+	}
+}
+
+func TestMatchResultsVolume(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	ms := func(tm time.Time) int64 { return tm.UnixNano() / int64(time.Millisecond) }
+
+	matches := []OrderMatchInfo{
+		{ID: 30, Price: "6500", FilledAmount: "0.1", CreatedAt: ms(start.AddDate(0, 0, 2))},
+		{ID: 20, Price: "6000", FilledAmount: "0.5", CreatedAt: ms(start.AddDate(0, 0, 1))},
+		{ID: 10, Price: "7000", FilledAmount: "1", CreatedAt: ms(start.AddDate(0, 0, -1))},
+	}
+
+	volume, oldest, err := matchResultsVolume(matches[:2], start, 0)
+	if err != nil || volume != 3650 || oldest != 20 {
+		t.Errorf("Test Failed - matchResultsVolume expected 3650 from 20, received %v %d %v", volume, oldest, err)
+	}
+
+	volume, oldest, err = matchResultsVolume(matches, start, 30)
+	if err != nil || volume != 3000 || oldest != 0 {
+		t.Errorf("Test Failed - matchResultsVolume expected 3000 and no further pages, received %v %d %v",
+			volume, oldest, err)
+	}
+
+	_, _, err = matchResultsVolume([]OrderMatchInfo{{ID: 1, Price: "", CreatedAt: ms(start)}}, start, 0)
+	if err == nil {
+		t.Error("Test Failed - matchResultsVolume expected error for an invalid price")
+	}
+}
+
+func TestConvertWithdrawalStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		state    string
+		expected exchange.WithdrawalStatus
+	}{
+		{"submitted", exchange.WithdrawalPending},
+		{"verifying", exchange.WithdrawalPending},
+		{"pass", exchange.WithdrawalProcessing},
+		{"wallet-transfer", exchange.WithdrawalProcessing},
+		{"confirmed", exchange.WithdrawalCompleted},
+		{"canceled", exchange.WithdrawalFailed},
+		{"wallet-reject", exchange.WithdrawalFailed},
+	}
+
+	for i := range tests {
+		if status := convertWithdrawalStatus(tests[i].state); status != tests[i].expected {
+			t.Errorf("Test Failed - convertWithdrawalStatus() %s expected %s, received %s",
+				tests[i].state, tests[i].expected, status)
+		}
+	}
+}
+
+func TestGetWithdrawalStatusInvalidID(t *testing.T) {
+	t.Parallel()
+	_, err := h.GetWithdrawalStatus("invalid")
+	if err == nil {
+		t.Error("Test Failed - GetWithdrawalStatus() expected error for an invalid withdrawal ID")
+	}
+}
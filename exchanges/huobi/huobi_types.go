@@ -9,6 +9,32 @@ type Response struct {
 	ErrorMessage string `json:"err-msg"`
 }
 
+// ResponseV2 stores the common response fields of the v2 endpoints
+type ResponseV2 struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// CurrencyChain stores the deposit and withdrawal details of a currency on a
+// single chain
+type CurrencyChain struct {
+	Chain               string `json:"chain"`
+	DisplayName         string `json:"displayName"`
+	BaseChain           string `json:"baseChain"`
+	DepositStatus       string `json:"depositStatus"`
+	WithdrawStatus      string `json:"withdrawStatus"`
+	TransactFeeWithdraw string `json:"transactFeeWithdraw"`
+	MinWithdrawAmt      string `json:"minWithdrawAmt"`
+}
+
+// DepositAddress stores the deposit address of a currency on a single chain
+type DepositAddress struct {
+	Currency   string `json:"currency"`
+	Address    string `json:"address"`
+	AddressTag string `json:"addressTag"`
+	Chain      string `json:"chain"`
+}
+
 // KlineItem stores a kline item
 type KlineItem struct {
 	ID     int64   `json:"id"`
@@ -128,6 +154,13 @@ type AccountBalanceDetail struct {
 	Balance  float64 `json:"balance,string"`
 }
 
+// OrderIDResponse is returned after placing or cancelling an order, Huobi
+// sends the order ID as a string which is decoded exactly
+type OrderIDResponse struct {
+	Response
+	OrderID int64 `json:"data,string"`
+}
+
 // CancelOrderBatch stores the cancel order batch data
 type CancelOrderBatch struct {
 	Success []string `json:"success"`
@@ -140,7 +173,7 @@ type CancelOrderBatch struct {
 
 // OrderInfo stores the order info
 type OrderInfo struct {
-	ID              int    `json:"id"`
+	ID              int64  `json:"id"`
 	Symbol          string `json:"symbol"`
 	AccountID       int    `json:"account-id"`
 	Amount          string `json:"amount"`
@@ -161,9 +194,9 @@ type OrderInfo struct {
 
 // OrderMatchInfo stores the order match info
 type OrderMatchInfo struct {
-	ID           int    `json:"id"`
-	OrderID      int    `json:"order-id"`
-	MatchID      int    `json:"match-id"`
+	ID           int64  `json:"id"`
+	OrderID      int64  `json:"order-id"`
+	MatchID      int64  `json:"match-id"`
 	Symbol       string `json:"symbol"`
 	Type         string `json:"type"`
 	Source       string `json:"source"`
@@ -206,12 +239,13 @@ type MarginAccountBalance struct {
 // SpotNewOrderRequestParams holds the params required to place
 // an order
 type SpotNewOrderRequestParams struct {
-	AccountID int                           `json:"account-id,string"` // Account ID, obtained using the accounts method. Curency trades use the accountid of the ‘spot’ account; for loan asset transactions, please use the accountid of the ‘margin’ account.
-	Amount    float64                       `json:"amount"`            // The limit price indicates the quantity of the order, the market price indicates how much to buy when the order is paid, and the market price indicates how much the coin is sold when the order is sold.
-	Price     float64                       `json:"price"`             // Order price, market price does not use  this parameter
-	Source    string                        `json:"source"`            // Order source, api: API call, margin-api: loan asset transaction
-	Symbol    string                        `json:"symbol"`            // The symbol to use; example btcusdt, bccbtc......
-	Type      SpotNewOrderRequestParamsType `json:"type"`              // 订单类型, buy-market: 市价买, sell-market: 市价卖, buy-limit: 限价买, sell-limit: 限价卖
+	AccountID     int                           `json:"account-id,string"` // Account ID, obtained using the accounts method. Curency trades use the accountid of the ‘spot’ account; for loan asset transactions, please use the accountid of the ‘margin’ account.
+	Amount        float64                       `json:"amount"`            // The limit price indicates the quantity of the order, the market price indicates how much to buy when the order is paid, and the market price indicates how much the coin is sold when the order is sold.
+	Price         float64                       `json:"price"`             // Order price, market price does not use  this parameter
+	Source        string                        `json:"source"`            // Order source, api: API call, margin-api: loan asset transaction
+	Symbol        string                        `json:"symbol"`            // The symbol to use; example btcusdt, bccbtc......
+	Type          SpotNewOrderRequestParamsType `json:"type"`              // 订单类型, buy-market: 市价买, sell-market: 市价卖, buy-limit: 限价买, sell-limit: 限价卖
+	ClientOrderID string                        `json:"client-order-id"`   // Client supplied order ID used to deduplicate orders, optional
 }
 
 // SpotNewOrderRequestParamsType order type
@@ -255,3 +289,20 @@ var (
 	TimeIntervalMohth          = TimeInterval("1mon")
 	TimeIntervalYear           = TimeInterval("1year")
 )
+
+// DepositWithdrawal stores a deposit or withdrawal record, State is the
+// progress of the record e.g. "confirmed" for a completed withdrawal
+type DepositWithdrawal struct {
+	ID         int64   `json:"id"`
+	Type       string  `json:"type"`
+	Currency   string  `json:"currency"`
+	Chain      string  `json:"chain"`
+	TxHash     string  `json:"tx-hash"`
+	Amount     float64 `json:"amount"`
+	Address    string  `json:"address"`
+	AddressTag string  `json:"address-tag"`
+	Fee        float64 `json:"fee"`
+	State      string  `json:"state"`
+	CreatedAt  int64   `json:"created-at"`
+	UpdatedAt  int64   `json:"updated-at"`
+}
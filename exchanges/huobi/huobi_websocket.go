@@ -1,11 +1,8 @@
 package huobi
 
 import (
-	"bytes"
-	"compress/gzip"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"math/big"
 	"net/http"
@@ -17,15 +14,41 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 const (
 	huobiSocketIOAddress = "wss://api.huobi.pro/ws"
 	wsMarketKline        = "market.%s.kline.1min"
 	wsMarketDepth        = "market.%s.depth.step0"
+	wsMarketDepthRefresh = "market.%s.mbp.refresh.%d"
 	wsMarketTrade        = "market.%s.trade.detail"
+	wsMarketDetail       = "market.%s.detail"
+	wsKlineInterval      = "1min"
+
+	// huobiWsTickerMaxAge is how old a websocket ticker may be before
+	// UpdateTicker falls back to REST
+	huobiWsTickerMaxAge = 10 * time.Second
+
+	// huobiWsOrderbookDepthFull subscribes to the step0 depth channel which
+	// delivers the full 150 level book
+	huobiWsOrderbookDepthFull    = 150
+	huobiWsOrderbookDepthDefault = 20
 )
 
+// huobiWsOrderbookDepths are the orderbook depths supported by the websocket
+var huobiWsOrderbookDepths = []int{5, 10, 20, huobiWsOrderbookDepthFull}
+
+// wsDepthTopic returns the depth topic variant for the configured orderbook
+// depth
+func (h *HUOBI) wsDepthTopic(symbol string) string {
+	depth := h.Websocket.GetOrderbookDepth()
+	if depth == 0 || depth == huobiWsOrderbookDepthFull {
+		return fmt.Sprintf(wsMarketDepth, symbol)
+	}
+	return fmt.Sprintf(wsMarketDepthRefresh, symbol, depth)
+}
+
 // WsConnect initiates a new websocket connection
 func (h *HUOBI) WsConnect() error {
 	if !h.Websocket.IsEnabled() || !h.IsEnabled() {
@@ -79,26 +102,14 @@ func (h *HUOBI) WsReadData() {
 			return
 
 		default:
-			_, resp, err := h.WebsocketConn.ReadMessage()
+			_, resp, err := h.Websocket.ReadMessage(h.WebsocketConn)
 			if err != nil {
 				log.Fatal(err)
 			}
 
 			h.Websocket.TrafficAlert <- struct{}{}
 
-			b := bytes.NewReader(resp)
-			gReader, err := gzip.NewReader(b)
-			if err != nil {
-				log.Fatal(err)
-			}
-
-			unzipped, err := ioutil.ReadAll(gReader)
-			if err != nil {
-				log.Fatal(err)
-			}
-			gReader.Close()
-
-			h.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: unzipped}
+			h.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: resp}
 		}
 	}
 }
@@ -130,83 +141,108 @@ func (h *HUOBI) WsHandleData() {
 			}
 
 			if init.Ping != 0 {
-				err = h.WebsocketConn.WriteJSON(`{"pong":1337}`)
+				err = h.Websocket.SendJSON(h.WebsocketConn, WsPong{Pong: init.Ping})
 				if err != nil {
 					log.Fatal(err)
 				}
 				continue
 			}
 
+			// Subscriptions push data under ch while requests reply once
+			// under rep
+			channel := init.Channel
+			if channel == "" {
+				channel = init.Reply
+			}
+
+			data := common.SplitStrings(channel, ".")
+			if len(data) < 3 {
+				continue
+			}
+
 			switch {
-			case common.StringContains(init.Channel, "depth"):
+			case common.StringContains(channel, "depth"),
+				common.StringContains(channel, "mbp"):
 				var depth WsDepth
 				err := common.JSONDecode(resp.Raw, &depth)
 				if err != nil {
 					log.Fatal(err)
 				}
 
-				data := common.SplitStrings(depth.Channel, ".")
-
-				h.WsProcessOrderbook(depth, data[1])
+				err = h.WsProcessOrderbook(depth, data[1])
+				if err != nil {
+					h.Websocket.DataHandler <- err
+				}
 
-			case common.StringContains(init.Channel, "kline"):
+			case common.StringContains(channel, "kline"):
 				var kline WsKline
 				err := common.JSONDecode(resp.Raw, &kline)
 				if err != nil {
 					log.Fatal(err)
 				}
 
-				data := common.SplitStrings(kline.Channel, ".")
-
-				h.Websocket.DataHandler <- exchange.KlineData{
-					Timestamp:  time.Unix(0, kline.Timestamp),
-					Exchange:   h.GetName(),
-					AssetType:  "SPOT",
-					Pair:       pair.NewCurrencyPairFromString(data[1]),
-					OpenPrice:  kline.Tick.Open,
-					ClosePrice: kline.Tick.Close,
-					HighPrice:  kline.Tick.High,
-					LowPrice:   kline.Tick.Low,
-					Volume:     kline.Tick.Volume,
-				}
+				h.WsProcessKline(kline, data[1])
 
-			case common.StringContains(init.Channel, "trade"):
+			case common.StringContains(channel, "trade"):
 				var trade WsTrade
 				err := common.JSONDecode(resp.Raw, &trade)
 				if err != nil {
 					log.Fatal(err)
 				}
 
-				data := common.SplitStrings(trade.Channel, ".")
-
 				h.Websocket.DataHandler <- exchange.TradeData{
 					Exchange:     h.GetName(),
 					AssetType:    "SPOT",
-					CurrencyPair: pair.NewCurrencyPairFromString(data[1]),
-					Timestamp:    time.Unix(0, trade.Tick.Timestamp),
+					CurrencyPair: h.wsPair(data[1]),
+					Timestamp:    time.Unix(0, trade.Tick.Timestamp*int64(time.Millisecond)),
+				}
+
+			case data[2] == "detail":
+				var tick WsTick
+				err := common.JSONDecode(resp.Raw, &tick)
+				if err != nil {
+					log.Fatal(err)
 				}
+
+				detail := tick.Tick
+				if tick.Reply != "" {
+					detail = tick.Data
+				}
+				h.WsProcessTicker(detail, data[1], tick.Timestamp)
 			}
 		}
 	}
 }
 
-// WsProcessOrderbook processes new orderbook data
+// wsPair returns the currency pair for a websocket channel symbol, matching
+// it against the available pairs so updates are stored under the same pair as
+// REST requests
+func (h *HUOBI) wsPair(symbol string) pair.CurrencyPair {
+	p, err := exchange.ParseExchangeCurrency(h.GetName(), symbol)
+	if err != nil {
+		return pair.NewCurrencyPairFromString(symbol)
+	}
+	return p
+}
+
+// WsProcessOrderbook processes new orderbook data, every depth push is a full
+// snapshot which replaces the previous one
 func (h *HUOBI) WsProcessOrderbook(ob WsDepth, symbol string) error {
 	var bids []orderbook.Item
 	for _, data := range ob.Tick.Bids {
 		bidLevel := data.([]interface{})
 		bids = append(bids, orderbook.Item{Price: bidLevel[0].(float64),
-			Amount: bidLevel[0].(float64)})
+			Amount: bidLevel[1].(float64)})
 	}
 
 	var asks []orderbook.Item
 	for _, data := range ob.Tick.Asks {
 		askLevel := data.([]interface{})
 		asks = append(asks, orderbook.Item{Price: askLevel[0].(float64),
-			Amount: askLevel[0].(float64)})
+			Amount: askLevel[1].(float64)})
 	}
 
-	p := pair.NewCurrencyPairFromString(symbol)
+	p := h.wsPair(symbol)
 
 	var newOrderbook orderbook.Base
 	newOrderbook.Asks = asks
@@ -214,7 +250,9 @@ func (h *HUOBI) WsProcessOrderbook(ob WsDepth, symbol string) error {
 	newOrderbook.CurrencyPair = symbol
 	newOrderbook.LastUpdated = time.Now()
 	newOrderbook.Pair = p
+	newOrderbook.AssetType = "SPOT"
 
+	h.Websocket.Orderbook.Invalidate(p, newOrderbook.AssetType)
 	err := h.Websocket.Orderbook.LoadSnapshot(newOrderbook, h.GetName())
 	if err != nil {
 		return err
@@ -229,43 +267,99 @@ func (h *HUOBI) WsProcessOrderbook(ob WsDepth, symbol string) error {
 	return nil
 }
 
+// WsProcessKline sends a candle update to the data handler
+func (h *HUOBI) WsProcessKline(kline WsKline, symbol string) {
+	start := time.Unix(kline.Tick.ID, 0)
+	h.Websocket.DataHandler <- exchange.KlineData{
+		Timestamp:  time.Unix(0, kline.Timestamp*int64(time.Millisecond)),
+		Exchange:   h.GetName(),
+		AssetType:  "SPOT",
+		Pair:       h.wsPair(symbol),
+		StartTime:  start,
+		CloseTime:  start.Add(time.Minute),
+		Interval:   wsKlineInterval,
+		OpenPrice:  kline.Tick.Open,
+		ClosePrice: kline.Tick.Close,
+		HighPrice:  kline.Tick.High,
+		LowPrice:   kline.Tick.Low,
+		Volume:     kline.Tick.Volume,
+	}
+}
+
+// WsProcessTicker processes market detail data into the ticker and sends it to
+// the data handler. Market detail carries no bid or ask, so they are taken from
+// the orderbook when one is loaded and otherwise kept from the previous ticker
+func (h *HUOBI) WsProcessTicker(detail WsMarketDetail, symbol string, timestamp int64) {
+	p := h.wsPair(symbol)
+
+	tickerPrice := ticker.Price{
+		Pair:   p,
+		Last:   detail.Close,
+		High:   detail.High,
+		Low:    detail.Low,
+		Volume: detail.Volume,
+	}
+
+	if ob, err := orderbook.GetOrderbook(h.GetName(), p, "SPOT"); err == nil &&
+		len(ob.Bids) > 0 && len(ob.Asks) > 0 {
+		tickerPrice.Bid = ob.Bids[0].Price
+		tickerPrice.Ask = ob.Asks[0].Price
+	} else if prev, err := ticker.GetTicker(h.GetName(), p, "SPOT"); err == nil {
+		tickerPrice.Bid = prev.Bid
+		tickerPrice.Ask = prev.Ask
+	}
+
+	ticker.ProcessTicker(h.GetName(), p, tickerPrice, "SPOT")
+
+	h.Websocket.DataHandler <- exchange.TickerData{
+		Timestamp:  time.Unix(0, timestamp*int64(time.Millisecond)),
+		Pair:       p,
+		AssetType:  "SPOT",
+		Exchange:   h.GetName(),
+		ClosePrice: detail.Close,
+		Quantity:   detail.Amount,
+		OpenPrice:  detail.Open,
+		HighPrice:  detail.High,
+		LowPrice:   detail.Low,
+	}
+}
+
+// wsSend encodes and writes a request to the websocket connection
+func (h *HUOBI) wsSend(req WsRequest) error {
+	reqJSON, err := common.JSONEncode(req)
+	if err != nil {
+		return err
+	}
+	return h.Websocket.SendMessage(h.WebsocketConn, websocket.TextMessage, reqJSON)
+}
+
 // WsSubscribe susbcribes to the current websocket streams based on the enabled
-// pair
+// pair. The market detail is also requested once so the ticker is populated
+// before the first push
 func (h *HUOBI) WsSubscribe() error {
 	pairs := h.GetEnabledCurrencies()
 
 	for _, p := range pairs {
-		fPair := exchange.FormatExchangeCurrency(h.GetName(), p)
-
-		depthTopic := fmt.Sprintf(wsMarketDepth, fPair.String())
-		depthJSON, err := common.JSONEncode(WsRequest{Subscribe: depthTopic})
-		if err != nil {
-			return err
-		}
-
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, depthJSON)
-		if err != nil {
-			return err
-		}
+		fPair := exchange.FormatExchangeCurrency(h.GetName(), p).String()
 
-		klineTopic := fmt.Sprintf(wsMarketKline, fPair.String())
-		KlineJSON, err := common.JSONEncode(WsRequest{Subscribe: klineTopic})
-		if err != nil {
-			return err
+		topics := []string{
+			h.wsDepthTopic(fPair),
+			fmt.Sprintf(wsMarketKline, fPair),
+			fmt.Sprintf(wsMarketTrade, fPair),
+			fmt.Sprintf(wsMarketDetail, fPair),
 		}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, KlineJSON)
-		if err != nil {
-			return err
-		}
-
-		tradeTopic := fmt.Sprintf(wsMarketTrade, fPair.String())
-		tradeJSON, err := common.JSONEncode(WsRequest{Subscribe: tradeTopic})
-		if err != nil {
-			return err
+		for _, topic := range topics {
+			err := h.wsSend(WsRequest{Subscribe: topic})
+			if err != nil {
+				return err
+			}
 		}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, tradeJSON)
+		err := h.wsSend(WsRequest{
+			Topic:             fmt.Sprintf(wsMarketDetail, fPair),
+			ClientGeneratedID: fPair,
+		})
 		if err != nil {
 			return err
 		}
@@ -289,9 +383,15 @@ type WsResponse struct {
 	ErrorMessage string `json:"err-msg"`
 	Ping         int64  `json:"ping"`
 	Channel      string `json:"ch"`
+	Reply        string `json:"rep"`
 	Subscribed   string `json:"subbed"`
 }
 
+// WsPong defines a reply to a websocket ping
+type WsPong struct {
+	Pong int64 `json:"pong"`
+}
+
 // WsHeartBeat defines a heartbeat request
 type WsHeartBeat struct {
 	ClientNonce int64 `json:"ping"`
@@ -341,3 +441,26 @@ type WsTrade struct {
 		} `json:"data"`
 	}
 }
+
+// WsMarketDetail defines the market detail of the last 24 hours
+type WsMarketDetail struct {
+	ID      int64   `json:"id"`
+	Open    float64 `json:"open"`
+	Close   float64 `json:"close"`
+	Low     float64 `json:"low"`
+	High    float64 `json:"high"`
+	Amount  float64 `json:"amount"`
+	Volume  float64 `json:"vol"`
+	Count   int64   `json:"count"`
+	Version int64   `json:"version"`
+}
+
+// WsTick defines a market detail websocket response, a subscription pushes the
+// detail under tick while a request replies with it under data
+type WsTick struct {
+	Channel   string         `json:"ch"`
+	Reply     string         `json:"rep"`
+	Timestamp int64          `json:"ts"`
+	Tick      WsMarketDetail `json:"tick"`
+	Data      WsMarketDetail `json:"data"`
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
@@ -15,6 +16,11 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*HUOBI)(nil)
+var _ exchange.DepositAddressesGetter = (*HUOBI)(nil)
+var _ exchange.TradeVolumeGetter = (*HUOBI)(nil)
+var _ exchange.WithdrawalStatusGetter = (*HUOBI)(nil)
+
 // Start starts the HUOBI go routine
 func (h *HUOBI) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -32,7 +38,11 @@ func (h *HUOBI) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", h.GetName(), len(h.EnabledPairs), h.EnabledPairs)
 	}
 
-	exchangeProducts, err := h.GetSymbols()
+	var exchangeProducts []Symbol
+	err := h.RetryPairUpdate(func() (err error) {
+		exchangeProducts, err = h.GetSymbols()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available symbols.\n", h.GetName())
 	} else {
@@ -73,15 +83,29 @@ func (h *HUOBI) Run() {
 				log.Printf("%s Failed to update enabled currencies.\n", h.GetName())
 			}
 		}
-		err = h.UpdateCurrencies(currencies, false, forceUpgrade)
-		if err != nil {
-			log.Printf("%s Failed to update available currencies.\n", h.GetName())
+		if h.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = h.UpdateCurrencies(currencies, false, forceUpgrade)
+			if err != nil {
+				log.Printf("%s Failed to update available currencies.\n", h.GetName())
+			}
 		}
 	}
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (h *HUOBI) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := h.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
+	if h.Websocket.IsEnabled() {
+		tick, err := ticker.GetTicker(h.Name, p, assetType)
+		if err == nil && !tick.IsStale(huobiWsTickerMaxAge) {
+			return tick, nil
+		}
+	}
+
 	var tickerPrice ticker.Price
 	tick, err := h.GetMarketDetailMerged(exchange.FormatExchangeCurrency(h.Name, p).String())
 	if err != nil {
@@ -108,6 +132,7 @@ func (h *HUOBI) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Pric
 
 // GetTickerPrice returns the ticker for a currency pair
 func (h *HUOBI) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = h.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(h.GetName(), p, assetType)
 	if err != nil {
 		return h.UpdateTicker(p, assetType)
@@ -117,6 +142,7 @@ func (h *HUOBI) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Pr
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (h *HUOBI) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = h.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(h.GetName(), p, assetType)
 	if err != nil {
 		return h.UpdateOrderbook(p, assetType)
@@ -126,6 +152,11 @@ func (h *HUOBI) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (h *HUOBI) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := h.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := h.GetDepth(OrderBookDataRequestParams{
 		Symbol: exchange.FormatExchangeCurrency(h.Name, p).String(),
@@ -222,11 +253,55 @@ func (h *HUOBI) GetAccountInfo() (exchange.AccountInfo, error) {
 	return info, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency, reading only that currency from the account balance
+func (h *HUOBI) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	accID, err := h.GetAccountID()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+
+	acc, err := h.GetAccountBalance(accID)
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+
+	balance, err := filterAccountBalance(acc, currency)
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	balance.Exchange = h.GetName()
+	return balance, nil
+}
+
+// filterAccountBalance returns the trade and frozen balance of a single
+// currency from the account balance details
+func filterAccountBalance(details []AccountBalanceDetail, currency string) (exchange.CurrencyBalance, error) {
+	balance := exchange.CurrencyBalance{Currency: common.StringToUpper(currency)}
+	for x := range details {
+		if common.StringToLower(details[x].Currency) != common.StringToLower(currency) {
+			continue
+		}
+
+		if details[x].Type == "trade" {
+			balance.Available = details[x].Balance
+		} else {
+			balance.Hold += details[x].Balance
+		}
+	}
+
+	if balance.Available == 0 && balance.Hold == 0 {
+		return exchange.CurrencyBalance{}, exchange.ErrCurrencyNotFound
+	}
+	return balance, nil
+}
+
 // GetFundingHistory returns funding history, deposits and
-// withdrawals
+// withdrawals. Huobi exposes deposit and withdrawal history but the wrapper
+// doesn't fetch it yet
 func (h *HUOBI) GetFundingHistory() ([]exchange.FundHistory, error) {
 	var fundHistory []exchange.FundHistory
-	return fundHistory, common.ErrFunctionNotSupported
+	return fundHistory, common.ErrNotYetImplemented
 }
 
 // GetExchangeHistory returns historic trade data since exchange opening.
@@ -238,26 +313,47 @@ func (h *HUOBI) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exc
 
 // SubmitOrder submits a new order
 func (h *HUOBI) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
-	accountID, err := strconv.ParseInt(clientID, 10, 64)
+	resp, err := h.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (h *HUOBI) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
+	accountID, err := strconv.ParseInt(order.ClientID, 10, 64)
+	if err != nil {
+		return submitOrderResponse, fmt.Errorf("%s SubmitOrder invalid account ID %q, clientID must be a numeric account ID: %s",
+			h.Name, order.ClientID, err)
+	}
+
+	if order.ClientOrderID == "" {
+		order.ClientOrderID, err = exchange.NewClientOrderID()
+		if err != nil {
+			return submitOrderResponse, err
+		}
+	}
+	submitOrderResponse.ClientOrderID = order.ClientOrderID
+
 	var formattedType SpotNewOrderRequestParamsType
 	var params = SpotNewOrderRequestParams{
-		Amount:    amount,
-		Source:    "api",
-		Symbol:    common.StringToLower(p.Pair().String()),
-		AccountID: int(accountID),
+		Amount:        order.Amount,
+		Source:        "api",
+		Symbol:        common.StringToLower(order.Pair.Pair().String()),
+		AccountID:     int(accountID),
+		ClientOrderID: order.ClientOrderID,
 	}
 
-	if side == exchange.Buy && orderType == exchange.Market {
+	if order.OrderSide == exchange.Buy && order.OrderType == exchange.Market {
 		formattedType = SpotNewOrderRequestTypeBuyMarket
-	} else if side == exchange.Sell && orderType == exchange.Market {
+	} else if order.OrderSide == exchange.Sell && order.OrderType == exchange.Market {
 		formattedType = SpotNewOrderRequestTypeSellMarket
-	} else if side == exchange.Buy && orderType == exchange.Limit {
+	} else if order.OrderSide == exchange.Buy && order.OrderType == exchange.Limit {
 		formattedType = SpotNewOrderRequestTypeBuyLimit
-		params.Price = price
-	} else if side == exchange.Sell && orderType == exchange.Limit {
+		params.Price = order.Price
+	} else if order.OrderSide == exchange.Sell && order.OrderType == exchange.Limit {
 		formattedType = SpotNewOrderRequestTypeSellLimit
-		params.Price = price
+		params.Price = order.Price
 	} else {
 		return submitOrderResponse, errors.New("Unsupported order type")
 	}
@@ -307,11 +403,14 @@ func (h *HUOBI) CancelAllOrders(orderCancellation exchange.OrderCancellation) (e
 			return cancelAllOrdersResponse, err
 		}
 
-		if resp.Data.FailedCount > 0 {
-			return cancelAllOrdersResponse, fmt.Errorf("%v orders failed to cancel", resp.Data.FailedCount)
-		}
+		// the batch cancel only reports counts, not the affected order IDs
+		cancelAllOrdersResponse.Succeeded += resp.Data.SuccessCount
+		cancelAllOrdersResponse.Failed += resp.Data.FailedCount
 	}
 
+	if cancelAllOrdersResponse.Failed > 0 {
+		return cancelAllOrdersResponse, fmt.Errorf("%v orders failed to cancel", cancelAllOrdersResponse.Failed)
+	}
 	return cancelAllOrdersResponse, nil
 }
 
@@ -321,27 +420,199 @@ func (h *HUOBI) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
-// GetDepositAddress returns a deposit address for a specified currency
-func (h *HUOBI) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
-	return "", common.ErrNotYetImplemented
+// GetDepositAddress returns a deposit address for a specified currency on the
+// supplied chain e.g. "ERC20" or "TRC20". An empty chain selects the
+// currency's default chain
+func (h *HUOBI) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
+	chains, err := h.GetCurrencyChains(cryptocurrency.String())
+	if err != nil {
+		return "", err
+	}
+
+	c, err := findChain(cryptocurrency.String(), chain, chains)
+	if err != nil {
+		return "", err
+	}
+
+	addresses, err := h.GetDepositAddressList(cryptocurrency.String())
+	if err != nil {
+		return "", err
+	}
+
+	for x := range addresses {
+		if addresses[x].Chain == c.Chain {
+			return addresses[x].Address, nil
+		}
+	}
+	return "", fmt.Errorf("deposit address not found for %s on chain %s",
+		cryptocurrency, c.DisplayName)
 }
 
-// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
-// submitted
-func (h *HUOBI) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+// GetDepositAddresses returns the deposit address of a currency on every chain
+// it is supported on, chains are returned by their display name e.g. "TRC20"
+func (h *HUOBI) GetDepositAddresses(cryptocurrency pair.CurrencyItem) ([]exchange.DepositAddress, error) {
+	chains, err := h.GetCurrencyChains(cryptocurrency.String())
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := h.GetDepositAddressList(cryptocurrency.String())
+	if err != nil {
+		return nil, err
+	}
+	return depositAddressesByChain(chains, addresses), nil
+}
+
+// depositAddressesByChain converts the deposit addresses of a currency to
+// exchange deposit addresses keyed by the chain's display name
+func depositAddressesByChain(chains []CurrencyChain, addresses []DepositAddress) []exchange.DepositAddress {
+	resp := make([]exchange.DepositAddress, len(addresses))
+	for x := range addresses {
+		chain := addresses[x].Chain
+		for y := range chains {
+			if chains[y].Chain == addresses[x].Chain && chains[y].DisplayName != "" {
+				chain = chains[y].DisplayName
+				break
+			}
+		}
+
+		resp[x] = exchange.DepositAddress{
+			Chain:   chain,
+			Address: addresses[x].Address,
+			Tag:     addresses[x].AddressTag,
+		}
+	}
+	return resp
+}
+
+// GetAvailableChains returns the chains a currency is supported on, chains are
+// returned by their display name e.g. "TRC20"
+func (h *HUOBI) GetAvailableChains(cryptocurrency pair.CurrencyItem) ([]exchange.CurrencyChain, error) {
+	chains, err := h.GetCurrencyChains(cryptocurrency.String())
+	if err != nil {
+		return nil, err
+	}
+	return availableChains(chains)
+}
+
+// availableChains converts the chains of a currency to exchange currency
+// chains keyed by the chain's display name
+func availableChains(chains []CurrencyChain) ([]exchange.CurrencyChain, error) {
+	resp := make([]exchange.CurrencyChain, len(chains))
+	for x := range chains {
+		name := chains[x].DisplayName
+		if name == "" {
+			name = chains[x].Chain
+		}
+
+		resp[x] = exchange.CurrencyChain{
+			Chain:           name,
+			DepositEnabled:  chains[x].DepositStatus == "allowed",
+			WithdrawEnabled: chains[x].WithdrawStatus == "allowed",
+		}
+
+		var err error
+		if chains[x].TransactFeeWithdraw != "" {
+			resp[x].WithdrawalFee, err = strconv.ParseFloat(chains[x].TransactFeeWithdraw, 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if chains[x].MinWithdrawAmt != "" {
+			resp[x].MinWithdrawal, err = strconv.ParseFloat(chains[x].MinWithdrawAmt, 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return resp, nil
+}
+
+// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal on the
+// supplied chain e.g. "ERC20" or "TRC20" is submitted. An empty chain selects
+// the currency's default chain
+func (h *HUOBI) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := h.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
+	chains, err := h.GetCurrencyChains(cryptocurrency.String())
+	if err != nil {
+		return "", err
+	}
+
+	c, err := findChain(cryptocurrency.String(), chain, chains)
+	if err != nil {
+		return "", err
+	}
+
+	var fee float64
+	if c.TransactFeeWithdraw != "" {
+		fee, err = strconv.ParseFloat(c.TransactFeeWithdraw, 64)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	id, err := h.Withdraw(address,
+		common.StringToLower(cryptocurrency.String()),
+		c.Chain,
+		"",
+		amount,
+		fee)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(id, 10), nil
+}
+
+// GetWithdrawalStatus returns the status of a withdrawal by its withdrawal ID
+func (h *HUOBI) GetWithdrawalStatus(withdrawalID string) (exchange.WithdrawalStatus, error) {
+	id, err := strconv.ParseInt(withdrawalID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("%s invalid withdrawal ID %s", h.Name, withdrawalID)
+	}
+
+	records, err := h.QueryDepositWithdrawals(huobiWithdrawalType, id, 1)
+	if err != nil {
+		return "", err
+	}
+
+	for i := range records {
+		if records[i].ID == id {
+			return convertWithdrawalStatus(records[i].State), nil
+		}
+	}
+	return "", fmt.Errorf("%s withdrawal %s not found", h.Name, withdrawalID)
+}
+
+// convertWithdrawalStatus maps a Huobi withdrawal state to a withdrawal status,
+// withdrawals awaiting review are pending
+func convertWithdrawalStatus(state string) exchange.WithdrawalStatus {
+	switch state {
+	case "confirmed":
+		return exchange.WithdrawalCompleted
+	case "canceled", "reject", "wallet-reject", "confirm-error", "repealed":
+		return exchange.WithdrawalFailed
+	case "pass", "pre-transfer", "wallet-transfer":
+		return exchange.WithdrawalProcessing
+	default:
+		return exchange.WithdrawalPending
+	}
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
-// withdrawal is submitted
+// withdrawal is submitted.
+// Huobi doesn't make fiat withdrawals, fiat is sold through its OTC market
 func (h *HUOBI) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
-// withdrawal is submitted
+// withdrawal is submitted, see WithdrawFiatFunds
 func (h *HUOBI) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return "", common.ErrFunctionNotSupported
 }
 
 // GetWebsocket returns a pointer to the exchange websocket
@@ -349,6 +620,47 @@ func (h *HUOBI) GetWebsocket() (*exchange.Websocket, error) {
 	return h.Websocket, nil
 }
 
+// FetchTradeVolume returns the account's trailing 30 day trade volume in USDT,
+// the currency Huobi assesses fee tiers in, summed from the match results of
+// each enabled pair. Pairs whose quote currency can't be valued in USDT are
+// excluded and listed in the note
+func (h *HUOBI) FetchTradeVolume() (exchange.TradeVolume, error) {
+	start := time.Now().AddDate(0, 0, -exchange.TradeVolumeDays)
+	var total float64
+	var excluded []string
+	for _, p := range h.GetEnabledCurrencies() {
+		volume, err := h.GetTradeVolume(exchange.FormatExchangeCurrency(h.Name, p).String(), start)
+		if err != nil {
+			return exchange.TradeVolume{}, err
+		}
+		if volume == 0 {
+			continue
+		}
+
+		quote := common.StringToLower(p.SecondCurrency.String())
+		if quote != huobiTradeVolumeCurrency {
+			rate, err := h.GetLatestSpotPrice(quote + huobiTradeVolumeCurrency)
+			if err != nil {
+				excluded = append(excluded, p.Pair().String())
+				continue
+			}
+			volume *= rate
+		}
+		total += volume
+	}
+
+	tradeVolume := exchange.TradeVolume{
+		Volume:   total,
+		Currency: common.StringToUpper(huobiTradeVolumeCurrency),
+		Days:     exchange.TradeVolumeDays,
+	}
+	if len(excluded) > 0 {
+		tradeVolume.Note = fmt.Sprintf("excludes %s which could not be valued in %s",
+			common.JoinStrings(excluded, ", "), tradeVolume.Currency)
+	}
+	return tradeVolume, nil
+}
+
 // GetFeeByType returns an estimate of fee based on type of transaction
 func (h *HUOBI) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
 	return h.GetFee(feeBuilder)
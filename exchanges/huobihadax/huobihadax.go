@@ -61,6 +61,10 @@ type HUOBIHADAX struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.RegisterExchange("huobihadax", func() exchange.IBotExchange { return new(HUOBIHADAX) })
+}
+
 // SetDefaults sets default values for the exchange
 func (h *HUOBIHADAX) SetDefaults() {
 	h.Name = "HuobiHadax"
@@ -83,6 +87,14 @@ func (h *HUOBIHADAX) SetDefaults() {
 	h.APIUrlDefault = huobihadaxAPIURL
 	h.APIUrl = h.APIUrlDefault
 	h.WebsocketInit()
+	h.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	h.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	h.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	h.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets user configuration
@@ -92,17 +104,22 @@ func (h *HUOBIHADAX) Setup(exch config.ExchangeConfig) {
 	} else {
 		h.Enabled = true
 		h.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		h.Verbose = exch.Verbose
 		h.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		h.APIAuthPEMKeySupport = exch.APIAuthPEMKeySupport
 		h.APIAuthPEMKey = exch.APIAuthPEMKey
 		h.SetHTTPClientTimeout(exch.HTTPTimeout)
+		h.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		h.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := h.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		h.RESTPollingDelay = exch.RESTPollingDelay
-		h.Verbose = exch.Verbose
 		h.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		h.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		h.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := h.SetCurrencyPairFormat()
+		err = h.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -110,6 +127,10 @@ func (h *HUOBIHADAX) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = h.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = h.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -3,6 +3,7 @@ package huobihadax
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/thrasher-/gocryptotrader/config"
@@ -407,6 +408,32 @@ func TestSubmitOrder(t *testing.T) {
 	}
 }
 
+func TestSubmitOrderInvalidClientID(t *testing.T) {
+	h.SetDefaults()
+
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USDT)
+	_, err := h.SubmitOrder(p, exchange.Buy, exchange.Limit, 1, 10, "notanumber")
+	if err == nil {
+		t.Error("Test failed - Huobi SubmitOrder() expected error for non-numeric clientID")
+	}
+}
+
+func TestSubmitOrderEmptyClientID(t *testing.T) {
+	var hadax HUOBIHADAX
+	hadax.SetDefaults()
+	hadax.AccountID = "1337"
+
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USDT)
+	_, err := hadax.SubmitOrder(p, exchange.Buy, exchange.Limit, 1, 10, "")
+	if err == nil {
+		t.Fatal("Test failed - Huobi SubmitOrder() expected error without credentials set")
+	}
+
+	if strings.Contains(err.Error(), "invalid account ID") {
+		t.Errorf("Test failed - Huobi SubmitOrder() did not fall back to the account ID: %s", err)
+	}
+}
+
 func TestCancelExchangeOrder(t *testing.T) {
 	// Arrange
 	h.SetDefaults()
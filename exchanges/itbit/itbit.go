@@ -41,6 +41,10 @@ type ItBit struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.RegisterExchange("itbit", func() exchange.IBotExchange { return new(ItBit) })
+}
+
 // SetDefaults sets the defaults for the exchange
 func (i *ItBit) SetDefaults() {
 	i.Name = "ITBIT"
@@ -64,6 +68,14 @@ func (i *ItBit) SetDefaults() {
 	i.APIUrlDefault = itbitAPIURL
 	i.APIUrl = i.APIUrlDefault
 	i.WebsocketInit()
+	i.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	i.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	i.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	i.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	i.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	i.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	i.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	i.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets the exchange parameters from exchange config
@@ -73,15 +85,20 @@ func (i *ItBit) Setup(exch config.ExchangeConfig) {
 	} else {
 		i.Enabled = true
 		i.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		i.Verbose = exch.Verbose
 		i.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
 		i.SetHTTPClientTimeout(exch.HTTPTimeout)
+		i.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		i.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := i.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		i.RESTPollingDelay = exch.RESTPollingDelay
-		i.Verbose = exch.Verbose
 		i.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		i.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		i.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := i.SetCurrencyPairFormat()
+		err = i.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -89,6 +106,10 @@ func (i *ItBit) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = i.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = i.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -44,6 +44,10 @@ const (
 
 	krakenAuthRate   = 0
 	krakenUnauthRate = 0
+
+	// Base tier trading fee rates used when the trade volume cannot be fetched
+	krakenDefaultMakerFee = 0.0016
+	krakenDefaultTakerFee = 0.0026
 )
 
 // Kraken is the overarching type across the alphapoint package
@@ -52,6 +56,10 @@ type Kraken struct {
 	CryptoFee, FiatFee float64
 }
 
+func init() {
+	exchange.RegisterExchange("kraken", func() exchange.IBotExchange { return new(Kraken) })
+}
+
 // SetDefaults sets current default settings
 func (k *Kraken) SetDefaults() {
 	k.Name = "Kraken"
@@ -76,6 +84,14 @@ func (k *Kraken) SetDefaults() {
 	k.APIUrlDefault = krakenAPIURL
 	k.APIUrl = k.APIUrlDefault
 	k.WebsocketInit()
+	k.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	k.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	k.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	k.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	k.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	k.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	k.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	k.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets current exchange configuration
@@ -85,15 +101,20 @@ func (k *Kraken) Setup(exch config.ExchangeConfig) {
 	} else {
 		k.Enabled = true
 		k.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		k.Verbose = exch.Verbose
 		k.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		k.SetHTTPClientTimeout(exch.HTTPTimeout)
+		k.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		k.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := k.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		k.RESTPollingDelay = exch.RESTPollingDelay
-		k.Verbose = exch.Verbose
 		k.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		k.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		k.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := k.SetCurrencyPairFormat()
+		err = k.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -101,6 +122,10 @@ func (k *Kraken) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = k.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = k.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -929,15 +954,9 @@ func (k *Kraken) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
 
 	switch feeBuilder.FeeType {
 	case exchange.CryptocurrencyTradeFee:
-		feePair, err := k.GetTradeVolume(true, currency)
-		if err != nil {
-			return 0, err
-		}
-		if feeBuilder.IsMaker {
-			fee = calculateTradingFee(currency, feePair.FeesMaker, feeBuilder.PurchasePrice, feeBuilder.Amount)
-		} else {
-			fee = calculateTradingFee(currency, feePair.Fees, feeBuilder.PurchasePrice, feeBuilder.Amount)
-		}
+		fee = calculateTradingFee(k.getTradingFeeRate(currency, feeBuilder.IsMaker),
+			feeBuilder.PurchasePrice,
+			feeBuilder.Amount)
 	case exchange.CryptocurrencyWithdrawalFee:
 		fee = getWithdrawalFee(feeBuilder.FirstCurrency)
 	case exchange.InternationalBankDepositFee:
@@ -976,6 +995,31 @@ func getCryptocurrencyDepositFee(currency string) float64 {
 	return DepositFees[currency]
 }
 
-func calculateTradingFee(currency string, feePair map[string]TradeVolumeFee, purchasePrice, amount float64) float64 {
-	return (feePair[currency].Fee / 100) * purchasePrice * amount
+// getTradingFeeRate returns the maker or taker fee rate of a currency pair for
+// the account's volume tier from the fee rate cache
+func (k *Kraken) getTradingFeeRate(currency string, isMaker bool) float64 {
+	fallback := krakenDefaultTakerFee
+	if isMaker {
+		fallback = krakenDefaultMakerFee
+	}
+
+	return k.GetCachedFeeRate(exchange.CryptocurrencyTradeFee, currency, isMaker, func() (float64, error) {
+		feePair, err := k.GetTradeVolume(true, currency)
+		if err != nil {
+			return 0, err
+		}
+		fees := feePair.Fees
+		if isMaker {
+			fees = feePair.FeesMaker
+		}
+		tradeFee, ok := fees[currency]
+		if !ok {
+			return 0, fmt.Errorf("no fee returned for %s", currency)
+		}
+		return tradeFee.Fee / 100, nil
+	}, fallback)
+}
+
+func calculateTradingFee(rate, purchasePrice, amount float64) float64 {
+	return rate * purchasePrice * amount
 }
@@ -12,6 +12,9 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*Kraken)(nil)
+var _ exchange.TradeVolumeGetter = (*Kraken)(nil)
+
 // Start starts the Kraken go routine
 func (k *Kraken) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -28,7 +31,11 @@ func (k *Kraken) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", k.GetName(), len(k.EnabledPairs), k.EnabledPairs)
 	}
 
-	assetPairs, err := k.GetAssetPairs()
+	var assetPairs map[string]AssetPairs
+	err := k.RetryPairUpdate(func() (err error) {
+		assetPairs, err = k.GetAssetPairs()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available symbols.\n", k.GetName())
 	} else {
@@ -62,15 +69,22 @@ func (k *Kraken) Run() {
 				log.Printf("%s Failed to get config.\n", k.GetName())
 			}
 		}
-		err = k.UpdateCurrencies(exchangeProducts, false, forceUpgrade)
-		if err != nil {
-			log.Printf("%s Failed to get config.\n", k.GetName())
+		if k.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = k.UpdateCurrencies(exchangeProducts, false, forceUpgrade)
+			if err != nil {
+				log.Printf("%s Failed to get config.\n", k.GetName())
+			}
 		}
 	}
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (k *Kraken) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := k.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	pairs := k.GetEnabledCurrencies()
 	pairsCollated, err := exchange.GetAndFormatExchangeCurrencies(k.Name, pairs)
@@ -102,6 +116,7 @@ func (k *Kraken) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Pri
 
 // GetTickerPrice returns the ticker for a currency pair
 func (k *Kraken) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = k.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(k.GetName(), p, assetType)
 	if err != nil {
 		return k.UpdateTicker(p, assetType)
@@ -111,6 +126,7 @@ func (k *Kraken) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.P
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (k *Kraken) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = k.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(k.GetName(), p, assetType)
 	if err != nil {
 		return k.UpdateOrderbook(p, assetType)
@@ -120,6 +136,11 @@ func (k *Kraken) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderboo
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (k *Kraken) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := k.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := k.GetDepth(exchange.FormatExchangeCurrency(k.GetName(), p).String())
 	if err != nil {
@@ -161,6 +182,16 @@ func (k *Kraken) GetAccountInfo() (exchange.AccountInfo, error) {
 	return info, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (k *Kraken) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := k.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return k.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (k *Kraken) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -177,10 +208,17 @@ func (k *Kraken) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (k *Kraken) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := k.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (k *Kraken) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 	var args = AddOrderOptions{}
 
-	response, err := k.AddOrder(p.Pair().String(), side.ToString(), orderType.ToString(), amount, price, 0, 0, args)
+	response, err := k.AddOrder(order.Pair.Pair().String(), order.OrderSide.ToString(), order.OrderType.ToString(), order.Amount, order.Price, 0, 0, args)
 
 	if len(response.TransactionIds) > 0 {
 		submitOrderResponse.OrderID = strings.Join(response.TransactionIds, ", ")
@@ -236,19 +274,27 @@ func (k *Kraken) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (k *Kraken) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (k *Kraken) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (k *Kraken) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (k *Kraken) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := k.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (k *Kraken) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := k.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
@@ -263,6 +309,21 @@ func (k *Kraken) GetWebsocket() (*exchange.Websocket, error) {
 	return nil, common.ErrNotYetImplemented
 }
 
+// FetchTradeVolume returns the account's trailing 30 day trade volume in the
+// currency Kraken reports it in, which its fee tiers are assessed on
+func (k *Kraken) FetchTradeVolume() (exchange.TradeVolume, error) {
+	resp, err := k.GetTradeVolume(false)
+	if err != nil {
+		return exchange.TradeVolume{}, err
+	}
+
+	return exchange.TradeVolume{
+		Volume:   resp.Volume,
+		Currency: resp.Currency,
+		Days:     exchange.TradeVolumeDays,
+	}, nil
+}
+
 // GetFeeByType returns an estimate of fee based on type of transaction
 func (k *Kraken) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
 	return k.GetFee(feeBuilder)
@@ -41,6 +41,10 @@ type LakeBTC struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.RegisterExchange("lakebtc", func() exchange.IBotExchange { return new(LakeBTC) })
+}
+
 // SetDefaults sets LakeBTC defaults
 func (l *LakeBTC) SetDefaults() {
 	l.Name = "LakeBTC"
@@ -64,6 +68,14 @@ func (l *LakeBTC) SetDefaults() {
 	l.APIUrlDefault = lakeBTCAPIURL
 	l.APIUrl = l.APIUrlDefault
 	l.WebsocketInit()
+	l.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	l.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	l.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets exchange configuration profile
@@ -73,15 +85,20 @@ func (l *LakeBTC) Setup(exch config.ExchangeConfig) {
 	} else {
 		l.Enabled = true
 		l.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		l.Verbose = exch.Verbose
 		l.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		l.SetHTTPClientTimeout(exch.HTTPTimeout)
+		l.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		l.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := l.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		l.RESTPollingDelay = exch.RESTPollingDelay
-		l.Verbose = exch.Verbose
 		l.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		l.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		l.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := l.SetCurrencyPairFormat()
+		err = l.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -89,6 +106,10 @@ func (l *LakeBTC) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = l.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = l.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
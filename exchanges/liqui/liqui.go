@@ -43,6 +43,10 @@ type Liqui struct {
 	Info   Info
 }
 
+func init() {
+	exchange.RegisterExchange("liqui", func() exchange.IBotExchange { return new(Liqui) })
+}
+
 // SetDefaults sets current default values for liqui
 func (l *Liqui) SetDefaults() {
 	l.Name = "Liqui"
@@ -69,6 +73,14 @@ func (l *Liqui) SetDefaults() {
 	l.APIUrlSecondaryDefault = liquiAPIPrivateURL
 	l.APIUrlSecondary = l.APIUrlSecondaryDefault
 	l.WebsocketInit()
+	l.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	l.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	l.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets exchange configuration parameters for liqui
@@ -78,15 +90,20 @@ func (l *Liqui) Setup(exch config.ExchangeConfig) {
 	} else {
 		l.Enabled = true
 		l.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		l.Verbose = exch.Verbose
 		l.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		l.SetHTTPClientTimeout(exch.HTTPTimeout)
+		l.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		l.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := l.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		l.RESTPollingDelay = exch.RESTPollingDelay
-		l.Verbose = exch.Verbose
 		l.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		l.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		l.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := l.SetCurrencyPairFormat()
+		err = l.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -94,6 +111,10 @@ func (l *Liqui) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = l.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = l.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
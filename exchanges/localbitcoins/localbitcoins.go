@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/exchanges"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 const (
@@ -96,8 +98,11 @@ const (
 	statePaidLateConfirmed   = "PAID_IN_LATE_AND_CONFIRMED"
 	statePaidPartlyConfirmed = "PAID_PARTLY_AND_CONFIRMED"
 
-	localbitcoinsAuthRate   = 0
-	localbitcoinsUnauthRate = 1
+	// LocalBitcoins responds with HTTP 429 and temporarily bans clients which
+	// exceed its request limits, so requests are kept to one per second
+	localbitcoinsRateInterval = time.Second
+	localbitcoinsAuthRate     = 1
+	localbitcoinsUnauthRate   = 1
 )
 
 var (
@@ -110,6 +115,10 @@ type LocalBitcoins struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.RegisterExchange("localbitcoins", func() exchange.IBotExchange { return new(LocalBitcoins) })
+}
+
 // SetDefaults sets the package defaults for localbitcoins
 func (l *LocalBitcoins) SetDefaults() {
 	l.Name = "LocalBitcoins"
@@ -118,6 +127,7 @@ func (l *LocalBitcoins) SetDefaults() {
 	l.Verbose = false
 	l.RESTPollingDelay = 10
 	l.APIWithdrawPermissions = exchange.WithdrawCryptoViaWebsiteOnly
+	l.AssetTypes = []string{ticker.Spot}
 	l.RequestCurrencyPairFormat.Delimiter = ""
 	l.RequestCurrencyPairFormat.Uppercase = true
 	l.ConfigCurrencyPairFormat.Delimiter = ""
@@ -125,12 +135,20 @@ func (l *LocalBitcoins) SetDefaults() {
 	l.SupportsAutoPairUpdating = true
 	l.SupportsRESTTickerBatching = true
 	l.Requester = request.New(l.Name,
-		request.NewRateLimit(time.Millisecond*500, localbitcoinsAuthRate),
-		request.NewRateLimit(time.Millisecond*500, localbitcoinsUnauthRate),
+		request.NewRateLimit(localbitcoinsRateInterval, localbitcoinsAuthRate),
+		request.NewRateLimit(localbitcoinsRateInterval, localbitcoinsUnauthRate),
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
 	l.APIUrlDefault = localbitcoinsAPIURL
 	l.APIUrl = l.APIUrlDefault
 	l.WebsocketInit()
+	l.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	l.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	l.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnsupported)
+	l.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnsupported)
 }
 
 // Setup sets exchange configuration parameters
@@ -140,15 +158,28 @@ func (l *LocalBitcoins) Setup(exch config.ExchangeConfig) {
 	} else {
 		l.Enabled = true
 		l.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		l.Verbose = exch.Verbose
 		l.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		l.SetHTTPClientTimeout(exch.HTTPTimeout)
+		l.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		l.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := l.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		l.RESTPollingDelay = exch.RESTPollingDelay
-		l.Verbose = exch.Verbose
 		l.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		l.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		l.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := l.SetCurrencyPairFormat()
+		err = l.SetCurrencyPairFormat()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = l.SetAssetTypes()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = l.SetPriceSources(exch.PriceSources)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -228,8 +259,52 @@ func (l *LocalBitcoins) EditAd(params AdEdit, adID string) error {
 // CreateAd creates a new advertisement
 //
 // params - see localbitcoins_types.go AdCreate for reference
-func (l *LocalBitcoins) CreateAd(params AdCreate) error {
-	return l.SendAuthenticatedHTTPRequest("POST", localbitcoinsAPIAdCreate, nil, nil)
+//
+// Returns the ID of the newly created advertisement
+func (l *LocalBitcoins) CreateAd(params AdCreate) (string, error) {
+	var resp AdCreateResponse
+	err := l.SendAuthenticatedHTTPRequest("POST", localbitcoinsAPIAdCreate, params.values(), &resp)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.Data.AdID == 0 {
+		return "", errors.New("ad created but no ad ID was returned")
+	}
+	return strconv.FormatInt(resp.Data.AdID, 10), nil
+}
+
+// values returns the url encoded form of the ad creation parameters, optional
+// arguments are only set when supplied
+func (a *AdCreate) values() url.Values {
+	v := url.Values{}
+	v.Set("price_equation", a.PriceEquation)
+	v.Set("lat", strconv.Itoa(a.Latitude))
+	v.Set("lon", strconv.Itoa(a.Longitude))
+	v.Set("city", a.City)
+	v.Set("location_string", a.Location)
+	v.Set("countrycode", a.CountryCode)
+	v.Set("currency", a.Currency)
+	v.Set("account_info", a.AccountInfo)
+	v.Set("bank_name", a.BankName)
+	v.Set("msg", a.MSG)
+	v.Set("sms_verification_required", strconv.FormatBool(a.SMSVerficationRequired))
+	v.Set("track_max_amount", strconv.FormatBool(a.TrackMaxAmount))
+	v.Set("require_trusted_by_advertiser", strconv.FormatBool(a.RequireTrustedByAdvertiser))
+	v.Set("require_identification", strconv.FormatBool(a.RequireIdentification))
+	v.Set("online_provider", a.OnlineProvider)
+	v.Set("trade_type", a.TradeType)
+
+	if a.MinAmount > 0 {
+		v.Set("min_amount", strconv.Itoa(a.MinAmount))
+	}
+	if a.MaxAmount > 0 {
+		v.Set("max_amount", strconv.Itoa(a.MaxAmount))
+	}
+	if a.LimitToFiatAmounts != "" {
+		v.Set("limit_to_fiat_amounts", a.LimitToFiatAmounts)
+	}
+	return v
 }
 
 // UpdatePriceEquation updates price equation of an advertisement. If there are
@@ -459,14 +534,47 @@ func (l *LocalBitcoins) MarkNotifications(notificationID string) error {
 // GetPaymentMethods returns a list of valid payment methods. Also contains name
 // and code for payment methods, and possible limitations in currencies and bank
 // name choices.
-func (l *LocalBitcoins) GetPaymentMethods() error {
-	return l.SendHTTPRequest(l.APIUrl+localbitcoinsAPIPaymentMethods, nil)
+func (l *LocalBitcoins) GetPaymentMethods() ([]PaymentMethod, error) {
+	return l.getPaymentMethods(l.APIUrl + localbitcoinsAPIPaymentMethods)
 }
 
 // GetPaymentMethodsByCountry returns a list of valid payment methods filtered
 // by countrycodes.
-func (l *LocalBitcoins) GetPaymentMethodsByCountry(countryCode string) error {
-	return l.SendHTTPRequest(l.APIUrl+localbitcoinsAPIPaymentMethods+countryCode, nil)
+//
+// countryCode - two letter country code e.g. "US", see GetCountryCodes
+func (l *LocalBitcoins) GetPaymentMethodsByCountry(countryCode string) ([]PaymentMethod, error) {
+	if len(countryCode) != 2 {
+		return nil, fmt.Errorf("invalid country code %q", countryCode)
+	}
+	return l.getPaymentMethods(l.APIUrl + localbitcoinsAPIPaymentMethods +
+		common.StringToLower(countryCode) + "/")
+}
+
+// getPaymentMethods fetches payment methods from path
+func (l *LocalBitcoins) getPaymentMethods(path string) ([]PaymentMethod, error) {
+	var resp PaymentMethodsResponse
+	err := l.SendHTTPRequest(path, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.methods(), nil
+}
+
+// methods returns the payment methods sorted by code, taking the code from the
+// map key when a method doesn't include it
+func (p *PaymentMethodsResponse) methods() []PaymentMethod {
+	methods := make([]PaymentMethod, 0, len(p.Data.Methods))
+	for code, method := range p.Data.Methods {
+		if method.Code == "" {
+			method.Code = code
+		}
+		methods = append(methods, method)
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Code < methods[j].Code
+	})
+	return methods
 }
 
 // CheckPincode checks the given PIN code against the token owners currently
@@ -1,6 +1,9 @@
 package localbitcoins
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/thrasher-/gocryptotrader/config"
@@ -110,6 +113,74 @@ func setFeeBuilder() exchange.FeeBuilder {
 	}
 }
 
+func TestAdCreateValues(t *testing.T) {
+	params := AdCreate{
+		PriceEquation: "btc_in_usd*1.01",
+		Currency:      "USD",
+		TradeType:     "ONLINE_SELL",
+		MinAmount:     10,
+	}
+
+	v := params.values()
+	if v.Get("price_equation") != "btc_in_usd*1.01" ||
+		v.Get("currency") != "USD" ||
+		v.Get("trade_type") != "ONLINE_SELL" ||
+		v.Get("min_amount") != "10" {
+		t.Error("Test failed - LocalBitcoins AdCreate values() incorrect values", v)
+	}
+
+	if _, ok := v["max_amount"]; ok {
+		t.Error("Test failed - LocalBitcoins AdCreate values() unset optional argument was sent")
+	}
+}
+
+func TestAdCreateResponse(t *testing.T) {
+	var resp AdCreateResponse
+	err := json.Unmarshal([]byte(`{"data":{"message":"Ad added","ad_id":12345}}`), &resp)
+	if err != nil {
+		t.Fatal("Test failed - LocalBitcoins AdCreateResponse unmarshal error", err)
+	}
+
+	if resp.Data.AdID != 12345 {
+		t.Errorf("Test failed - LocalBitcoins AdCreateResponse expected ad ID 12345, received %d",
+			resp.Data.AdID)
+	}
+}
+
+func TestPaymentMethodsResponse(t *testing.T) {
+	var resp PaymentMethodsResponse
+	err := json.Unmarshal([]byte(`{"data":{"methods":{
+		"NATIONAL_BANK":{"code":"NATIONAL_BANK","name":"National bank transfer","currencies":["USD","EUR"],"bank_name_choices":["HSBC"]},
+		"CASH_DEPOSIT":{"name":"Cash deposit","currencies":["USD"]}},
+		"method_count":2}}`), &resp)
+	if err != nil {
+		t.Fatal("Test failed - LocalBitcoins PaymentMethodsResponse unmarshal error", err)
+	}
+
+	methods := resp.methods()
+	if len(methods) != 2 {
+		t.Fatalf("Test failed - LocalBitcoins PaymentMethodsResponse expected 2 methods, received %d",
+			len(methods))
+	}
+
+	if methods[0].Code != "CASH_DEPOSIT" || methods[0].Name != "Cash deposit" {
+		t.Errorf("Test failed - LocalBitcoins PaymentMethodsResponse unexpected method %+v", methods[0])
+	}
+
+	if methods[1].Code != "NATIONAL_BANK" || len(methods[1].Currencies) != 2 ||
+		len(methods[1].BankNameChoices) != 1 {
+		t.Errorf("Test failed - LocalBitcoins PaymentMethodsResponse unexpected method %+v", methods[1])
+	}
+}
+
+func TestGetPaymentMethodsByCountry(t *testing.T) {
+	t.Parallel()
+	_, err := l.GetPaymentMethodsByCountry("USA")
+	if err == nil {
+		t.Error("Test failed - GetPaymentMethodsByCountry() expected error for an invalid country code")
+	}
+}
+
 func TestGetFee(t *testing.T) {
 	l.SetDefaults()
 	var feeBuilder = setFeeBuilder()
@@ -284,6 +355,15 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 	if len(resp.OrderStatus) > 0 {
 		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
 	}
+
+	if resp.Failed > 0 {
+		t.Errorf("%v orders failed to cancel", resp.Failed)
+	}
+
+	if resp.Succeeded != len(resp.CancelledOrderIDs) {
+		t.Errorf("%v orders cancelled but %v order IDs returned",
+			resp.Succeeded, len(resp.CancelledOrderIDs))
+	}
 }
 
 func TestModifyOrder(t *testing.T) {
@@ -292,3 +372,49 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestCreateAd(t *testing.T) {
+	var adID string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/"+localbitcoinsAPIAdCreate || req.Header.Get("Apiauth-Key") != "key" {
+			t.Errorf("Test failed - CreateAd() unexpected request %s", req.URL.Path)
+		}
+
+		err := req.ParseForm()
+		if err != nil || req.PostForm.Get("price_equation") != "btc_in_usd*1.01" ||
+			req.PostForm.Get("trade_type") != "ONLINE_SELL" {
+			t.Errorf("Test failed - CreateAd() unexpected params %v %v", req.PostForm, err)
+		}
+
+		if adID == "" {
+			w.Write([]byte(`{"data":{"message":"Ad not placed"}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"message":"Ad added","ad_id":` + adID + `}}`))
+	}))
+	defer ts.Close()
+
+	var stub LocalBitcoins
+	stub.SetDefaults()
+	stub.AuthenticatedAPISupport = true
+	stub.APIKey = "key"
+	stub.APISecret = "secret"
+	stub.APIUrl = ts.URL
+
+	params := AdCreate{
+		PriceEquation: "btc_in_usd*1.01",
+		TradeType:     "ONLINE_SELL",
+	}
+
+	adID = "12345"
+	id, err := stub.CreateAd(params)
+	if err != nil || id != "12345" {
+		t.Errorf("Test failed - CreateAd() expected ad 12345, received %s %v", id, err)
+	}
+
+	adID = ""
+	id, err = stub.CreateAd(params)
+	if err == nil || id != "" {
+		t.Errorf("Test failed - CreateAd() expected an error when the ad isn't placed, received %s", id)
+	}
+}
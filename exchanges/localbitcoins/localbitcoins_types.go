@@ -165,6 +165,33 @@ type AdCreate struct {
 	Floating bool `json:"floating,int"`
 }
 
+// AdCreateResponse holds the response from creating an advertisement
+type AdCreateResponse struct {
+	Data struct {
+		Message string `json:"message"`
+		AdID    int64  `json:"ad_id"`
+	} `json:"data"`
+}
+
+// PaymentMethod is an online payment method ads can be listed under. Code is
+// the online_provider value used by CreateAd and the payment method used to
+// search online ads
+type PaymentMethod struct {
+	Code            string   `json:"code"`
+	Name            string   `json:"name"`
+	Currencies      []string `json:"currencies"`
+	BankNameChoices []string `json:"bank_name_choices"`
+	URL             string   `json:"url"`
+}
+
+// PaymentMethodsResponse holds the payment methods keyed by code
+type PaymentMethodsResponse struct {
+	Data struct {
+		Methods     map[string]PaymentMethod `json:"methods"`
+		MethodCount int                      `json:"method_count"`
+	} `json:"data"`
+}
+
 // Message holds the returned message data from a contact
 type Message struct {
 	MSG    string `json:"msg"`
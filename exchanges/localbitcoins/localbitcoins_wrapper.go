@@ -1,7 +1,6 @@
 package localbitcoins
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -15,6 +14,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*LocalBitcoins)(nil)
+
 // Start starts the LocalBitcoins go routine
 func (l *LocalBitcoins) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -31,7 +32,11 @@ func (l *LocalBitcoins) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", l.GetName(), len(l.EnabledPairs), l.EnabledPairs)
 	}
 
-	currencies, err := l.GetTradableCurrencies()
+	var currencies []string
+	err := l.RetryPairUpdate(func() (err error) {
+		currencies, err = l.GetTradableCurrencies()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s failed to obtain available tradable currencies. Err: %s", l.Name, err)
 		return
@@ -42,15 +47,22 @@ func (l *LocalBitcoins) Run() {
 		pairs = append(pairs, "BTC"+currencies[x])
 	}
 
-	err = l.UpdateCurrencies(pairs, false, false)
-	if err != nil {
-		log.Printf("%s failed to update available currencies. Err %s", l.Name, err)
+	if l.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+		err = l.UpdateCurrencies(pairs, false, false)
+		if err != nil {
+			log.Printf("%s failed to update available currencies. Err %s", l.Name, err)
+		}
 	}
 
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (l *LocalBitcoins) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := l.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	tick, err := l.GetTicker()
 	if err != nil {
@@ -71,6 +83,7 @@ func (l *LocalBitcoins) UpdateTicker(p pair.CurrencyPair, assetType string) (tic
 
 // GetTickerPrice returns the ticker for a currency pair
 func (l *LocalBitcoins) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = l.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(l.GetName(), p, assetType)
 	if err != nil {
 		return l.UpdateTicker(p, assetType)
@@ -80,6 +93,7 @@ func (l *LocalBitcoins) GetTickerPrice(p pair.CurrencyPair, assetType string) (t
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (l *LocalBitcoins) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = l.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(l.GetName(), p, assetType)
 	if err != nil {
 		return l.UpdateOrderbook(p, assetType)
@@ -89,6 +103,11 @@ func (l *LocalBitcoins) GetOrderbookEx(p pair.CurrencyPair, assetType string) (o
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (l *LocalBitcoins) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := l.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := l.GetOrderbook(p.SecondCurrency.String())
 	if err != nil {
@@ -126,11 +145,22 @@ func (l *LocalBitcoins) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (l *LocalBitcoins) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := l.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return l.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
-// withdrawals
+// withdrawals. LocalBitcoins exposes wallet transactions but the wrapper
+// doesn't fetch them yet
 func (l *LocalBitcoins) GetFundingHistory() ([]exchange.FundHistory, error) {
 	var fundHistory []exchange.FundHistory
-	return fundHistory, common.ErrFunctionNotSupported
+	return fundHistory, common.ErrNotYetImplemented
 }
 
 // GetExchangeHistory returns historic trade data since exchange opening.
@@ -142,7 +172,14 @@ func (l *LocalBitcoins) GetExchangeHistory(p pair.CurrencyPair, assetType string
 
 // SubmitOrder submits a new order
 func (l *LocalBitcoins) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := l.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (l *LocalBitcoins) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 	// These are placeholder details
 	// TODO store a user's localbitcoin details to use here
 	var params = AdCreate{
@@ -152,59 +189,27 @@ func (l *LocalBitcoins) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide
 		City:                       "City",
 		Location:                   "Location",
 		CountryCode:                "US",
-		Currency:                   p.SecondCurrency.String(),
+		Currency:                   order.Pair.SecondCurrency.String(),
 		AccountInfo:                "-",
 		BankName:                   "Bank",
-		MSG:                        fmt.Sprintf("%s", side.ToString()),
+		MSG:                        fmt.Sprintf("%s", order.OrderSide.ToString()),
 		SMSVerficationRequired:     true,
 		TrackMaxAmount:             true,
 		RequireTrustedByAdvertiser: true,
 		RequireIdentification:      true,
 		OnlineProvider:             "",
 		TradeType:                  "",
-		MinAmount:                  int(math.Round(amount)),
+		MinAmount:                  int(math.Round(order.Amount)),
 	}
 
-	// Does not return any orderID, so create the add, then get the order
-	err := l.CreateAd(params)
+	adID, err := l.CreateAd(params)
 	if err != nil {
 		return submitOrderResponse, err
 	}
-	if err == nil {
-		submitOrderResponse.IsOrderPlaced = true
-	}
-
-	// Now to figure out what ad we just submitted
-	// The only details we have are the params above
-	var adID string
-	ads, err := l.Getads()
-	for _, i := range ads.AdList {
-		if i.Data.PriceEquation == params.PriceEquation &&
-			i.Data.Lat == float64(params.Latitude) &&
-			i.Data.Lon == float64(params.Longitude) &&
-			i.Data.City == params.City &&
-			i.Data.Location == params.Location &&
-			i.Data.CountryCode == params.CountryCode &&
-			i.Data.Currency == params.Currency &&
-			i.Data.AccountInfo == params.AccountInfo &&
-			i.Data.BankName == params.BankName &&
-			i.Data.SMSVerficationRequired == params.SMSVerficationRequired &&
-			i.Data.TrackMaxAmount == params.TrackMaxAmount &&
-			i.Data.RequireTrustedByAdvertiser == params.RequireTrustedByAdvertiser &&
-			i.Data.OnlineProvider == params.OnlineProvider &&
-			i.Data.TradeType == params.TradeType &&
-			i.Data.MinAmount == fmt.Sprintf("%v", params.MinAmount) {
-			adID = fmt.Sprintf("%v", i.Data.AdID)
-		}
-	}
-
-	if adID != "" {
-		submitOrderResponse.OrderID = adID
-	} else {
-		return submitOrderResponse, errors.New("Ad placed, but not found via API")
-	}
 
-	return submitOrderResponse, err
+	submitOrderResponse.IsOrderPlaced = true
+	submitOrderResponse.OrderID = adID
+	return submitOrderResponse, nil
 }
 
 // ModifyOrder will allow of changing orderbook placement and limit to
@@ -232,8 +237,12 @@ func (l *LocalBitcoins) CancelAllOrders(orderCancellation exchange.OrderCancella
 		adIDString := strconv.FormatInt(ad.Data.AdID, 10)
 		err = l.DeleteAd(adIDString)
 		if err != nil {
-			cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(ad.Data.AdID, 10)] = err.Error()
+			cancelAllOrdersResponse.OrderStatus[adIDString] = err.Error()
+			cancelAllOrdersResponse.Failed++
+			continue
 		}
+		cancelAllOrdersResponse.CancelledOrderIDs = append(cancelAllOrdersResponse.CancelledOrderIDs, adIDString)
+		cancelAllOrdersResponse.Succeeded++
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -246,31 +255,37 @@ func (l *LocalBitcoins) GetOrderInfo(orderID int64) (exchange.OrderDetail, error
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (l *LocalBitcoins) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (l *LocalBitcoins) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (l *LocalBitcoins) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (l *LocalBitcoins) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := l.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
-// withdrawal is submitted
+// withdrawal is submitted.
+// LocalBitcoins doesn't hold fiat, it is paid directly between traders
 func (l *LocalBitcoins) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
-// withdrawal is submitted
+// withdrawal is submitted, see WithdrawFiatFunds
 func (l *LocalBitcoins) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return "", common.ErrFunctionNotSupported
 }
 
-// GetWebsocket returns a pointer to the exchange websocket
+// GetWebsocket returns a pointer to the exchange websocket, LocalBitcoins
+// doesn't offer a websocket API
 func (l *LocalBitcoins) GetWebsocket() (*exchange.Websocket, error) {
-	return nil, common.ErrNotYetImplemented
+	return nil, common.ErrFunctionNotSupported
 }
 
 // GetFeeByType returns an estimate of fee based on type of transaction
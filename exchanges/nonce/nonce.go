@@ -6,6 +6,10 @@ import (
 	"time"
 )
 
+// Now returns the current time nonces are seeded from. It defaults to time.Now
+// and can be replaced in tests so generated nonces are deterministic
+var Now = time.Now
+
 // Nonce struct holds the nonce value
 type Nonce struct {
 	// Standard nonce
@@ -68,10 +72,10 @@ func (n *Nonce) GetValue(exchName string, nanoPrecision bool) Value {
 
 	if n.boundedCall[exchName] == 0 {
 		if nanoPrecision {
-			n.boundedCall[exchName] = time.Now().UnixNano()
+			n.boundedCall[exchName] = Now().UnixNano()
 			return Value(n.boundedCall[exchName])
 		}
-		n.boundedCall[exchName] = time.Now().Unix()
+		n.boundedCall[exchName] = Now().Unix()
 		return Value(n.boundedCall[exchName])
 	}
 	n.boundedCall[exchName]++
@@ -82,6 +82,25 @@ func TestGetValue(t *testing.T) {
 	}
 }
 
+func TestGetValueClock(t *testing.T) {
+	now := time.Unix(1514764800, 123456789)
+	Now = func() time.Time { return now }
+	defer func() { Now = time.Now }()
+
+	var nonce Nonce
+	if n := nonce.GetValue("dingdong", true); n != 1514764800123456789 {
+		t.Errorf("Test failed. Expected 1514764800123456789 got %d", n)
+	}
+
+	if n := nonce.GetValue("dingdong", true); n != 1514764800123456790 {
+		t.Errorf("Test failed. Expected 1514764800123456790 got %d", n)
+	}
+
+	if n := nonce.GetValue("dongding", false); n != 1514764800 {
+		t.Errorf("Test failed. Expected 1514764800 got %d", n)
+	}
+}
+
 func TestNonceConcurrency(t *testing.T) {
 	var nonce Nonce
 	nonce.Set(12312)
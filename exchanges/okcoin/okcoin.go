@@ -93,6 +93,11 @@ func (o *OKCoin) setCurrencyPairFormats() {
 	o.ConfigCurrencyPairFormat.Uppercase = true
 }
 
+func init() {
+	exchange.RegisterExchange("okcoin china", func() exchange.IBotExchange { return new(OKCoin) })
+	exchange.RegisterExchange("okcoin international", func() exchange.IBotExchange { return new(OKCoin) })
+}
+
 // SetDefaults sets current default values for this package
 func (o *OKCoin) SetDefaults() {
 	o.SetErrorDefaults()
@@ -105,6 +110,13 @@ func (o *OKCoin) SetDefaults() {
 	o.SupportsAutoPairUpdating = false
 	o.SupportsRESTTickerBatching = false
 	o.WebsocketInit()
+	o.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	o.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	o.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	o.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	o.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	o.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	o.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets exchange configuration parameters
@@ -146,16 +158,21 @@ func (o *OKCoin) Setup(exch config.ExchangeConfig) {
 
 		o.Enabled = true
 		o.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		o.Verbose = exch.Verbose
 		o.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		o.SetHTTPClientTimeout(exch.HTTPTimeout)
+		o.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		o.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := o.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		o.RESTPollingDelay = exch.RESTPollingDelay
-		o.Verbose = exch.Verbose
 		o.Websocket.SetEnabled(exch.Websocket)
 		o.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		o.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		o.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := o.SetCurrencyPairFormat()
+		err = o.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -163,6 +180,10 @@ func (o *OKCoin) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = o.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = o.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
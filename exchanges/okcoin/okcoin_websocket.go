@@ -39,7 +39,7 @@ func (o *OKCoin) AddChannel(channel string) error {
 		return err
 	}
 
-	return o.WebsocketConn.WriteMessage(websocket.TextMessage, json)
+	return o.Websocket.SendMessage(o.WebsocketConn, websocket.TextMessage, json)
 }
 
 // WsConnect initiates a websocket connection
@@ -105,7 +105,7 @@ func (o *OKCoin) WsReadData() {
 			return
 
 		default:
-			_, resp, err := o.WebsocketConn.ReadMessage()
+			_, resp, err := o.Websocket.ReadMessage(o.WebsocketConn)
 			if err != nil {
 				o.Websocket.DataHandler <- err
 				return
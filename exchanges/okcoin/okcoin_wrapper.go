@@ -14,6 +14,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*OKCoin)(nil)
+
 // Start starts the OKCoin go routine
 func (o *OKCoin) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -38,7 +40,11 @@ func (o *OKCoin) Run() {
 			forceUpgrade = true
 		}
 
-		prods, err := o.GetSpotInstruments()
+		var prods []SpotInstrument
+		err := o.RetryPairUpdate(func() (err error) {
+			prods, err = o.GetSpotInstruments()
+			return err
+		})
 		if err != nil {
 			log.Printf("OKEX failed to obtain available spot instruments. Err: %d", err)
 		} else {
@@ -47,9 +53,11 @@ func (o *OKCoin) Run() {
 				pairs = append(pairs, prods[x].BaseCurrency+"_"+prods[x].QuoteCurrency)
 			}
 
-			err = o.UpdateCurrencies(pairs, false, forceUpgrade)
-			if err != nil {
-				log.Printf("OKEX failed to update available currencies. Err: %s", err)
+			if o.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+				err = o.UpdateCurrencies(pairs, false, forceUpgrade)
+				if err != nil {
+					log.Printf("OKEX failed to update available currencies. Err: %s", err)
+				}
 			}
 		}
 
@@ -67,6 +75,11 @@ func (o *OKCoin) Run() {
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (o *OKCoin) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := o.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	currency := exchange.FormatExchangeCurrency(o.Name, p).String()
 	var tickerPrice ticker.Price
 
@@ -103,6 +116,7 @@ func (o *OKCoin) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Pri
 
 // GetTickerPrice returns the ticker for a currency pair
 func (o *OKCoin) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = o.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(o.GetName(), p, assetType)
 	if err != nil {
 		return o.UpdateTicker(p, assetType)
@@ -112,6 +126,7 @@ func (o *OKCoin) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.P
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (o *OKCoin) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = o.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(o.GetName(), currency, assetType)
 	if err != nil {
 		return o.UpdateOrderbook(currency, assetType)
@@ -121,6 +136,11 @@ func (o *OKCoin) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (o
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (o *OKCoin) UpdateOrderbook(currency pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := o.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := o.GetOrderBook(exchange.FormatExchangeCurrency(o.Name, currency).String(), 200, false)
 	if err != nil {
@@ -178,6 +198,16 @@ func (o *OKCoin) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (o *OKCoin) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := o.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return o.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (o *OKCoin) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -194,16 +224,23 @@ func (o *OKCoin) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (o *OKCoin) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := o.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (o *OKCoin) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 	var oT string
-	if orderType == exchange.Limit {
-		if side == exchange.Buy {
+	if order.OrderType == exchange.Limit {
+		if order.OrderSide == exchange.Buy {
 			oT = "buy"
 		} else {
 			oT = "sell"
 		}
-	} else if orderType == exchange.Market {
-		if side == exchange.Buy {
+	} else if order.OrderType == exchange.Market {
+		if order.OrderSide == exchange.Buy {
 			oT = "buy_market"
 		} else {
 			oT = "sell_market"
@@ -212,7 +249,7 @@ func (o *OKCoin) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, order
 		return submitOrderResponse, errors.New("Unsupported order type")
 	}
 
-	response, err := o.Trade(amount, price, p.Pair().String(), oT)
+	response, err := o.Trade(order.Amount, order.Price, order.Pair.Pair().String(), oT)
 
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
@@ -283,19 +320,27 @@ func (o *OKCoin) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (o *OKCoin) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (o *OKCoin) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (o *OKCoin) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (o *OKCoin) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := o.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (o *OKCoin) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := o.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
@@ -1,12 +1,15 @@
 package okex
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -58,6 +61,7 @@ const (
 	spotTrades  = "trades"
 	spotKline   = "kline"
 	instruments = "instruments"
+	tickers     = "ticker"
 
 	// Authenticated
 	spotUserInfo       = "userinfo"
@@ -73,15 +77,102 @@ const (
 
 	myWalletInfo = "wallet_info.do"
 
+	// System requests
+	systemStatus = "system/v3/status"
+
+	// Account requests
+	accountWithdrawalFee  = "account/v3/withdrawal/fee"
+	accountWithdrawal     = "account/v3/withdrawal"
+	accountWithdrawalHist = "account/v3/withdrawal/history"
+	accountDepositAddress = "account/v3/deposit/address"
+	accountCurrencies     = "account/v3/currencies"
+	accountSubAccount     = "account/v3/sub-account"
+	accountTransfer       = "account/v3/transfer"
+
+	// Spot v3 requests
+	spotOrders   = "spot/v3/orders"
+	spotAccounts = "spot/v3/accounts"
+
+	// Swap requests
+	swapAccounts    = "swap/v3/accounts"
+	swapInstruments = "swap/v3/instruments"
+	swapFundingTime = "funding_time"
+
+	// Sub account requests, listing sub accounts is only available on v5
+	subAccountList = "v5/users/subaccount/list"
+
+	// withdrawalDestinationAddress is the v3 withdrawal destination for an
+	// external digital currency address
+	withdrawalDestinationAddress = "4"
+
+	// v3 transfer account and direction codes for moving funds between the
+	// main account's funding account and a sub account
+	transferAccountSubAccount = "0"
+	transferAccountFunding    = "6"
+	transferMainToSubAccount  = "1"
+	transferSubAccountToMain  = "2"
+
+	// v3 spot order states, complete orders are filled or cancelled and
+	// incomplete orders are open or partially filled
+	spotOrderStateComplete   = "7"
+	spotOrderStateIncomplete = "6"
+	// spotOrdersLimit is the most orders the v3 orders endpoint returns per
+	// request
+	spotOrdersLimit = 100
+
+	// tradeVolumeCurrency is the currency OKEX assesses spot fee tiers in
+	tradeVolumeCurrency = "usdt"
+
+	// subAccountTypePrefix prefixes each account type key of a sub account
+	// balance e.g. "account_type:spot"
+	subAccountTypePrefix = "account_type:"
+
 	// just your average return type from okex
 	returnTypeOne = "map[string]interface {}"
 
+	// spotKlineMaxSize is the most candles the kline endpoint returns per
+	// request
+	spotKlineMaxSize = 2000
+	// contractKlineMaxSize is the most candles the contract kline endpoint
+	// returns per request
+	contractKlineMaxSize = 2000
+
+	// swapFundingIntervalsPerYear is the number of perpetual swap funding
+	// settlements in a year, funding is settled every eight hours
+	swapFundingIntervalsPerYear = 365 * 3
+
+	// defaultMaintenanceMarginRatio is the lowest tier futures maintenance
+	// margin requirement as a fraction of the position value
+	defaultMaintenanceMarginRatio = 0.005
+
 	okexAuthRate   = 0
 	okexUnauthRate = 0
 )
 
+// Contract types accepted by the futures API
+const (
+	ContractTypeThisWeek    = "this_week"
+	ContractTypeNextWeek    = "next_week"
+	ContractTypeQuarter     = "quarter"
+	ContractTypeNextQuarter = "next_quarter"
+)
+
 var errMissValue = errors.New("warning - resp value is missing from exchange")
 
+// ContractTypeError is returned when a contract type is not accepted by the
+// futures API
+type ContractTypeError struct {
+	ContractType string
+	ValidTypes   []string
+}
+
+// Error implements the error interface
+func (e ContractTypeError) Error() string {
+	return fmt.Sprintf("invalid contract type string %q, valid types: %s",
+		e.ContractType,
+		common.JoinStrings(e.ValidTypes, ", "))
+}
+
 // OKEX is the overaching type across the OKEX methods
 type OKEX struct {
 	exchange.Base
@@ -91,6 +182,13 @@ type OKEX struct {
 	// Spot and contract market error codes as per https://www.okex.com/rest_request.html
 	ErrorCodes map[string]error
 
+	// Spot trading rules keyed by lower case pair e.g. btc_usdt
+	pairInfo    map[string]PairInfo
+	pairInfoMtx sync.RWMutex
+
+	// TradePassword is the fund password required by withdrawals
+	TradePassword string
+
 	// Stores for corresponding variable checks
 	ContractTypes    []string
 	CurrencyPairs    []string
@@ -98,6 +196,10 @@ type OKEX struct {
 	Types            []string
 }
 
+func init() {
+	exchange.RegisterExchange("okex", func() exchange.IBotExchange { return new(OKEX) })
+}
+
 // SetDefaults method assignes the default values for Bittrex
 func (o *OKEX) SetDefaults() {
 	o.SetErrorDefaults()
@@ -112,7 +214,7 @@ func (o *OKEX) SetDefaults() {
 	o.ConfigCurrencyPairFormat.Delimiter = "_"
 	o.ConfigCurrencyPairFormat.Uppercase = true
 	o.SupportsAutoPairUpdating = true
-	o.SupportsRESTTickerBatching = false
+	o.SupportsRESTTickerBatching = true
 	o.Requester = request.New(o.Name,
 		request.NewRateLimit(time.Second, okexAuthRate),
 		request.NewRateLimit(time.Second, okexUnauthRate),
@@ -121,6 +223,10 @@ func (o *OKEX) SetDefaults() {
 	o.APIUrl = o.APIUrlDefault
 	o.AssetTypes = []string{ticker.Spot}
 	o.WebsocketInit()
+	o.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnimplemented)
+	o.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	o.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	o.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnsupported)
 }
 
 // Setup method sets current configuration details if enabled
@@ -130,16 +236,22 @@ func (o *OKEX) Setup(exch config.ExchangeConfig) {
 	} else {
 		o.Enabled = true
 		o.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		o.Verbose = exch.Verbose
 		o.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
+		o.TradePassword = exch.APITradePassword
 		o.SetHTTPClientTimeout(exch.HTTPTimeout)
+		o.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		o.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := o.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		o.RESTPollingDelay = exch.RESTPollingDelay
-		o.Verbose = exch.Verbose
 		o.Websocket.SetEnabled(exch.Websocket)
 		o.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		o.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		o.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := o.SetCurrencyPairFormat()
+		err = o.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -147,6 +259,10 @@ func (o *OKEX) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = o.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = o.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -167,6 +283,11 @@ func (o *OKEX) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		o.Websocket.SetOrderbookDepth(exch.WebsocketOrderbookDepth,
+			okexWsOrderbookDepthDefault,
+			okexWsOrderbookDepths)
+		o.Websocket.SetCompression(exchange.WebsocketCompressionDeflate)
+		o.Websocket.SetSubscriptionPersistence(exch.WebsocketPersistSubscriptions)
 	}
 }
 
@@ -184,14 +305,94 @@ func (o *OKEX) GetSpotInstruments() ([]SpotInstrument, error) {
 	return resp, nil
 }
 
+// updatePairInfo stores the trading rules for each spot instrument
+func (o *OKEX) updatePairInfo(prods []SpotInstrument) {
+	info := make(map[string]PairInfo)
+	for x := range prods {
+		sizeIncrement := prods[x].SizeIncrement
+		if sizeIncrement == 0 {
+			sizeIncrement = prods[x].BaseIncrement
+		}
+
+		minAmount := prods[x].MinSize
+		if minAmount == 0 {
+			minAmount = prods[x].BaseMinSize
+		}
+
+		info[common.StringToLower(prods[x].BaseCurrency+"_"+prods[x].QuoteCurrency)] = PairInfo{
+			TickSize:        prods[x].TickSize,
+			SizeIncrement:   sizeIncrement,
+			MinAmount:       minAmount,
+			PricePrecision:  decimalPlaces(prods[x].TickSize),
+			AmountPrecision: decimalPlaces(sizeIncrement),
+		}
+	}
+
+	o.pairInfoMtx.Lock()
+	o.pairInfo = info
+	o.pairInfoMtx.Unlock()
+}
+
+// decimalPlaces returns the number of decimal places of an increment such as
+// a tick size
+func decimalPlaces(increment float64) int {
+	str := strconv.FormatFloat(increment, 'f', -1, 64)
+	i := strings.Index(str, ".")
+	if i == -1 {
+		return 0
+	}
+	return len(str) - i - 1
+}
+
+// roundDown rounds a value down to the nearest increment
+func roundDown(value, increment float64, precision int) float64 {
+	if increment <= 0 {
+		return value
+	}
+	// a small epsilon stops values already on an increment being floored to
+	// the increment below due to float division error
+	rounded := math.Floor(value/increment+1e-9) * increment
+	result, _ := strconv.ParseFloat(strconv.FormatFloat(rounded, 'f', precision, 64), 64)
+	return result
+}
+
+// GetSpotAllTickers returns the latest ticker for every spot instrument in a
+// single request
+func (o *OKEX) GetSpotAllTickers() ([]SpotAllTicker, error) {
+	var resp []SpotAllTicker
+
+	path := fmt.Sprintf("%sspot/v3/%s/%s", o.APIUrl, instruments, tickers)
+	err := o.SendHTTPRequest(path, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetSystemMaintenance returns the scheduled and ongoing system maintenance
+func (o *OKEX) GetSystemMaintenance() ([]SystemMaintenance, error) {
+	var resp []SystemMaintenance
+
+	path := fmt.Sprintf("%s%s", o.APIUrl, systemStatus)
+	err := o.SendHTTPRequest(path, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 // GetContractPrice returns current contract prices
 //
 // symbol e.g. "btc_usd"
-// contractType e.g. "this_week" "next_week" "quarter"
+// contractType e.g. "this_week" "next_week" "quarter" "next_quarter"
 func (o *OKEX) GetContractPrice(symbol, contractType string) (ContractPrice, error) {
 	resp := ContractPrice{}
 
-	if err := o.CheckContractType(contractType); err != nil {
+	if err := o.validateContractType(contractType); err != nil {
 		return resp, err
 	}
 	if err := o.CheckSymbol(symbol); err != nil {
@@ -217,15 +418,63 @@ func (o *OKEX) GetContractPrice(symbol, contractType string) (ContractPrice, err
 	return resp, nil
 }
 
+// GetContractPrices returns the current contract prices of every contract
+// type for a symbol keyed by contract type. The contract types are requested
+// concurrently, contract types which aren't currently listed are left out of
+// the map and an error is only returned when no contract type returned a price
+//
+// symbol e.g. "btc_usd"
+func (o *OKEX) GetContractPrices(symbol string) (map[string]ContractPrice, error) {
+	if err := o.CheckSymbol(symbol); err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]ContractPrice)
+	var errs []string
+	var m sync.Mutex
+	var wg sync.WaitGroup
+	for x := range o.ContractTypes {
+		wg.Add(1)
+		go func(contractType string) {
+			defer wg.Done()
+			price, err := o.GetContractPrice(symbol, contractType)
+
+			m.Lock()
+			defer m.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", contractType, err))
+				return
+			}
+
+			if isContractListed(price) {
+				prices[contractType] = price
+			}
+		}(o.ContractTypes[x])
+	}
+	wg.Wait()
+
+	if len(prices) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("unable to get contract prices for %s: %s",
+			symbol, common.JoinStrings(errs, ", "))
+	}
+	return prices, nil
+}
+
+// isContractListed returns whether a contract price belongs to a listed
+// contract, unlisted contracts are returned without a contract ID
+func isContractListed(price ContractPrice) bool {
+	return price.Ticker.ContractID != 0
+}
+
 // GetContractMarketDepth returns contract market depth
 //
 // symbol e.g. "btc_usd"
-// contractType e.g. "this_week" "next_week" "quarter"
+// contractType e.g. "this_week" "next_week" "quarter" "next_quarter"
 func (o *OKEX) GetContractMarketDepth(symbol, contractType string) (ActualContractDepth, error) {
 	resp := ContractDepth{}
 	fullDepth := ActualContractDepth{}
 
-	if err := o.CheckContractType(contractType); err != nil {
+	if err := o.validateContractType(contractType); err != nil {
 		return fullDepth, err
 	}
 	if err := o.CheckSymbol(symbol); err != nil {
@@ -289,7 +538,7 @@ func (o *OKEX) GetContractTradeHistory(symbol, contractType string) ([]ActualCon
 	actualTradeHistory := []ActualContractTradeHistory{}
 	var resp interface{}
 
-	if err := o.CheckContractType(contractType); err != nil {
+	if err := o.validateContractType(contractType); err != nil {
 		return actualTradeHistory, err
 	}
 	if err := o.CheckSymbol(symbol); err != nil {
@@ -302,7 +551,7 @@ func (o *OKEX) GetContractTradeHistory(symbol, contractType string) ([]ActualCon
 
 	path := fmt.Sprintf("%s%s%s.do?%s", o.APIUrl, apiVersion, contractTradeHistory, values.Encode())
 
-	err := o.SendHTTPRequest(path, &resp)
+	err := o.sendHTTPRequest(request.History, path, &resp)
 	if err != nil {
 		return actualTradeHistory, err
 	}
@@ -417,16 +666,17 @@ func (o *OKEX) GetContractCandlestickData(symbol, typeInput, contractType string
 	if err := o.CheckSymbol(symbol); err != nil {
 		return candleData, err
 	}
-	if err := o.CheckContractType(contractType); err != nil {
+	if err := o.validateContractType(contractType); err != nil {
 		return candleData, err
 	}
-	if err := o.CheckType(typeInput); err != nil {
+	interval, err := o.validateInterval(typeInput)
+	if err != nil {
 		return candleData, err
 	}
 
 	values := url.Values{}
 	values.Set("symbol", symbol)
-	values.Set("type", typeInput)
+	values.Set("type", string(interval))
 	values.Set("contract_type", contractType)
 	values.Set("size", strconv.FormatInt(int64(size), 10))
 	values.Set("since", strconv.FormatInt(int64(since), 10))
@@ -434,7 +684,7 @@ func (o *OKEX) GetContractCandlestickData(symbol, typeInput, contractType string
 	path := fmt.Sprintf("%s%s%s.do?%s", o.APIUrl, apiVersion, contractCandleStick, values.Encode())
 	var resp interface{}
 
-	if err := o.SendHTTPRequest(path, &resp); err != nil {
+	if err := o.sendHTTPRequest(request.History, path, &resp); err != nil {
 		return candleData, err
 	}
 
@@ -472,13 +722,42 @@ func (o *OKEX) GetContractCandlestickData(symbol, typeInput, contractType string
 	return candleData, nil
 }
 
-// GetContractHoldingsNumber returns current number of holdings
-func (o *OKEX) GetContractHoldingsNumber(symbol, contractType string) (number float64, contract string, err error) {
-	if err = o.CheckSymbol(symbol); err != nil {
-		return number, contract, err
+// GetContractKlineRange returns the contract candles for a symbol and contract
+// type with open times between since and until, paging through
+// GetContractCandlestickData and removing candles repeated between pages. Size
+// caps the number of candles returned, 0 returns every candle in the range
+func (o *OKEX) GetContractKlineRange(symbol, typeInput, contractType string, size int, since, until int64) ([]CandleStickData, error) {
+	if err := o.CheckSymbol(symbol); err != nil {
+		return nil, err
+	}
+	if err := o.validateContractType(contractType); err != nil {
+		return nil, err
 	}
-	if err = o.CheckContractType(contractType); err != nil {
-		return number, contract, err
+	interval, err := o.validateInterval(typeInput)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := KlinesRequestParams{
+		Symbol: symbol,
+		Type:   interval,
+		Size:   size,
+		Since:  since,
+		Until:  until,
+	}
+	return pageKline(arg, contractKlineMaxSize, func(size int, since int64) ([]CandleStickData, error) {
+		return o.GetContractCandlestickData(symbol, string(interval), contractType, size, int(since))
+	})
+}
+
+// GetContractHoldingsNumber returns the number of open contracts of a futures
+// contract e.g. "btc_usd" "this_week"
+func (o *OKEX) GetContractHoldingsNumber(symbol, contractType string) (ContractHoldings, error) {
+	if err := o.CheckSymbol(symbol); err != nil {
+		return ContractHoldings{}, err
+	}
+	if err := o.validateContractType(contractType); err != nil {
+		return ContractHoldings{}, err
 	}
 
 	values := url.Values{}
@@ -488,33 +767,56 @@ func (o *OKEX) GetContractHoldingsNumber(symbol, contractType string) (number fl
 	path := fmt.Sprintf("%s%s%s.do?%s", o.APIUrl, apiVersion, contractFutureHoldAmount, values.Encode())
 	var resp interface{}
 
-	if err = o.SendHTTPRequest(path, &resp); err != nil {
-		return number, contract, err
+	if err := o.SendHTTPRequest(path, &resp); err != nil {
+		return ContractHoldings{}, err
 	}
+	return o.parseContractHoldings(resp)
+}
 
-	if reflect.TypeOf(resp).String() == returnTypeOne {
-		errorMap := resp.(map[string]interface{})
-		return number, contract, o.GetErrorCode(errorMap["error_code"].(float64))
+// parseContractHoldings converts a decoded future_hold_amount response into
+// ContractHoldings
+func (o *OKEX) parseContractHoldings(resp interface{}) (ContractHoldings, error) {
+	if errorMap, ok := resp.(map[string]interface{}); ok {
+		if code, ok := errorMap["error_code"]; ok {
+			return ContractHoldings{}, o.GetErrorCode(code)
+		}
+		return ContractHoldings{}, fmt.Errorf("%s unexpected contract holdings response %v", o.Name, resp)
+	}
+
+	holdings, ok := resp.([]interface{})
+	if !ok {
+		return ContractHoldings{}, fmt.Errorf("%s unexpected contract holdings response %v", o.Name, resp)
 	}
 
-	for _, holdings := range resp.([]interface{}) {
-		if reflect.TypeOf(holdings).String() == returnTypeOne {
-			holdingMap := holdings.(map[string]interface{})
-			number = holdingMap["amount"].(float64)
-			contract = holdingMap["contract_name"].(string)
+	for x := range holdings {
+		holdingMap, ok := holdings[x].(map[string]interface{})
+		if !ok {
+			continue
 		}
+
+		amount, ok := holdingMap["amount"].(float64)
+		if !ok {
+			return ContractHoldings{}, fmt.Errorf("%s invalid contract holdings amount %v", o.Name, holdingMap["amount"])
+		}
+
+		contractName, _ := holdingMap["contract_name"].(string)
+		return ContractHoldings{
+			ContractName:  contractName,
+			LongHoldings:  amount,
+			ShortHoldings: amount,
+		}, nil
 	}
-	return
+	return ContractHoldings{}, fmt.Errorf("%s no contract holdings returned", o.Name)
 }
 
 // GetContractlimit returns upper and lower price limit
-func (o *OKEX) GetContractlimit(symbol, contractType string) (map[string]float64, error) {
-	contractLimits := make(map[string]float64)
+func (o *OKEX) GetContractlimit(symbol, contractType string) (ContractPriceLimit, error) {
+	var resp ContractPriceLimit
 	if err := o.CheckSymbol(symbol); err != nil {
-		return contractLimits, err
+		return resp, err
 	}
-	if err := o.CheckContractType(contractType); err != nil {
-		return contractLimits, err
+	if err := o.validateContractType(contractType); err != nil {
+		return resp, err
 	}
 
 	values := url.Values{}
@@ -522,21 +824,24 @@ func (o *OKEX) GetContractlimit(symbol, contractType string) (map[string]float64
 	values.Set("contract_type", contractType)
 
 	path := fmt.Sprintf("%s%s%s.do?%s", o.APIUrl, apiVersion, contractFutureLimits, values.Encode())
-	var resp interface{}
-
 	if err := o.SendHTTPRequest(path, &resp); err != nil {
-		return contractLimits, err
+		return resp, err
 	}
 
-	contractLimitMap := resp.(map[string]interface{})
-	if i, ok := contractLimitMap["error_code"].(float64); ok {
-		return contractLimits, o.GetErrorCode(i)
+	if resp.Error != nil {
+		return resp, o.GetErrorCode(resp.Error)
 	}
+	return resp, nil
+}
 
-	contractLimits["high"] = contractLimitMap["high"].(float64)
-	contractLimits["usdCnyRate"] = contractLimitMap["usdCnyRate"].(float64)
-	contractLimits["low"] = contractLimitMap["low"].(float64)
-	return contractLimits, nil
+// checkContractPriceLimit returns an error when the price is outside of the
+// contract price limits
+func checkContractPriceLimit(limit ContractPriceLimit, price float64) error {
+	if price < limit.Low || price > limit.High {
+		return fmt.Errorf("price %v is outside of the contract price limits, low: %v high: %v",
+			price, limit.Low, limit.High)
+	}
+	return nil
 }
 
 // GetContractUserInfo returns OKEX Contract Account Info（Cross-Margin Mode）
@@ -553,6 +858,57 @@ func (o *OKEX) GetContractUserInfo() error {
 	return nil
 }
 
+// GetFuturesUserInfo returns the cross margin futures account of each
+// currency
+func (o *OKEX) GetFuturesUserInfo() (FuturesUserInfo, error) {
+	var resp FuturesUserInfo
+	if err := o.SendAuthenticatedHTTPRequest(contractFutureUserInfo, url.Values{}, &resp); err != nil {
+		return resp, err
+	}
+
+	if resp.ErrorCode != 0 {
+		return resp, o.GetErrorCode(resp.ErrorCode)
+	}
+	return resp, nil
+}
+
+// GetSwapAccounts returns the perpetual swap account of every swap contract
+func (o *OKEX) GetSwapAccounts() ([]SwapAccount, error) {
+	var resp struct {
+		Info []SwapAccount `json:"info"`
+	}
+
+	err := o.sendAuthenticatedHTTPRequestV3(request.Account, "GET", swapAccounts, nil, &resp)
+	return resp.Info, err
+}
+
+// GetSwapFundingRate returns the funding rate of a perpetual swap contract
+// settled at the next funding time, along with the estimated rate of the
+// following interval
+//
+// instrumentID e.g. "BTC-USD-SWAP"
+func (o *OKEX) GetSwapFundingRate(instrumentID string) (SwapFundingRate, error) {
+	var resp SwapFundingRate
+	if instrumentID == "" {
+		return resp, errors.New("swap instrument ID not set")
+	}
+
+	path := fmt.Sprintf("%s%s/%s/%s", o.APIUrl, swapInstruments,
+		common.StringToUpper(instrumentID), swapFundingTime)
+	err := o.SendHTTPRequest(path, &resp)
+	return resp, err
+}
+
+// GetSwapCarry fetches the funding rate of a perpetual swap position's
+// contract and returns the position's carry with CalculateSwapCarry
+func (o *OKEX) GetSwapCarry(p SwapPositionDetail) (SwapCarry, error) {
+	rate, err := o.GetSwapFundingRate(p.InstrumentID)
+	if err != nil {
+		return SwapCarry{}, err
+	}
+	return CalculateSwapCarry(rate, p)
+}
+
 // GetContractPosition returns User Contract Positions （Cross-Margin Mode）
 func (o *OKEX) GetContractPosition(symbol, contractType string) error {
 	var resp interface{}
@@ -560,7 +916,7 @@ func (o *OKEX) GetContractPosition(symbol, contractType string) error {
 	if err := o.CheckSymbol(symbol); err != nil {
 		return err
 	}
-	if err := o.CheckContractType(contractType); err != nil {
+	if err := o.validateContractType(contractType); err != nil {
 		return err
 	}
 
@@ -579,19 +935,102 @@ func (o *OKEX) GetContractPosition(symbol, contractType string) error {
 	return nil
 }
 
-// PlaceContractOrders places orders
-func (o *OKEX) PlaceContractOrders(symbol, contractType, position string, leverageRate int, price, amount float64, matchPrice bool) (float64, error) {
-	var resp interface{}
+// PlaceContractOrderInCoins places a contract order sized in the base
+// currency, converting coins to a whole number of contracts at the current
+// index price with CoinsToContracts. The contract count is rounded down so a
+// position is never larger than requested, the order is rejected when coins
+// is worth less than a single contract. The order ID and the number of
+// contracts ordered are returned
+func (o *OKEX) PlaceContractOrderInCoins(symbol, contractType, position string, leverageRate int, price, coins float64, matchPrice bool) (int64, float64, error) {
+	index, err := o.GetContractIndexPrice(symbol)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	contracts, err := coinsToWholeContracts(symbol, coins, index)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	orderID, err := o.PlaceContractOrders(symbol, contractType, position, leverageRate, price, contracts, matchPrice)
+	return orderID, contracts, err
+}
+
+// coinsToWholeContracts converts coins to the whole number of contracts worth
+// at most coins
+func coinsToWholeContracts(symbol string, coins, indexPrice float64) (float64, error) {
+	contracts, err := CoinsToContracts(symbol, coins, indexPrice)
+	if err != nil {
+		return 0, err
+	}
+
+	// round before flooring so float error doesn't lose a whole contract
+	contracts = math.Floor(math.Round(contracts*1e8) / 1e8)
+	if contracts < 1 {
+		return 0, fmt.Errorf("%v %s is worth less than one contract at index price %v",
+			coins, symbol, indexPrice)
+	}
+	return contracts, nil
+}
+
+// ContractsToCoins converts a number of futures contracts to the amount of the
+// base currency they are worth at the index price, each contract is worth its
+// face value in USD
+func ContractsToCoins(symbol string, contracts, indexPrice float64) (float64, error) {
+	faceValue, err := contractConversion(symbol, indexPrice)
+	if err != nil {
+		return 0, err
+	}
+	return contracts * faceValue / indexPrice, nil
+}
+
+// CoinsToContracts converts an amount of the base currency to the number of
+// futures contracts it is worth at the index price, the result can be
+// fractional as orders must be placed for whole contracts
+func CoinsToContracts(symbol string, coins, indexPrice float64) (float64, error) {
+	faceValue, err := contractConversion(symbol, indexPrice)
+	if err != nil {
+		return 0, err
+	}
+	return coins * indexPrice / faceValue, nil
+}
+
+// contractConversion validates the index price and returns the contract face
+// value used to convert between contracts and coins
+func contractConversion(symbol string, indexPrice float64) (float64, error) {
+	if indexPrice <= 0 {
+		return 0, fmt.Errorf("invalid index price %v", indexPrice)
+	}
+	return contractFaceValue(symbol)
+}
+
+// PlaceContractOrders places orders, amount is the number of contracts and not
+// an amount of the base currency, see PlaceContractOrderInCoins
+func (o *OKEX) PlaceContractOrders(symbol, contractType, position string, leverageRate int, price, amount float64, matchPrice bool) (int64, error) {
+	var resp ContractOrderResponse
 
 	if err := o.CheckSymbol(symbol); err != nil {
 		return 0, err
 	}
-	if err := o.CheckContractType(contractType); err != nil {
+	if err := o.validateContractType(contractType); err != nil {
 		return 0, err
 	}
 	if err := o.CheckContractPosition(position); err != nil {
 		return 0, err
 	}
+	if leverageRate != 10 && leverageRate != 20 {
+		return 0, errors.New("leverage rate can only be 10 or 20")
+	}
+
+	if !matchPrice {
+		limit, err := o.GetContractlimit(symbol, contractType)
+		if err != nil {
+			return 0, err
+		}
+		if err = checkContractPriceLimit(limit, price); err != nil {
+			return 0, fmt.Errorf("%s %s order rejected: %s", symbol, contractType, err)
+		}
+	}
 
 	values := url.Values{}
 	values.Set("symbol", symbol)
@@ -605,49 +1044,49 @@ func (o *OKEX) PlaceContractOrders(symbol, contractType, position string, levera
 		values.Set("match_price", "0")
 	}
 
-	if leverageRate != 10 && leverageRate != 20 {
-		return 0, errors.New("leverage rate can only be 10 or 20")
-	}
 	values.Set("lever_rate", strconv.FormatInt(int64(leverageRate), 10))
 
 	if err := o.SendAuthenticatedHTTPRequest(contractFutureTrade, values, &resp); err != nil {
 		return 0, err
 	}
 
-	contractMap := resp.(map[string]interface{})
-	if code, ok := contractMap["error_code"]; ok {
-		return 0, o.GetErrorCode(code)
+	if resp.ErrorCode != nil {
+		return 0, o.GetErrorCode(resp.ErrorCode)
 	}
 
-	if orderID, ok := contractMap["order_id"]; ok {
-		return orderID.(float64), nil
+	if resp.OrderID == "" {
+		return 0, errors.New("orderID returned nil")
 	}
-
-	return 0, errors.New("orderID returned nil")
+	return resp.OrderID.Int64()
 }
 
-// GetContractFuturesTradeHistory returns OKEX Contract Trade History (Not for Personal)
-func (o *OKEX) GetContractFuturesTradeHistory(symbol, date string, since int) error {
-	var resp interface{}
+// GetContractFuturesTradeHistory returns OKEX Contract Trade History fills for
+// the supplied date (format 2006-01-02). Results are paginated, pass the TID of
+// the last fill received as since to fetch the next page
+func (o *OKEX) GetContractFuturesTradeHistory(symbol, date string, since int64) ([]FuturesTradeHistory, error) {
+	var resp []FuturesTradeHistory
 
 	if err := o.CheckSymbol(symbol); err != nil {
-		return err
+		return resp, err
+	}
+
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return resp, fmt.Errorf("invalid date %q, expected format 2006-01-02", date)
 	}
 
 	values := url.Values{}
 	values.Set("symbol", symbol)
 	values.Set("date", date)
-	values.Set("since", strconv.FormatInt(int64(since), 10))
+	values.Set("since", strconv.FormatInt(since, 10))
 
 	if err := o.SendAuthenticatedHTTPRequest(contractFutureTradeHistory, values, &resp); err != nil {
-		return err
+		return resp, err
 	}
 
-	respMap := resp.(map[string]interface{})
-	if code, ok := respMap["error_code"]; ok {
-		return o.GetErrorCode(code)
+	for i := range resp {
+		resp[i].Timestamp = time.Unix(0, resp[i].DateInMS*int64(time.Millisecond))
 	}
-	return nil
+	return resp, nil
 }
 
 // GetTokenOrders returns details for a single orderID or all open orders when orderID == -1
@@ -667,33 +1106,70 @@ func (o *OKEX) GetTokenOrders(symbol string, orderID int64) (TokenOrdersResponse
 // GetUserInfo returns the user info
 func (o *OKEX) GetUserInfo() (SpotUserInfo, error) {
 	var resp SpotUserInfo
-	err := o.SendAuthenticatedHTTPRequest(spotUserInfo, url.Values{}, &resp)
+	err := o.SendAuthenticatedHTTPRequest(spotUserInfo+".do", url.Values{}, &resp)
 	if err != nil {
 		return resp, err
 	}
 	return resp, nil
 }
 
-// SpotNewOrder creates a new spot order
+// SpotNewOrder creates a new spot order via the v3 orders endpoint, a client
+// order ID is sent as client_oid so OKEX rejects a resubmitted order. Market
+// buys spend the price as the quote currency total
 func (o *OKEX) SpotNewOrder(arg SpotNewOrderRequestParams) (int64, error) {
-	type response struct {
-		Result  bool  `json:"result"`
-		OrderID int64 `json:"order_id"`
+	type orderRequest struct {
+		ClientOID    string `json:"client_oid,omitempty"`
+		Type         string `json:"type"`
+		Side         string `json:"side"`
+		InstrumentID string `json:"instrument_id"`
+		Price        string `json:"price,omitempty"`
+		Size         string `json:"size,omitempty"`
+		Notional     string `json:"notional,omitempty"`
+	}
+
+	type orderResponse struct {
+		ClientOID    string `json:"client_oid"`
+		OrderID      string `json:"order_id"`
+		Result       bool   `json:"result"`
+		ErrorCode    string `json:"error_code"`
+		ErrorMessage string `json:"error_message"`
+	}
+
+	req := orderRequest{
+		ClientOID:    arg.ClientOrderID,
+		InstrumentID: common.StringToUpper(common.ReplaceString(arg.Symbol, "_", "-", -1)),
+	}
+
+	switch arg.Type {
+	case SpotNewOrderRequestTypeBuy, SpotNewOrderRequestTypeSell:
+		req.Type = "limit"
+		req.Side = string(arg.Type)
+		req.Price = strconv.FormatFloat(arg.Price, 'f', -1, 64)
+		req.Size = strconv.FormatFloat(arg.Amount, 'f', -1, 64)
+	case SpotNewOrderRequestTypeBuyMarket:
+		req.Type = "market"
+		req.Side = string(SpotNewOrderRequestTypeBuy)
+		req.Notional = strconv.FormatFloat(arg.Price, 'f', -1, 64)
+	case SpotNewOrderRequestTypeSellMarket:
+		req.Type = "market"
+		req.Side = string(SpotNewOrderRequestTypeSell)
+		req.Size = strconv.FormatFloat(arg.Amount, 'f', -1, 64)
+	default:
+		return 0, fmt.Errorf("%s unsupported spot order type %s", o.Name, arg.Type)
 	}
 
-	var res response
-	params := url.Values{}
-	params.Set("symbol", arg.Symbol)
-	params.Set("type", string(arg.Type))
-	params.Set("price", strconv.FormatFloat(arg.Price, 'f', -1, 64))
-	params.Set("amount", strconv.FormatFloat(arg.Amount, 'f', -1, 64))
-
-	err := o.SendAuthenticatedHTTPRequest(spotTrade, params, &res)
+	var res orderResponse
+	err := o.sendAuthenticatedHTTPRequestV3(request.Trade, "POST", spotOrders, req, &res)
 	if err != nil {
-		return res.OrderID, err
+		return 0, err
 	}
 
-	return res.OrderID, nil
+	if !res.Result {
+		return 0, fmt.Errorf("%s spot order not placed, ErrCode:%s ErrMsg:%s",
+			o.Name, res.ErrorCode, res.ErrorMessage)
+	}
+
+	return common.Int64FromString(res.OrderID)
 }
 
 // SpotCancelOrder cancels a spot order
@@ -725,6 +1201,65 @@ func (o *OKEX) SpotCancelOrder(symbol string, argOrderID int64) (int64, error) {
 	return returnOrderID, nil
 }
 
+// GetSpotOrders returns up to 100 spot orders of an instrument e.g. "BTC-USDT"
+// in a v3 order state, newest first. A non empty after returns the orders
+// older than that order ID
+func (o *OKEX) GetSpotOrders(instrumentID, state, after string) ([]SpotOrder, error) {
+	vals := url.Values{}
+	vals.Set("instrument_id", instrumentID)
+	vals.Set("state", state)
+	vals.Set("limit", strconv.Itoa(spotOrdersLimit))
+	if after != "" {
+		vals.Set("after", after)
+	}
+
+	var resp []SpotOrder
+	path := fmt.Sprintf("%s?%s", spotOrders, vals.Encode())
+	err := o.sendAuthenticatedHTTPRequestV3(request.Account, "GET", path, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetSpotTradeVolume returns the value traded on an instrument since start in
+// its quote currency, summed from the filled notional of complete and
+// incomplete orders
+func (o *OKEX) GetSpotTradeVolume(instrumentID string, start time.Time) (float64, error) {
+	var volume float64
+	for _, state := range []string{spotOrderStateComplete, spotOrderStateIncomplete} {
+		var after string
+		for {
+			orders, err := o.GetSpotOrders(instrumentID, state, after)
+			if err != nil {
+				return 0, err
+			}
+
+			pageVolume, done := spotOrdersVolume(orders, start)
+			volume += pageVolume
+			if done || len(orders) < spotOrdersLimit {
+				break
+			}
+			after = orders[len(orders)-1].OrderID
+		}
+	}
+	return volume, nil
+}
+
+// spotOrdersVolume sums the filled notional of a page of orders placed since
+// start, returning whether the page reached orders older than start
+func spotOrdersVolume(orders []SpotOrder, start time.Time) (float64, bool) {
+	var volume float64
+	for x := range orders {
+		if orders[x].Timestamp.Before(start) {
+			return volume, true
+		}
+		volume += orders[x].FilledNotional
+	}
+	return volume, false
+}
+
 // GetLatestSpotPrice returns latest spot price of symbol
 //
 // symbol: string of currency pair
@@ -732,12 +1267,83 @@ func (o *OKEX) GetLatestSpotPrice(symbol string) (float64, error) {
 	spotPrice, err := o.GetSpotTicker(symbol)
 
 	if err != nil {
-		return 0, err
+		price, fallbackErr := o.getFallbackSpotPrice(symbol, err)
+		if fallbackErr != nil {
+			return 0, err
+		}
+		return price, nil
 	}
 
 	return spotPrice.Ticker.Last, nil
 }
 
+// getFallbackSpotPrice derives the last price of a symbol when the ticker is
+// unavailable, first from the most recent trade and then from the orderbook
+// mid price. tickerErr is the ticker failure which is logged alongside the
+// fallback used
+func (o *OKEX) getFallbackSpotPrice(symbol string, tickerErr error) (float64, error) {
+	trades, err := o.GetSpotRecentTrades(ActualSpotTradeHistoryRequestParams{Symbol: symbol})
+	if err == nil {
+		var price float64
+		price, err = lastTradePrice(trades)
+		if err == nil {
+			log.Printf("%s ticker unavailable for %s (%s), using last trade price %f",
+				o.Name, symbol, tickerErr, price)
+			return price, nil
+		}
+	}
+
+	depth, err := o.GetSpotMarketDepth(ActualSpotDepthRequestParams{Symbol: symbol, Size: 5})
+	if err != nil {
+		return 0, err
+	}
+
+	price, err := depthMidPrice(depth)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("%s ticker unavailable for %s (%s), using orderbook mid price %f",
+		o.Name, symbol, tickerErr, price)
+	return price, nil
+}
+
+// lastTradePrice returns the price of the most recent trade
+func lastTradePrice(trades []ActualSpotTradeHistory) (float64, error) {
+	if len(trades) == 0 {
+		return 0, errors.New("no recent trades")
+	}
+
+	latest := trades[0]
+	for x := range trades {
+		if trades[x].TID > latest.TID {
+			latest = trades[x]
+		}
+	}
+	return latest.Price, nil
+}
+
+// depthMidPrice returns the mid price between the best bid and best ask
+func depthMidPrice(depth ActualSpotDepth) (float64, error) {
+	if len(depth.Bids) == 0 || len(depth.Asks) == 0 {
+		return 0, errors.New("orderbook has no bids or asks")
+	}
+
+	var bid, ask float64
+	for x := range depth.Bids {
+		if depth.Bids[x].Price > bid {
+			bid = depth.Bids[x].Price
+		}
+	}
+
+	for x := range depth.Asks {
+		if ask == 0 || depth.Asks[x].Price < ask {
+			ask = depth.Asks[x].Price
+		}
+	}
+	return (bid + ask) / 2, nil
+}
+
 // GetSpotTicker returns Price Ticker
 func (o *OKEX) GetSpotTicker(symbol string) (SpotPrice, error) {
 	var resp SpotPrice
@@ -757,16 +1363,24 @@ func (o *OKEX) GetSpotTicker(symbol string) (SpotPrice, error) {
 	return resp, nil
 }
 
+// spotDepthValues returns the request values for the spot depth endpoint, the
+// merge parameter is only sent when aggregated depth is requested
+func spotDepthValues(asd ActualSpotDepthRequestParams) url.Values {
+	values := url.Values{}
+	values.Set("symbol", asd.Symbol)
+	values.Set("size", fmt.Sprintf("%d", asd.Size))
+	if asd.Merge != SpotDepthMergeNone {
+		values.Set("merge", string(asd.Merge))
+	}
+	return values
+}
+
 //GetSpotMarketDepth returns Market Depth
 func (o *OKEX) GetSpotMarketDepth(asd ActualSpotDepthRequestParams) (ActualSpotDepth, error) {
 	resp := SpotDepth{}
 	fullDepth := ActualSpotDepth{}
 
-	values := url.Values{}
-	values.Set("symbol", asd.Symbol)
-	values.Set("size", fmt.Sprintf("%d", asd.Size))
-
-	path := fmt.Sprintf("%s%s%s.do?%s", o.APIUrl, apiVersion, "depth", values.Encode())
+	path := fmt.Sprintf("%s%s%s.do?%s", o.APIUrl, apiVersion, "depth", spotDepthValues(asd).Encode())
 
 	err := o.SendHTTPRequest(path, &resp)
 	if err != nil {
@@ -849,24 +1463,108 @@ func (o *OKEX) GetSpotRecentTrades(ast ActualSpotTradeHistoryRequestParams) ([]A
 	return actualTradeHistory, nil
 }
 
-// GetSpotKline returns candlestick data
+// GetSpotKline returns candlestick data. When Until is set the candles between
+// Since and Until are fetched in pages of at most spotKlineMaxSize, ordered by
+// open time with duplicates across pages removed
 func (o *OKEX) GetSpotKline(arg KlinesRequestParams) ([]CandleStickData, error) {
+	interval, err := o.validateInterval(string(arg.Type))
+	if err != nil {
+		return nil, err
+	}
+
+	if arg.Until == 0 {
+		return o.getSpotKline(arg.Symbol, interval, arg.Size, arg.Since)
+	}
+
+	return pageKline(arg, spotKlineMaxSize, func(size int, since int64) ([]CandleStickData, error) {
+		return o.getSpotKline(arg.Symbol, interval, size, since)
+	})
+}
+
+// pageKline fetches the candles between arg.Since and arg.Until in pages of at
+// most maxSize, starting each page from the open time of the last candle
+// received. Paging stops once Until or Size is reached, or a page returns no
+// newer candles
+func pageKline(arg KlinesRequestParams, maxSize int, fetch func(size int, since int64) ([]CandleStickData, error)) ([]CandleStickData, error) {
+	if arg.Since == 0 {
+		return nil, errors.New("kline since timestamp is required with until")
+	}
+
+	if arg.Until < arg.Since {
+		return nil, fmt.Errorf("kline until %d is before since %d", arg.Until, arg.Since)
+	}
+
+	var candleData []CandleStickData
+	seen := make(map[float64]bool)
+	since := arg.Since
+	for {
+		size := maxSize
+		if arg.Size > 0 {
+			// later pages can repeat the candle they start from, so request
+			// one more than remains
+			remaining := arg.Size - len(candleData)
+			if since != arg.Since {
+				remaining++
+			}
+			if remaining < size {
+				size = remaining
+			}
+		}
+
+		page, err := fetch(size, since)
+		if err != nil {
+			return nil, err
+		}
+
+		last := since
+		for x := range page {
+			openTime := page[x].Timestamp
+			if int64(openTime) > last {
+				last = int64(openTime)
+			}
+
+			if seen[openTime] || int64(openTime) < arg.Since || int64(openTime) > arg.Until {
+				continue
+			}
+			seen[openTime] = true
+			candleData = append(candleData, page[x])
+		}
+
+		if last <= since || last >= arg.Until || len(page) < size ||
+			(arg.Size > 0 && len(candleData) >= arg.Size) {
+			break
+		}
+		since = last
+	}
+
+	sort.Slice(candleData, func(i, j int) bool {
+		return candleData[i].Timestamp < candleData[j].Timestamp
+	})
+
+	if arg.Size > 0 && len(candleData) > arg.Size {
+		candleData = candleData[:arg.Size]
+	}
+	return candleData, nil
+}
+
+// getSpotKline sends a single kline request
+func (o *OKEX) getSpotKline(symbol string, interval TimeInterval, size int, since int64) ([]CandleStickData, error) {
 	var candleData []CandleStickData
 
 	values := url.Values{}
-	values.Set("symbol", arg.Symbol)
-	values.Set("type", string(arg.Type))
-	if arg.Size != 0 {
-		values.Set("size", strconv.FormatInt(int64(arg.Size), 10))
+	values.Set("symbol", symbol)
+	values.Set("type", string(interval))
+	if size != 0 {
+		values.Set("size", strconv.FormatInt(int64(size), 10))
 	}
-	if arg.Since != 0 {
-		values.Set("since", strconv.FormatInt(int64(arg.Since), 10))
+	if since != 0 {
+		values.Set("since", strconv.FormatInt(since, 10))
 	}
 
 	path := fmt.Sprintf("%s%s%s.do?%s", o.APIUrl, apiVersion, spotKline, values.Encode())
 	var resp interface{}
 
-	if err := o.SendHTTPRequest(path, &resp); err != nil {
+	if err := o.sendHTTPRequest(request.History, path, &resp); err != nil {
 		return candleData, err
 	}
 
@@ -926,7 +1624,27 @@ func (o *OKEX) GetErrorCode(code interface{}) error {
 
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (o *OKEX) SendHTTPRequest(path string, result interface{}) error {
-	return o.SendPayload("GET", path, nil, nil, result, false, o.Verbose)
+	return o.sendHTTPRequest(request.MarketData, path, result)
+}
+
+// sendHTTPRequest sends an unauthenticated HTTP request using the timeout set
+// for the request type
+func (o *OKEX) sendHTTPRequest(reqType request.Type, path string, result interface{}) error {
+	return o.SendPayloadWithType(reqType, "GET", path, nil, nil, result, false, o.Verbose)
+}
+
+// authRequestType returns the request type of an authenticated endpoint so
+// order placement and cancellation can be given a tighter timeout than
+// account and history queries
+func authRequestType(method string) request.Type {
+	switch strings.TrimSuffix(method, ".do") {
+	case spotTrade, spotBatchTrade, spotCancelTrade,
+		contractFutureTrade, contractFutureBatchTrade, contractFutureCancel:
+		return request.Trade
+	case spotAccountRecords, contractFutureTradeHistory:
+		return request.History
+	}
+	return request.Account
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated http request to a desired
@@ -944,7 +1662,7 @@ func (o *OKEX) SendAuthenticatedHTTPRequest(method string, values url.Values, re
 	path := o.APIUrl + apiVersion + method
 
 	if o.Verbose {
-		log.Printf("Sending POST request to %s with params %s\n", path, encoded)
+		log.Printf("Sending POST request to %s with params %s\n", path, exchange.RedactSecrets(encoded))
 	}
 
 	headers := make(map[string]string)
@@ -957,7 +1675,7 @@ func (o *OKEX) SendAuthenticatedHTTPRequest(method string, values url.Values, re
 		Error  int64 `json:"error_code"`
 	}{}
 
-	err = o.SendPayload("POST", path, headers, strings.NewReader(encoded), &intermediary, true, o.Verbose)
+	err = o.SendPayloadWithType(authRequestType(method), "POST", path, headers, strings.NewReader(encoded), &intermediary, true, o.Verbose)
 	if err != nil {
 		return err
 	}
@@ -973,6 +1691,48 @@ func (o *OKEX) SendAuthenticatedHTTPRequest(method string, values url.Values, re
 	return common.JSONDecode(intermediary, result)
 }
 
+// sendAuthenticatedHTTPRequestV3 sends an authenticated request to a v3
+// endpoint, signing it with the API secret and using the client ID as the API
+// passphrase
+func (o *OKEX) sendAuthenticatedHTTPRequestV3(reqType request.Type, httpMethod, path string, data, result interface{}) error {
+	if !o.AuthenticatedAPISupport {
+		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, o.Name)
+	}
+
+	var body []byte
+	if data != nil {
+		var err error
+		body, err = common.JSONEncode(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	endpoint := o.APIUrl + path
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	hmac := common.GetHMAC(common.HashSHA256,
+		[]byte(timestamp+httpMethod+u.RequestURI()+string(body)),
+		[]byte(o.APISecret))
+
+	headers := make(map[string]string)
+	headers["Content-Type"] = "application/json"
+	headers["OK-ACCESS-KEY"] = o.APIKey
+	headers["OK-ACCESS-SIGN"] = common.Base64Encode(hmac)
+	headers["OK-ACCESS-TIMESTAMP"] = timestamp
+	headers["OK-ACCESS-PASSPHRASE"] = o.ClientID
+
+	if o.Verbose {
+		log.Printf("Sending %s request to %s with body %s\n", httpMethod, endpoint, exchange.RedactSecrets(string(body)))
+	}
+
+	return o.SendPayloadWithType(reqType, httpMethod, endpoint, headers, bytes.NewReader(body), result, true, o.Verbose)
+}
+
 // SetErrorDefaults sets the full error default list
 func (o *OKEX) SetErrorDefaults() {
 	o.ErrorCodes = map[string]error{
@@ -1121,10 +1881,13 @@ func (o *OKEX) SetErrorDefaults() {
 // api does not return an error if there are misspellings in strings. So better
 // to check on this, this end.
 func (o *OKEX) SetCheckVarDefaults() {
-	o.ContractTypes = []string{"this_week", "next_week", "quarter"}
+	o.ContractTypes = []string{ContractTypeThisWeek, ContractTypeNextWeek,
+		ContractTypeQuarter, ContractTypeNextQuarter}
 	o.CurrencyPairs = []string{"btc_usd", "ltc_usd", "eth_usd", "etc_usd", "bch_usd"}
-	o.Types = []string{"1min", "3min", "5min", "15min", "30min", "1day", "3day",
-		"1week", "1hour", "2hour", "4hour", "6hour", "12hour"}
+	o.Types = nil
+	for i := range klineIntervals {
+		o.Types = append(o.Types, string(klineIntervals[i]))
+	}
 	o.ContractPosition = []string{"1", "2", "3", "4"}
 }
 
@@ -1146,18 +1909,36 @@ func (o *OKEX) CheckSymbol(symbol string) error {
 
 // CheckContractType checks to see if the string is a correct asset
 func (o *OKEX) CheckContractType(contractType string) error {
+	return o.validateContractType(contractType)
+}
+
+// validateContractType is the single check used by all contract methods,
+// returning a ContractTypeError listing the valid options on failure
+func (o *OKEX) validateContractType(contractType string) error {
 	if !common.StringDataCompare(o.ContractTypes, contractType) {
-		return errors.New("invalid contract type string")
+		return ContractTypeError{
+			ContractType: contractType,
+			ValidTypes:   o.ContractTypes,
+		}
 	}
 	return nil
 }
 
 // CheckType checks to see if the string is a correct type
 func (o *OKEX) CheckType(typeInput string) error {
-	if !common.StringDataCompare(o.Types, typeInput) {
-		return errors.New("invalid type string")
+	_, err := o.validateInterval(typeInput)
+	return err
+}
+
+// validateInterval checks a kline interval against the accepted intervals and
+// returns its API representation
+func (o *OKEX) validateInterval(interval string) (TimeInterval, error) {
+	if !common.StringDataCompare(o.Types, interval) {
+		return "", fmt.Errorf("invalid interval string %q, valid intervals: %s",
+			interval,
+			common.JoinStrings(o.Types, ", "))
 	}
-	return nil
+	return TimeInterval(interval), nil
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -1168,6 +1949,11 @@ func (o *OKEX) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
 		fee = calculateTradingFee(feeBuilder.PurchasePrice, feeBuilder.Amount, feeBuilder.IsMaker)
 	case exchange.CryptocurrencyWithdrawalFee:
 		fee = getWithdrawalFee(feeBuilder.FirstCurrency)
+	case exchange.InternationalBankDepositFee, exchange.InternationalBankWithdrawalFee:
+		// OKEX doesn't accept bank deposits or make bank withdrawals for any
+		// BankTransactionType, fiat is bought and sold through its C2C market
+		// which charges no deposit or withdrawal fee
+		fee = 0
 	}
 	if fee < 0 {
 		fee = 0
@@ -1176,6 +1962,102 @@ func (o *OKEX) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
 	return fee, nil
 }
 
+// EstimateLiquidationPrice returns the mark price at which a futures position
+// would be liquidated, the price where its margin plus unrealised profit falls
+// to the maintenance margin. OKEX futures are inverse contracts margined and
+// settled in the underlying currency, so profit is
+// contracts * face value * (1/entry - 1/price) for a long position. Zero is
+// returned for a short position which is fully collateralised and can't be
+// liquidated
+func EstimateLiquidationPrice(p FuturesPositionDetail) (float64, error) {
+	if p.EntryPrice <= 0 || p.Contracts <= 0 {
+		return 0, fmt.Errorf("invalid position entry price %v or contracts %v", p.EntryPrice, p.Contracts)
+	}
+
+	faceValue, err := contractFaceValue(p.Symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	mmr := p.MaintenanceMarginRatio
+	if mmr == 0 {
+		mmr = defaultMaintenanceMarginRatio
+	}
+	if mmr < 0 || mmr >= 1 {
+		return 0, fmt.Errorf("invalid maintenance margin ratio %v", mmr)
+	}
+
+	// position value in the settlement currency at the entry price
+	value := p.Contracts * faceValue / p.EntryPrice
+	margin := p.Margin
+	switch p.MarginMode {
+	case IsolatedMargin:
+		if margin == 0 {
+			if p.Leverage <= 0 {
+				return 0, fmt.Errorf("invalid position leverage %v", p.Leverage)
+			}
+			margin = value / p.Leverage
+		}
+	case CrossMargin:
+		if margin <= 0 {
+			return 0, errors.New("cross margin positions require the account equity as margin")
+		}
+	default:
+		return 0, fmt.Errorf("invalid margin mode %q", p.MarginMode)
+	}
+	if margin < 0 {
+		return 0, fmt.Errorf("invalid position margin %v", margin)
+	}
+
+	notional := p.Contracts * faceValue
+	if p.Long {
+		return notional * (1 + mmr) / (margin + value), nil
+	}
+
+	if margin >= value {
+		return 0, nil
+	}
+	return notional * (1 - mmr) / (value - margin), nil
+}
+
+// CalculateSwapCarry returns the funding a perpetual swap position pays or
+// receives at the next funding time and the annualised carry of the rate.
+// A positive funding rate is paid by long positions to short positions and a
+// negative rate by short positions to long positions, so Payment and
+// AnnualisedRate are positive when the position receives funding and
+// negative when it pays. The annualised rate assumes the rate holds for every
+// interval over the year without compounding
+func CalculateSwapCarry(rate SwapFundingRate, p SwapPositionDetail) (SwapCarry, error) {
+	if p.Value <= 0 {
+		return SwapCarry{}, fmt.Errorf("invalid position value %v", p.Value)
+	}
+
+	carryRate := rate.FundingRate
+	if p.Long {
+		carryRate = -carryRate
+	}
+
+	return SwapCarry{
+		InstrumentID:   rate.InstrumentID,
+		FundingRate:    rate.FundingRate,
+		FundingTime:    rate.FundingTime,
+		Payment:        carryRate * p.Value,
+		AnnualisedRate: carryRate * swapFundingIntervalsPerYear,
+	}, nil
+}
+
+// contractFaceValue returns the USD value of a single futures contract, BTC
+// contracts are worth 100 USD and every other contract 10 USD
+func contractFaceValue(symbol string) (float64, error) {
+	if symbol == "" {
+		return 0, errors.New("position symbol not set")
+	}
+	if common.StringToLower(symbol) == "btc_usd" {
+		return 100, nil
+	}
+	return 10, nil
+}
+
 func calculateTradingFee(purchasePrice, amount float64, isMaker bool) (fee float64) {
 	// TODO volume based fees
 	if isMaker {
@@ -1190,6 +2072,351 @@ func getWithdrawalFee(currency string) float64 {
 	return WithdrawalFees[currency]
 }
 
+// GetWithdrawalFees returns the withdrawal fee range of a currency, multi
+// chain currencies return an entry per chain e.g. "USDT-ERC20" and
+// "USDT-TRC20"
+func (o *OKEX) GetWithdrawalFees(currency string) ([]WithdrawalFee, error) {
+	var resp []WithdrawalFee
+
+	path := accountWithdrawalFee
+	if currency != "" {
+		path = fmt.Sprintf("%s?currency=%s", path, common.StringToLower(currency))
+	}
+
+	err := o.sendAuthenticatedHTTPRequestV3(request.Account, "GET", path, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetCurrencies returns every currency supported by the account API, multi
+// chain currencies return an entry per chain e.g. "USDT-ERC20" and
+// "USDT-TRC20"
+func (o *OKEX) GetCurrencies() ([]AccountCurrency, error) {
+	var resp []AccountCurrency
+
+	err := o.sendAuthenticatedHTTPRequestV3(request.Account, "GET", accountCurrencies, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetWithdrawalFeeEstimate returns the withdrawal fee of a currency on the
+// supplied chain e.g. "ERC20" or "TRC20" along with the chains the currency can
+// be withdrawn on. An empty chain selects the currency's default chain
+func (o *OKEX) GetWithdrawalFeeEstimate(currency, chain string) (WithdrawalFeeEstimate, error) {
+	fees, err := o.GetWithdrawalFees(currency)
+	if err != nil {
+		return WithdrawalFeeEstimate{}, err
+	}
+
+	return withdrawalFeeEstimate(currency, chain, fees)
+}
+
+// selectChain returns the index of the entry for the requested chain from a
+// list of chain qualified currencies e.g. "USDT-ERC20", the chain the entry is
+// on and the currency's available chains. When no chain is supplied and the
+// currency has no default chain an error listing the chains is returned
+// rather than guessing one
+func selectChain(currency, chain string, names []string) (index int, selected string, chains []string, err error) {
+	currency = common.StringToUpper(currency)
+	chain = strings.TrimPrefix(common.StringToUpper(chain), currency+"-")
+
+	index = -1
+	var last int
+	var lastChain string
+	for x := range names {
+		name := common.StringToUpper(names[x])
+		var nameChain string
+		switch {
+		case name == currency:
+		case strings.HasPrefix(name, currency+"-"):
+			nameChain = strings.TrimPrefix(name, currency+"-")
+		default:
+			continue
+		}
+
+		chains = append(chains, name)
+		if nameChain == chain {
+			index = x
+		}
+		last, lastChain = x, nameChain
+	}
+
+	if len(chains) == 0 {
+		return -1, chain, nil, fmt.Errorf("currency %s not found", currency)
+	}
+
+	if index == -1 && chain == "" && len(chains) == 1 {
+		return last, lastChain, chains, nil
+	}
+
+	if index == -1 {
+		if chain == "" {
+			return -1, chain, chains, fmt.Errorf("currency %s requires a chain, available chains: %s",
+				currency, common.JoinStrings(chains, ", "))
+		}
+		return -1, chain, chains, fmt.Errorf("chain %s not supported for currency %s, available chains: %s",
+			chain, currency, common.JoinStrings(chains, ", "))
+	}
+
+	return index, chain, chains, nil
+}
+
+// withdrawalFeeEstimate selects the fee of the requested chain from the
+// withdrawal fees returned for a currency
+func withdrawalFeeEstimate(currency, chain string, fees []WithdrawalFee) (WithdrawalFeeEstimate, error) {
+	names := make([]string, len(fees))
+	for x := range fees {
+		names[x] = fees[x].Currency
+	}
+
+	estimate := WithdrawalFeeEstimate{Currency: common.StringToUpper(currency)}
+	index, selected, chains, err := selectChain(currency, chain, names)
+	estimate.Chain = selected
+	estimate.Chains = chains
+	if err != nil {
+		return estimate, err
+	}
+
+	if fees[index].MinFee != "" {
+		estimate.Fee, err = strconv.ParseFloat(fees[index].MinFee, 64)
+		if err != nil {
+			return estimate, err
+		}
+	}
+
+	if fees[index].MaxFee != "" {
+		estimate.MaxFee, err = strconv.ParseFloat(fees[index].MaxFee, 64)
+		if err != nil {
+			return estimate, err
+		}
+	}
+
+	return estimate, nil
+}
+
+// GetDepositAddressList returns the deposit addresses of a currency, multi
+// chain currencies return an address per chain e.g. "usdt-erc20" and
+// "usdt-trc20"
+func (o *OKEX) GetDepositAddressList(currency string) ([]DepositAddress, error) {
+	var resp []DepositAddress
+
+	path := fmt.Sprintf("%s?currency=%s", accountDepositAddress, common.StringToLower(currency))
+	err := o.sendAuthenticatedHTTPRequestV3(request.Account, "GET", path, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetDepositAddressOnChain returns the deposit address of a currency on the
+// supplied chain e.g. "ERC20" or "TRC20". An empty chain selects the
+// currency's default chain
+func (o *OKEX) GetDepositAddressOnChain(currency, chain string) (DepositAddress, error) {
+	addresses, err := o.GetDepositAddressList(currency)
+	if err != nil {
+		return DepositAddress{}, err
+	}
+
+	return depositAddressForChain(currency, chain, addresses)
+}
+
+// depositAddressForChain selects the deposit address of the requested chain
+func depositAddressForChain(currency, chain string, addresses []DepositAddress) (DepositAddress, error) {
+	names := make([]string, len(addresses))
+	for x := range addresses {
+		names[x] = addresses[x].Currency
+	}
+
+	index, _, _, err := selectChain(currency, chain, names)
+	if err != nil {
+		return DepositAddress{}, err
+	}
+	return addresses[index], nil
+}
+
+// Withdraw withdraws a cryptocurrency to an external address on the requested
+// chain, the chain is validated against the chains supported for the currency.
+// When no fee is supplied the minimum fee for the chain is used
+func (o *OKEX) Withdraw(arg WithdrawRequest) (WithdrawResponse, error) {
+	var resp WithdrawResponse
+
+	estimate, err := o.GetWithdrawalFeeEstimate(arg.Currency, arg.Chain)
+	if err != nil {
+		return resp, err
+	}
+
+	arg.Chain = estimate.Chain
+	if arg.Fee == 0 {
+		arg.Fee = estimate.Fee
+	}
+
+	currency := common.StringToLower(arg.Currency)
+	if arg.Chain != "" {
+		currency = fmt.Sprintf("%s-%s", currency, common.StringToLower(arg.Chain))
+	}
+
+	data := map[string]string{
+		"currency":    currency,
+		"amount":      strconv.FormatFloat(arg.Amount, 'f', -1, 64),
+		"destination": withdrawalDestinationAddress,
+		"to_address":  arg.Address,
+		"trade_pwd":   arg.TradePassword,
+		"fee":         strconv.FormatFloat(arg.Fee, 'f', -1, 64),
+	}
+
+	err = o.sendAuthenticatedHTTPRequestV3(request.Trade, "POST", accountWithdrawal, data, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	if !resp.Result {
+		return resp, errors.New("unable to process withdrawal request")
+	}
+
+	return resp, nil
+}
+
+// GetWithdrawalHistory returns the most recent withdrawals of a currency, or
+// of every currency when currency is empty
+func (o *OKEX) GetWithdrawalHistory(currency string) ([]WithdrawalHistory, error) {
+	var resp []WithdrawalHistory
+
+	path := accountWithdrawalHist
+	if currency != "" {
+		path = fmt.Sprintf("%s/%s", path, common.StringToLower(currency))
+	}
+
+	err := o.sendAuthenticatedHTTPRequestV3(request.Account, "GET", path, nil, &resp)
+	return resp, err
+}
+
+// GetSpotAccount returns the spot account balance of a single currency
+func (o *OKEX) GetSpotAccount(currency string) (SpotAccount, error) {
+	var resp SpotAccount
+
+	path := fmt.Sprintf("%s/%s", spotAccounts, common.StringToLower(currency))
+	err := o.sendAuthenticatedHTTPRequestV3(request.Account, "GET", path, nil, &resp)
+	return resp, err
+}
+
+// ListSubAccounts returns the sub accounts created under the main account
+func (o *OKEX) ListSubAccounts() ([]SubAccount, error) {
+	var resp subAccountListResponse
+
+	err := o.sendAuthenticatedHTTPRequestV3(request.Account, "GET", subAccountList, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != "0" {
+		return nil, fmt.Errorf("%s unable to list sub accounts, error code %s: %s",
+			o.Name, resp.Code, resp.Msg)
+	}
+
+	return resp.Data, nil
+}
+
+// GetSubAccountBalance returns a sub account's balances in each of its
+// accounts
+func (o *OKEX) GetSubAccountBalance(subAccount string) (SubAccountBalance, error) {
+	if subAccount == "" {
+		return SubAccountBalance{}, errors.New("sub account name not set")
+	}
+
+	var resp struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+
+	path := fmt.Sprintf("%s?sub-account=%s", accountSubAccount, url.QueryEscape(subAccount))
+	err := o.sendAuthenticatedHTTPRequestV3(request.Account, "GET", path, nil, &resp)
+	if err != nil {
+		return SubAccountBalance{}, err
+	}
+
+	return parseSubAccountBalance(resp.Data)
+}
+
+// parseSubAccountBalance decodes a sub account balance, whose account types are
+// returned as separate "account_type:" prefixed keys
+func parseSubAccountBalance(data map[string]json.RawMessage) (SubAccountBalance, error) {
+	balance := SubAccountBalance{
+		Accounts: make(map[string][]SubAccountCurrencyBalance),
+	}
+
+	for key, value := range data {
+		var err error
+		switch {
+		case key == "sub_account":
+			err = json.Unmarshal(value, &balance.SubAccount)
+		case key == "asset_valuation":
+			var valuation string
+			err = json.Unmarshal(value, &valuation)
+			if err == nil && valuation != "" {
+				balance.AssetValuation, err = strconv.ParseFloat(valuation, 64)
+			}
+		case strings.HasPrefix(key, subAccountTypePrefix):
+			var currencies []SubAccountCurrencyBalance
+			err = json.Unmarshal(value, &currencies)
+			balance.Accounts[strings.TrimPrefix(key, subAccountTypePrefix)] = currencies
+		}
+		if err != nil {
+			return balance, fmt.Errorf("unable to decode sub account %s: %s", key, err)
+		}
+	}
+	return balance, nil
+}
+
+// SubAccountTransfer moves funds between the main account's funding account
+// and a sub account. An empty from or to is the main account, transfers
+// between two sub accounts are not supported
+func (o *OKEX) SubAccountTransfer(currency string, amount float64, from, to string) (SubAccountTransferResponse, error) {
+	var resp SubAccountTransferResponse
+
+	data := map[string]string{
+		"currency": common.StringToLower(currency),
+		"amount":   strconv.FormatFloat(amount, 'f', -1, 64),
+	}
+
+	switch {
+	case from == "" && to != "":
+		data["from"] = transferAccountFunding
+		data["to"] = transferAccountSubAccount
+		data["type"] = transferMainToSubAccount
+		data["sub_account"] = to
+	case from != "" && to == "":
+		data["from"] = transferAccountSubAccount
+		data["to"] = transferAccountFunding
+		data["type"] = transferSubAccountToMain
+		data["sub_account"] = from
+	case from == "" && to == "":
+		return resp, errors.New("a sub account is required to transfer from or to")
+	default:
+		return resp, errors.New("transfers between sub accounts are not supported")
+	}
+
+	if amount <= 0 {
+		return resp, fmt.Errorf("invalid transfer amount %v", amount)
+	}
+
+	err := o.sendAuthenticatedHTTPRequestV3(request.Trade, "POST", accountTransfer, data, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	if !resp.Result {
+		return resp, errors.New("unable to process sub account transfer")
+	}
+
+	return resp, nil
+}
+
 // GetBalance returns the full balance accross all wallets
 func (o *OKEX) GetBalance() ([]FullBalance, error) {
 	var resp Balance
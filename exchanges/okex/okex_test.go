@@ -1,12 +1,20 @@
 package okex
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
 )
 
 var o OKEX
@@ -48,6 +56,83 @@ func TestGetSpotInstruments(t *testing.T) {
 	}
 }
 
+func TestGetSpotAllTickers(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetSpotAllTickers()
+	if err != nil {
+		t.Errorf("Test failed - okex GetSpotAllTickers() failed: %s", err)
+	}
+}
+
+func TestValidateContractType(t *testing.T) {
+	var ok OKEX
+	ok.SetDefaults()
+
+	for _, contractType := range []string{ContractTypeThisWeek,
+		ContractTypeNextWeek,
+		ContractTypeQuarter,
+		ContractTypeNextQuarter} {
+		if err := ok.validateContractType(contractType); err != nil {
+			t.Errorf("Test failed - okex validateContractType() %s error %s", contractType, err)
+		}
+	}
+
+	err := ok.validateContractType("this_wok")
+	if err == nil {
+		t.Fatal("Test failed - okex validateContractType() expected error")
+	}
+
+	if _, isContractTypeErr := err.(ContractTypeError); !isContractTypeErr {
+		t.Errorf("Test failed - okex validateContractType() expected ContractTypeError, received %T", err)
+	}
+}
+
+func TestValidateAssetType(t *testing.T) {
+	var ok OKEX
+	ok.SetDefaults()
+
+	_, err := ok.UpdateTicker(pair.NewCurrencyPair("BTC", "USDT"), "binary")
+	if !errors.Is(err, exchange.ErrAssetTypeNotSupported) {
+		t.Errorf("Test failed - okex UpdateTicker() expected %s, received %v",
+			exchange.ErrAssetTypeNotSupported, err)
+	}
+
+	_, err = ok.UpdateOrderbook(pair.NewCurrencyPair("BTC", "USDT"), "binary")
+	if !errors.Is(err, exchange.ErrAssetTypeNotSupported) {
+		t.Errorf("Test failed - okex UpdateOrderbook() expected %s, received %v",
+			exchange.ErrAssetTypeNotSupported, err)
+	}
+
+	assetType, err := ok.validateAssetType(ContractTypeQuarter)
+	if err != nil || assetType != ContractTypeQuarter {
+		t.Errorf("Test failed - okex validateAssetType() unexpected result %s %v", assetType, err)
+	}
+}
+
+func TestValidateInterval(t *testing.T) {
+	var ok OKEX
+	ok.SetDefaults()
+
+	for _, interval := range klineIntervals {
+		resp, err := ok.validateInterval(string(interval))
+		if err != nil {
+			t.Errorf("Test failed - okex validateInterval() %s error %s", interval, err)
+		}
+		if resp != interval {
+			t.Errorf("Test failed - okex validateInterval() expected %s, received %s", interval, resp)
+		}
+	}
+
+	if _, err := ok.validateInterval("min"); err == nil {
+		t.Error("Test failed - okex validateInterval() expected error")
+	}
+
+	_, err := ok.GetSpotKline(KlinesRequestParams{Symbol: "ltc_btc", Type: "min"})
+	if err == nil {
+		t.Error("Test failed - okex GetSpotKline() expected error")
+	}
+}
+
 func TestGetContractPrice(t *testing.T) {
 	t.Parallel()
 	_, err := o.GetContractPrice("btc_usd", "this_week")
@@ -64,6 +149,18 @@ func TestGetContractPrice(t *testing.T) {
 	}
 }
 
+func TestGetContractPrices(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetContractPrices("btc_usd")
+	if err != nil {
+		t.Error("Test failed - okex GetContractPrices() error", err)
+	}
+	_, err = o.GetContractPrices("btc_bla")
+	if err == nil {
+		t.Error("Test failed - okex GetContractPrices() error", err)
+	}
+}
+
 func TestGetContractMarketDepth(t *testing.T) {
 	t.Parallel()
 	_, err := o.GetContractMarketDepth("btc_usd", "this_week")
@@ -108,6 +205,18 @@ func TestGetContractIndexPrice(t *testing.T) {
 	}
 }
 
+func TestGetIndexPrice(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetIndexPrice(pair.NewCurrencyPair("BTC", "USD"))
+	if err != nil {
+		t.Error("Test failed - okex GetIndexPrice() error", err)
+	}
+	_, err = o.GetIndexPrice(pair.NewCurrencyPairFromString("lol123"))
+	if err == nil {
+		t.Error("Test failed - okex GetIndexPrice() error", err)
+	}
+}
+
 func TestGetContractExchangeRate(t *testing.T) {
 	t.Parallel()
 	_, err := o.GetContractExchangeRate()
@@ -136,27 +245,98 @@ func TestGetContractCandlestickData(t *testing.T) {
 	}
 }
 
+func TestGetContractKlineRange(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetContractKlineRange("btc_bla", "1min", "this_week", 0, 1, 2)
+	if err == nil {
+		t.Error("Test failed - okex GetContractKlineRange() expected invalid symbol error")
+	}
+	_, err = o.GetContractKlineRange("btc_usd", "min", "this_week", 0, 1, 2)
+	if err == nil {
+		t.Error("Test failed - okex GetContractKlineRange() expected invalid interval error")
+	}
+	_, err = o.GetContractKlineRange("btc_usd", "1min", "this_wok", 0, 1, 2)
+	if err == nil {
+		t.Error("Test failed - okex GetContractKlineRange() expected invalid contract type error")
+	}
+}
+
 func TestGetContractHoldingsNumber(t *testing.T) {
 	t.Parallel()
-	_, _, err := o.GetContractHoldingsNumber("btc_usd", "this_week")
+	holdings, err := o.GetContractHoldingsNumber("btc_usd", "this_week")
 	if err != nil {
 		t.Error("Test failed - okex GetContractHoldingsNumber() error", err)
+	} else if holdings.ContractName == "" || holdings.LongHoldings < 0 ||
+		holdings.LongHoldings != holdings.ShortHoldings {
+		t.Error("Test failed - okex GetContractHoldingsNumber() incorrect holdings", holdings)
 	}
-	_, _, err = o.GetContractHoldingsNumber("btc_bla", "this_week")
+	_, err = o.GetContractHoldingsNumber("btc_bla", "this_week")
 	if err == nil {
 		t.Error("Test failed - okex GetContractHoldingsNumber() error", err)
 	}
-	_, _, err = o.GetContractHoldingsNumber("btc_usd", "this_bla")
+	_, err = o.GetContractHoldingsNumber("btc_usd", "this_bla")
 	if err == nil {
 		t.Error("Test failed - okex GetContractHoldingsNumber() error", err)
 	}
 }
 
+func TestParseContractHoldings(t *testing.T) {
+	t.Parallel()
+	var resp interface{}
+	err := common.JSONDecode([]byte(`[{"amount":106856,"contract_name":"BTC0213"}]`), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	holdings, err := o.parseContractHoldings(resp)
+	if err != nil {
+		t.Fatal("Test failed - okex parseContractHoldings() error", err)
+	}
+
+	if holdings.ContractName != "BTC0213" || holdings.LongHoldings != 106856 ||
+		holdings.ShortHoldings != 106856 {
+		t.Errorf("Test failed - okex parseContractHoldings() unexpected holdings %+v", holdings)
+	}
+
+	for _, data := range []string{`{"error_code":20049}`, `[]`, `[{"amount":"abc"}]`} {
+		err = common.JSONDecode([]byte(data), &resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = o.parseContractHoldings(resp); err == nil {
+			t.Errorf("Test failed - okex parseContractHoldings() expected error for %s", data)
+		}
+	}
+}
+
+func TestGetOpenInterest(t *testing.T) {
+	t.Parallel()
+	openInterest, err := o.GetOpenInterest("btc_usd", "this_week")
+	if err == nil && (openInterest.ContractName == "" || openInterest.Amount < 0 ||
+		openInterest.Symbol != "btc_usd" || openInterest.ContractType != "this_week") {
+		t.Error("Test failed - okex GetOpenInterest() incorrect open interest", openInterest)
+	}
+
+	_, err = o.GetOpenInterest("btc_bla", "this_week")
+	if err == nil {
+		t.Error("Test failed - okex GetOpenInterest() expected an error for an invalid symbol")
+	}
+
+	_, err = o.GetOpenInterest("btc_usd", "this_bla")
+	if err == nil {
+		t.Error("Test failed - okex GetOpenInterest() expected an error for an invalid contract type")
+	}
+}
+
 func TestGetContractlimit(t *testing.T) {
 	t.Parallel()
-	_, err := o.GetContractlimit("btc_usd", "this_week")
+	limit, err := o.GetContractlimit("btc_usd", "this_week")
 	if err != nil {
 		t.Error("Test failed - okex GetContractlimit() error", err)
+	} else if limit.Low <= 0 || limit.High < limit.Low {
+		t.Errorf("Test failed - okex GetContractlimit() invalid limits, low: %v high: %v",
+			limit.Low, limit.High)
 	}
 	_, err = o.GetContractlimit("btc_bla", "this_week")
 	if err == nil {
@@ -168,6 +348,22 @@ func TestGetContractlimit(t *testing.T) {
 	}
 }
 
+func TestCheckContractPriceLimit(t *testing.T) {
+	t.Parallel()
+	limit := ContractPriceLimit{High: 110, Low: 90}
+	for _, price := range []float64{90, 100, 110} {
+		if err := checkContractPriceLimit(limit, price); err != nil {
+			t.Errorf("Test failed - okex checkContractPriceLimit() price %v error %s", price, err)
+		}
+	}
+
+	for _, price := range []float64{0, 89.99, 110.01} {
+		if err := checkContractPriceLimit(limit, price); err == nil {
+			t.Errorf("Test failed - okex checkContractPriceLimit() price %v expected error", price)
+		}
+	}
+}
+
 func TestGetContractUserInfo(t *testing.T) {
 	t.Parallel()
 	err := o.GetContractUserInfo()
@@ -176,6 +372,102 @@ func TestGetContractUserInfo(t *testing.T) {
 	}
 }
 
+func TestEstimateLiquidationPrice(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		position FuturesPositionDetail
+		expected float64
+	}{
+		{FuturesPositionDetail{Symbol: "btc_usd", Long: true, EntryPrice: 10000, Contracts: 100,
+			Leverage: 10, MarginMode: IsolatedMargin}, 9136.36},
+		{FuturesPositionDetail{Symbol: "btc_usd", EntryPrice: 10000, Contracts: 100,
+			Leverage: 10, MarginMode: IsolatedMargin}, 11055.56},
+		{FuturesPositionDetail{Symbol: "btc_usd", Long: true, EntryPrice: 10000, Contracts: 100,
+			MarginMode: CrossMargin, Margin: 0.5}, 6700},
+		{FuturesPositionDetail{Symbol: "ltc_usd", Long: true, EntryPrice: 100, Contracts: 10,
+			Leverage: 20, MarginMode: IsolatedMargin, MaintenanceMarginRatio: 0.01}, 96.19},
+		{FuturesPositionDetail{Symbol: "btc_usd", EntryPrice: 10000, Contracts: 100,
+			Leverage: 1, MarginMode: IsolatedMargin}, 0},
+	}
+
+	for x := range tests {
+		price, err := EstimateLiquidationPrice(tests[x].position)
+		if err != nil {
+			t.Fatal("Test failed - okex EstimateLiquidationPrice() error", err)
+		}
+		if math.Abs(price-tests[x].expected) > 0.01 {
+			t.Errorf("Test failed - okex EstimateLiquidationPrice() %d expected %v, received %v",
+				x, tests[x].expected, price)
+		}
+	}
+
+	_, err := EstimateLiquidationPrice(FuturesPositionDetail{Symbol: "btc_usd", Long: true,
+		EntryPrice: 10000, Contracts: 100, MarginMode: CrossMargin})
+	if err == nil {
+		t.Error("Test failed - okex EstimateLiquidationPrice() expected error without cross margin equity")
+	}
+
+	_, err = EstimateLiquidationPrice(FuturesPositionDetail{Symbol: "btc_usd", EntryPrice: 10000,
+		Contracts: 100, Leverage: 10})
+	if err == nil {
+		t.Error("Test failed - okex EstimateLiquidationPrice() expected error without margin mode")
+	}
+}
+
+func TestSwapFundingRate(t *testing.T) {
+	t.Parallel()
+	var rate SwapFundingRate
+	err := common.JSONDecode([]byte(`{"instrument_id":"BTC-USD-SWAP","funding_time":"2019-01-19T16:00:00.000Z",`+
+		`"funding_rate":"-0.00035","estimated_rate":"0.00019","interest_rate":"0.00000","settlement_time":"2019-01-19T08:00:00.000Z"}`),
+		&rate)
+	if err != nil {
+		t.Fatal("Test failed - okex SwapFundingRate decode error", err)
+	}
+
+	if rate.FundingRate != -0.00035 || rate.EstimatedRate != 0.00019 ||
+		!rate.FundingTime.Equal(time.Date(2019, 1, 19, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("Test failed - okex SwapFundingRate unexpected decode %+v", rate)
+	}
+
+	_, err = o.GetSwapFundingRate("")
+	if err == nil {
+		t.Error("Test failed - okex GetSwapFundingRate() expected error without instrument ID")
+	}
+}
+
+func TestCalculateSwapCarry(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		rate       float64
+		long       bool
+		payment    float64
+		annualised float64
+	}{
+		{0.0001, true, -0.1, -0.1095},
+		{0.0001, false, 0.1, 0.1095},
+		{-0.0003, true, 0.3, 0.3285},
+		{-0.0003, false, -0.3, -0.3285},
+	}
+
+	for x := range tests {
+		carry, err := CalculateSwapCarry(SwapFundingRate{InstrumentID: "BTC-USD-SWAP", FundingRate: tests[x].rate},
+			SwapPositionDetail{InstrumentID: "BTC-USD-SWAP", Long: tests[x].long, Value: 1000})
+		if err != nil {
+			t.Fatal("Test failed - okex CalculateSwapCarry() error", err)
+		}
+		if math.Abs(carry.Payment-tests[x].payment) > 1e-9 ||
+			math.Abs(carry.AnnualisedRate-tests[x].annualised) > 1e-9 {
+			t.Errorf("Test failed - okex CalculateSwapCarry() %d expected payment %v annualised %v, received %v %v",
+				x, tests[x].payment, tests[x].annualised, carry.Payment, carry.AnnualisedRate)
+		}
+	}
+
+	_, err := CalculateSwapCarry(SwapFundingRate{FundingRate: 0.0001}, SwapPositionDetail{Long: true})
+	if err == nil {
+		t.Error("Test failed - okex CalculateSwapCarry() expected error without position value")
+	}
+}
+
 func TestGetContractPosition(t *testing.T) {
 	t.Parallel()
 	err := o.GetContractPosition("btc_usd", "this_week")
@@ -192,12 +484,96 @@ func TestPlaceContractOrders(t *testing.T) {
 	}
 }
 
+func TestContractOrderResponse(t *testing.T) {
+	t.Parallel()
+	var resp ContractOrderResponse
+	err := common.JSONDecode([]byte(`{"result":true,"order_id":9007199254740993}`), &resp)
+	if err != nil {
+		t.Fatal("Test failed - okex ContractOrderResponse decode error", err)
+	}
+
+	orderID, err := resp.OrderID.Int64()
+	if err != nil || orderID != 9007199254740993 {
+		t.Errorf("Test failed - okex ContractOrderResponse expected order ID 9007199254740993, received %d %v",
+			orderID, err)
+	}
+}
+
+func TestPlaceContractOrderInCoins(t *testing.T) {
+	t.Parallel()
+	_, _, err := o.PlaceContractOrderInCoins("btc_usd", "this_week", "1", 10, 1, 1, true)
+	if err == nil {
+		t.Error("Test failed - okex PlaceContractOrderInCoins() error", err)
+	}
+}
+
+func TestContractConversion(t *testing.T) {
+	t.Parallel()
+	coins, err := ContractsToCoins("btc_usd", 100, 10000)
+	if err != nil || coins != 1 {
+		t.Errorf("Test failed - okex ContractsToCoins() expected 1 received %v %v", coins, err)
+	}
+
+	contracts, err := CoinsToContracts("ltc_usd", 2, 50)
+	if err != nil || contracts != 10 {
+		t.Errorf("Test failed - okex CoinsToContracts() expected 10 received %v %v", contracts, err)
+	}
+
+	if _, err = CoinsToContracts("btc_usd", 1, 0); err == nil {
+		t.Error("Test failed - okex CoinsToContracts() expected error with no index price")
+	}
+
+	if _, err = ContractsToCoins("", 1, 10000); err == nil {
+		t.Error("Test failed - okex ContractsToCoins() expected error with no symbol")
+	}
+
+	contracts, err = coinsToWholeContracts("btc_usd", 0.3, 1000)
+	if err != nil || contracts != 3 {
+		t.Errorf("Test failed - okex coinsToWholeContracts() expected 3 received %v %v", contracts, err)
+	}
+
+	contracts, err = coinsToWholeContracts("btc_usd", 0.25, 1000)
+	if err != nil || contracts != 2 {
+		t.Errorf("Test failed - okex coinsToWholeContracts() expected 2 received %v %v", contracts, err)
+	}
+
+	if _, err = coinsToWholeContracts("btc_usd", 0.05, 1000); err == nil {
+		t.Error("Test failed - okex coinsToWholeContracts() expected error for less than one contract")
+	}
+}
+
 func TestGetContractFuturesTradeHistory(t *testing.T) {
 	t.Parallel()
-	err := o.GetContractFuturesTradeHistory("btc_usd", "1972-01-01", 0)
+	_, err := o.GetContractFuturesTradeHistory("btc_usd", "1972-01-01", 0)
 	if err == nil {
 		t.Error("Test failed - okex GetContractTradeHistory() error", err)
 	}
+
+	_, err = o.GetContractFuturesTradeHistory("btc_usd", "01-01-1972", 0)
+	if err == nil {
+		t.Error("Test failed - okex GetContractTradeHistory() expected invalid date error")
+	}
+}
+
+func TestFuturesTradeHistoryJSON(t *testing.T) {
+	t.Parallel()
+	data := []byte(`[{"amount":11,"date":1418815650000,"price":350.35,"fee":0.0012,"order_id":10000591,"tid":1234,"type":"sell"}]`)
+
+	var result []FuturesTradeHistory
+	err := common.JSONDecode(data, &result)
+	if err != nil {
+		t.Fatal("Test failed - okex FuturesTradeHistory decode error", err)
+	}
+
+	if len(result) != 1 {
+		t.Fatalf("Test failed - okex FuturesTradeHistory expected 1 fill, received %d", len(result))
+	}
+
+	if result[0].Amount != 11 || result[0].Price != 350.35 || result[0].Fee != 0.0012 ||
+		result[0].OrderID != 10000591 || result[0].TID != 1234 || result[0].Type != "sell" ||
+		result[0].DateInMS != 1418815650000 {
+		t.Errorf("Test failed - okex FuturesTradeHistory unexpected values %+v", result[0])
+	}
 }
 
 func TestGetLatestSpotPrice(t *testing.T) {
@@ -238,6 +614,40 @@ func TestGetSpotRecentTrades(t *testing.T) {
 	}
 }
 
+func TestConvertRecentTrades(t *testing.T) {
+	trades, err := o.convertRecentTrades([]ActualSpotTradeHistory{
+		{TID: 1, Price: 100, Amount: 2, Date: 1540000000, Type: "buy"},
+		{TID: 2, Price: 99, Amount: 1, Date: 1540000001, Type: "sell"},
+	})
+	if err != nil {
+		t.Fatal("Test failed - okex convertRecentTrades() error", err)
+	}
+
+	if trades[0].Side != exchange.Buy || trades[1].Side != exchange.Sell {
+		t.Errorf("Test failed - okex convertRecentTrades() unexpected sides %s %s",
+			trades[0].Side, trades[1].Side)
+	}
+	if trades[0].Timestamp != 1540000000 || trades[0].TID != 1 ||
+		trades[0].Price != 100 || trades[0].Amount != 2 || trades[0].Type != "buy" {
+		t.Errorf("Test failed - okex convertRecentTrades() unexpected values %+v", trades[0])
+	}
+
+	_, err = o.convertRecentTrades([]ActualSpotTradeHistory{{Type: "unknown"}})
+	if err == nil {
+		t.Error("Test failed - okex convertRecentTrades() accepted an unknown side")
+	}
+}
+
+func TestGetExchangeHistoryAssetType(t *testing.T) {
+	var ok OKEX
+	ok.SetDefaults()
+	_, err := ok.GetExchangeHistory(pair.NewCurrencyPair("btc", "usd"), "futures")
+	if !errors.Is(err, exchange.ErrAssetTypeNotSupported) {
+		t.Errorf("Test failed - okex GetExchangeHistory() expected %s, received %v",
+			exchange.ErrAssetTypeNotSupported, err)
+	}
+}
+
 func TestGetSpotKline(t *testing.T) {
 	t.Parallel()
 	arg := KlinesRequestParams{
@@ -251,6 +661,65 @@ func TestGetSpotKline(t *testing.T) {
 	}
 }
 
+func TestPageKline(t *testing.T) {
+	const minute = 60000
+	var requests int
+	// returns candles from since inclusive, so each page overlaps the last by
+	// one candle
+	fetch := func(size int, since int64) ([]CandleStickData, error) {
+		requests++
+		if size > spotKlineMaxSize {
+			t.Fatalf("Test failed - okex pageKline() requested %d candles", size)
+		}
+
+		var candles []CandleStickData
+		for x := 0; x < size; x++ {
+			openTime := since + int64(x)*minute
+			if openTime > 5000*minute {
+				break
+			}
+			candles = append(candles, CandleStickData{Timestamp: float64(openTime)})
+		}
+		return candles, nil
+	}
+
+	arg := KlinesRequestParams{Since: minute, Until: 4500 * minute}
+	candles, err := pageKline(arg, spotKlineMaxSize, fetch)
+	if err != nil {
+		t.Fatal("Test failed - okex pageKline() error", err)
+	}
+
+	if len(candles) != 4500 || requests != 3 {
+		t.Errorf("Test failed - okex pageKline() expected 4500 candles from 3 requests, received %d from %d",
+			len(candles), requests)
+	}
+
+	for x := range candles {
+		if candles[x].Timestamp != float64(int64(x+1)*minute) {
+			t.Fatalf("Test failed - okex pageKline() unexpected candle %d open time %v",
+				x, candles[x].Timestamp)
+		}
+	}
+
+	arg.Size = 2500
+	candles, err = pageKline(arg, spotKlineMaxSize, fetch)
+	if err != nil {
+		t.Fatal("Test failed - okex pageKline() error", err)
+	}
+
+	if len(candles) != 2500 {
+		t.Errorf("Test failed - okex pageKline() expected 2500 candles, received %d", len(candles))
+	}
+
+	if _, err = pageKline(KlinesRequestParams{Until: minute}, spotKlineMaxSize, fetch); err == nil {
+		t.Error("Test failed - okex pageKline() expected error without since")
+	}
+
+	if _, err = pageKline(KlinesRequestParams{Since: minute * 2, Until: minute}, spotKlineMaxSize, fetch); err == nil {
+		t.Error("Test failed - okex pageKline() expected error with until before since")
+	}
+}
+
 func TestSpotNewOrder(t *testing.T) {
 	t.Parallel()
 
@@ -269,6 +738,62 @@ func TestSpotNewOrder(t *testing.T) {
 	}
 }
 
+func TestSpotNewOrderClientOrderID(t *testing.T) {
+	t.Parallel()
+	var placed map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "POST" || req.URL.Path != "/api/"+spotOrders ||
+			req.Header.Get("OK-ACCESS-KEY") != "key" {
+			t.Errorf("Test failed - okex SpotNewOrder() unexpected request %s %s", req.Method, req.URL.Path)
+		}
+
+		placed = nil
+		if err := json.NewDecoder(req.Body).Decode(&placed); err != nil {
+			t.Error("Test failed - okex SpotNewOrder() unexpected body", err)
+		}
+		w.Write([]byte(`{"client_oid":"` + placed["client_oid"] + `","error_code":"","error_message":"","order_id":"2510789768709120","result":true}`))
+	}))
+	defer ts.Close()
+
+	var stub OKEX
+	stub.SetDefaults()
+	stub.AuthenticatedAPISupport = true
+	stub.APIKey = "key"
+	stub.APISecret = "secret"
+	stub.APIUrl = ts.URL + "/api/"
+
+	id, err := stub.SpotNewOrder(SpotNewOrderRequestParams{
+		Symbol:        "ltc_btc",
+		Amount:        1.1,
+		Price:         10.1,
+		Type:          SpotNewOrderRequestTypeBuy,
+		ClientOrderID: "gct1337",
+	})
+	if err != nil || id != 2510789768709120 {
+		t.Fatalf("Test failed - okex SpotNewOrder() expected order 2510789768709120, received %d %v", id, err)
+	}
+
+	if placed["client_oid"] != "gct1337" || placed["instrument_id"] != "LTC-BTC" ||
+		placed["type"] != "limit" || placed["side"] != "buy" ||
+		placed["price"] != "10.1" || placed["size"] != "1.1" {
+		t.Errorf("Test failed - okex SpotNewOrder() unexpected order %v", placed)
+	}
+
+	_, err = stub.SpotNewOrder(SpotNewOrderRequestParams{
+		Symbol: "ltc_btc",
+		Price:  100,
+		Type:   SpotNewOrderRequestTypeBuyMarket,
+	})
+	if err != nil {
+		t.Fatal("Test failed - okex SpotNewOrder() error", err)
+	}
+
+	if _, ok := placed["client_oid"]; ok || placed["type"] != "market" ||
+		placed["notional"] != "100" || placed["size"] != "" {
+		t.Errorf("Test failed - okex SpotNewOrder() unexpected market order %v", placed)
+	}
+}
+
 func TestSpotCancelOrder(t *testing.T) {
 	t.Parallel()
 
@@ -383,6 +908,24 @@ func TestGetFee(t *testing.T) {
 		t.Errorf("Test Failed - GetFee() error. Expected: %f, Recieved: %f", float64(0), resp)
 		t.Error(err)
 	}
+
+	// International bank fees for each BankTransactionType
+	for _, feeType := range []exchange.FeeType{exchange.InternationalBankDepositFee,
+		exchange.InternationalBankWithdrawalFee} {
+		for _, bankType := range []exchange.InternationalBankTransactionType{exchange.WireTransfer,
+			exchange.SEPA, exchange.Swift, exchange.VisaMastercard} {
+			feeBuilder = setFeeBuilder()
+			feeBuilder.FeeType = feeType
+			feeBuilder.BankTransactionType = bankType
+			feeBuilder.CurrencyItem = symbol.USD
+			feeBuilder.Amount = 1000
+			if resp, err := o.GetFee(feeBuilder); resp != float64(0) || err != nil {
+				t.Errorf("Test Failed - GetFee() %s %s error. Expected: %f, Recieved: %f",
+					feeType, bankType, float64(0), resp)
+				t.Error(err)
+			}
+		}
+	}
 }
 
 func TestFormatWithdrawPermissions(t *testing.T) {
@@ -483,6 +1026,15 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 	if len(resp.OrderStatus) > 0 {
 		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
 	}
+
+	if resp.Failed > 0 {
+		t.Errorf("%v orders failed to cancel", resp.Failed)
+	}
+
+	if resp.Succeeded != len(resp.CancelledOrderIDs) {
+		t.Errorf("%v orders cancelled but %v order IDs returned",
+			resp.Succeeded, len(resp.CancelledOrderIDs))
+	}
 }
 
 func TestGetAccountInfo(t *testing.T) {
@@ -499,9 +1051,741 @@ func TestGetAccountInfo(t *testing.T) {
 	}
 }
 
-func TestModifyOrder(t *testing.T) {
-	_, err := o.ModifyOrder(exchange.ModifyOrder{})
+func TestParseSpotUserInfoFunds(t *testing.T) {
+	t.Parallel()
+	userInfo := SpotUserInfo{
+		Result: true,
+		Info: map[string]map[string]map[string]string{
+			"funds": {
+				"free":    {"btc": "1.5", "ltc": "0", "eth": "0"},
+				"freezed": {"btc": "0.5", "ltc": "2", "eth": "0"},
+			},
+		},
+	}
+
+	balances, err := parseSpotUserInfoFunds(userInfo)
+	if err != nil {
+		t.Fatal("Test failed - okex parseSpotUserInfoFunds() error", err)
+	}
+
+	if len(balances) != 2 {
+		t.Fatalf("Test failed - okex parseSpotUserInfoFunds() expected 2 balances, received %d", len(balances))
+	}
+
+	for _, balance := range balances {
+		switch balance.CurrencyName {
+		case "BTC":
+			if balance.TotalValue != 2 || balance.Hold != 0.5 {
+				t.Errorf("Test failed - okex parseSpotUserInfoFunds() unexpected BTC balance %+v", balance)
+			}
+		case "LTC":
+			if balance.TotalValue != 2 || balance.Hold != 2 {
+				t.Errorf("Test failed - okex parseSpotUserInfoFunds() unexpected LTC balance %+v", balance)
+			}
+		default:
+			t.Errorf("Test failed - okex parseSpotUserInfoFunds() unexpected currency %s", balance.CurrencyName)
+		}
+	}
+
+	_, err = parseSpotUserInfoFunds(SpotUserInfo{})
 	if err == nil {
-		t.Error("Test failed - ModifyOrder() error")
+		t.Error("Test failed - okex parseSpotUserInfoFunds() expected error on missing funds")
+	}
+}
+
+func TestSpotAccountBalance(t *testing.T) {
+	t.Parallel()
+	balance, err := spotAccountBalance("okex", SpotAccount{
+		Currency:  "btc",
+		Balance:   2,
+		Hold:      0.5,
+		Available: 1.5,
+	}, "btc")
+	if err != nil {
+		t.Fatal("Test failed - okex spotAccountBalance() error", err)
+	}
+
+	if balance.Exchange != "okex" || balance.Currency != "BTC" ||
+		balance.Available != 1.5 || balance.Hold != 0.5 {
+		t.Errorf("Test failed - okex spotAccountBalance() unexpected balance %+v", balance)
+	}
+
+	_, err = spotAccountBalance("okex", SpotAccount{Currency: "eth"}, "ETH")
+	if err != exchange.ErrCurrencyNotFound {
+		t.Errorf("Test failed - okex spotAccountBalance() expected %s, received %v",
+			exchange.ErrCurrencyNotFound, err)
+	}
+}
+
+func TestGetCurrencyBalance(t *testing.T) {
+	if apiKey != "" || apiSecret != "" {
+		_, err := o.GetCurrencyBalance("BTC")
+		if err != nil && err != exchange.ErrCurrencyNotFound {
+			t.Error("Test Failed - GetCurrencyBalance() error", err)
+		}
+	} else {
+		_, err := o.GetCurrencyBalance("BTC")
+		if err == nil {
+			t.Error("Test Failed - GetCurrencyBalance() error")
+		}
+	}
+}
+
+func TestWsProcessOrderbook(t *testing.T) {
+	var ok OKEX
+	ok.SetDefaults()
+	p := pair.NewCurrencyPairFromString("BTC_USDT")
+
+	snapshot := DepthStreamData{
+		Asks: [][]string{{"6001", "1"}},
+		Bids: [][]string{{"5999", "1"}},
+	}
+
+	err := ok.wsProcessOrderbook(snapshot, p, "ok_sub_spot_btc_usdt_depth")
+	if err != nil {
+		t.Fatal("Test failed - okex wsProcessOrderbook() snapshot error", err)
+	}
+
+	if !ok.Websocket.IsOrderbookSynced(p, "SPOT") {
+		t.Error("Test failed - okex wsProcessOrderbook() orderbook should be synced")
+	}
+
+	diff := DepthStreamData{
+		Bids: [][]string{{"5998", "2"}},
+	}
+
+	err = ok.wsProcessOrderbook(diff, p, "ok_sub_spot_btc_usdt_depth")
+	if err != nil {
+		t.Error("Test failed - okex wsProcessOrderbook() diff error", err)
+	}
+
+	ok.Websocket.Orderbook.FlushCache()
+	err = ok.wsProcessOrderbook(snapshot, p, "ok_sub_spot_btc_usdt_depth")
+	if err != nil {
+		t.Error("Test failed - okex wsProcessOrderbook() resync error", err)
+	}
+
+	err = ok.wsProcessOrderbook(snapshot, p, "ok_sub_spot_btc_usdt_depth_5")
+	if err != nil {
+		t.Error("Test failed - okex wsProcessOrderbook() fixed depth error", err)
+	}
+
+	_, err = wsDepthToItems([][]string{{"1"}})
+	if err == nil {
+		t.Error("Test failed - okex wsDepthToItems() expected error on malformed level")
+	}
+}
+
+func TestModifyOrder(t *testing.T) {
+	_, err := o.ModifyOrder(exchange.ModifyOrder{})
+	if err == nil {
+		t.Error("Test failed - ModifyOrder() error")
+	}
+}
+
+func TestWsParseBalance(t *testing.T) {
+	var ok OKEX
+	ok.SetDefaults()
+
+	data := []byte(`{"info":{"free":{"btc":"1.5","ltc":2},"freezed":{"btc":0.5}}}`)
+	balances, err := ok.wsParseBalance(data)
+	if err != nil {
+		t.Fatal("Test failed - okex wsParseBalance() error", err)
+	}
+
+	if len(balances) != 2 {
+		t.Fatalf("Test failed - okex wsParseBalance() expected 2 balances, received %d", len(balances))
+	}
+
+	for i := range balances {
+		switch balances[i].Currency {
+		case "BTC":
+			if balances[i].Available != 1.5 || balances[i].Frozen != 0.5 {
+				t.Errorf("Test failed - okex wsParseBalance() unexpected BTC balance %+v", balances[i])
+			}
+		case "LTC":
+			if balances[i].Available != 2 || balances[i].Frozen != 0 {
+				t.Errorf("Test failed - okex wsParseBalance() unexpected LTC balance %+v", balances[i])
+			}
+		default:
+			t.Errorf("Test failed - okex wsParseBalance() unexpected currency %s", balances[i].Currency)
+		}
+
+		if balances[i].Snapshot {
+			t.Error("Test failed - okex wsParseBalance() pushed balances should not be snapshots")
+		}
+	}
+
+	_, err = ok.wsParseBalance([]byte(`{"info":{"free":{"btc":"abc"}}}`))
+	if err == nil {
+		t.Error("Test failed - okex wsParseBalance() expected error")
+	}
+}
+
+func TestPairInfo(t *testing.T) {
+	var ok OKEX
+	ok.SetDefaults()
+
+	ok.updatePairInfo([]SpotInstrument{{
+		BaseCurrency:  "LTC",
+		QuoteCurrency: "BTC",
+		MinSize:       0.001,
+		SizeIncrement: 0.000001,
+		TickSize:      0.00001,
+	}})
+
+	p := pair.NewCurrencyPairDelimiter("LTC_BTC", "_")
+	info, err := ok.GetPairInfo(p)
+	if err != nil {
+		t.Fatal("Test failed - okex GetPairInfo() error", err)
+	}
+
+	if info.PricePrecision != 5 || info.AmountPrecision != 6 || info.MinAmount != 0.001 {
+		t.Errorf("Test failed - okex GetPairInfo() unexpected values %+v", info)
+	}
+
+	_, err = ok.GetPairInfo(pair.NewCurrencyPairDelimiter("ETH_BTC", "_"))
+	if err == nil {
+		t.Error("Test failed - okex GetPairInfo() expected error for unknown pair")
+	}
+
+	amount, price, err := ok.applyPairInfo(p, SpotNewOrderRequestTypeBuy, 1.23456789, 0.0123456)
+	if err != nil {
+		t.Fatal("Test failed - okex applyPairInfo() error", err)
+	}
+
+	if amount != 1.234567 || price != 0.01234 {
+		t.Errorf("Test failed - okex applyPairInfo() unexpected rounding amount %v price %v", amount, price)
+	}
+
+	_, _, err = ok.applyPairInfo(p, SpotNewOrderRequestTypeSell, 0.0001, 0.01)
+	if err == nil {
+		t.Error("Test failed - okex applyPairInfo() expected minimum amount error")
+	}
+}
+
+func TestAuthRequestType(t *testing.T) {
+	t.Parallel()
+	if authRequestType(spotTrade) != request.Trade ||
+		authRequestType(spotCancelTrade+".do") != request.Trade ||
+		authRequestType(contractFutureTrade) != request.Trade {
+		t.Error("Test failed - okex authRequestType() trade endpoints not classified as trade")
+	}
+
+	if authRequestType(contractFutureTradeHistory) != request.History {
+		t.Error("Test failed - okex authRequestType() history endpoint not classified as history")
+	}
+
+	if authRequestType(spotUserInfo+".do") != request.Account {
+		t.Error("Test failed - okex authRequestType() userinfo not classified as account")
+	}
+}
+
+func TestSpotDepthValues(t *testing.T) {
+	t.Parallel()
+	v := spotDepthValues(ActualSpotDepthRequestParams{Symbol: "btc_usdt", Size: 50})
+	if v.Get("symbol") != "btc_usdt" || v.Get("size") != "50" {
+		t.Error("Test failed - okex spotDepthValues() incorrect values", v)
+	}
+
+	if _, ok := v["merge"]; ok {
+		t.Error("Test failed - okex spotDepthValues() merge should not be sent for raw depth")
+	}
+
+	v = spotDepthValues(ActualSpotDepthRequestParams{
+		Symbol: "btc_usdt",
+		Size:   50,
+		Merge:  SpotDepthMergeTenth,
+	})
+	if v.Get("merge") != "0.1" {
+		t.Error("Test failed - okex spotDepthValues() merge not set", v)
+	}
+}
+
+func TestSystemStatusFromMaintenance(t *testing.T) {
+	t.Parallel()
+	if systemStatusFromMaintenance(nil) != exchange.SystemStatusOperational {
+		t.Error("Test failed - okex systemStatusFromMaintenance() expected operational without maintenance")
+	}
+
+	maintenance := []SystemMaintenance{
+		{Title: "Completed", Status: SystemMaintenanceCompleted},
+		{Title: "Scheduled", Status: SystemMaintenanceWaiting},
+	}
+	if systemStatusFromMaintenance(maintenance) != exchange.SystemStatusOperational {
+		t.Error("Test failed - okex systemStatusFromMaintenance() expected operational for scheduled maintenance")
+	}
+
+	maintenance = append(maintenance, SystemMaintenance{Title: "Upgrade", Status: SystemMaintenanceInProgress})
+	if systemStatusFromMaintenance(maintenance) != exchange.SystemStatusMaintenance {
+		t.Error("Test failed - okex systemStatusFromMaintenance() expected maintenance")
+	}
+}
+
+func TestWsDefaultChannels(t *testing.T) {
+	var ok OKEX
+	ok.SetDefaults()
+	ok.EnabledPairs = []string{"btc_usdt"}
+
+	channels := ok.wsDefaultChannels()
+	expected := []string{
+		"ok_sub_spot_btc_usdt_ticker",
+		"ok_sub_spot_btc_usdt_depth",
+		"ok_sub_spot_btc_usdt_deals",
+		"ok_sub_spot_btc_usdt_kline_1min",
+	}
+
+	if len(channels) != len(expected) {
+		t.Fatalf("Test failed - okex wsDefaultChannels() expected %v, received %v", expected, channels)
+	}
+
+	for i := range expected {
+		if channels[i] != expected[i] {
+			t.Errorf("Test failed - okex wsDefaultChannels() expected %s, received %s",
+				expected[i], channels[i])
+		}
+	}
+}
+
+func TestWithdrawalFeeEstimate(t *testing.T) {
+	t.Parallel()
+	fees := []WithdrawalFee{
+		{Currency: "BTC", MinFee: "0.0005", MaxFee: "0.01"},
+		{Currency: "USDT-ERC20", MinFee: "2", MaxFee: "20"},
+		{Currency: "USDT-TRC20", MinFee: "0.1", MaxFee: "1"},
+	}
+
+	estimate, err := withdrawalFeeEstimate("usdt", "trc20", fees)
+	if err != nil {
+		t.Fatal("Test failed - okex withdrawalFeeEstimate() error", err)
+	}
+	if estimate.Chain != "TRC20" || estimate.Fee != 0.1 || estimate.MaxFee != 1 {
+		t.Error("Test failed - okex withdrawalFeeEstimate() incorrect estimate", estimate)
+	}
+	if len(estimate.Chains) != 2 {
+		t.Error("Test failed - okex withdrawalFeeEstimate() expected 2 chains, received", estimate.Chains)
+	}
+
+	estimate, err = withdrawalFeeEstimate("USDT", "USDT-ERC20", fees)
+	if err != nil || estimate.Fee != 2 {
+		t.Error("Test failed - okex withdrawalFeeEstimate() chain qualified currency error", err, estimate)
+	}
+
+	_, err = withdrawalFeeEstimate("USDT", "", fees)
+	if err == nil {
+		t.Error("Test failed - okex withdrawalFeeEstimate() expected an error without a chain for a multi chain currency")
+	}
+
+	_, err = withdrawalFeeEstimate("USDT", "OMNI", fees)
+	if err == nil {
+		t.Error("Test failed - okex withdrawalFeeEstimate() expected an error for an unsupported chain")
+	}
+
+	estimate, err = withdrawalFeeEstimate("BTC", "", fees)
+	if err != nil || estimate.Fee != 0.0005 || estimate.Chain != "" {
+		t.Error("Test failed - okex withdrawalFeeEstimate() default chain error", err, estimate)
+	}
+
+	_, err = withdrawalFeeEstimate("LTC", "", fees)
+	if err == nil {
+		t.Error("Test failed - okex withdrawalFeeEstimate() expected an error for an unknown currency")
+	}
+}
+
+func TestDepositAddressForChain(t *testing.T) {
+	t.Parallel()
+	addresses := []DepositAddress{
+		{Address: "0xabc", Currency: "usdt-erc20"},
+		{Address: "Tabc", Currency: "usdt-trc20"},
+		{Address: "1abc", Currency: "btc"},
+	}
+
+	addr, err := depositAddressForChain("USDT", "TRC20", addresses)
+	if err != nil || addr.Address != "Tabc" {
+		t.Error("Test failed - okex depositAddressForChain() error", err, addr)
+	}
+
+	_, err = depositAddressForChain("USDT", "", addresses)
+	if err == nil {
+		t.Error("Test failed - okex depositAddressForChain() expected an error without a chain for a multi chain currency")
+	}
+
+	_, err = depositAddressForChain("USDT", "OMNI", addresses)
+	if err == nil {
+		t.Error("Test failed - okex depositAddressForChain() expected an error for an unsupported chain")
+	}
+
+	addr, err = depositAddressForChain("btc", "", addresses)
+	if err != nil || addr.Address != "1abc" {
+		t.Error("Test failed - okex depositAddressForChain() default chain error", err, addr)
+	}
+}
+
+func TestDepositAddressesByChain(t *testing.T) {
+	t.Parallel()
+	addresses := []DepositAddress{
+		{Address: "0xabc", Currency: "usdt-erc20"},
+		{Address: "Tabc", Currency: "usdt-trc20"},
+		{Address: "1abc", Currency: "usdt", Tag: "1"},
+		{Address: "1def", Currency: "btc"},
+	}
+
+	resp := depositAddressesByChain("USDT", addresses)
+	if len(resp) != 3 {
+		t.Fatal("Test failed - okex depositAddressesByChain() expected 3 addresses, received", resp)
+	}
+
+	if resp[0].Chain != "ERC20" || resp[1].Chain != "TRC20" || resp[1].Address != "Tabc" {
+		t.Error("Test failed - okex depositAddressesByChain() incorrect chains", resp)
+	}
+
+	if resp[2].Chain != "" || resp[2].Tag != "1" {
+		t.Error("Test failed - okex depositAddressesByChain() incorrect default chain", resp[2])
+	}
+}
+
+func TestAvailableChains(t *testing.T) {
+	t.Parallel()
+	currencies := []AccountCurrency{
+		{Currency: "USDT-ERC20", CanDeposit: "1", CanWithdraw: "1", MinWithdrawal: "2"},
+		{Currency: "USDT-TRC20", CanDeposit: "1", CanWithdraw: "0", MinWithdrawal: "1"},
+		{Currency: "BTC", CanDeposit: "1", CanWithdraw: "1", MinWithdrawal: "0.001"},
+	}
+	fees := []WithdrawalFee{
+		{Currency: "usdt-erc20", MinFee: "4", MaxFee: "20"},
+		{Currency: "usdt-trc20", MinFee: "1", MaxFee: "5"},
+	}
+
+	chains, err := availableChains("usdt", currencies, fees)
+	if err != nil {
+		t.Fatal("Test failed - okex availableChains() error", err)
+	}
+
+	if len(chains) != 2 {
+		t.Fatal("Test failed - okex availableChains() expected 2 chains, received", chains)
+	}
+
+	if chains[0].Chain != "ERC20" || !chains[0].WithdrawEnabled || chains[0].WithdrawalFee != 4 ||
+		chains[0].MinWithdrawal != 2 {
+		t.Error("Test failed - okex availableChains() incorrect ERC20 chain", chains[0])
+	}
+
+	if chains[1].Chain != "TRC20" || !chains[1].DepositEnabled || chains[1].WithdrawEnabled ||
+		chains[1].WithdrawalFee != 1 {
+		t.Error("Test failed - okex availableChains() incorrect TRC20 chain", chains[1])
+	}
+
+	chains, err = availableChains("BTC", currencies, nil)
+	if err != nil || len(chains) != 1 || chains[0].Chain != "" || chains[0].WithdrawalFee != 0 {
+		t.Error("Test failed - okex availableChains() incorrect default chain", chains, err)
+	}
+
+	_, err = availableChains("LTC", currencies, fees)
+	if err == nil {
+		t.Error("Test failed - okex availableChains() expected an error for an unknown currency")
+	}
+}
+
+func TestLastTradePrice(t *testing.T) {
+	t.Parallel()
+	_, err := lastTradePrice(nil)
+	if err == nil {
+		t.Error("Test failed - okex lastTradePrice() expected an error without trades")
+	}
+
+	trades := []ActualSpotTradeHistory{
+		{TID: 2, Price: 101},
+		{TID: 3, Price: 102},
+		{TID: 1, Price: 100},
+	}
+	price, err := lastTradePrice(trades)
+	if err != nil || price != 102 {
+		t.Errorf("Test failed - okex lastTradePrice() expected 102, received %f %v", price, err)
+	}
+}
+
+func TestDepthMidPrice(t *testing.T) {
+	t.Parallel()
+	_, err := depthMidPrice(ActualSpotDepth{})
+	if err == nil {
+		t.Error("Test failed - okex depthMidPrice() expected an error for an empty orderbook")
+	}
+
+	var depth ActualSpotDepth
+	depth.Bids = append(depth.Bids, struct {
+		Price  float64
+		Volume float64
+	}{Price: 99}, struct {
+		Price  float64
+		Volume float64
+	}{Price: 98})
+	depth.Asks = append(depth.Asks, struct {
+		Price  float64
+		Volume float64
+	}{Price: 102}, struct {
+		Price  float64
+		Volume float64
+	}{Price: 101})
+
+	price, err := depthMidPrice(depth)
+	if err != nil || price != 100 {
+		t.Errorf("Test failed - okex depthMidPrice() expected 100, received %f %v", price, err)
+	}
+}
+
+func TestIsContractListed(t *testing.T) {
+	t.Parallel()
+	var price ContractPrice
+	if isContractListed(price) {
+		t.Error("Test failed - okex isContractListed() expected an unlisted contract without a contract ID")
+	}
+
+	price.Ticker.ContractID = 201812280000013
+	if !isContractListed(price) {
+		t.Error("Test failed - okex isContractListed() expected a listed contract")
+	}
+}
+
+func TestContractDepthToOrderbook(t *testing.T) {
+	t.Parallel()
+	type level = struct {
+		Price  float64
+		Volume float64
+	}
+	depth := ActualContractDepth{
+		Asks: []level{{Price: 103, Volume: 1}, {Price: 102, Volume: 2}, {Price: 101, Volume: 3}},
+		Bids: []level{{Price: 100, Volume: 4}, {Price: 99, Volume: 5}},
+	}
+
+	ob := contractDepthToOrderbook(depth)
+	if len(ob.Asks) != 3 || len(ob.Bids) != 2 {
+		t.Fatal("Test failed - okex contractDepthToOrderbook() incorrect depth", ob)
+	}
+
+	if ob.Asks[0].Price != 101 || ob.Asks[0].Amount != 3 {
+		t.Error("Test failed - okex contractDepthToOrderbook() expected the lowest ask first", ob.Asks)
+	}
+
+	if ob.Bids[0].Price != 100 || ob.Bids[0].Amount != 4 {
+		t.Error("Test failed - okex contractDepthToOrderbook() expected the highest bid first", ob.Bids)
+	}
+}
+
+func TestParseSubAccountBalance(t *testing.T) {
+	var resp struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	err := common.JSONDecode([]byte(`{"data":{
+		"sub_account":"strategy",
+		"asset_valuation":"1.5",
+		"account_type:spot":[
+			{"balance":"2","available":"1.5","currency":"BTC","hold":"0.5","max_withdraw":"1.5"},
+			{"balance":"100","available":"100","currency":"USDT","hold":"0","max_withdraw":"100"}],
+		"account_type:funding":[
+			{"balance":"1","available":"1","currency":"btc","hold":"0","max_withdraw":"1"}]}}`), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	balance, err := parseSubAccountBalance(resp.Data)
+	if err != nil {
+		t.Fatal("Test failed - okex parseSubAccountBalance() error", err)
+	}
+
+	if balance.SubAccount != "strategy" || balance.AssetValuation != 1.5 ||
+		len(balance.Accounts["spot"]) != 2 || len(balance.Accounts["funding"]) != 1 {
+		t.Errorf("Test failed - okex parseSubAccountBalance() unexpected balance %+v", balance)
+	}
+
+	info := o.subAccountInfo(balance)
+	if len(info.Currencies) != 2 {
+		t.Fatalf("Test failed - okex subAccountInfo() expected 2 currencies, received %d",
+			len(info.Currencies))
+	}
+
+	if info.Currencies[0].CurrencyName != "BTC" || info.Currencies[0].TotalValue != 3 ||
+		info.Currencies[0].Hold != 0.5 {
+		t.Errorf("Test failed - okex subAccountInfo() unexpected BTC total %+v", info.Currencies[0])
+	}
+}
+
+func TestSubAccountTransfer(t *testing.T) {
+	_, err := o.SubAccountTransfer("btc", 1, "", "")
+	if err == nil {
+		t.Error("Test failed - okex SubAccountTransfer() expected error without a sub account")
+	}
+
+	_, err = o.SubAccountTransfer("btc", 1, "a", "b")
+	if err == nil {
+		t.Error("Test failed - okex SubAccountTransfer() expected error between sub accounts")
+	}
+
+	_, err = o.SubAccountTransfer("btc", 0, "", "a")
+	if err == nil {
+		t.Error("Test failed - okex SubAccountTransfer() expected error with a zero amount")
+	}
+
+	if o.APIKey == "" || o.APISecret == "" || !canManipulateRealOrders {
+		t.Skip()
+	}
+
+	_, err = o.SubAccountTransfer("btc", 0.001, "", "a")
+	if err != nil {
+		t.Error("Test failed - okex SubAccountTransfer() error", err)
+	}
+}
+
+func TestGetAccountsInfo(t *testing.T) {
+	if o.APIKey == "" || o.APISecret == "" {
+		t.Skip()
+	}
+
+	_, err := o.GetAccountsInfo()
+	if err != nil {
+		t.Error("Test failed - okex GetAccountsInfo() error", err)
+	}
+}
+
+func TestCombineAccountTypes(t *testing.T) {
+	t.Parallel()
+	info, err := o.combineAccountTypes(map[string]func() ([]exchange.AccountCurrencyInfo, error){
+		AccountTypeSpot: func() ([]exchange.AccountCurrencyInfo, error) {
+			return []exchange.AccountCurrencyInfo{
+				{CurrencyName: "USDT", TotalValue: 100},
+				{CurrencyName: "BTC", TotalValue: 1, Hold: 0.5},
+			}, nil
+		},
+		AccountTypeFutures: func() ([]exchange.AccountCurrencyInfo, error) {
+			return futuresAccountBalances(FuturesUserInfo{Info: map[string]Info{
+				"btc": {AccountRights: 2, KeepDeposit: 0.2},
+				"ltc": {},
+			}}), nil
+		},
+		AccountTypeSwap: func() ([]exchange.AccountCurrencyInfo, error) {
+			return nil, errors.New("swap account unavailable")
+		},
+	})
+	if err != nil {
+		t.Fatal("Test failed - okex combineAccountTypes() error", err)
+	}
+
+	expected := []exchange.AccountCurrencyInfo{
+		{CurrencyName: "BTC", TotalValue: 2, Hold: 0.2, AccountType: AccountTypeFutures},
+		{CurrencyName: "BTC", TotalValue: 1, Hold: 0.5, AccountType: AccountTypeSpot},
+		{CurrencyName: "USDT", TotalValue: 100, AccountType: AccountTypeSpot},
+	}
+	if len(info.Currencies) != len(expected) {
+		t.Fatalf("Test failed - okex combineAccountTypes() expected %d balances, received %+v",
+			len(expected), info.Currencies)
+	}
+	for x := range expected {
+		if info.Currencies[x] != expected[x] {
+			t.Errorf("Test failed - okex combineAccountTypes() expected %+v, received %+v",
+				expected[x], info.Currencies[x])
+		}
+	}
+
+	_, err = o.combineAccountTypes(map[string]func() ([]exchange.AccountCurrencyInfo, error){
+		AccountTypeSwap: func() ([]exchange.AccountCurrencyInfo, error) {
+			return nil, errors.New("swap account unavailable")
+		},
+	})
+	if err == nil {
+		t.Error("Test failed - okex combineAccountTypes() expected error when every account type fails")
+	}
+}
+
+func TestSwapAccountBalances(t *testing.T) {
+	t.Parallel()
+	balances := swapAccountBalances([]SwapAccount{
+		{InstrumentID: "BTC-USD-SWAP", Equity: 1, Margin: 0.1},
+		{InstrumentID: "ETH-USDT-SWAP", Equity: 50, Margin: 5, MarginFrozen: 1},
+		{InstrumentID: "BTC-USDT-SWAP", Equity: 25},
+		{InstrumentID: "LTC-USD-SWAP"},
+	})
+
+	if len(balances) != 2 || balances[0].CurrencyName != "BTC" || balances[0].Hold != 0.1 ||
+		balances[1].CurrencyName != "USDT" || balances[1].TotalValue != 75 || balances[1].Hold != 6 {
+		t.Errorf("Test failed - okex swapAccountBalances() unexpected balances %+v", balances)
+	}
+}
+
+func TestGetAllAccountsInfo(t *testing.T) {
+	if o.APIKey == "" || o.APISecret == "" {
+		t.Skip()
+	}
+
+	_, err := o.GetAllAccountsInfo()
+	if err != nil {
+		t.Error("Test failed - okex GetAllAccountsInfo() error", err)
+	}
+}
+
+func TestWsParseChannelAck(t *testing.T) {
+	t.Parallel()
+	channel, err := wsParseChannelAck([]byte(`{"result":true,"channel":"ok_sub_spot_btc_usdt_ticker"}`))
+	if err != nil || channel != "ok_sub_spot_btc_usdt_ticker" {
+		t.Errorf("Test failed - okex wsParseChannelAck() unexpected result %s %v", channel, err)
+	}
+
+	channel, err = wsParseChannelAck([]byte(`{"result":false,"channel":"ok_sub_spot_abc_usdt_ticker","error_code":20116}`))
+	if err == nil || channel != "ok_sub_spot_abc_usdt_ticker" {
+		t.Errorf("Test failed - okex wsParseChannelAck() expected a rejection, received %s %v", channel, err)
+	}
+
+	_, err = wsParseChannelAck([]byte(`{"result":true}`))
+	if err == nil {
+		t.Error("Test failed - okex wsParseChannelAck() expected error without a channel")
+	}
+}
+
+func TestSpotOrdersVolume(t *testing.T) {
+	t.Parallel()
+	var orders []SpotOrder
+	err := common.JSONDecode([]byte(`[
+		{"order_id":"3","filled_notional":"150.5","timestamp":"2018-10-03T00:00:00.000Z"},
+		{"order_id":"2","filled_notional":"0","timestamp":"2018-10-02T00:00:00.000Z"},
+		{"order_id":"1","filled_notional":"1000","timestamp":"2018-08-01T00:00:00.000Z"}
+	]`), &orders)
+	if err != nil {
+		t.Fatal("Test failed - okex SpotOrder decode error", err)
+	}
+
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	volume, done := spotOrdersVolume(orders, start)
+	if volume != 150.5 || !done {
+		t.Errorf("Test failed - okex spotOrdersVolume() expected 150.5 and done, received %v %v", volume, done)
+	}
+
+	volume, done = spotOrdersVolume(orders[:2], start)
+	if volume != 150.5 || done {
+		t.Errorf("Test failed - okex spotOrdersVolume() expected 150.5 and more pages, received %v %v", volume, done)
+	}
+}
+
+func TestConvertWithdrawalStatus(t *testing.T) {
+	var history []WithdrawalHistory
+	err := common.JSONDecode([]byte(`[
+		{"withdrawal_id":"1","currency":"btc","amount":"0.5","status":"2"},
+		{"withdrawal_id":"2","currency":"btc","amount":"1","status":"-2"},
+		{"withdrawal_id":"3","currency":"btc","amount":"1","status":"1"},
+		{"withdrawal_id":"4","currency":"btc","amount":"1","status":"4"}
+	]`), &history)
+	if err != nil {
+		t.Fatal("Test failed - okex WithdrawalHistory decode error", err)
+	}
+
+	expected := []exchange.WithdrawalStatus{
+		exchange.WithdrawalCompleted,
+		exchange.WithdrawalFailed,
+		exchange.WithdrawalProcessing,
+		exchange.WithdrawalPending,
+	}
+
+	for i := range history {
+		if status := convertWithdrawalStatus(history[i].Status); status != expected[i] {
+			t.Errorf("Test failed - okex convertWithdrawalStatus() %s expected %s, received %s",
+				history[i].WithdrawalID, expected[i], status)
+		}
 	}
 }
@@ -1,7 +1,13 @@
 package okex
 
-import "encoding/json"
-import "github.com/thrasher-/gocryptotrader/currency/symbol"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
 
 // SpotInstrument stores the spot instrument info
 type SpotInstrument struct {
@@ -17,6 +23,44 @@ type SpotInstrument struct {
 	TickSize       float64 `json:"tick_size,string"`
 }
 
+// SpotOrder is a v3 spot order, FilledNotional is the value filled in the
+// quote currency
+type SpotOrder struct {
+	OrderID        string    `json:"order_id"`
+	ClientOID      string    `json:"client_oid"`
+	InstrumentID   string    `json:"instrument_id"`
+	Side           string    `json:"side"`
+	Type           string    `json:"type"`
+	FilledSize     float64   `json:"filled_size,string"`
+	FilledNotional float64   `json:"filled_notional,string"`
+	State          string    `json:"state"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// PairInfo holds the trading rules for a spot currency pair
+type PairInfo struct {
+	TickSize        float64
+	SizeIncrement   float64
+	MinAmount       float64
+	PricePrecision  int
+	AmountPrecision int
+}
+
+// SpotAllTicker stores a single spot instrument ticker returned by the all
+// tickers endpoint
+type SpotAllTicker struct {
+	InstrumentID   string  `json:"instrument_id"`
+	Last           float64 `json:"last,string"`
+	BestBid        float64 `json:"best_bid,string"`
+	BestAsk        float64 `json:"best_ask,string"`
+	Open24H        float64 `json:"open_24h,string"`
+	High24H        float64 `json:"high_24h,string"`
+	Low24H         float64 `json:"low_24h,string"`
+	BaseVolume24H  float64 `json:"base_volume_24h,string"`
+	QuoteVolume24H float64 `json:"quote_volume_24h,string"`
+	Timestamp      string  `json:"timestamp"`
+}
+
 // ContractPrice holds date and ticker price price for contracts.
 type ContractPrice struct {
 	Date   string `json:"date"`
@@ -34,12 +78,41 @@ type ContractPrice struct {
 	Error  interface{} `json:"error_code"`
 }
 
+// ContractPriceLimit holds the upper and lower price limits for a contract
+type ContractPriceLimit struct {
+	High       float64     `json:"high"`
+	Low        float64     `json:"low"`
+	UsdCnyRate float64     `json:"usdCnyRate"`
+	Error      interface{} `json:"error_code"`
+}
+
 // MultiStreamData contains raw data from okex
 type MultiStreamData struct {
 	Channel string          `json:"channel"`
 	Data    json.RawMessage `json:"data"`
 }
 
+// WsChannelAck is the response to a websocket addChannel request
+type WsChannelAck struct {
+	Result    bool   `json:"result"`
+	Channel   string `json:"channel"`
+	ErrorMsg  string `json:"error_msg"`
+	ErrorCode int64  `json:"error_code"`
+}
+
+// WsLoginResponse is returned after a websocket login request
+type WsLoginResponse struct {
+	Result bool `json:"result"`
+}
+
+// WsBalanceData holds the balances pushed over the private balance channel
+type WsBalanceData struct {
+	Info struct {
+		Free    map[string]json.Number `json:"free"`
+		Freezed map[string]json.Number `json:"freezed"`
+	} `json:"info"`
+}
+
 // TokenOrdersResponse is returned after a request for all Token Orders
 type TokenOrdersResponse struct {
 	Result bool         `json:"result"`
@@ -105,6 +178,44 @@ type ActualContractDepth struct {
 	}
 }
 
+// IndexPrice holds the futures index price of an underlying pair
+type IndexPrice struct {
+	Pair      pair.CurrencyPair
+	Index     float64
+	Timestamp time.Time // time the index was retrieved
+}
+
+// ContractOrderResponse is returned after placing a contract order. OrderID is
+// decoded as a json.Number as order IDs can exceed the integers a float64
+// holds exactly
+type ContractOrderResponse struct {
+	Result    bool        `json:"result"`
+	OrderID   json.Number `json:"order_id"`
+	ErrorCode interface{} `json:"error_code"`
+}
+
+// ContractHoldings holds the number of open contracts of a futures contract.
+// OKEX reports a single amount and every open contract has a long and a short
+// side, so LongHoldings and ShortHoldings are both that amount. The endpoint
+// doesn't report force closed or available contracts
+type ContractHoldings struct {
+	ContractName  string
+	LongHoldings  float64
+	ShortHoldings float64
+}
+
+// OpenInterest holds the open interest of a futures contract. Every open
+// contract has a long and a short side, so Amount is both the long and the
+// short open interest, OKEX doesn't report how positions are split between
+// accounts
+type OpenInterest struct {
+	Symbol       string
+	ContractType string
+	ContractName string
+	Amount       float64
+	Timestamp    time.Time // time the open interest was retrieved
+}
+
 // ActualContractTradeHistory holds contract trade history
 type ActualContractTradeHistory struct {
 	Amount   float64 `json:"amount"`
@@ -115,6 +226,19 @@ type ActualContractTradeHistory struct {
 	Type     string  `json:"buy"`
 }
 
+// FuturesTradeHistory holds a single futures trade fill returned from the
+// trade history endpoint
+type FuturesTradeHistory struct {
+	Amount    float64   `json:"amount"`
+	DateInMS  int64     `json:"date"`
+	Price     float64   `json:"price"`
+	Fee       float64   `json:"fee"`
+	OrderID   int64     `json:"order_id"`
+	TID       int64     `json:"tid"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"-"`
+}
+
 // CandleStickData holds candlestick data
 type CandleStickData struct {
 	Timestamp float64 `json:"timestamp"`
@@ -169,6 +293,94 @@ type FuturePosition struct {
 	Holding               []HoldData `json:"holding"`
 }
 
+// MarginMode is the margin mode of a futures position
+type MarginMode string
+
+// Futures margin modes, cross margin positions share the account's equity as
+// margin while isolated margin positions only risk the margin assigned to them
+const (
+	CrossMargin    MarginMode = "crossed"
+	IsolatedMargin MarginMode = "fixed"
+)
+
+// FuturesPositionDetail holds an open futures position used to estimate its
+// liquidation price. Contracts is the number of contracts held, each worth the
+// contract face value in USD. For isolated margin Margin is the margin held by
+// the position, the initial margin from Leverage is used when it is zero. For
+// cross margin Margin is the account equity in the contract's settlement
+// currency. A zero MaintenanceMarginRatio uses the lowest OKEX maintenance
+// margin tier
+type FuturesPositionDetail struct {
+	Symbol                 string // e.g. btc_usd
+	Long                   bool
+	EntryPrice             float64
+	Contracts              float64
+	Leverage               float64
+	MarginMode             MarginMode
+	Margin                 float64
+	MaintenanceMarginRatio float64
+}
+
+// Account types labelling the balances returned by GetAllAccountsInfo
+const (
+	AccountTypeSpot    = "spot"
+	AccountTypeFutures = "futures"
+	AccountTypeSwap    = "swap"
+)
+
+// FuturesUserInfo holds the cross margin futures account of each currency
+type FuturesUserInfo struct {
+	Info      map[string]Info `json:"info"`
+	Result    bool            `json:"result"`
+	ErrorCode float64         `json:"error_code"`
+}
+
+// SwapAccount holds the perpetual swap account of a single contract, balances
+// are in the contract's settlement currency
+type SwapAccount struct {
+	InstrumentID      string  `json:"instrument_id"`
+	Equity            float64 `json:"equity,string"`
+	TotalAvailBalance float64 `json:"total_avail_balance,string"`
+	Margin            float64 `json:"margin,string"`
+	MarginFrozen      float64 `json:"margin_frozen,string"`
+	RealizedPNL       float64 `json:"realized_pnl,string"`
+	UnrealizedPNL     float64 `json:"unrealized_pnl,string"`
+	MarginMode        string  `json:"margin_mode"`
+}
+
+// SwapFundingRate holds the funding rate of a perpetual swap contract,
+// FundingRate is settled at FundingTime and EstimatedRate is the estimate of
+// the rate settled at the following funding time
+type SwapFundingRate struct {
+	InstrumentID   string    `json:"instrument_id"`
+	FundingTime    time.Time `json:"funding_time"`
+	FundingRate    float64   `json:"funding_rate,string"`
+	EstimatedRate  float64   `json:"estimated_rate,string"`
+	InterestRate   float64   `json:"interest_rate,string"`
+	SettlementTime time.Time `json:"settlement_time"`
+}
+
+// SwapPositionDetail holds an open perpetual swap position used to calculate
+// its funding carry. Value is the position value funding is charged on, in
+// the contract's settlement currency for coin margined swaps e.g.
+// contracts * face value / mark price
+type SwapPositionDetail struct {
+	InstrumentID string // e.g. BTC-USD-SWAP
+	Long         bool
+	Value        float64
+}
+
+// SwapCarry is the funding of a perpetual swap position, Payment is in the
+// same currency as the position value. Payment and AnnualisedRate are
+// positive when the position receives funding and negative when it pays
+type SwapCarry struct {
+	InstrumentID   string
+	FundingRate    float64
+	FundingTime    time.Time
+	Payment        float64
+	AnnualisedRate float64
+}
+
 // FutureTradeHistory will contain futures trade data
 type FutureTradeHistory struct {
 	Amount float64 `json:"amount"`
@@ -205,10 +417,21 @@ type SpotDepth struct {
 
 // ActualSpotDepthRequestParams represents Klines request data.
 type ActualSpotDepthRequestParams struct {
-	Symbol string `json:"symbol"` // Symbol; example ltc_btc
-	Size   int    `json:"size"`   // value: 1-200
+	Symbol string         `json:"symbol"` // Symbol; example ltc_btc
+	Size   int            `json:"size"`   // value: 1-200
+	Merge  SpotDepthMerge `json:"merge"`  // price step to aggregate levels by; when none, raw depth is returned
 }
 
+// SpotDepthMerge is the price step used to aggregate spot depth levels
+type SpotDepthMerge string
+
+// vars for SpotDepthMerge
+var (
+	SpotDepthMergeNone  = SpotDepthMerge("")
+	SpotDepthMergeOne   = SpotDepthMerge("1")
+	SpotDepthMergeTenth = SpotDepthMerge("0.1")
+)
+
 // ActualSpotDepth better manipulated structure to return
 type ActualSpotDepth struct {
 	Asks []struct {
@@ -245,10 +468,11 @@ type SpotUserInfo struct {
 
 // SpotNewOrderRequestParams holds the params for making a new spot order
 type SpotNewOrderRequestParams struct {
-	Amount float64                 `json:"amount"` // Order quantity
-	Price  float64                 `json:"price"`  // Order price
-	Symbol string                  `json:"symbol"` // Symbol; example btc_usdt, eth_btc......
-	Type   SpotNewOrderRequestType `json:"type"`   // Order type (see below)
+	Amount        float64                 `json:"amount"`     // Order quantity
+	Price         float64                 `json:"price"`      // Order price
+	Symbol        string                  `json:"symbol"`     // Symbol; example btc_usdt, eth_btc......
+	Type          SpotNewOrderRequestType `json:"type"`       // Order type (see below)
+	ClientOrderID string                  `json:"client_oid"` // Client supplied order ID used to deduplicate orders, optional
 }
 
 // SpotNewOrderRequestType order type
@@ -274,6 +498,7 @@ type KlinesRequestParams struct {
 	Type   TimeInterval // Kline data time interval; 1min, 5min, 15min......
 	Size   int          // Size; [1-2000]
 	Since  int64        // Since timestamp, return data after the specified timestamp (for example, 1417536000000)
+	Until  int64        // Until timestamp, when set the range from Since is paged through and Size caps the total
 }
 
 // TimeInterval represents interval enum.
@@ -287,6 +512,7 @@ var (
 	TimeIntervalFifteenMinutes = TimeInterval("15min")
 	TimeIntervalThirtyMinutes  = TimeInterval("30min")
 	TimeIntervalHour           = TimeInterval("1hour")
+	TimeIntervalTwoHours       = TimeInterval("2hour")
 	TimeIntervalFourHours      = TimeInterval("4hour")
 	TimeIntervalSixHours       = TimeInterval("6hour")
 	TimeIntervalTwelveHours    = TimeInterval("12hour")
@@ -295,6 +521,24 @@ var (
 	TimeIntervalWeek           = TimeInterval("1week")
 )
 
+// klineIntervals is the single source of truth for the kline intervals
+// accepted by both the spot and contract APIs
+var klineIntervals = []TimeInterval{
+	TimeIntervalMinute,
+	TimeIntervalThreeMinutes,
+	TimeIntervalFiveMinutes,
+	TimeIntervalFifteenMinutes,
+	TimeIntervalThirtyMinutes,
+	TimeIntervalHour,
+	TimeIntervalTwoHours,
+	TimeIntervalFourHours,
+	TimeIntervalSixHours,
+	TimeIntervalTwelveHours,
+	TimeIntervalDay,
+	TimeIntervalThreeDays,
+	TimeIntervalWeek,
+}
+
 // WithdrawalFees the large list of predefined withdrawal fees
 // Prone to change, using highest value
 var WithdrawalFees = map[string]float64{
@@ -463,3 +707,160 @@ type Balance struct {
 		} `json:"funds"`
 	} `json:"info"`
 }
+
+// WithdrawalFee holds the withdrawal fee range of a currency on a single
+// chain e.g. "USDT-ERC20"
+type WithdrawalFee struct {
+	Currency string `json:"currency"`
+	MinFee   string `json:"min_fee"`
+	MaxFee   string `json:"max_fee"`
+}
+
+// AccountCurrency holds whether a currency can be deposited and withdrawn
+type AccountCurrency struct {
+	Currency      string `json:"currency"`
+	Name          string `json:"name"`
+	CanDeposit    string `json:"can_deposit"`
+	CanWithdraw   string `json:"can_withdraw"`
+	MinWithdrawal string `json:"min_withdrawal"`
+}
+
+// WithdrawalFeeEstimate holds the withdrawal fee of a currency on the selected
+// chain and the chains the currency can be withdrawn on
+type WithdrawalFeeEstimate struct {
+	Currency string
+	Chain    string
+	Fee      float64
+	MaxFee   float64
+	Chains   []string
+}
+
+// DepositAddress holds a deposit address of a currency on a single chain
+type DepositAddress struct {
+	Address   string `json:"address"`
+	Tag       string `json:"tag"`
+	PaymentID string `json:"payment_id"`
+	Currency  string `json:"currency"`
+}
+
+// WithdrawRequest holds the parameters of a cryptocurrency withdrawal
+type WithdrawRequest struct {
+	Currency      string
+	Chain         string
+	Amount        float64
+	Address       string
+	TradePassword string
+	Fee           float64
+}
+
+// WithdrawResponse holds the result of a withdrawal request
+type WithdrawResponse struct {
+	Amount       float64 `json:"amount,string"`
+	WithdrawalID string  `json:"withdrawal_id"`
+	Currency     string  `json:"currency"`
+	Result       bool    `json:"result"`
+}
+
+// Withdrawal statuses returned by the withdrawal history
+const (
+	WithdrawalStatusPendingCancel        = "-3"
+	WithdrawalStatusCancelled            = "-2"
+	WithdrawalStatusFailed               = "-1"
+	WithdrawalStatusPending              = "0"
+	WithdrawalStatusSending              = "1"
+	WithdrawalStatusSent                 = "2"
+	WithdrawalStatusEmailConfirmation    = "3"
+	WithdrawalStatusManualConfirmation   = "4"
+	WithdrawalStatusIdentityConfirmation = "5"
+)
+
+// WithdrawalHistory holds a withdrawal returned by the withdrawal history
+type WithdrawalHistory struct {
+	WithdrawalID string  `json:"withdrawal_id"`
+	Currency     string  `json:"currency"`
+	Amount       float64 `json:"amount,string"`
+	Fee          string  `json:"fee"`
+	From         string  `json:"from"`
+	To           string  `json:"to"`
+	Tag          string  `json:"tag"`
+	TxID         string  `json:"txid"`
+	Timestamp    string  `json:"timestamp"`
+	Status       string  `json:"status"`
+}
+
+// SpotAccount holds the spot account balance of a single currency
+type SpotAccount struct {
+	ID        string  `json:"id"`
+	Currency  string  `json:"currency"`
+	Balance   float64 `json:"balance,string"`
+	Hold      float64 `json:"hold,string"`
+	Available float64 `json:"available,string"`
+}
+
+// SystemMaintenance holds a scheduled or ongoing system maintenance window
+type SystemMaintenance struct {
+	Title       string `json:"title"`
+	Status      string `json:"status"` // 0: waiting, 1: in progress, 2: completed
+	StartTime   string `json:"start_time"`
+	EndTime     string `json:"end_time"`
+	Href        string `json:"href"`
+	ServiceType string `json:"service_type"`
+}
+
+// vars for SystemMaintenance statuses
+var (
+	SystemMaintenanceWaiting    = "0"
+	SystemMaintenanceInProgress = "1"
+	SystemMaintenanceCompleted  = "2"
+)
+
+// SubAccount holds a sub account created under the main account
+type SubAccount struct {
+	Name      string `json:"subAcct"`
+	Label     string `json:"label"`
+	Enabled   bool   `json:"enable"`
+	Timestamp string `json:"ts"`
+}
+
+// subAccountListResponse is the v5 envelope of the sub account list
+type subAccountListResponse struct {
+	Code string       `json:"code"`
+	Msg  string       `json:"msg"`
+	Data []SubAccount `json:"data"`
+}
+
+// SubAccountCurrencyBalance holds a sub account's balance of a single currency
+// in one of its accounts
+type SubAccountCurrencyBalance struct {
+	Currency    string  `json:"currency"`
+	Balance     float64 `json:"balance,string"`
+	Available   float64 `json:"available,string"`
+	Hold        float64 `json:"hold,string"`
+	MaxWithdraw float64 `json:"max_withdraw,string"`
+}
+
+// SubAccountBalance holds a sub account's balances keyed by account type e.g.
+// "spot" or "funding", along with the sub account's BTC valuation
+type SubAccountBalance struct {
+	SubAccount     string
+	AssetValuation float64
+	Accounts       map[string][]SubAccountCurrencyBalance
+}
+
+// SubAccountTransferResponse holds the result of a transfer between the main
+// account and a sub account
+type SubAccountTransferResponse struct {
+	TransferID string  `json:"transfer_id"`
+	Currency   string  `json:"currency"`
+	From       string  `json:"from"`
+	To         string  `json:"to"`
+	Amount     float64 `json:"amount,string"`
+	Result     bool    `json:"result"`
+}
+
+// AccountsInfo holds the main account's balances alongside each sub
+// account's, keyed by sub account name
+type AccountsInfo struct {
+	Main        exchange.AccountInfo
+	SubAccounts map[string]exchange.AccountInfo
+}
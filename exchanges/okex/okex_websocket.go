@@ -1,11 +1,9 @@
 package okex
 
 import (
-	"bytes"
-	"compress/flate"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
@@ -17,17 +15,53 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 )
 
 const (
 	okexDefaultWebsocketURL = "wss://real.okex.com:10440/websocket/okexapi"
+
+	// okexWsOrderbookDepthIncremental subscribes to the incremental depth
+	// channel which delivers the full 200 level book
+	okexWsOrderbookDepthIncremental = 200
+	okexWsOrderbookDepthDefault     = 20
 )
 
+// okexWsOrderbookDepths are the orderbook depths supported by the websocket
+var okexWsOrderbookDepths = []int{5, 10, 20, okexWsOrderbookDepthIncremental}
+
+// wsDepthChannel returns the depth channel variant for the configured
+// orderbook depth
+func (o *OKEX) wsDepthChannel(symbol string) string {
+	depth := o.Websocket.GetOrderbookDepth()
+	if depth == 0 || depth == okexWsOrderbookDepthIncremental {
+		return fmt.Sprintf("ok_sub_spot_%s_depth", symbol)
+	}
+	return fmt.Sprintf("ok_sub_spot_%s_depth_%d", symbol, depth)
+}
+
+// wsSymbol returns the websocket channel symbol for an enabled pair
+func wsSymbol(pair string) string {
+	// ----------- deprecate when usd pairs are upgraded to usdt ----------
+	checkSymbol := common.SplitStrings(pair, "_")
+	for i := range checkSymbol {
+		if common.StringContains(checkSymbol[i], "usdt") {
+			break
+		}
+		if common.StringContains(checkSymbol[i], "usd") {
+			checkSymbol[i] = "usdt"
+		}
+	}
+
+	return common.JoinStrings(checkSymbol, "_")
+	// ----------- deprecate when usd pairs are upgraded to usdt ----------
+}
+
 func (o *OKEX) writeToWebsocket(message string) error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
-	return o.WebsocketConn.WriteMessage(websocket.TextMessage, []byte(message))
+	return o.Websocket.SendMessage(o.WebsocketConn, websocket.TextMessage, []byte(message))
 }
 
 // WsConnect initiates a websocket connection
@@ -62,60 +96,232 @@ func (o *OKEX) WsConnect() error {
 
 	err = o.WsSubscribe()
 	if err != nil {
+		o.wsTeardown()
 		return fmt.Errorf("Error: Could not subscribe to the OKEX websocket %s",
 			err)
 	}
 
+	if o.AuthenticatedAPISupport {
+		// The account channel is subscribed once the login response is
+		// received
+		err = o.wsLogin()
+		if err != nil {
+			o.wsTeardown()
+			return fmt.Errorf("Error: Could not login to the OKEX websocket %s",
+				err)
+		}
+	}
+
 	return nil
 }
 
-// WsSubscribe subscribes to the websocket channels
-func (o *OKEX) WsSubscribe() error {
-	myEnabledSubscriptionChannels := []string{}
+// wsTeardown stops the routines started by WsConnect when the connection
+// fails, so they aren't left running while the connection is retried
+func (o *OKEX) wsTeardown() {
+	close(o.Websocket.ShutdownC)
+	// unblock the pending read so the connection is closed by WsReadData
+	err := o.WebsocketConn.SetReadDeadline(time.Now())
+	if err != nil {
+		log.Printf("%s websocket unable to stop reading: %s\n", o.Name, err)
+	}
+	o.Websocket.Wg.Wait()
+}
+
+// wsLogin sends a login request so private channels can be subscribed to
+func (o *OKEX) wsLogin() error {
+	values := url.Values{}
+	values.Set("api_key", o.APIKey)
+	hasher := common.GetMD5([]byte(values.Encode() + "&secret_key=" + o.APISecret))
+
+	return o.writeToWebsocket(
+		fmt.Sprintf("{'event':'login','parameters':{'api_key':'%s','sign':'%s'}}",
+			o.APIKey,
+			common.StringToUpper(common.HexEncodeToString(hasher))))
+}
+
+// wsSubscribeAccount emits a balance snapshot fetched over REST then
+// subscribes to the balance channel for each enabled pair so that only
+// changes are pushed afterwards. This runs after every login, so balances are
+// reconciled against REST whenever the websocket reconnects
+func (o *OKEX) wsSubscribeAccount() error {
+	userInfo, err := o.GetUserInfo()
+	if err != nil {
+		return err
+	}
+
+	balances, err := parseSpotUserInfoFunds(userInfo)
+	if err != nil {
+		return err
+	}
+
+	for i := range balances {
+		o.Websocket.DataHandler <- exchange.WebsocketBalanceUpdate{
+			Timestamp: time.Now(),
+			Exchange:  o.GetName(),
+			Currency:  balances[i].CurrencyName,
+			Available: balances[i].TotalValue - balances[i].Hold,
+			Frozen:    balances[i].Hold,
+			Snapshot:  true,
+		}
+	}
 
 	for _, pair := range o.EnabledPairs {
+		err = o.WsAddChannel(fmt.Sprintf("ok_sub_spot_%s_balance", wsSymbol(pair)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		// ----------- deprecate when usd pairs are upgraded to usdt ----------
-		checkSymbol := common.SplitStrings(pair, "_")
-		for i := range checkSymbol {
-			if common.StringContains(checkSymbol[i], "usdt") {
-				break
-			}
-			if common.StringContains(checkSymbol[i], "usd") {
-				checkSymbol[i] = "usdt"
+// wsParseBalance converts balance channel data into balance updates
+func (o *OKEX) wsParseBalance(data json.RawMessage) ([]exchange.WebsocketBalanceUpdate, error) {
+	var balance WsBalanceData
+	err := common.JSONDecode(data, &balance)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]*exchange.WebsocketBalanceUpdate)
+	getUpdate := func(currency string) *exchange.WebsocketBalanceUpdate {
+		if _, ok := updates[currency]; !ok {
+			updates[currency] = &exchange.WebsocketBalanceUpdate{
+				Timestamp: time.Now(),
+				Exchange:  o.GetName(),
+				Currency:  common.StringToUpper(currency),
 			}
 		}
+		return updates[currency]
+	}
 
-		symbolRedone := common.JoinStrings(checkSymbol, "_")
-		// ----------- deprecate when usd pairs are upgraded to usdt ----------
+	for currency, amount := range balance.Info.Free {
+		available, err := amount.Float64()
+		if err != nil {
+			return nil, err
+		}
+		getUpdate(currency).Available = available
+	}
 
-		myEnabledSubscriptionChannels = append(myEnabledSubscriptionChannels,
-			fmt.Sprintf("{'event':'addChannel','channel':'ok_sub_spot_%s_ticker'}",
-				symbolRedone))
+	for currency, amount := range balance.Info.Freezed {
+		frozen, err := amount.Float64()
+		if err != nil {
+			return nil, err
+		}
+		getUpdate(currency).Frozen = frozen
+	}
 
-		myEnabledSubscriptionChannels = append(myEnabledSubscriptionChannels,
-			fmt.Sprintf("{'event':'addChannel','channel':'ok_sub_spot_%s_depth'}",
-				symbolRedone))
+	var result []exchange.WebsocketBalanceUpdate
+	for _, update := range updates {
+		result = append(result, *update)
+	}
+	return result, nil
+}
 
-		myEnabledSubscriptionChannels = append(myEnabledSubscriptionChannels,
-			fmt.Sprintf("{'event':'addChannel','channel':'ok_sub_spot_%s_deals'}",
-				symbolRedone))
+// WsSubscribe subscribes to the websocket channels in a single batch. When
+// subscription persistence is enabled the channels active before the last
+// shutdown are restored, otherwise channels are built from the enabled pairs.
+// Channels which fail are logged and skipped, an error is only returned when
+// every channel fails
+func (o *OKEX) WsSubscribe() error {
+	channels := o.Websocket.GetPersistedSubscriptions()
+	if len(channels) == 0 {
+		channels = o.wsDefaultChannels()
+	}
 
-		myEnabledSubscriptionChannels = append(myEnabledSubscriptionChannels,
-			fmt.Sprintf("{'event':'addChannel','channel':'ok_sub_spot_%s_kline_1min'}",
-				symbolRedone))
+	var public []string
+	for _, channel := range channels {
+		if strings.HasSuffix(channel, "_balance") {
+			// private channels are subscribed once logged in
+			continue
+		}
+		public = append(public, channel)
 	}
 
-	for _, outgoing := range myEnabledSubscriptionChannels {
-		err := o.writeToWebsocket(outgoing)
-		if err != nil {
-			return err
+	if len(public) == 0 {
+		return nil
+	}
+
+	results := o.Websocket.SubscribeBatch(public, o.wsAddChannels)
+	var failed int
+	for _, channel := range public {
+		if results[channel] != nil {
+			failed++
+			log.Printf("%s\n", results[channel])
 		}
 	}
 
+	if failed == len(public) {
+		return fmt.Errorf("all %d channel subscriptions failed", failed)
+	}
 	return nil
 }
 
+// wsDefaultChannels returns the ticker, depth, deals and kline channels for
+// each enabled pair
+func (o *OKEX) wsDefaultChannels() []string {
+	var channels []string
+	for _, pair := range o.EnabledPairs {
+		symbolRedone := wsSymbol(pair)
+		channels = append(channels,
+			fmt.Sprintf("ok_sub_spot_%s_ticker", symbolRedone),
+			o.wsDepthChannel(symbolRedone),
+			fmt.Sprintf("ok_sub_spot_%s_deals", symbolRedone),
+			fmt.Sprintf("ok_sub_spot_%s_kline_1min", symbolRedone))
+	}
+	return channels
+}
+
+// WsAddChannel subscribes to a websocket channel and waits for OKEX to
+// acknowledge the subscription
+func (o *OKEX) WsAddChannel(channel string) error {
+	return o.Websocket.Subscribe(channel, func() error {
+		return o.writeToWebsocket(
+			fmt.Sprintf("{'event':'addChannel','channel':'%s'}", channel))
+	})
+}
+
+// wsAddChannels sends the addChannel requests for channels as one message
+func (o *OKEX) wsAddChannels(channels []string) error {
+	events := make([]string, len(channels))
+	for i := range channels {
+		events[i] = fmt.Sprintf("{'event':'addChannel','channel':'%s'}", channels[i])
+	}
+	return o.writeToWebsocket("[" + strings.Join(events, ",") + "]")
+}
+
+// wsParseChannelAck parses the response to an addChannel request, returning
+// the channel it is for and the rejection reason when OKEX refused it
+func wsParseChannelAck(data json.RawMessage) (string, error) {
+	var ack WsChannelAck
+	err := common.JSONDecode(data, &ack)
+	if err != nil {
+		return "", err
+	}
+
+	if ack.Channel == "" {
+		return "", errors.New("okex_websocket.go - addChannel response missing channel")
+	}
+
+	if ack.Result {
+		return ack.Channel, nil
+	}
+
+	if ack.ErrorMsg != "" {
+		return ack.Channel, errors.New(ack.ErrorMsg)
+	}
+	return ack.Channel, fmt.Errorf("error code %d", ack.ErrorCode)
+}
+
+// WsRemoveChannel unsubscribes from a websocket channel
+func (o *OKEX) WsRemoveChannel(channel string) error {
+	err := o.writeToWebsocket(
+		fmt.Sprintf("{'event':'removeChannel','channel':'%s'}", channel))
+	if err != nil {
+		return err
+	}
+	return o.Websocket.RemoveSubscription(channel)
+}
+
 // WsReadData reads data from the websocket connection
 func (o *OKEX) WsReadData() {
 	o.Websocket.Wg.Add(1)
@@ -135,31 +341,20 @@ func (o *OKEX) WsReadData() {
 			return
 
 		default:
-			mType, resp, err := o.WebsocketConn.ReadMessage()
+			_, resp, err := o.Websocket.ReadMessage(o.WebsocketConn)
 			if err != nil {
-				o.Websocket.DataHandler <- err
+				select {
+				case <-o.Websocket.ShutdownC:
+					// the read was unblocked by wsTeardown
+				default:
+					o.Websocket.DataHandler <- err
+				}
 				return
 			}
 
 			o.Websocket.TrafficAlert <- struct{}{}
 
-			var standardMessage []byte
-
-			switch mType {
-			case websocket.TextMessage:
-				standardMessage = resp
-
-			case websocket.BinaryMessage:
-				reader := flate.NewReader(bytes.NewReader(resp))
-				standardMessage, err = ioutil.ReadAll(reader)
-				reader.Close()
-				if err != nil {
-					o.Websocket.DataHandler <- err
-					return
-				}
-			}
-
-			o.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: standardMessage}
+			o.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: resp}
 		}
 	}
 }
@@ -208,6 +403,19 @@ func (o *OKEX) WsHandleData() {
 			}
 
 			for _, multiStreamData := range multiStreamDataArr {
+				if multiStreamData.Channel == "addChannel" {
+					channel, ackErr := wsParseChannelAck(multiStreamData.Data)
+					if channel != "" && o.Websocket.AcknowledgeSubscription(channel, ackErr) {
+						continue
+					}
+
+					if ackErr != nil {
+						o.Websocket.DataHandler <- fmt.Errorf("okex_websocket.go - subscription %s failed: %s",
+							channel, ackErr)
+					}
+					continue
+				}
+
 				var errResponse ErrorResponse
 				if common.StringContains(string(resp.Raw), "error_msg") {
 					err = common.JSONDecode(resp.Raw, &errResponse)
@@ -228,6 +436,42 @@ func (o *OKEX) WsHandleData() {
 					assetType = currencyPairSlice[2]
 				}
 
+				if multiStreamData.Channel == "login" {
+					var login WsLoginResponse
+					err = common.JSONDecode(multiStreamData.Data, &login)
+					if err != nil {
+						o.Websocket.DataHandler <- err
+						continue
+					}
+
+					if !login.Result {
+						o.Websocket.DataHandler <- errors.New("okex_websocket.go - websocket login failed")
+						continue
+					}
+
+					// subscribe without blocking the handler which processes the
+					// subscription acknowledgements
+					go func() {
+						if err := o.wsSubscribeAccount(); err != nil {
+							o.Websocket.DataHandler <- err
+						}
+					}()
+					continue
+				}
+
+				if strings.HasSuffix(multiStreamData.Channel, "_balance") {
+					balances, err := o.wsParseBalance(multiStreamData.Data)
+					if err != nil {
+						o.Websocket.DataHandler <- err
+						continue
+					}
+
+					for i := range balances {
+						o.Websocket.DataHandler <- balances[i]
+					}
+					continue
+				}
+
 				if strings.Contains(multiStreamData.Channel, "ticker") {
 					var ticker TickerStreamData
 
@@ -303,10 +547,17 @@ func (o *OKEX) WsHandleData() {
 						log.Fatal("OKEX Depth Decode Error:", err)
 					}
 
+					p := pair.NewCurrencyPairFromString(common.StringToUpper(newPair))
+					err = o.wsProcessOrderbook(depth, p, multiStreamData.Channel)
+					if err != nil {
+						o.Websocket.DataHandler <- err
+						continue
+					}
+
 					o.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
 						Exchange: o.GetName(),
 						Asset:    assetType,
-						Pair:     pair.NewCurrencyPairFromString(newPair),
+						Pair:     p,
 					}
 				}
 			}
@@ -314,6 +565,69 @@ func (o *OKEX) WsHandleData() {
 	}
 }
 
+// wsProcessOrderbook applies depth data to the local orderbook. Fixed depth
+// channels push a full book every time and are loaded as a fresh snapshot.
+// The incremental channel pushes a full book first, including after a
+// reconnect, followed by diffs which are only applied once the book is synced
+func (o *OKEX) wsProcessOrderbook(depth DepthStreamData, p pair.CurrencyPair, channel string) error {
+	bids, err := wsDepthToItems(depth.Bids)
+	if err != nil {
+		return err
+	}
+
+	asks, err := wsDepthToItems(depth.Asks)
+	if err != nil {
+		return err
+	}
+
+	updated := time.Unix(0, int64(depth.Timestamp)*int64(time.Millisecond))
+	incremental := strings.HasSuffix(channel, "_depth")
+
+	if incremental && o.Websocket.Orderbook.IsSynced(p, orderbook.Spot) {
+		return o.Websocket.Orderbook.Update(bids,
+			asks,
+			p,
+			updated,
+			o.GetName(),
+			orderbook.Spot)
+	}
+
+	o.Websocket.Orderbook.Invalidate(p, orderbook.Spot)
+
+	var newOrderbook orderbook.Base
+	newOrderbook.Asks = asks
+	newOrderbook.Bids = bids
+	newOrderbook.AssetType = orderbook.Spot
+	newOrderbook.CurrencyPair = p.Pair().String()
+	newOrderbook.LastUpdated = updated
+	newOrderbook.Pair = p
+
+	return o.Websocket.Orderbook.LoadSnapshot(newOrderbook, o.GetName())
+}
+
+// wsDepthToItems converts websocket price levels to orderbook items
+func wsDepthToItems(levels [][]string) ([]orderbook.Item, error) {
+	var items []orderbook.Item
+	for i := range levels {
+		if len(levels[i]) < 2 {
+			return nil, errors.New("okex_websocket.go - malformed depth level")
+		}
+
+		price, err := strconv.ParseFloat(levels[i][0], 64)
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := strconv.ParseFloat(levels[i][1], 64)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, orderbook.Item{Price: price, Amount: amount})
+	}
+	return items, nil
+}
+
 // ErrorResponse defines an error response type from the websocket connection
 type ErrorResponse struct {
 	Result    bool   `json:"result"`
@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -14,6 +17,12 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*OKEX)(nil)
+var _ exchange.SystemStatusGetter = (*OKEX)(nil)
+var _ exchange.DepositAddressesGetter = (*OKEX)(nil)
+var _ exchange.TradeVolumeGetter = (*OKEX)(nil)
+var _ exchange.WithdrawalStatusGetter = (*OKEX)(nil)
+
 // Start starts the OKEX go routine
 func (o *OKEX) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -31,7 +40,11 @@ func (o *OKEX) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", o.GetName(), len(o.EnabledPairs), o.EnabledPairs)
 	}
 
-	prods, err := o.GetSpotInstruments()
+	var prods []SpotInstrument
+	err := o.RetryPairUpdate(func() (err error) {
+		prods, err = o.GetSpotInstruments()
+		return err
+	})
 	if err != nil {
 		log.Printf("OKEX failed to obtain available spot instruments. Err: %d", err)
 		return
@@ -41,15 +54,23 @@ func (o *OKEX) Run() {
 	for x := range prods {
 		pairs = append(pairs, prods[x].BaseCurrency+"_"+prods[x].QuoteCurrency)
 	}
+	o.updatePairInfo(prods)
 
-	err = o.UpdateCurrencies(pairs, false, false)
-	if err != nil {
-		log.Printf("OKEX failed to update available currencies. Err: %s", err)
+	if o.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+		err = o.UpdateCurrencies(pairs, false, false)
+		if err != nil {
+			log.Printf("OKEX failed to update available currencies. Err: %s", err)
+		}
 	}
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (o *OKEX) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := o.validateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	currency := exchange.FormatExchangeCurrency(o.Name, p).String()
 	var tickerPrice ticker.Price
 
@@ -68,25 +89,49 @@ func (o *OKEX) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price
 		tickerPrice.High = tick.Ticker.High
 		ticker.ProcessTicker(o.GetName(), p, tickerPrice, assetType)
 	} else {
-		tick, err := o.GetSpotTicker(currency)
+		tickers, err := o.GetSpotAllTickers()
 		if err != nil {
-			return tickerPrice, err
+			last, fallbackErr := o.getFallbackSpotPrice(currency, err)
+			if fallbackErr != nil {
+				return tickerPrice, err
+			}
+
+			tickerPrice.Pair = p
+			tickerPrice.Last = last
+			ticker.ProcessTicker(o.GetName(), p, tickerPrice, assetType)
+			return ticker.GetTicker(o.Name, p, assetType)
 		}
-		tickerPrice.Pair = p
-		tickerPrice.Ask = tick.Ticker.Sell
-		tickerPrice.Bid = tick.Ticker.Buy
-		tickerPrice.Low = tick.Ticker.Low
-		tickerPrice.Last = tick.Ticker.Last
-		tickerPrice.Volume = tick.Ticker.Vol
-		tickerPrice.High = tick.Ticker.High
-		ticker.ProcessTicker(o.GetName(), p, tickerPrice, ticker.Spot)
 
+		tickerMap := make(map[string]SpotAllTicker)
+		for x := range tickers {
+			symbol := common.StringToLower(common.ReplaceString(tickers[x].InstrumentID, "-", "_", -1))
+			tickerMap[symbol] = tickers[x]
+		}
+
+		for _, x := range o.GetEnabledCurrencies() {
+			curr := exchange.FormatExchangeCurrency(o.Name, x).String()
+			tick, ok := tickerMap[curr]
+			if !ok {
+				continue
+			}
+
+			var tp ticker.Price
+			tp.Pair = x
+			tp.Ask = tick.BestAsk
+			tp.Bid = tick.BestBid
+			tp.Low = tick.Low24H
+			tp.Last = tick.Last
+			tp.Volume = tick.BaseVolume24H
+			tp.High = tick.High24H
+			ticker.ProcessTicker(o.GetName(), x, tp, ticker.Spot)
+		}
 	}
 	return ticker.GetTicker(o.Name, p, assetType)
 }
 
 // GetTickerPrice returns the ticker for a currency pair
 func (o *OKEX) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = o.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(o.GetName(), p, assetType)
 	if err != nil {
 		return o.UpdateTicker(p, assetType)
@@ -96,6 +141,7 @@ func (o *OKEX) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Pri
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (o *OKEX) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = o.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(o.GetName(), currency, assetType)
 	if err != nil {
 		return o.UpdateOrderbook(currency, assetType)
@@ -105,6 +151,11 @@ func (o *OKEX) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (ord
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (o *OKEX) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := o.validateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	currency := exchange.FormatExchangeCurrency(o.Name, p).String()
 
@@ -114,16 +165,7 @@ func (o *OKEX) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook
 			return orderBook, err
 		}
 
-		for x := range orderbookNew.Bids {
-			data := orderbookNew.Bids[x]
-			orderBook.Bids = append(orderBook.Bids, orderbook.Item{Amount: data.Volume, Price: data.Price})
-		}
-
-		for x := range orderbookNew.Asks {
-			data := orderbookNew.Asks[x]
-			orderBook.Asks = append(orderBook.Asks, orderbook.Item{Amount: data.Volume, Price: data.Price})
-		}
-
+		orderBook = contractDepthToOrderbook(orderbookNew)
 	} else {
 		orderbookNew, err := o.GetSpotMarketDepth(ActualSpotDepthRequestParams{
 			Symbol: currency,
@@ -148,56 +190,505 @@ func (o *OKEX) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook
 	return orderbook.GetOrderbook(o.Name, p, assetType)
 }
 
+// GetIndexPrice returns the futures index price of an underlying pair e.g.
+// BTC-USD, returning an error when the pair is not a futures underlying
+func (o *OKEX) GetIndexPrice(p pair.CurrencyPair) (IndexPrice, error) {
+	symbol := p.Display("_", false).String()
+	if err := o.CheckSymbol(symbol); err != nil {
+		return IndexPrice{}, fmt.Errorf("%s is not a futures underlying: %s", p.Pair(), err)
+	}
+
+	index, err := o.GetContractIndexPrice(symbol)
+	if err != nil {
+		return IndexPrice{}, err
+	}
+
+	return IndexPrice{
+		Pair:      p,
+		Index:     index,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// GetOpenInterest returns the open interest of a futures contract e.g.
+// "btc_usd" "this_week"
+func (o *OKEX) GetOpenInterest(symbol, contractType string) (OpenInterest, error) {
+	holdings, err := o.GetContractHoldingsNumber(symbol, contractType)
+	if err != nil {
+		return OpenInterest{}, err
+	}
+
+	return OpenInterest{
+		Symbol:       symbol,
+		ContractType: contractType,
+		ContractName: holdings.ContractName,
+		Amount:       holdings.LongHoldings,
+		Timestamp:    time.Now(),
+	}, nil
+}
+
+// contractDepthToOrderbook converts futures contract depth into an orderbook
+// with the best bid and ask first, the contract API returns asks with the
+// highest price first
+func contractDepthToOrderbook(depth ActualContractDepth) orderbook.Base {
+	var ob orderbook.Base
+	for x := range depth.Bids {
+		ob.Bids = append(ob.Bids, orderbook.Item{Amount: depth.Bids[x].Volume, Price: depth.Bids[x].Price})
+	}
+
+	for x := range depth.Asks {
+		ob.Asks = append(ob.Asks, orderbook.Item{Amount: depth.Asks[x].Volume, Price: depth.Asks[x].Price})
+	}
+
+	sort.Slice(ob.Bids, func(i, j int) bool { return ob.Bids[i].Price > ob.Bids[j].Price })
+	sort.Slice(ob.Asks, func(i, j int) bool { return ob.Asks[i].Price < ob.Asks[j].Price })
+	return ob
+}
+
 // GetAccountInfo retrieves balances for all enabled currencies for the
 // OKEX exchange
 func (o *OKEX) GetAccountInfo() (exchange.AccountInfo, error) {
 	var info exchange.AccountInfo
-	bal, err := o.GetBalance()
+	info.ExchangeName = o.GetName()
+
+	userInfo, err := o.GetUserInfo()
+	if err != nil {
+		return info, err
+	}
+
+	balances, err := parseSpotUserInfoFunds(userInfo)
+	if err != nil {
+		return info, err
+	}
+
+	info.Currencies = balances
+	return info, nil
+}
+
+// GetAccountsInfo returns the main account's balances along with the balances
+// of every sub account, each sub account's currencies are totalled across its
+// account types
+func (o *OKEX) GetAccountsInfo() (AccountsInfo, error) {
+	mainInfo, err := o.GetAccountInfo()
+	if err != nil {
+		return AccountsInfo{}, err
+	}
+
+	info := AccountsInfo{
+		Main:        mainInfo,
+		SubAccounts: make(map[string]exchange.AccountInfo),
+	}
+
+	subAccounts, err := o.ListSubAccounts()
 	if err != nil {
 		return info, err
 	}
 
+	for x := range subAccounts {
+		balance, err := o.GetSubAccountBalance(subAccounts[x].Name)
+		if err != nil {
+			return info, err
+		}
+		info.SubAccounts[subAccounts[x].Name] = o.subAccountInfo(balance)
+	}
+	return info, nil
+}
+
+// subAccountInfo totals a sub account's currency balances across its account
+// types
+func (o *OKEX) subAccountInfo(balance SubAccountBalance) exchange.AccountInfo {
+	totals := make(map[string]*exchange.AccountCurrencyInfo)
+	var currencies []string
+	for _, account := range balance.Accounts {
+		for x := range account {
+			currency := common.StringToUpper(account[x].Currency)
+			if currency == "" {
+				continue
+			}
+
+			total, ok := totals[currency]
+			if !ok {
+				total = &exchange.AccountCurrencyInfo{CurrencyName: currency}
+				totals[currency] = total
+				currencies = append(currencies, currency)
+			}
+			total.TotalValue += account[x].Balance
+			total.Hold += account[x].Hold
+		}
+	}
+
+	sort.Strings(currencies)
+	info := exchange.AccountInfo{ExchangeName: o.GetName()}
+	for x := range currencies {
+		info.Currencies = append(info.Currencies, *totals[currencies[x]])
+	}
+	return info
+}
+
+// GetAllAccountsInfo fetches the spot, futures and swap account balances in
+// parallel and returns them combined, each balance labelled with its account
+// type. An account type which can't be fetched is logged and left out, an
+// error is only returned when no account type could be fetched
+func (o *OKEX) GetAllAccountsInfo() (exchange.AccountInfo, error) {
+	return o.combineAccountTypes(map[string]func() ([]exchange.AccountCurrencyInfo, error){
+		AccountTypeSpot: func() ([]exchange.AccountCurrencyInfo, error) {
+			info, err := o.GetAccountInfo()
+			return info.Currencies, err
+		},
+		AccountTypeFutures: func() ([]exchange.AccountCurrencyInfo, error) {
+			info, err := o.GetFuturesUserInfo()
+			if err != nil {
+				return nil, err
+			}
+			return futuresAccountBalances(info), nil
+		},
+		AccountTypeSwap: func() ([]exchange.AccountCurrencyInfo, error) {
+			accounts, err := o.GetSwapAccounts()
+			if err != nil {
+				return nil, err
+			}
+			return swapAccountBalances(accounts), nil
+		},
+	})
+}
+
+// combineAccountTypes fetches the balances of each account type concurrently
+// and combines them ordered by account type and currency
+func (o *OKEX) combineAccountTypes(fetchers map[string]func() ([]exchange.AccountCurrencyInfo, error)) (exchange.AccountInfo, error) {
+	type result struct {
+		balances []exchange.AccountCurrencyInfo
+		err      error
+	}
+
+	var wg sync.WaitGroup
+	var m sync.Mutex
+	results := make(map[string]result)
+	for accountType, fetch := range fetchers {
+		wg.Add(1)
+		go func(accountType string, fetch func() ([]exchange.AccountCurrencyInfo, error)) {
+			defer wg.Done()
+			balances, err := fetch()
+			m.Lock()
+			results[accountType] = result{balances: balances, err: err}
+			m.Unlock()
+		}(accountType, fetch)
+	}
+	wg.Wait()
+
+	var accountTypes []string
+	for accountType := range results {
+		accountTypes = append(accountTypes, accountType)
+	}
+	sort.Strings(accountTypes)
+
+	info := exchange.AccountInfo{ExchangeName: o.GetName()}
+	var failures []string
+	for _, accountType := range accountTypes {
+		r := results[accountType]
+		if r.err != nil {
+			log.Printf("%s %s account balances unavailable: %s", o.Name, accountType, r.err)
+			failures = append(failures, fmt.Sprintf("%s: %s", accountType, r.err))
+			continue
+		}
+
+		sort.Slice(r.balances, func(i, j int) bool {
+			return r.balances[i].CurrencyName < r.balances[j].CurrencyName
+		})
+		for x := range r.balances {
+			r.balances[x].AccountType = accountType
+			info.Currencies = append(info.Currencies, r.balances[x])
+		}
+	}
+
+	if len(failures) == len(accountTypes) && len(failures) > 0 {
+		return info, fmt.Errorf("%s unable to fetch any account balances, %s",
+			o.Name, common.JoinStrings(failures, ", "))
+	}
+	return info, nil
+}
+
+// futuresAccountBalances converts the futures account of each currency to a
+// balance, the equity is the total and the margin held by open positions and
+// orders is the hold
+func futuresAccountBalances(info FuturesUserInfo) []exchange.AccountCurrencyInfo {
 	var balances []exchange.AccountCurrencyInfo
-	for _, data := range bal {
+	for currency, account := range info.Info {
+		if account.AccountRights == 0 && account.KeepDeposit == 0 {
+			continue
+		}
 		balances = append(balances, exchange.AccountCurrencyInfo{
-			CurrencyName: data.Currency,
-			TotalValue:   data.Available + data.Hold,
-			Hold:         data.Hold,
+			CurrencyName: common.StringToUpper(currency),
+			TotalValue:   account.AccountRights,
+			Hold:         account.KeepDeposit,
 		})
 	}
+	return balances
+}
 
-	info.ExchangeName = o.GetName()
-	info.Currencies = balances
-	return info, nil
+// swapSettlementCurrency returns the settlement currency of a swap contract,
+// the quote currency of USDT margined contracts e.g. BTC-USDT-SWAP and the base
+// currency of coin margined contracts e.g. BTC-USD-SWAP
+func swapSettlementCurrency(instrumentID string) string {
+	parts := strings.Split(common.StringToUpper(instrumentID), "-")
+	if len(parts) > 1 && parts[1] == "USDT" {
+		return parts[1]
+	}
+	return parts[0]
+}
+
+// swapAccountBalances converts swap accounts to balances of their settlement
+// currency, totalling contracts settled in the same currency
+func swapAccountBalances(accounts []SwapAccount) []exchange.AccountCurrencyInfo {
+	totals := make(map[string]*exchange.AccountCurrencyInfo)
+	var balances []exchange.AccountCurrencyInfo
+	var currencies []string
+	for x := range accounts {
+		if accounts[x].Equity == 0 {
+			continue
+		}
+
+		currency := swapSettlementCurrency(accounts[x].InstrumentID)
+		total, ok := totals[currency]
+		if !ok {
+			total = &exchange.AccountCurrencyInfo{CurrencyName: currency}
+			totals[currency] = total
+			currencies = append(currencies, currency)
+		}
+		total.TotalValue += accounts[x].Equity
+		total.Hold += accounts[x].Margin + accounts[x].MarginFrozen
+	}
+
+	for x := range currencies {
+		balances = append(balances, *totals[currencies[x]])
+	}
+	return balances
+}
+
+// GetSystemStatus returns the exchange system status, reporting maintenance
+// while any maintenance window is in progress
+func (o *OKEX) GetSystemStatus() (exchange.SystemStatus, error) {
+	maintenance, err := o.GetSystemMaintenance()
+	if err != nil {
+		return exchange.SystemStatusUnknown, err
+	}
+	return systemStatusFromMaintenance(maintenance), nil
+}
+
+// systemStatusFromMaintenance maps OKEX maintenance windows to a system status
+func systemStatusFromMaintenance(maintenance []SystemMaintenance) exchange.SystemStatus {
+	for x := range maintenance {
+		if maintenance[x].Status == SystemMaintenanceInProgress {
+			return exchange.SystemStatusMaintenance
+		}
+	}
+	return exchange.SystemStatusOperational
+}
+
+// FetchTradeVolume returns the account's trailing 30 day spot trade volume in
+// USDT, the currency OKEX assesses fee tiers in, summed from the orders of
+// each enabled pair. Pairs whose quote currency can't be valued in USDT are
+// excluded and listed in the note
+func (o *OKEX) FetchTradeVolume() (exchange.TradeVolume, error) {
+	start := time.Now().AddDate(0, 0, -exchange.TradeVolumeDays)
+	var total float64
+	var excluded []string
+	for _, p := range o.GetEnabledCurrencies() {
+		instrumentID := common.StringToUpper(p.FirstCurrency.String() + "-" + p.SecondCurrency.String())
+		volume, err := o.GetSpotTradeVolume(instrumentID, start)
+		if err != nil {
+			return exchange.TradeVolume{}, err
+		}
+		if volume == 0 {
+			continue
+		}
+
+		quote := common.StringToLower(p.SecondCurrency.String())
+		if quote != tradeVolumeCurrency {
+			rate, err := o.GetLatestSpotPrice(quote + "_" + tradeVolumeCurrency)
+			if err != nil {
+				excluded = append(excluded, p.Pair().String())
+				continue
+			}
+			volume *= rate
+		}
+		total += volume
+	}
+
+	tradeVolume := exchange.TradeVolume{
+		Volume:   total,
+		Currency: common.StringToUpper(tradeVolumeCurrency),
+		Days:     exchange.TradeVolumeDays,
+	}
+	if len(excluded) > 0 {
+		tradeVolume.Note = fmt.Sprintf("excludes %s which could not be valued in %s",
+			common.JoinStrings(excluded, ", "), tradeVolume.Currency)
+	}
+	return tradeVolume, nil
+}
+
+// GetCurrencyBalance returns the available and held spot balance of a single
+// currency from the spot account of that currency
+func (o *OKEX) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	account, err := o.GetSpotAccount(currency)
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return spotAccountBalance(o.GetName(), account, currency)
+}
+
+// spotAccountBalance converts the spot account of a single currency into its
+// currency balance
+func spotAccountBalance(exchName string, account SpotAccount, currency string) (exchange.CurrencyBalance, error) {
+	if account.Available == 0 && account.Hold == 0 {
+		return exchange.CurrencyBalance{}, exchange.ErrCurrencyNotFound
+	}
+
+	return exchange.CurrencyBalance{
+		Exchange:  exchName,
+		Currency:  common.StringToUpper(currency),
+		Available: account.Available,
+		Hold:      account.Hold,
+	}, nil
+}
+
+// parseSpotUserInfoFunds converts the free and frozen spot funds returned by
+// the userinfo endpoint into account currency info, omitting currencies with
+// no balance
+func parseSpotUserInfoFunds(userInfo SpotUserInfo) ([]exchange.AccountCurrencyInfo, error) {
+	funds, ok := userInfo.Info["funds"]
+	if !ok {
+		return nil, errMissValue
+	}
+
+	type hold struct {
+		Avail float64
+		Hold  float64
+	}
+
+	var currencyData = make(map[string]*hold)
+	for key, available := range funds["free"] {
+		free, err := strconv.ParseFloat(available, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := currencyData[key]; !ok {
+			currencyData[key] = &hold{}
+		}
+		currencyData[key].Avail = free
+	}
+
+	for key, frozen := range funds["freezed"] {
+		inUse, err := strconv.ParseFloat(frozen, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := currencyData[key]; !ok {
+			currencyData[key] = &hold{}
+		}
+		currencyData[key].Hold = inUse
+	}
+
+	var balances []exchange.AccountCurrencyInfo
+	for key, data := range currencyData {
+		if data.Avail == 0 && data.Hold == 0 {
+			continue
+		}
+
+		balances = append(balances, exchange.AccountCurrencyInfo{
+			CurrencyName: common.StringToUpper(key),
+			TotalValue:   data.Avail + data.Hold,
+			Hold:         data.Hold,
+		})
+	}
+	return balances, nil
 }
 
 // GetFundingHistory returns funding history, deposits and
-// withdrawals
+// withdrawals. OKEX exposes deposit and withdrawal history but the wrapper
+// doesn't fetch it yet
 func (o *OKEX) GetFundingHistory() ([]exchange.FundHistory, error) {
 	var fundHistory []exchange.FundHistory
-	return fundHistory, common.ErrFunctionNotSupported
+	return fundHistory, common.ErrNotYetImplemented
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
+// GetExchangeHistory returns the most recent public trades for a currency
+// pair, using the contract trade history when the asset type is a futures
+// contract type. Any other asset type returns ErrAssetTypeNotSupported
 func (o *OKEX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
-	var resp []exchange.TradeHistory
+	assetType, err := o.validateAssetType(assetType)
+	if err != nil {
+		return nil, err
+	}
 
-	return resp, common.ErrNotYetImplemented
+	currency := exchange.FormatExchangeCurrency(o.Name, p).String()
+
+	if assetType != ticker.Spot {
+		contractTrades, err := o.GetContractTradeHistory(currency, assetType)
+		if err != nil {
+			return nil, err
+		}
+
+		trades := make([]ActualSpotTradeHistory, len(contractTrades))
+		for i := range contractTrades {
+			trades[i] = ActualSpotTradeHistory(contractTrades[i])
+		}
+		return o.convertRecentTrades(trades)
+	}
+
+	trades, err := o.GetSpotRecentTrades(ActualSpotTradeHistoryRequestParams{
+		Symbol: currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return o.convertRecentTrades(trades)
+}
+
+// convertRecentTrades converts OKEX public trades to the exchange trade
+// history format. OKEX reports the taker side directly as "buy" or "sell"
+func (o *OKEX) convertRecentTrades(trades []ActualSpotTradeHistory) ([]exchange.TradeHistory, error) {
+	resp := make([]exchange.TradeHistory, 0, len(trades))
+	for i := range trades {
+		side, err := exchange.TradeSideFromString(trades[i].Type)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, exchange.TradeHistory{
+			Timestamp: int64(trades[i].Date),
+			TID:       int64(trades[i].TID),
+			Price:     trades[i].Price,
+			Amount:    trades[i].Amount,
+			Exchange:  o.Name,
+			Type:      trades[i].Type,
+			Side:      side,
+		})
+	}
+	return resp, nil
 }
 
 // SubmitOrder submits a new order
 func (o *OKEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := o.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (o *OKEX) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 	var oT SpotNewOrderRequestType
 
-	if orderType == exchange.Limit {
-		if side == exchange.Buy {
+	if order.OrderType == exchange.Limit {
+		if order.OrderSide == exchange.Buy {
 			oT = SpotNewOrderRequestTypeBuy
 		} else {
 			oT = SpotNewOrderRequestTypeSell
 		}
-	} else if orderType == exchange.Market {
-		if side == exchange.Buy {
+	} else if order.OrderType == exchange.Market {
+		if order.OrderSide == exchange.Buy {
 			oT = SpotNewOrderRequestTypeBuyMarket
 		} else {
 			oT = SpotNewOrderRequestTypeSellMarket
@@ -206,11 +697,25 @@ func (o *OKEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderTy
 		return submitOrderResponse, errors.New("Unsupported order type")
 	}
 
+	amount, price, err := o.applyPairInfo(order.Pair, oT, order.Amount, order.Price)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	if order.ClientOrderID == "" {
+		order.ClientOrderID, err = exchange.NewClientOrderID()
+		if err != nil {
+			return submitOrderResponse, err
+		}
+	}
+	submitOrderResponse.ClientOrderID = order.ClientOrderID
+
 	var params = SpotNewOrderRequestParams{
-		Amount: amount,
-		Price:  price,
-		Symbol: p.Pair().String(),
-		Type:   oT,
+		Amount:        amount,
+		Price:         price,
+		Symbol:        order.Pair.Pair().String(),
+		Type:          oT,
+		ClientOrderID: order.ClientOrderID,
 	}
 
 	response, err := o.SpotNewOrder(params)
@@ -226,6 +731,44 @@ func (o *OKEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderTy
 	return submitOrderResponse, err
 }
 
+// GetPairInfo returns the trading rules for a spot currency pair
+func (o *OKEX) GetPairInfo(p pair.CurrencyPair) (PairInfo, error) {
+	o.pairInfoMtx.RLock()
+	defer o.pairInfoMtx.RUnlock()
+
+	info, ok := o.pairInfo[common.StringToLower(p.FirstCurrency.String()+"_"+p.SecondCurrency.String())]
+	if !ok {
+		return PairInfo{}, fmt.Errorf("%s trading rules for %s not found", o.Name, p.Pair())
+	}
+	return info, nil
+}
+
+// applyPairInfo rounds the order amount and price to the pair's increments and
+// validates the amount against the pair's minimum. Orders are passed through
+// unchanged when the pair's trading rules haven't been fetched
+func (o *OKEX) applyPairInfo(p pair.CurrencyPair, oT SpotNewOrderRequestType, amount, price float64) (float64, float64, error) {
+	info, err := o.GetPairInfo(p)
+	if err != nil {
+		return amount, price, nil
+	}
+
+	if oT == SpotNewOrderRequestTypeBuyMarket {
+		// market buys are placed using the quote currency price total
+		return amount, price, nil
+	}
+
+	amount = roundDown(amount, info.SizeIncrement, info.AmountPrecision)
+	if amount < info.MinAmount {
+		return amount, price, fmt.Errorf("%s order amount %v is below the minimum amount %v for %s",
+			o.Name, amount, info.MinAmount, p.Pair())
+	}
+
+	if oT != SpotNewOrderRequestTypeSellMarket {
+		price = roundDown(price, info.TickSize, info.PricePrecision)
+	}
+	return amount, price, nil
+}
+
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (o *OKEX) ModifyOrder(action exchange.ModifyOrder) (string, error) {
@@ -266,10 +809,15 @@ func (o *OKEX) CancelAllOrders(orderCancellation exchange.OrderCancellation) (ex
 	}
 
 	for _, openOrder := range allOpenOrders {
+		orderID := strconv.FormatInt(openOrder.OrderID, 10)
 		_, err := o.SpotCancelOrder(openOrder.Symbol, openOrder.OrderID)
 		if err != nil {
-			cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(openOrder.OrderID, 10)] = err.Error()
+			cancelAllOrdersResponse.OrderStatus[orderID] = err.Error()
+			cancelAllOrdersResponse.Failed++
+			continue
 		}
+		cancelAllOrdersResponse.CancelledOrderIDs = append(cancelAllOrdersResponse.CancelledOrderIDs, orderID)
+		cancelAllOrdersResponse.Succeeded++
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -281,27 +829,185 @@ func (o *OKEX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
-// GetDepositAddress returns a deposit address for a specified currency
-func (o *OKEX) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
-	return "", common.ErrNotYetImplemented
+// GetDepositAddress returns a deposit address for a specified currency on the
+// supplied chain e.g. "ERC20" or "TRC20". An empty chain selects the
+// currency's default chain
+func (o *OKEX) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
+	addr, err := o.GetDepositAddressOnChain(cryptocurrency.String(), chain)
+	if err != nil {
+		return "", err
+	}
+	return addr.Address, nil
+}
+
+// GetDepositAddresses returns the deposit address of a currency on every chain
+// it is supported on, the default chain is returned with an empty chain
+func (o *OKEX) GetDepositAddresses(cryptocurrency pair.CurrencyItem) ([]exchange.DepositAddress, error) {
+	addresses, err := o.GetDepositAddressList(cryptocurrency.String())
+	if err != nil {
+		return nil, err
+	}
+	return depositAddressesByChain(cryptocurrency.String(), addresses), nil
 }
 
-// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
-// submitted
-func (o *OKEX) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+// depositAddressesByChain converts the deposit addresses of a currency to
+// exchange deposit addresses keyed by chain
+func depositAddressesByChain(currency string, addresses []DepositAddress) []exchange.DepositAddress {
+	currency = common.StringToUpper(currency)
+	var resp []exchange.DepositAddress
+	for x := range addresses {
+		name := common.StringToUpper(addresses[x].Currency)
+		var chain string
+		switch {
+		case name == currency:
+		case strings.HasPrefix(name, currency+"-"):
+			chain = strings.TrimPrefix(name, currency+"-")
+		default:
+			continue
+		}
+
+		tag := addresses[x].Tag
+		if tag == "" {
+			tag = addresses[x].PaymentID
+		}
+
+		resp = append(resp, exchange.DepositAddress{
+			Chain:   chain,
+			Address: addresses[x].Address,
+			Tag:     tag,
+		})
+	}
+	return resp
+}
+
+// GetAvailableChains returns the chains a currency is supported on, the
+// default chain is returned with an empty chain
+func (o *OKEX) GetAvailableChains(cryptocurrency pair.CurrencyItem) ([]exchange.CurrencyChain, error) {
+	currencies, err := o.GetCurrencies()
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := o.GetWithdrawalFees(cryptocurrency.String())
+	if err != nil {
+		return nil, err
+	}
+	return availableChains(cryptocurrency.String(), currencies, fees)
+}
+
+// availableChains converts the account currencies and withdrawal fees of a
+// currency to exchange currency chains
+func availableChains(currency string, currencies []AccountCurrency, fees []WithdrawalFee) ([]exchange.CurrencyChain, error) {
+	currency = common.StringToUpper(currency)
+	var resp []exchange.CurrencyChain
+	for x := range currencies {
+		name := common.StringToUpper(currencies[x].Currency)
+		var chain string
+		switch {
+		case name == currency:
+		case strings.HasPrefix(name, currency+"-"):
+			chain = strings.TrimPrefix(name, currency+"-")
+		default:
+			continue
+		}
+
+		c := exchange.CurrencyChain{
+			Chain:           chain,
+			DepositEnabled:  currencies[x].CanDeposit == "1",
+			WithdrawEnabled: currencies[x].CanWithdraw == "1",
+		}
+
+		var err error
+		if currencies[x].MinWithdrawal != "" {
+			c.MinWithdrawal, err = strconv.ParseFloat(currencies[x].MinWithdrawal, 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for y := range fees {
+			if common.StringToUpper(fees[y].Currency) != name || fees[y].MinFee == "" {
+				continue
+			}
+
+			c.WithdrawalFee, err = strconv.ParseFloat(fees[y].MinFee, 64)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+		resp = append(resp, c)
+	}
+
+	if len(resp) == 0 {
+		return nil, fmt.Errorf("currency %s not found", currency)
+	}
+	return resp, nil
+}
+
+// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal on the
+// supplied chain e.g. "ERC20" or "TRC20" is submitted. An empty chain selects
+// the currency's default chain
+func (o *OKEX) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := o.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
+	resp, err := o.Withdraw(WithdrawRequest{
+		Currency:      cryptocurrency.String(),
+		Chain:         chain,
+		Amount:        amount,
+		Address:       address,
+		TradePassword: o.TradePassword,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.WithdrawalID, nil
+}
+
+// GetWithdrawalStatus returns the status of a withdrawal by its withdrawal ID
+func (o *OKEX) GetWithdrawalStatus(withdrawalID string) (exchange.WithdrawalStatus, error) {
+	history, err := o.GetWithdrawalHistory("")
+	if err != nil {
+		return "", err
+	}
+
+	for i := range history {
+		if history[i].WithdrawalID == withdrawalID {
+			return convertWithdrawalStatus(history[i].Status), nil
+		}
+	}
+	return "", fmt.Errorf("%s withdrawal %s not found", o.Name, withdrawalID)
+}
+
+// convertWithdrawalStatus maps an OKEX withdrawal status to a withdrawal
+// status, withdrawals awaiting confirmation or cancellation are pending
+func convertWithdrawalStatus(status string) exchange.WithdrawalStatus {
+	switch status {
+	case WithdrawalStatusSent:
+		return exchange.WithdrawalCompleted
+	case WithdrawalStatusCancelled, WithdrawalStatusFailed:
+		return exchange.WithdrawalFailed
+	case WithdrawalStatusSending:
+		return exchange.WithdrawalProcessing
+	default:
+		return exchange.WithdrawalPending
+	}
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
-// withdrawal is submitted
+// withdrawal is submitted.
+// OKEX doesn't make fiat withdrawals, fiat is sold through its C2C market
 func (o *OKEX) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
-// withdrawal is submitted
+// withdrawal is submitted, see WithdrawFiatFunds
 func (o *OKEX) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return "", common.ErrFunctionNotSupported
 }
 
 // GetWebsocket returns a pointer to the exchange websocket
@@ -318,3 +1024,14 @@ func (o *OKEX) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
 func (o *OKEX) GetWithdrawCapabilities() uint32 {
 	return o.GetWithdrawPermissions()
 }
+
+// validateAssetType returns the supplied asset type, or the default asset type
+// when it is empty, accepting the futures contract types alongside the
+// exchanges asset types
+func (o *OKEX) validateAssetType(assetType string) (string, error) {
+	assetType = o.GetAssetTypeOrDefault(assetType)
+	if common.StringDataCompare(o.ContractTypes, assetType) {
+		return assetType, nil
+	}
+	return o.ValidateAssetType(assetType)
+}
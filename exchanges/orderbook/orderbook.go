@@ -2,6 +2,7 @@ package orderbook
 
 import (
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
@@ -13,14 +14,26 @@ const (
 	ErrOrderbookForExchangeNotFound = "Ticker for exchange does not exist."
 	ErrPrimaryCurrencyNotFound      = "Error primary currency for orderbook not found."
 	ErrSecondaryCurrencyNotFound    = "Error secondary currency for orderbook not found."
+	ErrAssetTypeNotFound            = "Error asset type for orderbook not found."
 
 	Spot = "SPOT"
+
+	// UpdateBufferSize is the number of updates buffered for each subscriber,
+	// updates are dropped for a subscriber whose buffer is full
+	UpdateBufferSize = 1024
 )
 
 // Vars for the orderbook package
 var (
 	Orderbooks []Orderbook
 	m          sync.Mutex
+
+	subscribers   = make(map[int]*subscriber)
+	subscriberID  int
+	subscriberMtx sync.RWMutex
+
+	maxStoredLevels    = make(map[string]int)
+	maxStoredLevelsMtx sync.RWMutex
 )
 
 // Item stores the amount and price values
@@ -70,6 +83,34 @@ func (o *Base) CalculateTotalAsks() (float64, float64) {
 	return amountCollated, total
 }
 
+// Imbalance returns the orderbook imbalance over the top levels of each side,
+// the cumulative bid amount less the cumulative ask amount divided by their
+// sum. The ratio ranges from -1 when there are only asks to 1 when there are
+// only bids. Sides with fewer levels use every level they have, a levels
+// value of zero or less uses every level and an empty orderbook returns 0
+func (o *Base) Imbalance(levels int) float64 {
+	bidAmount := sumLevelAmounts(o.Bids, levels)
+	askAmount := sumLevelAmounts(o.Asks, levels)
+	if bidAmount+askAmount == 0 {
+		return 0
+	}
+	return (bidAmount - askAmount) / (bidAmount + askAmount)
+}
+
+// sumLevelAmounts returns the cumulative amount of the top levels of an
+// orderbook side
+func sumLevelAmounts(items []Item, levels int) float64 {
+	if levels <= 0 || levels > len(items) {
+		levels = len(items)
+	}
+
+	var amount float64
+	for x := 0; x < levels; x++ {
+		amount += items[x].Amount
+	}
+	return amount
+}
+
 // Update updates the bids and asks
 func (o *Base) Update(Bids, Asks []Item) {
 	o.Bids = Bids
@@ -77,9 +118,16 @@ func (o *Base) Update(Bids, Asks []Item) {
 	o.LastUpdated = time.Now()
 }
 
+// IsStale returns true if the orderbook has not been updated within the
+// supplied maximum age
+func (o *Base) IsStale(maxAge time.Duration) bool {
+	return o.LastUpdated.IsZero() || time.Since(o.LastUpdated) > maxAge
+}
+
 // GetOrderbook checks and returns the orderbook given an exchange name and
 // currency pair if it exists
 func GetOrderbook(exchange string, p pair.CurrencyPair, orderbookType string) (Base, error) {
+	p = p.Normalize()
 	orderbook, err := GetOrderbookByExchange(exchange)
 	if err != nil {
 		return Base{}, err
@@ -93,7 +141,13 @@ func GetOrderbook(exchange string, p pair.CurrencyPair, orderbookType string) (B
 		return Base{}, errors.New(ErrSecondaryCurrencyNotFound)
 	}
 
-	return orderbook.Orderbook[p.FirstCurrency][p.SecondCurrency][orderbookType], nil
+	m.Lock()
+	defer m.Unlock()
+	result, ok := orderbook.Orderbook[p.FirstCurrency][p.SecondCurrency][orderbookType]
+	if !ok {
+		return Base{}, errors.New(ErrAssetTypeNotFound)
+	}
+	return result, nil
 }
 
 // GetOrderbookByExchange returns an exchange orderbook
@@ -111,6 +165,7 @@ func GetOrderbookByExchange(exchange string) (*Orderbook, error) {
 // FirstCurrencyExists checks to see if the first currency of the orderbook map
 // exists
 func FirstCurrencyExists(exchange string, currency pair.CurrencyItem) bool {
+	currency = currency.Upper()
 	m.Lock()
 	defer m.Unlock()
 	for _, y := range Orderbooks {
@@ -126,6 +181,7 @@ func FirstCurrencyExists(exchange string, currency pair.CurrencyItem) bool {
 // SecondCurrencyExists checks to see if the second currency of the orderbook
 // map exists
 func SecondCurrencyExists(exchange string, p pair.CurrencyPair) bool {
+	p = p.Normalize()
 	m.Lock()
 	defer m.Unlock()
 	for _, y := range Orderbooks {
@@ -140,8 +196,51 @@ func SecondCurrencyExists(exchange string, p pair.CurrencyPair) bool {
 	return false
 }
 
+// SetMaxStoredLevels sets the number of bid and ask levels stored for each of
+// an exchanges orderbooks, levels beyond the cap are discarded by
+// ProcessOrderbook. A cap of 0 or less stores every level
+func SetMaxStoredLevels(exchangeName string, levels int) {
+	maxStoredLevelsMtx.Lock()
+	defer maxStoredLevelsMtx.Unlock()
+	if levels <= 0 {
+		delete(maxStoredLevels, exchangeName)
+		return
+	}
+	maxStoredLevels[exchangeName] = levels
+}
+
+// GetMaxStoredLevels returns the number of bid and ask levels stored for an
+// exchanges orderbooks, 0 when every level is stored
+func GetMaxStoredLevels(exchangeName string) int {
+	maxStoredLevelsMtx.RLock()
+	defer maxStoredLevelsMtx.RUnlock()
+	return maxStoredLevels[exchangeName]
+}
+
+// truncateLevels sorts the bids by descending price and the asks by
+// ascending price, then keeps at most levels of each so the best prices are
+// preserved
+func truncateLevels(ob *Base, levels int) {
+	if len(ob.Bids) > levels {
+		bids := append([]Item(nil), ob.Bids...)
+		sort.SliceStable(bids, func(i, j int) bool {
+			return bids[i].Price > bids[j].Price
+		})
+		ob.Bids = bids[:levels]
+	}
+
+	if len(ob.Asks) > levels {
+		asks := append([]Item(nil), ob.Asks...)
+		sort.SliceStable(asks, func(i, j int) bool {
+			return asks[i].Price < asks[j].Price
+		})
+		ob.Asks = asks[:levels]
+	}
+}
+
 // CreateNewOrderbook creates a new orderbook
 func CreateNewOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew Base, orderbookType string) Orderbook {
+	p = p.Normalize()
 	m.Lock()
 	defer m.Unlock()
 	orderbook := Orderbook{}
@@ -157,14 +256,26 @@ func CreateNewOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew B
 }
 
 // ProcessOrderbook processes incoming orderbooks, creating or updating the
-// Orderbook list
+// Orderbook list. When the exchange has a stored levels cap set with
+// SetMaxStoredLevels, a side deeper than the cap is sorted best price first
+// before being truncated, so the top levels are the ones kept
 func ProcessOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew Base, orderbookType string) {
 	if orderbookNew.Pair.Pair() == "" {
 		// set Pair if not set
 		orderbookNew.Pair = p
 	}
 	orderbookNew.CurrencyPair = p.Pair().String()
-	orderbookNew.LastUpdated = time.Now()
+	if orderbookNew.LastUpdated.IsZero() {
+		// set LastUpdated if the exchange didn't supply an update time
+		orderbookNew.LastUpdated = time.Now()
+	}
+	if levels := GetMaxStoredLevels(exchangeName); levels > 0 {
+		truncateLevels(&orderbookNew, levels)
+	}
+	defer notifySubscribers(exchangeName, p, orderbookNew, orderbookType)
+
+	// key the stored data on the canonical pair so lookups in any format hit
+	key := p.Normalize()
 
 	orderbook, err := GetOrderbookByExchange(exchangeName)
 	if err != nil {
@@ -172,11 +283,15 @@ func ProcessOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew Bas
 		return
 	}
 
-	if FirstCurrencyExists(exchangeName, p.FirstCurrency) {
+	if FirstCurrencyExists(exchangeName, key.FirstCurrency) {
 		m.Lock()
-		a := make(map[string]Base)
+		// keep any existing asset types stored under this currency pair
+		a, ok := orderbook.Orderbook[key.FirstCurrency][key.SecondCurrency]
+		if !ok {
+			a = make(map[string]Base)
+			orderbook.Orderbook[key.FirstCurrency][key.SecondCurrency] = a
+		}
 		a[orderbookType] = orderbookNew
-		orderbook.Orderbook[p.FirstCurrency][p.SecondCurrency] = a
 		m.Unlock()
 		return
 	}
@@ -185,7 +300,81 @@ func ProcessOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew Bas
 	a := make(map[pair.CurrencyItem]map[string]Base)
 	b := make(map[string]Base)
 	b[orderbookType] = orderbookNew
-	a[p.SecondCurrency] = b
-	orderbook.Orderbook[p.FirstCurrency] = a
+	a[key.SecondCurrency] = b
+	orderbook.Orderbook[key.FirstCurrency] = a
 	m.Unlock()
 }
+
+// Update holds a processed orderbook delivered to subscribers
+type Update struct {
+	Exchange  string
+	Pair      pair.CurrencyPair
+	AssetType string
+	Orderbook Base
+}
+
+type subscriber struct {
+	exchangeName string
+	updates      chan Update
+}
+
+// Subscribe registers a handler which is called with each orderbook processed
+// for the supplied exchange. Handlers run on their own goroutine fed by a
+// buffered channel so a slow handler does not stall ProcessOrderbook. The
+// returned function unsubscribes the handler
+func Subscribe(exchangeName string, handler func(Update)) (unsubscribe func()) {
+	sub := &subscriber{
+		exchangeName: exchangeName,
+		updates:      make(chan Update, UpdateBufferSize),
+	}
+
+	subscriberMtx.Lock()
+	subscriberID++
+	id := subscriberID
+	subscribers[id] = sub
+	subscriberMtx.Unlock()
+
+	go func() {
+		for update := range sub.updates {
+			handler(update)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			subscriberMtx.Lock()
+			delete(subscribers, id)
+			close(sub.updates)
+			subscriberMtx.Unlock()
+		})
+	}
+}
+
+// notifySubscribers delivers a copy of a processed orderbook to the exchanges
+// subscribers without blocking
+func notifySubscribers(exchangeName string, p pair.CurrencyPair, ob Base, orderbookType string) {
+	subscriberMtx.RLock()
+	defer subscriberMtx.RUnlock()
+	if len(subscribers) == 0 {
+		return
+	}
+
+	ob.Bids = append([]Item(nil), ob.Bids...)
+	ob.Asks = append([]Item(nil), ob.Asks...)
+	for _, sub := range subscribers {
+		if sub.exchangeName != exchangeName {
+			continue
+		}
+
+		select {
+		case sub.updates <- Update{
+			Exchange:  exchangeName,
+			Pair:      p,
+			AssetType: orderbookType,
+			Orderbook: ob,
+		}:
+		default:
+		}
+	}
+}
@@ -42,6 +42,42 @@ func TestCalculateTotaAsks(t *testing.T) {
 	}
 }
 
+func TestImbalance(t *testing.T) {
+	t.Parallel()
+	base := Base{
+		Bids: []Item{{Price: 100, Amount: 3}, {Price: 99, Amount: 2}, {Price: 98, Amount: 5}},
+		Asks: []Item{{Price: 101, Amount: 1}, {Price: 102, Amount: 4}},
+	}
+
+	testCases := []struct {
+		levels   int
+		expected float64
+	}{
+		{1, 0.5},
+		{2, 0},
+		{3, 1.0 / 3},
+		{10, 1.0 / 3},
+		{0, 1.0 / 3},
+	}
+
+	for x := range testCases {
+		if r := base.Imbalance(testCases[x].levels); r != testCases[x].expected {
+			t.Errorf("Test failed. TestImbalance levels %d expected %v received %v",
+				testCases[x].levels, testCases[x].expected, r)
+		}
+	}
+
+	base.Asks = nil
+	if r := base.Imbalance(5); r != 1 {
+		t.Errorf("Test failed. TestImbalance bids only expected 1 received %v", r)
+	}
+
+	base.Bids = nil
+	if r := base.Imbalance(5); r != 0 {
+		t.Errorf("Test failed. TestImbalance empty orderbook expected 0 received %v", r)
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	t.Parallel()
 	currency := pair.NewCurrencyPair("BTC", "USD")
@@ -74,6 +110,49 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestIsStale(t *testing.T) {
+	t.Parallel()
+	var base Base
+	if !base.IsStale(time.Minute) {
+		t.Fatal("Test failed. TestIsStale expected an orderbook without an update time to be stale")
+	}
+
+	base.LastUpdated = time.Now()
+	if base.IsStale(time.Minute) {
+		t.Fatal("Test failed. TestIsStale expected orderbook to not be stale")
+	}
+
+	base.LastUpdated = time.Now().Add(-time.Minute * 2)
+	if !base.IsStale(time.Minute) {
+		t.Fatal("Test failed. TestIsStale expected orderbook to be stale")
+	}
+}
+
+func TestProcessOrderbookLastUpdated(t *testing.T) {
+	currency := pair.NewCurrencyPair("LTC", "EUR")
+	updated := time.Now().Add(-time.Hour)
+	ProcessOrderbook("LastUpdatedTest", currency, Base{LastUpdated: updated}, Spot)
+
+	result, err := GetOrderbook("LastUpdatedTest", currency, Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestProcessOrderbookLastUpdated error", err)
+	}
+
+	if !result.LastUpdated.Equal(updated) {
+		t.Error("Test failed. TestProcessOrderbookLastUpdated supplied update time was overwritten")
+	}
+
+	ProcessOrderbook("LastUpdatedTest", currency, Base{}, Spot)
+	result, err = GetOrderbook("LastUpdatedTest", currency, Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestProcessOrderbookLastUpdated error", err)
+	}
+
+	if result.IsStale(time.Minute) {
+		t.Error("Test failed. TestProcessOrderbookLastUpdated expected update time to be set")
+	}
+}
+
 func TestGetOrderbook(t *testing.T) {
 	currency := pair.NewCurrencyPair("BTC", "USD")
 	base := Base{
@@ -328,3 +407,141 @@ func TestProcessOrderbook(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestProcessOrderbookAssetTypes(t *testing.T) {
+	currency := pair.NewCurrencyPair("BTC", "USDT")
+	assetTypes := []string{Spot, "FUTURES", "SWAP"}
+
+	for i, assetType := range assetTypes {
+		ProcessOrderbook("AssetTypeTest",
+			currency,
+			Base{Bids: []Item{{Price: float64(i + 1), Amount: 1}}},
+			assetType)
+	}
+
+	for i, assetType := range assetTypes {
+		result, err := GetOrderbook("AssetTypeTest", currency, assetType)
+		if err != nil {
+			t.Fatalf("Test failed. TestProcessOrderbookAssetTypes %s error: %s", assetType, err)
+		}
+
+		if len(result.Bids) != 1 || result.Bids[0].Price != float64(i+1) {
+			t.Errorf("Test failed. TestProcessOrderbookAssetTypes %s orderbook was overwritten",
+				assetType)
+		}
+	}
+
+	_, err := GetOrderbook("AssetTypeTest", currency, "OPTIONS")
+	if err == nil {
+		t.Error("Test failed. TestProcessOrderbookAssetTypes expected error for unknown asset type")
+	}
+}
+
+func TestProcessOrderbookPairFormats(t *testing.T) {
+	ProcessOrderbook("PairFormatTest", pair.NewCurrencyPairDelimiter("btc_usd", "_"),
+		Base{Bids: []Item{{Price: 1337, Amount: 1}}}, Spot)
+
+	lookups := []pair.CurrencyPair{
+		pair.NewCurrencyPair("BTC", "USD"),
+		pair.NewCurrencyPairDelimiter("BTC-USD", "-"),
+		pair.NewCurrencyPairFromString("btcusd"),
+	}
+
+	for x := range lookups {
+		result, err := GetOrderbook("PairFormatTest", lookups[x], Spot)
+		if err != nil {
+			t.Fatalf("Test failed. TestProcessOrderbookPairFormats %s error: %s",
+				lookups[x].Pair(), err)
+		}
+
+		if len(result.Bids) != 1 || result.Bids[0].Price != 1337 {
+			t.Errorf("Test failed. TestProcessOrderbookPairFormats %s returned an incorrect orderbook",
+				lookups[x].Pair())
+		}
+	}
+
+	ProcessOrderbook("PairFormatTest", pair.NewCurrencyPair("BTC", "USD"),
+		Base{Bids: []Item{{Price: 9001, Amount: 1}}}, Spot)
+	result, err := GetOrderbook("PairFormatTest", pair.NewCurrencyPairDelimiter("btc_usd", "_"), Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestProcessOrderbookPairFormats error", err)
+	}
+
+	if len(result.Bids) != 1 || result.Bids[0].Price != 9001 {
+		t.Error("Test failed. TestProcessOrderbookPairFormats orderbook wasn't updated")
+	}
+}
+
+func TestProcessOrderbookMaxStoredLevels(t *testing.T) {
+	currency := pair.NewCurrencyPair("BTC", "USD")
+	base := Base{
+		Bids: []Item{{Price: 98}, {Price: 100}, {Price: 97}, {Price: 99}},
+		Asks: []Item{{Price: 103}, {Price: 101}, {Price: 104}, {Price: 102}},
+	}
+
+	SetMaxStoredLevels("capped", 2)
+	defer SetMaxStoredLevels("capped", 0)
+	ProcessOrderbook("capped", currency, base, Spot)
+
+	result, err := GetOrderbook("capped", currency, Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestProcessOrderbookMaxStoredLevels failed to retrieve orderbook")
+	}
+
+	if len(result.Bids) != 2 || result.Bids[0].Price != 100 || result.Bids[1].Price != 99 {
+		t.Errorf("Test failed. TestProcessOrderbookMaxStoredLevels unexpected bids %v", result.Bids)
+	}
+
+	if len(result.Asks) != 2 || result.Asks[0].Price != 101 || result.Asks[1].Price != 102 {
+		t.Errorf("Test failed. TestProcessOrderbookMaxStoredLevels unexpected asks %v", result.Asks)
+	}
+
+	if base.Bids[0].Price != 98 {
+		t.Error("Test failed. TestProcessOrderbookMaxStoredLevels modified the supplied orderbook")
+	}
+
+	ProcessOrderbook("uncapped", currency, base, Spot)
+	result, err = GetOrderbook("uncapped", currency, Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestProcessOrderbookMaxStoredLevels failed to retrieve orderbook")
+	}
+
+	if len(result.Bids) != 4 || len(result.Asks) != 4 || result.Bids[0].Price != 98 {
+		t.Error("Test failed. TestProcessOrderbookMaxStoredLevels capped an uncapped exchange")
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	updates := make(chan Update, 1)
+	unsubscribe := Subscribe("subscribetest", func(u Update) {
+		updates <- u
+	})
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	ob := Base{
+		Bids: []Item{{Price: 1336, Amount: 1}},
+		Asks: []Item{{Price: 1338, Amount: 1}},
+	}
+	ProcessOrderbook("othertest", p, ob, Spot)
+	ProcessOrderbook("subscribetest", p, ob, Spot)
+	ob.Bids[0].Price = 1
+
+	select {
+	case u := <-updates:
+		if u.Exchange != "subscribetest" || u.AssetType != Spot ||
+			len(u.Orderbook.Bids) != 1 || u.Orderbook.Bids[0].Price != 1336 {
+			t.Errorf("test failed. Subscribe unexpected update %+v", u)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("test failed. Subscribe handler was not called")
+	}
+
+	unsubscribe()
+	ProcessOrderbook("subscribetest", p, ob, Spot)
+
+	select {
+	case u := <-updates:
+		t.Errorf("test failed. Subscribe received update after unsubscribe %+v", u)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
@@ -51,6 +51,10 @@ const (
 
 	poloniexAuthRate   = 6
 	poloniexUnauthRate = 6
+
+	// Base tier trading fee rates used when the fee info cannot be fetched
+	poloniexDefaultMakerFee = 0.001
+	poloniexDefaultTakerFee = 0.002
 )
 
 // Poloniex is the overarching type across the poloniex package
@@ -59,6 +63,10 @@ type Poloniex struct {
 	WebsocketConn *websocket.Conn
 }
 
+func init() {
+	exchange.RegisterExchange("poloniex", func() exchange.IBotExchange { return new(Poloniex) })
+}
+
 // SetDefaults sets default settings for poloniex
 func (p *Poloniex) SetDefaults() {
 	p.Name = "Poloniex"
@@ -81,6 +89,12 @@ func (p *Poloniex) SetDefaults() {
 	p.APIUrlDefault = poloniexAPIURL
 	p.APIUrl = p.APIUrlDefault
 	p.WebsocketInit()
+	p.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	p.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	p.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	p.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	p.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	p.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets user exchange configuration settings
@@ -90,16 +104,21 @@ func (p *Poloniex) Setup(exch config.ExchangeConfig) {
 	} else {
 		p.Enabled = true
 		p.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		p.Verbose = exch.Verbose
 		p.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		p.SetHTTPClientTimeout(exch.HTTPTimeout)
+		p.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		p.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := p.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		p.RESTPollingDelay = exch.RESTPollingDelay
-		p.Verbose = exch.Verbose
 		p.Websocket.SetEnabled(exch.Websocket)
 		p.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		p.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		p.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := p.SetCurrencyPairFormat()
+		err = p.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -107,6 +126,10 @@ func (p *Poloniex) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = p.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = p.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -915,11 +938,9 @@ func (p *Poloniex) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
 	var fee float64
 	switch feeBuilder.FeeType {
 	case exchange.CryptocurrencyTradeFee:
-		feeInfo, err := p.GetFeeInfo()
-		if err != nil {
-			return 0, err
-		}
-		fee = calculateTradingFee(feeInfo, feeBuilder.PurchasePrice, feeBuilder.Amount, feeBuilder.IsMaker)
+		fee = calculateTradingFee(p.getTradingFeeRate(feeBuilder.IsMaker),
+			feeBuilder.PurchasePrice,
+			feeBuilder.Amount)
 	case exchange.CryptocurrencyWithdrawalFee:
 		fee = getWithdrawalFee(feeBuilder.FirstCurrency)
 	}
@@ -930,13 +951,28 @@ func (p *Poloniex) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
 	return fee, nil
 }
 
-func calculateTradingFee(feeInfo Fee, purchasePrice, amount float64, isMaker bool) (fee float64) {
+// getTradingFeeRate returns the account's maker or taker fee rate, which
+// applies to every currency pair, from the fee rate cache
+func (p *Poloniex) getTradingFeeRate(isMaker bool) float64 {
+	fallback := poloniexDefaultTakerFee
 	if isMaker {
-		fee = feeInfo.MakerFee
-	} else {
-		fee = feeInfo.TakerFee
+		fallback = poloniexDefaultMakerFee
 	}
-	return fee * amount * purchasePrice
+
+	return p.GetCachedFeeRate(exchange.CryptocurrencyTradeFee, "", isMaker, func() (float64, error) {
+		feeInfo, err := p.GetFeeInfo()
+		if err != nil {
+			return 0, err
+		}
+		if isMaker {
+			return feeInfo.MakerFee, nil
+		}
+		return feeInfo.TakerFee, nil
+	}, fallback)
+}
+
+func calculateTradingFee(rate, purchasePrice, amount float64) float64 {
+	return rate * amount * purchasePrice
 }
 
 func getWithdrawalFee(currency string) float64 {
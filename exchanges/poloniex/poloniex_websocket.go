@@ -62,7 +62,7 @@ func (p *Poloniex) WsSubscribe() error {
 		return err
 	}
 
-	err = p.WebsocketConn.WriteMessage(websocket.TextMessage, tickerJSON)
+	err = p.Websocket.SendMessage(p.WebsocketConn, websocket.TextMessage, tickerJSON)
 	if err != nil {
 		return err
 	}
@@ -79,7 +79,7 @@ func (p *Poloniex) WsSubscribe() error {
 			return err
 		}
 
-		err = p.WebsocketConn.WriteMessage(websocket.TextMessage, orderbookJSON)
+		err = p.Websocket.SendMessage(p.WebsocketConn, websocket.TextMessage, orderbookJSON)
 		if err != nil {
 			return err
 		}
@@ -106,7 +106,7 @@ func (p *Poloniex) WsReadData() {
 			return
 
 		default:
-			_, resp, err := p.WebsocketConn.ReadMessage()
+			_, resp, err := p.Websocket.ReadMessage(p.WebsocketConn)
 			if err != nil {
 				p.Websocket.DataHandler <- err
 				return
@@ -13,6 +13,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*Poloniex)(nil)
+
 // Start starts the Poloniex go routine
 func (p *Poloniex) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -30,7 +32,11 @@ func (p *Poloniex) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", p.GetName(), len(p.EnabledPairs), p.EnabledPairs)
 	}
 
-	exchangeCurrencies, err := p.GetExchangeCurrencies()
+	var exchangeCurrencies []string
+	err := p.RetryPairUpdate(func() (err error) {
+		exchangeCurrencies, err = p.GetExchangeCurrencies()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available symbols.\n", p.GetName())
 	} else {
@@ -40,15 +46,22 @@ func (p *Poloniex) Run() {
 				p.GetName())
 			forceUpdate = true
 		}
-		err = p.UpdateCurrencies(exchangeCurrencies, false, forceUpdate)
-		if err != nil {
-			log.Printf("%s Failed to update available currencies %s.\n", p.GetName(), err)
+		if p.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = p.UpdateCurrencies(exchangeCurrencies, false, forceUpdate)
+			if err != nil {
+				log.Printf("%s Failed to update available currencies %s.\n", p.GetName(), err)
+			}
 		}
 	}
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (p *Poloniex) UpdateTicker(currencyPair pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := p.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	tick, err := p.GetTicker()
 	if err != nil {
@@ -72,6 +85,7 @@ func (p *Poloniex) UpdateTicker(currencyPair pair.CurrencyPair, assetType string
 
 // GetTickerPrice returns the ticker for a currency pair
 func (p *Poloniex) GetTickerPrice(currencyPair pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = p.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(p.GetName(), currencyPair, assetType)
 	if err != nil {
 		return p.UpdateTicker(currencyPair, assetType)
@@ -81,6 +95,7 @@ func (p *Poloniex) GetTickerPrice(currencyPair pair.CurrencyPair, assetType stri
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (p *Poloniex) GetOrderbookEx(currencyPair pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = p.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(p.GetName(), currencyPair, assetType)
 	if err != nil {
 		return p.UpdateOrderbook(currencyPair, assetType)
@@ -90,6 +105,11 @@ func (p *Poloniex) GetOrderbookEx(currencyPair pair.CurrencyPair, assetType stri
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (p *Poloniex) UpdateOrderbook(currencyPair pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := p.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := p.GetOrderbook("", 1000)
 	if err != nil {
@@ -141,6 +161,16 @@ func (p *Poloniex) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (p *Poloniex) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := p.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return p.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (p *Poloniex) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -157,10 +187,17 @@ func (p *Poloniex) GetExchangeHistory(currencyPair pair.CurrencyPair, assetType
 
 // SubmitOrder submits a new order
 func (p *Poloniex) SubmitOrder(currencyPair pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
-	fillOrKill := orderType == exchange.Market
-	isBuyOrder := side == exchange.Buy
-	response, err := p.PlaceOrder(currencyPair.Pair().String(), price, amount, false, fillOrKill, isBuyOrder)
+	resp, err := p.SubmitOrderWithParams(exchange.NewOrderSubmission(currencyPair, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (p *Poloniex) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
+	fillOrKill := order.OrderType == exchange.Market
+	isBuyOrder := order.OrderSide == exchange.Buy
+	response, err := p.PlaceOrder(order.Pair.Pair().String(), order.Price, order.Amount, false, fillOrKill, isBuyOrder)
 
 	if response.OrderNumber > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response.OrderNumber)
@@ -235,19 +272,27 @@ func (p *Poloniex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (p *Poloniex) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (p *Poloniex) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (p *Poloniex) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (p *Poloniex) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := p.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (p *Poloniex) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := p.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
@@ -1,6 +1,10 @@
 package request
 
 import (
+	"bytes"
+	"container/heap"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -9,6 +13,9 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,12 +24,137 @@ import (
 
 var supportedMethods = []string{"GET", "POST", "HEAD", "PUT", "DELETE", "OPTIONS", "CONNECT"}
 
+// Now returns the current time used for rate limit cycles and IP ban expiry.
+// It defaults to time.Now and can be replaced in tests so rate limiting can be
+// tested without waiting on the wall clock
+var Now = time.Now
+
 const (
 	maxRequestJobs              = 50
 	proxyTLSTimeout             = 15 * time.Second
 	defaultTimeoutRetryAttempts = 3
+
+	// Backoff applied when an exchange responds with HTTP 429 and does not
+	// supply a Retry-After header, doubled on each subsequent attempt
+	defaultTooManyRequestsBackoff = time.Second
+	maxTooManyRequestsBackoff     = time.Second * 30
+
+	// Pause applied to all requests when an exchange bans the IP address
+	// without saying when the ban expires
+	defaultIPBanPause = time.Minute
 )
 
+// sensitiveHeaders are matched against request header names, case
+// insensitive, to determine which header values are redacted from verbose
+// logging
+var sensitiveHeaders = []string{"key", "sign", "secret", "passphrase", "authorization", "token"}
+
+// redactedHeader replaces the value of a sensitive header in verbose logging
+const redactedHeader = "[REDACTED]"
+
+// maxResponseSnippet is the most bytes of an unexpected response body included
+// in an UnexpectedResponseError
+const maxResponseSnippet = 200
+
+// ErrIPBanned is wrapped by IPBanError, so errors.Is(err, ErrIPBanned) reports
+// whether a request failed because the exchange has banned the IP address
+var ErrIPBanned = errors.New("IP address banned by the exchange")
+
+// banUntilRegexp matches ban messages with an expiry timestamp, e.g. "IP
+// banned until 1567590000000"
+var banUntilRegexp = regexp.MustCompile(`(?i)banned until (\d{10,13})`)
+
+// IPBanError is returned when an exchange has banned the IP address, usually
+// after its rate limits have been exceeded. Until is when the ban expires and
+// is zero when the exchange did not provide it
+type IPBanError struct {
+	Exchange string
+	Until    time.Time
+}
+
+// Error implements the error interface
+func (e *IPBanError) Error() string {
+	if e.Until.IsZero() {
+		return fmt.Sprintf("%s %s", e.Exchange, ErrIPBanned)
+	}
+	return fmt.Sprintf("%s %s until %s", e.Exchange, ErrIPBanned, e.Until.UTC().Format(time.RFC3339))
+}
+
+// Unwrap returns ErrIPBanned
+func (e *IPBanError) Unwrap() error {
+	return ErrIPBanned
+}
+
+// ErrUnexpectedResponse is wrapped by UnexpectedResponseError, so
+// errors.Is(err, ErrUnexpectedResponse) reports whether an exchange responded
+// with something other than the JSON expected
+var ErrUnexpectedResponse = errors.New("unexpected response from exchange")
+
+// cloudflareChallengeMarkers are found in the body of Cloudflare browser
+// challenge pages
+var cloudflareChallengeMarkers = []string{
+	"cf-browser-verification",
+	"cf_chl_",
+	"Checking your browser",
+	"Attention Required! | Cloudflare",
+}
+
+// UnexpectedResponseError is returned when a response body can't be decoded
+// as JSON, such as a HTML error page or a truncated body. Snippet holds the
+// start of the body for diagnosis
+type UnexpectedResponseError struct {
+	Exchange    string
+	StatusCode  int
+	ContentType string
+	Reason      string
+	Snippet     string
+}
+
+// Error implements the error interface
+func (e *UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("%s %s (HTTP %d, content type %q): %s, body: %q",
+		e.Exchange, ErrUnexpectedResponse, e.StatusCode, e.ContentType, e.Reason, e.Snippet)
+}
+
+// Unwrap returns ErrUnexpectedResponse
+func (e *UnexpectedResponseError) Unwrap() error {
+	return ErrUnexpectedResponse
+}
+
+// Type categorises a request so it can be given its own timeout
+type Type int
+
+// Request types which can be assigned individual timeouts
+const (
+	Trade Type = iota
+	MarketData
+	Account
+	History
+)
+
+// Priority orders requests waiting on the rate limiter, when a request can be
+// sent the highest priority request waiting goes first and requests of equal
+// priority are sent in the order they were made
+type Priority int
+
+// Request priorities
+const (
+	LowPriority Priority = iota
+	NormalPriority
+	HighPriority
+)
+
+// defaultPriorities are the priorities of each request type unless overridden
+// with SetPriority, so an urgent order cancellation isn't stuck behind a
+// backfill of historical data. Requests sent without a type have
+// NormalPriority
+var defaultPriorities = map[Type]Priority{
+	Trade:      HighPriority,
+	MarketData: LowPriority,
+	Account:    NormalPriority,
+	History:    LowPriority,
+}
+
 // Requester struct for the request client
 type Requester struct {
 	HTTPClient           *http.Client
@@ -32,9 +164,13 @@ type Requester struct {
 	UserAgent            string
 	Cycle                time.Time
 	timeoutRetryAttempts int
+	timeouts             map[Type]time.Duration
+	priorities           map[Type]Priority
 	m                    sync.Mutex
 	Jobs                 chan Job
 	WorkerStarted        bool
+	ban                  *IPBanError
+	pausedUntil          time.Time
 }
 
 // RateLimit struct
@@ -62,6 +198,32 @@ type Job struct {
 	JobResult   chan *JobResult
 	AuthRequest bool
 	Verbose     bool
+	Priority    Priority
+	seq         uint64
+}
+
+// jobQueue is a heap of jobs waiting on the rate limiter ordered by priority
+// and then by the order they were received
+type jobQueue []Job
+
+func (q jobQueue) Len() int { return len(q) }
+
+func (q jobQueue) Less(i, j int) bool {
+	if q[i].Priority != q[j].Priority {
+		return q[i].Priority > q[j].Priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q jobQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *jobQueue) Push(x interface{}) { *q = append(*q, x.(Job)) }
+
+func (q *jobQueue) Pop() interface{} {
+	old := *q
+	job := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return job
 }
 
 // NewRateLimit creates a new RateLimit
@@ -118,7 +280,7 @@ func (r *RateLimit) GetDuration() time.Duration {
 
 // StartCycle restarts the cycle time and requests counters
 func (r *Requester) StartCycle() {
-	r.Cycle = time.Now()
+	r.Cycle = Now()
 	r.AuthLimit.SetRequests(0)
 	r.UnauthLimit.SetRequests(0)
 }
@@ -204,6 +366,56 @@ func (r *Requester) SetTimeoutRetryAttempts(n int) error {
 	return nil
 }
 
+// SetTimeout sets the timeout for a request type, overriding the HTTP client
+// timeout for requests sent with SendPayloadWithType. A zero duration removes
+// the override
+func (r *Requester) SetTimeout(t Type, d time.Duration) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if d <= 0 {
+		delete(r.timeouts, t)
+		return
+	}
+
+	if r.timeouts == nil {
+		r.timeouts = make(map[Type]time.Duration)
+	}
+	r.timeouts[t] = d
+}
+
+// GetTimeout returns the timeout for a request type and whether one has been
+// set
+func (r *Requester) GetTimeout(t Type) (time.Duration, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	d, ok := r.timeouts[t]
+	return d, ok
+}
+
+// SetPriority sets the priority of a request type sent with
+// SendPayloadWithType, overriding its default priority
+func (r *Requester) SetPriority(t Type, p Priority) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.priorities == nil {
+		r.priorities = make(map[Type]Priority)
+	}
+	r.priorities[t] = p
+}
+
+// GetPriority returns the priority of a request type
+func (r *Requester) GetPriority(t Type) Priority {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if p, ok := r.priorities[t]; ok {
+		return p
+	}
+	if p, ok := defaultPriorities[t]; ok {
+		return p
+	}
+	return NormalPriority
+}
+
 // New returns a new Requester
 func New(name string, authLimit, unauthLimit *RateLimit, httpRequester *http.Client) *Requester {
 	return &Requester{
@@ -224,11 +436,11 @@ func IsValidMethod(method string) bool {
 // IsValidCycle checks to see whether the current request cycle is valid or not
 func (r *Requester) IsValidCycle(auth bool) bool {
 	if auth {
-		if time.Since(r.Cycle) < r.AuthLimit.GetDuration() {
+		if Now().Sub(r.Cycle) < r.AuthLimit.GetDuration() {
 			return true
 		}
 	} else {
-		if time.Since(r.Cycle) < r.UnauthLimit.GetDuration() {
+		if Now().Sub(r.Cycle) < r.UnauthLimit.GetDuration() {
 			return true
 		}
 	}
@@ -254,27 +466,57 @@ func (r *Requester) checkRequest(method, path string, body io.Reader, headers ma
 	return req, nil
 }
 
+// redactHeader returns the header value to log, sensitive header values such
+// as API keys, signatures and passphrases are redacted
+func redactHeader(name, value string) string {
+	name = strings.ToLower(name)
+	for x := range sensitiveHeaders {
+		if strings.Contains(name, sensitiveHeaders[x]) {
+			return redactedHeader
+		}
+	}
+	return value
+}
+
 // DoRequest performs a HTTP/HTTPS request with the supplied params
 func (r *Requester) DoRequest(req *http.Request, method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool) error {
+	if err := r.checkIPBan(); err != nil {
+		return err
+	}
+
 	if verbose {
 		log.Printf("%s exchange request path: %s requires rate limiter: %v", r.Name, path, r.RequiresRateLimiter())
 		for k, d := range headers {
-			log.Printf("%s exchange request header [%s]: %s", r.Name, k, d)
+			log.Printf("%s exchange request header [%s]: %s", r.Name, k, redactHeader(k, d))
 		}
-		log.Println(body)
 	}
 
-	var timeoutError error
+	httpClient := r.HTTPClient
+	if _, ok := req.Context().Deadline(); ok {
+		// the request type timeout replaces the client timeout
+		client := *r.HTTPClient
+		client.Timeout = 0
+		httpClient = &client
+	}
+
+	var retryError error
 	for i := 0; i < r.timeoutRetryAttempts+1; i++ {
-		resp, err := r.HTTPClient.Do(req)
+		resp, err := httpClient.Do(req)
 		if err != nil {
+			if req.Context().Err() != nil {
+				if r.RequiresRateLimiter() {
+					r.DecrementRequests(authRequest)
+				}
+				return err
+			}
+
 			if timeoutErr, ok := err.(net.Error); ok && timeoutErr.Timeout() {
 				if verbose {
 					log.Printf("%s request has timed-out retrying request, count %d",
 						r.Name,
 						i)
 				}
-				timeoutError = err
+				retryError = err
 				continue
 			}
 
@@ -292,9 +534,71 @@ func (r *Requester) DoRequest(req *http.Request, method, path string, headers ma
 
 		contents, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
+			resp.Body.Close()
+			if err == io.ErrUnexpectedEOF {
+				return r.unexpectedResponse(resp, contents, "truncated body")
+			}
 			return err
 		}
 
+		if banned, until := detectIPBan(resp.StatusCode, resp.Header.Get("Retry-After"), contents, Now()); banned {
+			resp.Body.Close()
+			banErr := r.setIPBan(until)
+			log.Printf("%s %s, pausing all requests until %s", r.Name, banErr,
+				r.GetPausedUntil().Format(time.RFC3339))
+			return banErr
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests && i < r.timeoutRetryAttempts {
+			resp.Body.Close()
+			retryError = fmt.Errorf("unsuccessful HTTP status code: %d", resp.StatusCode)
+
+			err = resetRequestBody(req)
+			if err != nil {
+				return err
+			}
+
+			backoff, err := tooManyRequestsBackoff(resp.Header.Get("Retry-After"), i)
+			if err != nil {
+				return fmt.Errorf("%s request has been rate limited by the exchange, %s", r.Name, err)
+			}
+			if verbose {
+				log.Printf("%s request has been rate limited by the exchange, backing off for %v, count %d",
+					r.Name,
+					backoff,
+					i)
+			}
+			time.Sleep(backoff)
+			continue
+		}
+
+		if isCloudflareChallenge(resp, contents) {
+			resp.Body.Close()
+			challengeErr := r.unexpectedResponse(resp, contents, "Cloudflare challenge")
+			if !isIdempotentMethod(req.Method) || i == r.timeoutRetryAttempts {
+				return challengeErr
+			}
+			retryError = challengeErr
+
+			err = resetRequestBody(req)
+			if err != nil {
+				return err
+			}
+
+			backoff, err := tooManyRequestsBackoff(resp.Header.Get("Retry-After"), i)
+			if err != nil {
+				return challengeErr
+			}
+			if verbose {
+				log.Printf("%s request received a Cloudflare challenge, retrying in %v, count %d",
+					r.Name,
+					backoff,
+					i)
+			}
+			time.Sleep(backoff)
+			continue
+		}
+
 		if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 {
 			err = fmt.Errorf("unsuccessful HTTP status code: %d", resp.StatusCode)
 
@@ -313,57 +617,281 @@ func (r *Requester) DoRequest(req *http.Request, method, path string, headers ma
 		}
 
 		if result != nil {
+			if reason := checkJSONResponse(resp.Header.Get("Content-Type"), contents); reason != "" {
+				return r.unexpectedResponse(resp, contents, reason)
+			}
 			return common.JSONDecode(contents, result)
 		}
 
 		return nil
 	}
 	return fmt.Errorf("request.go error - failed to retry request %s",
-		timeoutError)
+		retryError)
+}
+
+// detectIPBan returns whether a response is an IP ban and the time the ban
+// expires if the exchange provided one. A HTTP 418 is always a ban, as is a
+// HTTP 403 or 429 with a ban message. The expiry is read from a "banned until"
+// unix timestamp in the body, or failing that the Retry-After header
+func detectIPBan(statusCode int, retryAfter string, contents []byte, now time.Time) (bool, time.Time) {
+	switch statusCode {
+	case http.StatusTeapot:
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if !strings.Contains(strings.ToLower(string(contents)), "banned") {
+			return false, time.Time{}
+		}
+	default:
+		return false, time.Time{}
+	}
+
+	if match := banUntilRegexp.FindSubmatch(contents); match != nil {
+		ts, err := strconv.ParseInt(string(match[1]), 10, 64)
+		if err == nil {
+			if len(match[1]) > 10 {
+				return true, time.Unix(0, ts*int64(time.Millisecond))
+			}
+			return true, time.Unix(ts, 0)
+		}
+	}
+
+	if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+		return true, now.Add(time.Duration(secs) * time.Second)
+	}
+
+	if t, err := http.ParseTime(retryAfter); err == nil {
+		return true, t
+	}
+	return true, time.Time{}
+}
+
+// setIPBan records an IP ban and pauses all requests until it expires, or for
+// defaultIPBanPause when the expiry is unknown
+func (r *Requester) setIPBan(until time.Time) *IPBanError {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.ban = &IPBanError{Exchange: r.Name, Until: until}
+	r.pausedUntil = until
+	if until.IsZero() {
+		r.pausedUntil = Now().Add(defaultIPBanPause)
+	}
+	return r.ban
 }
 
+// checkIPBan returns the recorded IPBanError while requests are paused
+func (r *Requester) checkIPBan() error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.ban == nil || !Now().Before(r.pausedUntil) {
+		return nil
+	}
+	return r.ban
+}
+
+// GetPausedUntil returns the time requests are paused until after an IP ban,
+// or the zero time when no ban has been recorded
+func (r *Requester) GetPausedUntil() time.Time {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.pausedUntil
+}
+
+// IsIPBanned returns whether requests are paused due to an IP ban
+func (r *Requester) IsIPBanned() bool {
+	return r.checkIPBan() != nil
+}
+
+// tooManyRequestsBackoff returns how long to wait before retrying a request
+// which received a HTTP 429 response. The exchange supplied Retry-After
+// seconds are used when present, otherwise the default backoff is doubled for
+// each attempt up to the maximum. An error is returned when Retry-After
+// exceeds the maximum backoff so the request fails instead of blocking
+func tooManyRequestsBackoff(retryAfter string, attempt int) (time.Duration, error) {
+	if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+		if secs > int(maxTooManyRequestsBackoff/time.Second) {
+			return 0, fmt.Errorf("Retry-After %ds exceeds the maximum backoff of %v",
+				secs, maxTooManyRequestsBackoff)
+		}
+		return time.Duration(secs) * time.Second, nil
+	}
+
+	backoff := defaultTooManyRequestsBackoff << uint(attempt)
+	if backoff > maxTooManyRequestsBackoff || backoff <= 0 {
+		return maxTooManyRequestsBackoff, nil
+	}
+	return backoff, nil
+}
+
+// unexpectedResponse returns an UnexpectedResponseError for a response,
+// including the start of its body
+func (r *Requester) unexpectedResponse(resp *http.Response, contents []byte, reason string) *UnexpectedResponseError {
+	snippet := strings.TrimSpace(string(contents))
+	if len(snippet) > maxResponseSnippet {
+		snippet = snippet[:maxResponseSnippet] + "..."
+	}
+	return &UnexpectedResponseError{
+		Exchange:    r.Name,
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Reason:      reason,
+		Snippet:     snippet,
+	}
+}
+
+// checkJSONResponse returns why a response body isn't the JSON expected, or
+// an empty string when it is valid JSON
+func checkJSONResponse(contentType string, contents []byte) string {
+	trimmed := bytes.TrimSpace(contents)
+	if json.Valid(trimmed) {
+		return ""
+	}
+
+	switch {
+	case len(trimmed) == 0:
+		return "empty body"
+	case trimmed[0] == '<' || strings.Contains(strings.ToLower(contentType), "html"):
+		return "HTML response"
+	case contentType != "" && !strings.Contains(strings.ToLower(contentType), "json"):
+		return "non-JSON content type"
+	case trimmed[0] == '{' || trimmed[0] == '[':
+		return "truncated or malformed JSON"
+	}
+	return "invalid JSON"
+}
+
+// isCloudflareChallenge returns whether a response is a Cloudflare challenge
+// page served in place of the exchange's response
+func isCloudflareChallenge(resp *http.Response, contents []byte) bool {
+	if resp.Header.Get("Cf-Mitigated") == "challenge" {
+		return true
+	}
+
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+
+	if !strings.EqualFold(resp.Header.Get("Server"), "cloudflare") {
+		return false
+	}
+
+	for x := range cloudflareChallengeMarkers {
+		if bytes.Contains(contents, []byte(cloudflareChallengeMarkers[x])) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdempotentMethod returns whether a request can be safely sent again
+// without risking it taking effect twice, only requests which don't change
+// state such as order placement are retried
+func isIdempotentMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// resetRequestBody rewinds the request body so the request can be sent again
+func resetRequestBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	if req.GetBody == nil {
+		return errors.New("request.go error - unable to retry request, body cannot be rewound")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+// worker sends queued jobs as the rate limiter allows, taking the highest
+// priority job waiting each time a request can be sent
 func (r *Requester) worker() {
+	var queue jobQueue
+	var seq uint64
+	receive := func(job Job) {
+		seq++
+		job.seq = seq
+		heap.Push(&queue, job)
+	}
+
 	for {
-		for x := range r.Jobs {
-			if !r.IsRateLimited(x.AuthRequest) {
-				r.IncrementRequests(x.AuthRequest)
-
-				err := r.DoRequest(x.Request, x.Method, x.Path, x.Headers, x.Body, x.Result, x.AuthRequest, x.Verbose)
-				x.JobResult <- &JobResult{
-					Error:  err,
-					Result: x.Result,
-				}
-			} else {
-				limit := r.GetRateLimit(x.AuthRequest)
-				diff := limit.GetDuration() - time.Since(r.Cycle)
-				if x.Verbose {
-					log.Printf("%s request. Rate limited! Sleeping for %v", r.Name, diff)
-				}
-				time.Sleep(diff)
-
-				for {
-					if !r.IsRateLimited(x.AuthRequest) {
-						r.IncrementRequests(x.AuthRequest)
-
-						if x.Verbose {
-							log.Printf("%s request. No longer rate limited! Doing request", r.Name)
-						}
-
-						err := r.DoRequest(x.Request, x.Method, x.Path, x.Headers, x.Body, x.Result, x.AuthRequest, x.Verbose)
-						x.JobResult <- &JobResult{
-							Error:  err,
-							Result: x.Result,
-						}
-						break
-					}
-				}
+		if queue.Len() == 0 {
+			receive(<-r.Jobs)
+		}
+
+		// pick up everything queued since the last request so a high
+		// priority job made while rate limited is sent next
+	drain:
+		for queue.Len() < maxRequestJobs {
+			select {
+			case job := <-r.Jobs:
+				receive(job)
+			default:
+				break drain
+			}
+		}
+
+		x := heap.Pop(&queue).(Job)
+		if r.IsRateLimited(x.AuthRequest) {
+			heap.Push(&queue, x)
+			limit := r.GetRateLimit(x.AuthRequest)
+			diff := limit.GetDuration() - Now().Sub(r.Cycle)
+			if x.Verbose {
+				log.Printf("%s request. Rate limited! Sleeping for %v", r.Name, diff)
 			}
+			time.Sleep(diff)
+			continue
+		}
+
+		// the caller stops waiting once its deadline passes, so don't spend
+		// the rate limit on a request nobody will receive
+		if err := x.Request.Context().Err(); err != nil {
+			x.JobResult <- &JobResult{Error: err}
+			continue
+		}
+
+		r.IncrementRequests(x.AuthRequest)
+		err := r.DoRequest(x.Request, x.Method, x.Path, x.Headers, x.Body, x.Result, x.AuthRequest, x.Verbose)
+		x.JobResult <- &JobResult{
+			Error:  err,
+			Result: x.Result,
 		}
 	}
 }
 
 // SendPayload handles sending HTTP/HTTPS requests
 func (r *Requester) SendPayload(method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool) error {
+	return r.sendPayload(context.Background(), NormalPriority, method, path, headers, body, result, authRequest, verbose)
+}
+
+// SendPayloadWithType handles sending HTTP/HTTPS requests, applying the
+// timeout set for the request type in place of the HTTP client timeout. The
+// deadline covers any time spent waiting on the rate limiter, where the
+// request is queued with the priority of its type
+func (r *Requester) SendPayloadWithType(t Type, method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool) error {
+	if r == nil {
+		return errors.New("not initiliased, SetDefaults() called before making request?")
+	}
+
+	priority := r.GetPriority(t)
+	timeout, ok := r.GetTimeout(t)
+	if !ok {
+		return r.sendPayload(context.Background(), priority, method, path, headers, body, result, authRequest, verbose)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return r.sendPayload(ctx, priority, method, path, headers, body, result, authRequest, verbose)
+}
+
+func (r *Requester) sendPayload(ctx context.Context, priority Priority, method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool) error {
 	if r == nil || r.Name == "" {
 		return errors.New("not initiliased, SetDefaults() called before making request?")
 	}
@@ -376,10 +904,15 @@ func (r *Requester) SendPayload(method, path string, headers map[string]string,
 		return errors.New("invalid path")
 	}
 
+	if err := r.checkIPBan(); err != nil {
+		return err
+	}
+
 	req, err := r.checkRequest(method, path, body, headers)
 	if err != nil {
 		return err
 	}
+	req = req.WithContext(ctx)
 
 	if !r.RequiresRateLimiter() {
 		return r.DoRequest(req, method, path, headers, body, result, authRequest, verbose)
@@ -397,7 +930,8 @@ func (r *Requester) SendPayload(method, path string, headers map[string]string,
 	}
 	r.m.Unlock()
 
-	jobResult := make(chan *JobResult)
+	// buffered so the worker never blocks on a caller which stopped waiting
+	jobResult := make(chan *JobResult, 1)
 
 	newJob := Job{
 		Request:     req,
@@ -409,22 +943,31 @@ func (r *Requester) SendPayload(method, path string, headers map[string]string,
 		JobResult:   jobResult,
 		AuthRequest: authRequest,
 		Verbose:     verbose,
+		Priority:    priority,
 	}
 
 	if verbose {
 		log.Printf("%s request. Attaching new job.", r.Name)
 	}
-	r.Jobs <- newJob
+	select {
+	case r.Jobs <- newJob:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 
 	if verbose {
 		log.Printf("%s request. Waiting for job to complete.", r.Name)
 	}
-	resp := <-newJob.JobResult
 
-	if verbose {
-		log.Printf("%s request. Job complete.", r.Name)
+	select {
+	case resp := <-newJob.JobResult:
+		if verbose {
+			log.Printf("%s request. Job complete.", r.Name)
+		}
+		return resp.Error
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	return resp.Error
 }
 
 // SetProxy sets a proxy address to the client transport
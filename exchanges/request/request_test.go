@@ -1,8 +1,18 @@
 package request
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -190,6 +200,42 @@ func TestIsValidCycle(t *testing.T) {
 	}
 }
 
+func TestRateLimitClock(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	Now = func() time.Time { return now }
+	defer func() { Now = time.Now }()
+
+	r := New("bitfinex", NewRateLimit(time.Second*10, 5), NewRateLimit(time.Second*20, 100), new(http.Client))
+	r.StartCycle()
+	r.AuthLimit.SetRequests(5)
+
+	now = now.Add(time.Second*10 - time.Nanosecond)
+	if !r.IsRateLimited(true) {
+		t.Fatal("test failed - expected to be rate limited until the end of the cycle")
+	}
+
+	now = now.Add(time.Nanosecond)
+	if r.IsRateLimited(true) {
+		t.Fatal("test failed - expected a new cycle once the duration elapsed")
+	}
+
+	if !r.Cycle.Equal(now) || r.AuthLimit.GetRequests() != 0 {
+		t.Fatalf("test failed - expected the cycle to restart at %s, received %s with %d requests",
+			now, r.Cycle, r.AuthLimit.GetRequests())
+	}
+
+	r.setIPBan(time.Time{})
+	now = now.Add(defaultIPBanPause - time.Nanosecond)
+	if !r.IsIPBanned() {
+		t.Fatal("test failed - expected requests to be paused for the default IP ban pause")
+	}
+
+	now = now.Add(time.Nanosecond)
+	if r.IsIPBanned() {
+		t.Fatal("test failed - expected the IP ban pause to have expired")
+	}
+}
+
 func TestCheckRequest(t *testing.T) {
 	r := New("", NewRateLimit(time.Second*10, 5), NewRateLimit(time.Second*20, 100), new(http.Client))
 	_, err := r.checkRequest("bad method, bad", "http://www.google.com", nil, nil)
@@ -322,3 +368,385 @@ func TestDoRequest(t *testing.T) {
 		t.Error("failed to set proxy")
 	}
 }
+
+func TestTooManyRequestsBackoff(t *testing.T) {
+	if backoff, err := tooManyRequestsBackoff("5", 0); err != nil || backoff != time.Second*5 {
+		t.Error("test failed - Retry-After header not respected", err)
+	}
+
+	if backoff, err := tooManyRequestsBackoff("", 0); err != nil || backoff != defaultTooManyRequestsBackoff {
+		t.Error("test failed - unexpected default backoff", err)
+	}
+
+	if backoff, err := tooManyRequestsBackoff("", 2); err != nil || backoff != defaultTooManyRequestsBackoff*4 {
+		t.Error("test failed - backoff not doubled per attempt", err)
+	}
+
+	if backoff, err := tooManyRequestsBackoff("", 100); err != nil || backoff != maxTooManyRequestsBackoff {
+		t.Error("test failed - backoff not capped", err)
+	}
+
+	if _, err := tooManyRequestsBackoff("86400", 0); err == nil {
+		t.Error("test failed - expected an error for a Retry-After above the maximum backoff")
+	}
+
+	if _, err := tooManyRequestsBackoff("9223372036854775807", 0); err == nil {
+		t.Error("test failed - expected an error for an overflowing Retry-After")
+	}
+}
+
+func TestDoRequestRetryAfterTooLong(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	start := time.Now()
+	err := r.SendPayload("GET", ts.URL, nil, nil, nil, false, false)
+	if err == nil {
+		t.Fatal("test failed - expected an error for a Retry-After above the maximum backoff")
+	}
+
+	if calls != 1 || time.Since(start) > maxTooManyRequestsBackoff {
+		t.Errorf("test failed - expected the request to fail without retrying, received %d calls", calls)
+	}
+}
+
+func TestDoRequestVerboseRedactsHeaders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	headers := map[string]string{
+		"OK-ACCESS-KEY":        "apikey123",
+		"OK-ACCESS-SIGN":       "signature456",
+		"OK-ACCESS-PASSPHRASE": "passphrase789",
+		"OK-ACCESS-TIMESTAMP":  "2018-01-01T00:00:00.000Z",
+	}
+	err := r.SendPayload("GET", ts.URL, headers, nil, nil, true, true)
+	if err != nil {
+		t.Fatal("test failed - verbose request error", err)
+	}
+
+	logged := buf.String()
+	for _, secret := range []string{"apikey123", "signature456", "passphrase789"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("test failed - verbose logging exposed header value %s", secret)
+		}
+	}
+
+	if !strings.Contains(logged, "[OK-ACCESS-KEY]: "+redactedHeader) ||
+		!strings.Contains(logged, "2018-01-01T00:00:00.000Z") {
+		t.Errorf("test failed - unexpected verbose header logging %s", logged)
+	}
+}
+
+func TestDoRequestTooManyRequests(t *testing.T) {
+	var calls int
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer ts.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	var result struct {
+		OK bool `json:"ok"`
+	}
+	err := r.SendPayload("POST", ts.URL, nil, strings.NewReader("a=b"), &result, true, false)
+	if err != nil {
+		t.Fatal("test failed - request was not retried after HTTP 429", err)
+	}
+
+	if calls != 2 || !result.OK {
+		t.Errorf("test failed - expected 2 calls and a decoded result, received %d calls", calls)
+	}
+
+	if bodies[1] != "a=b" {
+		t.Error("test failed - request body was not resent on retry")
+	}
+}
+
+func TestDetectIPBan(t *testing.T) {
+	now := time.Unix(1567590000, 0)
+	banned, until := detectIPBan(http.StatusTeapot, "",
+		[]byte(`{"code":-1003,"msg":"Way too many requests; IP banned until 1567590060000."}`), now)
+	if !banned || !until.Equal(now.Add(time.Minute)) {
+		t.Errorf("test failed - expected a ban until %s, received %v %s", now.Add(time.Minute), banned, until)
+	}
+
+	banned, until = detectIPBan(http.StatusForbidden, "120", []byte("Your IP has been banned"), now)
+	if !banned || !until.Equal(now.Add(time.Minute*2)) {
+		t.Errorf("test failed - Retry-After not used as the ban expiry, received %v %s", banned, until)
+	}
+
+	banned, until = detectIPBan(http.StatusTeapot, "", nil, now)
+	if !banned || !until.IsZero() {
+		t.Errorf("test failed - expected a ban without an expiry, received %v %s", banned, until)
+	}
+
+	if banned, _ = detectIPBan(http.StatusTooManyRequests, "5", []byte("slow down"), now); banned {
+		t.Error("test failed - HTTP 429 without a ban message detected as a ban")
+	}
+
+	if banned, _ = detectIPBan(http.StatusOK, "", []byte("banned"), now); banned {
+		t.Error("test failed - successful response detected as a ban")
+	}
+}
+
+func TestDoRequestIPBan(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer ts.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	err := r.SendPayload("GET", ts.URL, nil, nil, nil, false, false)
+	if !errors.Is(err, ErrIPBanned) {
+		t.Fatalf("test failed - expected %s, received %v", ErrIPBanned, err)
+	}
+
+	banErr, ok := err.(*IPBanError)
+	if !ok || banErr.Until.Before(time.Now().Add(time.Second*50)) {
+		t.Errorf("test failed - expected an IPBanError expiring in 60 seconds, received %v", err)
+	}
+
+	if !r.IsIPBanned() || !r.GetPausedUntil().Equal(banErr.Until) {
+		t.Error("test failed - requests were not paused until the ban expires")
+	}
+
+	err = r.SendPayload("GET", ts.URL, nil, nil, nil, false, false)
+	if !errors.Is(err, ErrIPBanned) || calls != 1 {
+		t.Errorf("test failed - request sent while banned, %d calls %v", calls, err)
+	}
+
+	r.setIPBan(time.Now().Add(-time.Second))
+	if r.IsIPBanned() {
+		t.Error("test failed - requests still paused after the ban expired")
+	}
+}
+
+func TestSendPayloadWithType(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		time.Sleep(time.Millisecond * 200)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0),
+		&http.Client{Timeout: time.Millisecond * 100})
+
+	r.SetTimeout(Trade, time.Millisecond*50)
+	r.SetTimeout(History, time.Second*5)
+	if d, ok := r.GetTimeout(History); !ok || d != time.Second*5 {
+		t.Fatal("test failed - GetTimeout unexpected value")
+	}
+
+	err := r.SendPayloadWithType(Trade, "GET", ts.URL, nil, nil, nil, false, false)
+	if err == nil {
+		t.Error("test failed - trade request should have exceeded its timeout")
+	}
+
+	err = r.SendPayloadWithType(History, "GET", ts.URL, nil, nil, nil, false, false)
+	if err != nil {
+		t.Error("test failed - history timeout should replace the client timeout", err)
+	}
+
+	r.SetTimeout(History, 0)
+	if _, ok := r.GetTimeout(History); ok {
+		t.Fatal("test failed - SetTimeout zero duration did not remove the timeout")
+	}
+
+	err = r.SetTimeoutRetryAttempts(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SendPayloadWithType(History, "GET", ts.URL, nil, nil, nil, false, false)
+	if err == nil {
+		t.Error("test failed - request without a type timeout should use the client timeout")
+	}
+}
+
+func TestSendPayloadWithTypeRateLimited(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Millisecond*300, 1),
+		new(http.Client))
+	r.SetTimeout(Trade, time.Millisecond*50)
+
+	err := r.SendPayloadWithType(Trade, "GET", ts.URL, nil, nil, nil, false, false)
+	if err != nil {
+		t.Fatal("test failed - SendPayloadWithType error", err)
+	}
+
+	// the rate limit is spent so the next request can't be sent before its
+	// deadline passes
+	start := time.Now()
+	err = r.SendPayloadWithType(Trade, "GET", ts.URL, nil, nil, nil, false, false)
+	if err != context.DeadlineExceeded {
+		t.Errorf("test failed - expected %v while rate limited, received %v", context.DeadlineExceeded, err)
+	}
+
+	if time.Since(start) > time.Millisecond*250 {
+		t.Error("test failed - SendPayloadWithType waited on the rate limiter past its deadline")
+	}
+
+	time.Sleep(time.Millisecond * 400)
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("test failed - expected the expired request not to be sent, received %d calls", calls)
+	}
+}
+
+func TestRequestPriority(t *testing.T) {
+	var m sync.Mutex
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		m.Lock()
+		paths = append(paths, req.URL.Path)
+		m.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Millisecond*300, 1),
+		new(http.Client))
+
+	if r.GetPriority(Trade) != HighPriority || r.GetPriority(History) != LowPriority {
+		t.Fatal("test failed - unexpected default request priorities")
+	}
+
+	err := r.SendPayloadWithType(History, "GET", ts.URL+"/first", nil, nil, nil, false, false)
+	if err != nil {
+		t.Fatal("test failed - SendPayloadWithType error", err)
+	}
+
+	// queue a backfill request while rate limited, then an urgent trade
+	// request which should be sent ahead of it once the limit resets
+	var wg sync.WaitGroup
+	send := func(reqType Type, path string) {
+		defer wg.Done()
+		if err := r.SendPayloadWithType(reqType, "GET", ts.URL+path, nil, nil, nil, false, false); err != nil {
+			t.Error("test failed - SendPayloadWithType error", err)
+		}
+	}
+
+	wg.Add(2)
+	go send(History, "/backfill")
+	time.Sleep(time.Millisecond * 50)
+	go send(Trade, "/cancel")
+	wg.Wait()
+
+	if len(paths) != 3 || paths[1] != "/cancel" || paths[2] != "/backfill" {
+		t.Errorf("test failed - expected the trade request before the backfill, received %v", paths)
+	}
+
+	r.SetPriority(History, HighPriority)
+	if r.GetPriority(History) != HighPriority {
+		t.Error("test failed - SetPriority did not override the default priority")
+	}
+}
+
+func TestCheckJSONResponse(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		reason      string
+	}{
+		{"application/json", `{"result":true}`, ""},
+		{"text/plain", ` [1, 2] `, ""},
+		{"application/json", ``, "empty body"},
+		{"text/html", `<html><body>502 Bad Gateway</body></html>`, "HTML response"},
+		{"", `<!DOCTYPE html>`, "HTML response"},
+		{"text/plain", `maintenance`, "non-JSON content type"},
+		{"application/json", `{"result":tr`, "truncated or malformed JSON"},
+		{"application/json", `maintenance`, "invalid JSON"},
+	}
+
+	for _, test := range tests {
+		if reason := checkJSONResponse(test.contentType, []byte(test.body)); reason != test.reason {
+			t.Errorf("test failed - checkJSONResponse %q %q expected %q, received %q",
+				test.contentType, test.body, test.reason, reason)
+		}
+	}
+}
+
+func TestDoRequestUnexpectedResponse(t *testing.T) {
+	var challenges int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/html":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html>` + strings.Repeat("a", 500) + `</html>`))
+		case "/challenge":
+			challenges++
+			if challenges == 1 {
+				w.Header().Set("Server", "cloudflare")
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`<title>Just a moment...</title><div>Checking your browser</div>`))
+				return
+			}
+			w.Write([]byte(`{"ok":true}`))
+		default:
+			w.Header().Set("Cf-Mitigated", "challenge")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<html></html>`))
+		}
+	}))
+	defer ts.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+
+	var result struct {
+		OK bool `json:"ok"`
+	}
+	err := r.SendPayload("GET", ts.URL+"/html", nil, nil, &result, false, false)
+	if !errors.Is(err, ErrUnexpectedResponse) {
+		t.Fatal("test failed - expected ErrUnexpectedResponse for a HTML response", err)
+	}
+
+	unexpected, ok := err.(*UnexpectedResponseError)
+	if !ok || unexpected.Reason != "HTML response" ||
+		len(unexpected.Snippet) != maxResponseSnippet+3 || unexpected.StatusCode != http.StatusOK {
+		t.Errorf("test failed - unexpected UnexpectedResponseError %+v", err)
+	}
+
+	err = r.SendPayload("GET", ts.URL+"/challenge", nil, nil, &result, false, false)
+	if err != nil || !result.OK || challenges != 2 {
+		t.Errorf("test failed - expected the Cloudflare challenge to be retried, received %v after %d requests",
+			err, challenges)
+	}
+
+	err = r.SendPayload("POST", ts.URL+"/order", nil, nil, &result, false, false)
+	unexpected, ok = err.(*UnexpectedResponseError)
+	if !ok || unexpected.Reason != "Cloudflare challenge" {
+		t.Errorf("test failed - expected a Cloudflare challenge error without retrying a POST, received %v", err)
+	}
+}
@@ -15,14 +15,23 @@ const (
 	ErrTickerForExchangeNotFound = "Ticker for exchange does not exist."
 	ErrPrimaryCurrencyNotFound   = "Error primary currency for ticker not found."
 	ErrSecondaryCurrencyNotFound = "Error secondary currency for ticker not found."
+	ErrAssetTypeNotFound         = "Error asset type for ticker not found."
 
 	Spot = "SPOT"
+
+	// UpdateBufferSize is the number of updates buffered for each subscriber,
+	// updates are dropped for a subscriber whose buffer is full
+	UpdateBufferSize = 1024
 )
 
 // Vars for the ticker package
 var (
 	Tickers []Ticker
 	m       sync.Mutex
+
+	subscribers   = make(map[int]*subscriber)
+	subscriberID  int
+	subscriberMtx sync.RWMutex
 )
 
 // Price struct stores the currency pair and pricing information
@@ -39,6 +48,12 @@ type Price struct {
 	PriceATH     float64           `json:"PriceATH"`
 }
 
+// IsStale returns true if the ticker has not been updated within the supplied
+// maximum age
+func (p *Price) IsStale(maxAge time.Duration) bool {
+	return p.LastUpdated.IsZero() || time.Since(p.LastUpdated) > maxAge
+}
+
 // Ticker struct holds the ticker information for a currency pair and type
 type Ticker struct {
 	Price        map[pair.CurrencyItem]map[pair.CurrencyItem]map[string]Price
@@ -47,6 +62,7 @@ type Ticker struct {
 
 // PriceToString returns the string version of a stored price field
 func (t *Ticker) PriceToString(p pair.CurrencyPair, priceType, tickerType string) string {
+	p = p.Normalize()
 	priceType = common.StringToLower(priceType)
 
 	switch priceType {
@@ -71,6 +87,7 @@ func (t *Ticker) PriceToString(p pair.CurrencyPair, priceType, tickerType string
 
 // GetTicker checks and returns a requested ticker if it exists
 func GetTicker(exchange string, p pair.CurrencyPair, tickerType string) (Price, error) {
+	p = p.Normalize()
 	ticker, err := GetTickerByExchange(exchange)
 	if err != nil {
 		return Price{}, err
@@ -84,7 +101,13 @@ func GetTicker(exchange string, p pair.CurrencyPair, tickerType string) (Price,
 		return Price{}, errors.New(ErrSecondaryCurrencyNotFound)
 	}
 
-	return ticker.Price[p.FirstCurrency][p.SecondCurrency][tickerType], nil
+	m.Lock()
+	defer m.Unlock()
+	result, ok := ticker.Price[p.FirstCurrency][p.SecondCurrency][tickerType]
+	if !ok {
+		return Price{}, errors.New(ErrAssetTypeNotFound)
+	}
+	return result, nil
 }
 
 // GetTickerByExchange returns an exchange Ticker
@@ -102,6 +125,7 @@ func GetTickerByExchange(exchange string) (*Ticker, error) {
 // FirstCurrencyExists checks to see if the first currency of the Price map
 // exists
 func FirstCurrencyExists(exchange string, currency pair.CurrencyItem) bool {
+	currency = currency.Upper()
 	m.Lock()
 	defer m.Unlock()
 	for _, y := range Tickers {
@@ -117,6 +141,7 @@ func FirstCurrencyExists(exchange string, currency pair.CurrencyItem) bool {
 // SecondCurrencyExists checks to see if the second currency of the Price map
 // exists
 func SecondCurrencyExists(exchange string, p pair.CurrencyPair) bool {
+	p = p.Normalize()
 	m.Lock()
 	defer m.Unlock()
 	for _, y := range Tickers {
@@ -133,6 +158,7 @@ func SecondCurrencyExists(exchange string, p pair.CurrencyPair) bool {
 
 // CreateNewTicker creates a new Ticker
 func CreateNewTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price, tickerType string) Ticker {
+	p = p.Normalize()
 	m.Lock()
 	defer m.Unlock()
 	ticker := Ticker{}
@@ -157,6 +183,10 @@ func ProcessTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price, ti
 
 	tickerNew.CurrencyPair = p.Pair().String()
 	tickerNew.LastUpdated = time.Now()
+	defer notifySubscribers(exchangeName, p, tickerNew, tickerType)
+
+	// key the stored data on the canonical pair so lookups in any format hit
+	key := p.Normalize()
 
 	ticker, err := GetTickerByExchange(exchangeName)
 	if err != nil {
@@ -164,11 +194,15 @@ func ProcessTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price, ti
 		return
 	}
 
-	if FirstCurrencyExists(exchangeName, p.FirstCurrency) {
+	if FirstCurrencyExists(exchangeName, key.FirstCurrency) {
 		m.Lock()
-		a := make(map[string]Price)
+		// keep any existing asset types stored under this currency pair
+		a, ok := ticker.Price[key.FirstCurrency][key.SecondCurrency]
+		if !ok {
+			a = make(map[string]Price)
+			ticker.Price[key.FirstCurrency][key.SecondCurrency] = a
+		}
 		a[tickerType] = tickerNew
-		ticker.Price[p.FirstCurrency][p.SecondCurrency] = a
 		m.Unlock()
 		return
 	}
@@ -177,7 +211,75 @@ func ProcessTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price, ti
 	a := make(map[pair.CurrencyItem]map[string]Price)
 	b := make(map[string]Price)
 	b[tickerType] = tickerNew
-	a[p.SecondCurrency] = b
-	ticker.Price[p.FirstCurrency] = a
+	a[key.SecondCurrency] = b
+	ticker.Price[key.FirstCurrency] = a
 	m.Unlock()
 }
+
+// Update holds a processed ticker delivered to subscribers
+type Update struct {
+	Exchange  string
+	Pair      pair.CurrencyPair
+	AssetType string
+	Price     Price
+}
+
+type subscriber struct {
+	exchangeName string
+	updates      chan Update
+}
+
+// Subscribe registers a handler which is called with each ticker processed for
+// the supplied exchange. Handlers run on their own goroutine fed by a buffered
+// channel so a slow handler does not stall ProcessTicker. The returned
+// function unsubscribes the handler
+func Subscribe(exchangeName string, handler func(Update)) (unsubscribe func()) {
+	sub := &subscriber{
+		exchangeName: exchangeName,
+		updates:      make(chan Update, UpdateBufferSize),
+	}
+
+	subscriberMtx.Lock()
+	subscriberID++
+	id := subscriberID
+	subscribers[id] = sub
+	subscriberMtx.Unlock()
+
+	go func() {
+		for update := range sub.updates {
+			handler(update)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			subscriberMtx.Lock()
+			delete(subscribers, id)
+			close(sub.updates)
+			subscriberMtx.Unlock()
+		})
+	}
+}
+
+// notifySubscribers delivers a processed ticker to the exchanges subscribers
+// without blocking
+func notifySubscribers(exchangeName string, p pair.CurrencyPair, price Price, tickerType string) {
+	subscriberMtx.RLock()
+	defer subscriberMtx.RUnlock()
+	for _, sub := range subscribers {
+		if sub.exchangeName != exchangeName {
+			continue
+		}
+
+		select {
+		case sub.updates <- Update{
+			Exchange:  exchangeName,
+			Pair:      p,
+			AssetType: tickerType,
+			Price:     price,
+		}:
+		default:
+		}
+	}
+}
@@ -333,3 +333,111 @@ func TestProcessTicker(t *testing.T) { //non-appending function to tickers
 	wg.Wait()
 
 }
+
+func TestProcessTickerAssetTypes(t *testing.T) {
+	newPair := pair.NewCurrencyPair("BTC", "USDT")
+	assetTypes := []string{Spot, "FUTURES", "SWAP"}
+
+	for i, assetType := range assetTypes {
+		ProcessTicker("AssetTypeTest", newPair, Price{Last: float64(i + 1)}, assetType)
+	}
+
+	for i, assetType := range assetTypes {
+		result, err := GetTicker("AssetTypeTest", newPair, assetType)
+		if err != nil {
+			t.Fatalf("Test failed. TestProcessTickerAssetTypes %s error: %s", assetType, err)
+		}
+
+		if result.Last != float64(i+1) {
+			t.Errorf("Test failed. TestProcessTickerAssetTypes %s expected %v, received %v",
+				assetType, i+1, result.Last)
+		}
+	}
+
+	_, err := GetTicker("AssetTypeTest", newPair, "OPTIONS")
+	if err == nil {
+		t.Error("Test failed. TestProcessTickerAssetTypes expected error for unknown asset type")
+	}
+}
+
+func TestProcessTickerPairFormats(t *testing.T) {
+	ProcessTicker("PairFormatTest", pair.NewCurrencyPairDelimiter("btc_usd", "_"),
+		Price{Last: 1337}, Spot)
+
+	lookups := []pair.CurrencyPair{
+		pair.NewCurrencyPair("BTC", "USD"),
+		pair.NewCurrencyPairDelimiter("BTC-USD", "-"),
+		pair.NewCurrencyPairFromString("btcusd"),
+	}
+
+	for x := range lookups {
+		result, err := GetTicker("PairFormatTest", lookups[x], Spot)
+		if err != nil {
+			t.Fatalf("Test failed. TestProcessTickerPairFormats %s error: %s",
+				lookups[x].Pair(), err)
+		}
+
+		if result.Last != 1337 {
+			t.Errorf("Test failed. TestProcessTickerPairFormats %s expected 1337, received %v",
+				lookups[x].Pair(), result.Last)
+		}
+	}
+
+	ProcessTicker("PairFormatTest", pair.NewCurrencyPair("BTC", "USD"), Price{Last: 9001}, Spot)
+	result, err := GetTicker("PairFormatTest", pair.NewCurrencyPairDelimiter("btc_usd", "_"), Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestProcessTickerPairFormats error", err)
+	}
+
+	if result.Last != 9001 {
+		t.Errorf("Test failed. TestProcessTickerPairFormats expected 9001, received %v", result.Last)
+	}
+}
+
+func TestIsStale(t *testing.T) {
+	var price Price
+	if !price.IsStale(time.Minute) {
+		t.Fatal("Test failed. TestIsStale expected a ticker without an update time to be stale")
+	}
+
+	price.LastUpdated = time.Now()
+	if price.IsStale(time.Minute) {
+		t.Fatal("Test failed. TestIsStale expected ticker to not be stale")
+	}
+
+	price.LastUpdated = time.Now().Add(-time.Minute * 2)
+	if !price.IsStale(time.Minute) {
+		t.Fatal("Test failed. TestIsStale expected ticker to be stale")
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	updates := make(chan Update, 1)
+	unsubscribe := Subscribe("subscribetest", func(u Update) {
+		updates <- u
+	})
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	ProcessTicker("othertest", p, Price{Last: 1}, Spot)
+	ProcessTicker("subscribetest", p, Price{Last: 1337}, Spot)
+
+	select {
+	case u := <-updates:
+		if u.Exchange != "subscribetest" || u.AssetType != Spot ||
+			u.Price.Last != 1337 || u.Pair.Pair().String() != "BTCUSD" {
+			t.Errorf("Test Failed - ticker Subscribe unexpected update %+v", u)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("Test Failed - ticker Subscribe handler was not called")
+	}
+
+	unsubscribe()
+	unsubscribe()
+	ProcessTicker("subscribetest", p, Price{Last: 1}, Spot)
+
+	select {
+	case u := <-updates:
+		t.Errorf("Test Failed - ticker Subscribe received update after unsubscribe %+v", u)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
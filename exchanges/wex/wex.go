@@ -48,6 +48,10 @@ type WEX struct {
 	Ticker map[string]Ticker
 }
 
+func init() {
+	exchange.RegisterExchange("wex", func() exchange.IBotExchange { return new(WEX) })
+}
+
 // SetDefaults sets current default value for WEX
 func (w *WEX) SetDefaults() {
 	w.Name = "WEX"
@@ -74,6 +78,14 @@ func (w *WEX) SetDefaults() {
 	w.APIUrlSecondaryDefault = wexAPIPrivateURL
 	w.APIUrlSecondary = w.APIUrlSecondaryDefault
 	w.WebsocketInit()
+	w.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	w.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	w.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnimplemented)
+	w.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	w.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	w.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	w.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	w.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets exchange configuration parameters for WEX
@@ -83,15 +95,20 @@ func (w *WEX) Setup(exch config.ExchangeConfig) {
 	} else {
 		w.Enabled = true
 		w.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		w.Verbose = exch.Verbose
 		w.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		w.SetHTTPClientTimeout(exch.HTTPTimeout)
+		w.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		w.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := w.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		w.RESTPollingDelay = exch.RESTPollingDelay
-		w.Verbose = exch.Verbose
 		w.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		w.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		w.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := w.SetCurrencyPairFormat()
+		err = w.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -99,6 +116,10 @@ func (w *WEX) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = w.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = w.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
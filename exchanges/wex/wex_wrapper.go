@@ -13,6 +13,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*WEX)(nil)
+
 // Start starts the WEX go routine
 func (w *WEX) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -30,7 +32,11 @@ func (w *WEX) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", w.GetName(), len(w.EnabledPairs), w.EnabledPairs)
 	}
 
-	exchangeProducts, err := w.GetTradablePairs()
+	var exchangeProducts []string
+	err := w.RetryPairUpdate(func() (err error) {
+		exchangeProducts, err = w.GetTradablePairs()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Failed to get available symbols.\n", w.GetName())
 	} else {
@@ -48,15 +54,22 @@ func (w *WEX) Run() {
 				log.Printf("%s Failed to get config.\n", w.GetName())
 			}
 		}
-		err = w.UpdateCurrencies(exchangeProducts, false, forceUpgrade)
-		if err != nil {
-			log.Printf("%s Failed to get config.\n", w.GetName())
+		if w.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = w.UpdateCurrencies(exchangeProducts, false, forceUpgrade)
+			if err != nil {
+				log.Printf("%s Failed to get config.\n", w.GetName())
+			}
 		}
 	}
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (w *WEX) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := w.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 	pairsCollated, err := exchange.GetAndFormatExchangeCurrencies(w.Name, w.GetEnabledCurrencies())
 	if err != nil {
@@ -85,6 +98,7 @@ func (w *WEX) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price,
 
 // GetTickerPrice returns the ticker for a currency pair
 func (w *WEX) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = w.GetAssetTypeOrDefault(assetType)
 	tick, err := ticker.GetTicker(w.GetName(), p, assetType)
 	if err != nil {
 		return w.UpdateTicker(p, assetType)
@@ -94,6 +108,7 @@ func (w *WEX) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Pric
 
 // GetOrderbookEx returns the orderbook for a currency pair
 func (w *WEX) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = w.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(w.GetName(), p, assetType)
 	if err != nil {
 		return w.UpdateOrderbook(p, assetType)
@@ -103,6 +118,11 @@ func (w *WEX) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.B
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (w *WEX) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := w.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	orderbookNew, err := w.GetDepth(exchange.FormatExchangeCurrency(w.Name, p).String())
 	if err != nil {
@@ -144,6 +164,16 @@ func (w *WEX) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (w *WEX) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := w.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return w.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (w *WEX) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -160,8 +190,15 @@ func (w *WEX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]excha
 
 // SubmitOrder submits a new order
 func (w *WEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
-	response, err := w.Trade(common.StringToLower(p.Pair().String()), common.StringToLower(side.ToString()), amount, price)
+	resp, err := w.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (w *WEX) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
+	response, err := w.Trade(common.StringToLower(order.Pair.Pair().String()), common.StringToLower(order.OrderSide.ToString()), order.Amount, order.Price)
 
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
@@ -232,19 +269,27 @@ func (w *WEX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (w *WEX) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (w *WEX) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (w *WEX) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (w *WEX) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := w.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (w *WEX) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := w.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
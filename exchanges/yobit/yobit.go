@@ -46,6 +46,10 @@ type Yobit struct {
 	Ticker map[string]Ticker
 }
 
+func init() {
+	exchange.RegisterExchange("yobit", func() exchange.IBotExchange { return new(Yobit) })
+}
+
 // SetDefaults sets current default value for Yobit
 func (y *Yobit) SetDefaults() {
 	y.Name = "Yobit"
@@ -73,6 +77,14 @@ func (y *Yobit) SetDefaults() {
 	y.APIUrlSecondaryDefault = apiPrivateURL
 	y.APIUrlSecondary = y.APIUrlSecondaryDefault
 	y.WebsocketInit()
+	y.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	y.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	y.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	y.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	y.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	y.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	y.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	y.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets exchange configuration parameters for Yobit
@@ -82,16 +94,21 @@ func (y *Yobit) Setup(exch config.ExchangeConfig) {
 	} else {
 		y.Enabled = true
 		y.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		y.Verbose = exch.Verbose
 		y.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		y.RESTPollingDelay = exch.RESTPollingDelay
-		y.Verbose = exch.Verbose
 		y.Websocket.SetEnabled(exch.Websocket)
 		y.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		y.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		y.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
 		y.SetHTTPClientTimeout(exch.HTTPTimeout)
+		y.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		y.SetHTTPClientUserAgent(exch.HTTPUserAgent)
-		err := y.SetCurrencyPairFormat()
+		err := y.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = y.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -99,6 +116,10 @@ func (y *Yobit) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = y.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = y.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -126,7 +126,7 @@ func TestWithdrawCoinsToAddress(t *testing.T) {
 
 func TestGetDepositAddress(t *testing.T) {
 	t.Parallel()
-	_, err := y.GetDepositAddress("btc")
+	_, err := y.GetDepositAddress("btc", "")
 	if err == nil {
 		t.Error("Test Failed - GetDepositAddress() error", err)
 	}
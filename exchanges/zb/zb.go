@@ -43,6 +43,10 @@ type ZB struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.RegisterExchange("zb", func() exchange.IBotExchange { return new(ZB) })
+}
+
 // SetDefaults sets default values for the exchange
 func (z *ZB) SetDefaults() {
 	z.Name = "ZB"
@@ -67,6 +71,14 @@ func (z *ZB) SetDefaults() {
 	z.APIUrlSecondaryDefault = zbMarketURL
 	z.APIUrlSecondary = z.APIUrlSecondaryDefault
 	z.WebsocketInit()
+	z.SetCapability(exchange.MethodGetExchangeHistory, exchange.CapabilityUnimplemented)
+	z.SetCapability(exchange.MethodGetFundingHistory, exchange.CapabilityUnsupported)
+	z.SetCapability(exchange.MethodModifyOrder, exchange.CapabilityUnsupported)
+	z.SetCapability(exchange.MethodGetOrderInfo, exchange.CapabilityUnimplemented)
+	z.SetCapability(exchange.MethodGetDepositAddress, exchange.CapabilityUnimplemented)
+	z.SetCapability(exchange.MethodWithdrawCryptocurrencyFunds, exchange.CapabilityUnimplemented)
+	z.SetCapability(exchange.MethodWithdrawFiatFunds, exchange.CapabilityUnimplemented)
+	z.SetCapability(exchange.MethodGetWebsocket, exchange.CapabilityUnimplemented)
 }
 
 // Setup sets user configuration
@@ -76,17 +88,22 @@ func (z *ZB) Setup(exch config.ExchangeConfig) {
 	} else {
 		z.Enabled = true
 		z.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		z.Verbose = exch.Verbose
 		z.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		z.APIAuthPEMKey = exch.APIAuthPEMKey
 		z.SetHTTPClientTimeout(exch.HTTPTimeout)
+		z.SetHTTPRequestTimeouts(exch.HTTPRequestTimeouts)
 		z.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		err := z.SetHTTPRateLimiter(exch.HTTPRateLimiter)
+		if err != nil {
+			log.Fatal(err)
+		}
 		z.RESTPollingDelay = exch.RESTPollingDelay
-		z.Verbose = exch.Verbose
 		z.Websocket.SetEnabled(exch.Websocket)
 		z.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
 		z.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
 		z.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
-		err := z.SetCurrencyPairFormat()
+		err = z.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -94,6 +111,10 @@ func (z *ZB) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = z.SetPriceSources(exch.PriceSources)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = z.SetAutoPairDefaults()
 		if err != nil {
 			log.Fatal(err)
@@ -13,6 +13,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
+var _ exchange.IBotExchange = (*ZB)(nil)
+
 // Start starts the OKEX go routine
 func (z *ZB) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -30,7 +32,11 @@ func (z *ZB) Run() {
 		log.Printf("%s %d currencies enabled: %s.\n", z.GetName(), len(z.EnabledPairs), z.EnabledPairs)
 	}
 
-	markets, err := z.GetMarkets()
+	var markets map[string]MarketResponseItem
+	err := z.RetryPairUpdate(func() (err error) {
+		markets, err = z.GetMarkets()
+		return err
+	})
 	if err != nil {
 		log.Printf("%s Unable to fetch symbols.\n", z.GetName())
 	} else {
@@ -39,15 +45,22 @@ func (z *ZB) Run() {
 			currencies = append(currencies, x)
 		}
 
-		err = z.UpdateCurrencies(currencies, false, false)
-		if err != nil {
-			log.Printf("%s Failed to update available currencies.\n", z.GetName())
+		if z.IsFeatureEnabled(exchange.FeatureAutoPairUpdates) {
+			err = z.UpdateCurrencies(currencies, false, false)
+			if err != nil {
+				log.Printf("%s Failed to update available currencies.\n", z.GetName())
+			}
 		}
 	}
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (z *ZB) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType, err := z.ValidateAssetType(assetType)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
 	var tickerPrice ticker.Price
 
 	result, err := z.GetTickers()
@@ -75,6 +88,7 @@ func (z *ZB) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price,
 
 // GetTickerPrice returns the ticker for a currency pair
 func (z *ZB) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	assetType = z.GetAssetTypeOrDefault(assetType)
 	tickerNew, err := ticker.GetTicker(z.GetName(), p, assetType)
 	if err != nil {
 		return z.UpdateTicker(p, assetType)
@@ -84,6 +98,7 @@ func (z *ZB) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price
 
 // GetOrderbookEx returns orderbook base on the currency pair
 func (z *ZB) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType = z.GetAssetTypeOrDefault(assetType)
 	ob, err := orderbook.GetOrderbook(z.GetName(), currency, assetType)
 	if err != nil {
 		return z.UpdateOrderbook(currency, assetType)
@@ -93,6 +108,11 @@ func (z *ZB) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (order
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (z *ZB) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	assetType, err := z.ValidateAssetType(assetType)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
 	var orderBook orderbook.Base
 	currency := exchange.FormatExchangeCurrency(z.Name, p).String()
 
@@ -148,6 +168,16 @@ func (z *ZB) GetAccountInfo() (exchange.AccountInfo, error) {
 	return info, nil
 }
 
+// GetCurrencyBalance returns the available and held balance of a single
+// currency filtered from the account info
+func (z *ZB) GetCurrencyBalance(currency string) (exchange.CurrencyBalance, error) {
+	info, err := z.GetAccountInfo()
+	if err != nil {
+		return exchange.CurrencyBalance{}, err
+	}
+	return z.FilterCurrencyBalance(info, currency)
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (z *ZB) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -164,19 +194,26 @@ func (z *ZB) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchan
 
 // SubmitOrder submits a new order
 func (z *ZB) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
+	resp, err := z.SubmitOrderWithParams(exchange.NewOrderSubmission(p, side, orderType, amount, price, clientID))
+	return resp.SubmitOrderResponse(), err
+}
+
+// SubmitOrderWithParams submits a new order using the supplied order
+// parameters
+func (z *ZB) SubmitOrderWithParams(order exchange.OrderSubmission) (exchange.OrderSubmissionResponse, error) {
+	var submitOrderResponse exchange.OrderSubmissionResponse
 	var oT SpotNewOrderRequestParamsType
 
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		oT = SpotNewOrderRequestParamsTypeBuy
 	} else {
 		oT = SpotNewOrderRequestParamsTypeSell
 	}
 
 	var params = SpotNewOrderRequestParams{
-		Amount: amount,
-		Price:  price,
-		Symbol: common.StringToLower(p.Pair().String()),
+		Amount: order.Amount,
+		Price:  order.Price,
+		Symbol: common.StringToLower(order.Pair.Pair().String()),
 		Type:   oT,
 	}
 	response, err := z.SpotNewOrder(params)
@@ -243,19 +280,27 @@ func (z *ZB) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
-func (z *ZB) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+func (z *ZB) GetDepositAddress(cryptocurrency pair.CurrencyItem, chain string) (string, error) {
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
-func (z *ZB) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+func (z *ZB) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, chain string, amount float64) (string, error) {
+	if err := z.CheckCryptoWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (z *ZB) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	if err := z.CheckFiatWithdrawPermissions(); err != nil {
+		return "", err
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
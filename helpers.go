@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
@@ -19,11 +20,14 @@ import (
 )
 
 const (
-	logFile = "debug.log"
+	logFile     = "debug.log"
+	journalFile = "journal.jsonl"
 )
 
 var (
-	logFileHandle *os.File
+	logFileHandle  *os.File
+	journalHandle  *exchange.FileJournal
+	wsRawLogHandle *websocketRawLog
 )
 
 // InitLogFile initialises the log file
@@ -48,6 +52,74 @@ func GetLogFile(dir string) string {
 	return dir + common.GetOSPathSlash() + logFile
 }
 
+// InitJournal opens the journal file and records every order and withdrawal
+// action to it
+func InitJournal(jFile string) error {
+	if journalHandle != nil {
+		return nil
+	}
+
+	var err error
+	journalHandle, err = exchange.NewFileJournal(jFile)
+	if err != nil {
+		return err
+	}
+
+	exchange.SetJournal(journalHandle)
+	return nil
+}
+
+// websocketRawLog serialises raw websocket messages written by every exchange
+// to a single file
+type websocketRawLog struct {
+	m    sync.Mutex
+	file *os.File
+}
+
+// Write appends a raw websocket message to the file
+func (w *websocketRawLog) Write(p []byte) (int, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+	return w.file.Write(p)
+}
+
+// Close closes the file, messages written afterwards return an error
+func (w *websocketRawLog) Close() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// InitWebsocketRawLog opens the file every raw inbound websocket message is
+// written to for debugging, the tap is registered when websocket connections
+// are established by WebsocketRoutine
+func InitWebsocketRawLog(path string) error {
+	if wsRawLogHandle != nil {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	wsRawLogHandle = &websocketRawLog{file: f}
+	return nil
+}
+
+// GetJournalFile returns the journal file path in the supplied data directory
+func GetJournalFile(dir string) string {
+	return dir + common.GetOSPathSlash() + journalFile
+}
+
 // GetAllAvailablePairs returns a list of all available pairs on either enabled
 // or disabled exchanges
 func GetAllAvailablePairs(enabledExchangesOnly bool) []pair.CurrencyPair {
@@ -259,10 +331,11 @@ func GetRelatableCurrencies(p pair.CurrencyPair, incOrig, incUSDT bool) []pair.C
 func GetSpecificOrderbook(currency, exchangeName, assetType string) (orderbook.Base, error) {
 	var specificOrderbook orderbook.Base
 	var err error
-	for x := range bot.exchanges {
-		if bot.exchanges[x] != nil {
-			if bot.exchanges[x].GetName() == exchangeName {
-				specificOrderbook, err = bot.exchanges[x].GetOrderbookEx(
+	exchanges := bot.exchanges.GetExchanges()
+	for x := range exchanges {
+		if exchanges[x] != nil {
+			if exchanges[x].GetName() == exchangeName {
+				specificOrderbook, err = exchanges[x].GetOrderbookEx(
 					pair.NewCurrencyPairFromString(currency),
 					assetType,
 				)
@@ -278,10 +351,11 @@ func GetSpecificOrderbook(currency, exchangeName, assetType string) (orderbook.B
 func GetSpecificTicker(currency, exchangeName, assetType string) (ticker.Price, error) {
 	var specificTicker ticker.Price
 	var err error
-	for x := range bot.exchanges {
-		if bot.exchanges[x] != nil {
-			if bot.exchanges[x].GetName() == exchangeName {
-				specificTicker, err = bot.exchanges[x].GetTickerPrice(
+	exchanges := bot.exchanges.GetExchanges()
+	for x := range exchanges {
+		if exchanges[x] != nil {
+			if exchanges[x].GetName() == exchangeName {
+				specificTicker, err = exchanges[x].GetTickerPrice(
 					pair.NewCurrencyPairFromString(currency),
 					assetType,
 				)
@@ -396,3 +470,28 @@ func SeedExchangeAccountInfo(data []exchange.AccountInfo) {
 		}
 	}
 }
+
+// SetExchangeSystemStatus stores the latest system status of an exchange and
+// returns whether it changed
+func SetExchangeSystemStatus(exchName string, status exchange.SystemStatus) bool {
+	return exchange.SetSystemStatus(exchName, status)
+}
+
+// IsTradingPaused returns whether trading on an exchange should be paused due
+// to the exchange reporting maintenance
+func IsTradingPaused(exchName string) bool {
+	return exchange.IsTradingPaused(exchName)
+}
+
+// KillSwitch cancels all orders on every enabled pair of every loaded exchange
+// and optionally closes open positions, logging each failure
+func KillSwitch(closePositions bool) exchange.KillSwitchReport {
+	report := exchange.KillSwitch(bot.exchanges.GetExchanges(), closePositions)
+	errs := report.Errors()
+	for x := range errs {
+		log.Printf("Kill switch error: %s", errs[x])
+	}
+	log.Printf("Kill switch complete. %d order cancellations attempted, %d errors.",
+		len(report.Cancellations), len(errs))
+	return report
+}
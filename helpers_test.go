@@ -377,3 +377,26 @@ func TestGetExchangeLowestPriceByCurrencyPair(t *testing.T) {
 		log.Fatal("Unexpected reuslt")
 	}
 }
+
+func TestIsTradingPaused(t *testing.T) {
+	if IsTradingPaused("StatusTest") {
+		t.Error("Test Failed - IsTradingPaused returned true for an unknown exchange")
+	}
+
+	if !SetExchangeSystemStatus("StatusTest", exchange.SystemStatusMaintenance) {
+		t.Error("Test Failed - SetExchangeSystemStatus did not report a change")
+	}
+
+	if !IsTradingPaused("statustest") {
+		t.Error("Test Failed - IsTradingPaused returned false during maintenance")
+	}
+
+	if SetExchangeSystemStatus("StatusTest", exchange.SystemStatusMaintenance) {
+		t.Error("Test Failed - SetExchangeSystemStatus reported an unchanged status as changed")
+	}
+
+	SetExchangeSystemStatus("StatusTest", exchange.SystemStatusOperational)
+	if IsTradingPaused("StatusTest") {
+		t.Error("Test Failed - IsTradingPaused returned true once operational")
+	}
+}
@@ -25,7 +25,7 @@ import (
 type Bot struct {
 	config     *config.Config
 	portfolio  *portfolio.Base
-	exchanges  []exchange.IBotExchange
+	exchanges  exchange.Manager
 	comms      *communications.Communications
 	shutdown   chan bool
 	dryRun     bool
@@ -60,6 +60,7 @@ func main() {
 	dryrun := flag.Bool("dryrun", false, "dry runs bot, doesn't save config file")
 	version := flag.Bool("version", false, "retrieves current GoCryptoTrader version")
 	verbosity := flag.Bool("verbose", false, "increases logging verbosity for GoCryptoTrader")
+	wsRawLog := flag.String("wsrawlog", "", "writes every raw inbound websocket message to the supplied file for debugging")
 
 	flag.Parse()
 
@@ -96,6 +97,23 @@ func main() {
 		log.Printf("Using log file: %s.\n", bot.logFile)
 	}
 
+	journalPath := GetJournalFile(bot.dataDir)
+	err = InitJournal(journalPath)
+	if err != nil {
+		log.Printf("Failed to create order and withdrawal journal. Err: %s", err)
+	} else {
+		log.Printf("Using journal file: %s.\n", journalPath)
+	}
+
+	if *wsRawLog != "" {
+		err = InitWebsocketRawLog(*wsRawLog)
+		if err != nil {
+			log.Printf("Failed to create websocket raw message log. Err: %s", err)
+		} else {
+			log.Printf("Logging raw websocket messages to: %s.\n", *wsRawLog)
+		}
+	}
+
 	AdjustGoMaxProcs()
 	log.Printf("Bot '%s' started.\n", bot.config.Name)
 	log.Printf("Bot dry run mode: %v.\n", common.IsEnabled(bot.dryRun))
@@ -108,7 +126,7 @@ func main() {
 	log.Printf("Global HTTP request timeout: %v.\n", common.HTTPClient.Timeout)
 
 	SetupExchanges()
-	if len(bot.exchanges) == 0 {
+	if len(bot.exchanges.GetExchanges()) == 0 {
 		log.Fatalf("No exchanges were able to be loaded. Exiting")
 	}
 
@@ -142,7 +160,7 @@ func main() {
 			common.ExtractHost(listenAddr), common.ExtractPort(listenAddr),
 		)
 
-		router := NewRouter(bot.exchanges)
+		router := NewRouter(bot.exchanges.GetExchanges())
 		go func() {
 			err = http.ListenAndServe(listenAddr, router)
 			if err != nil {
@@ -161,6 +179,8 @@ func main() {
 
 	go TickerUpdaterRoutine()
 	go OrderbookUpdaterRoutine()
+	go SystemStatusRoutine()
+	go ConfigReloadRoutine()
 	go WebsocketRoutine(*verbosity)
 
 	<-bot.shutdown
@@ -221,6 +241,14 @@ func Shutdown() {
 
 	log.Println("Exiting.")
 
+	if journalHandle != nil {
+		journalHandle.Close()
+	}
+
+	if wsRawLogHandle != nil {
+		wsRawLogHandle.Close()
+	}
+
 	if logFileHandle != nil {
 		logFileHandle.Close()
 	}
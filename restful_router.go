@@ -100,6 +100,18 @@ func NewRouter(exchanges []exchange.IBotExchange) *mux.Router {
 			"/exchanges/{exchangeName}/orderbook/latest/{currency}",
 			RESTGetOrderbook,
 		},
+		Route{
+			"AllExchangeCapabilities",
+			"GET",
+			"/exchanges/capabilities/all",
+			RESTGetCapabilityMatrix,
+		},
+		Route{
+			"IndividualExchangeCapabilities",
+			"GET",
+			"/exchanges/{exchangeName}/capabilities",
+			RESTGetCapabilities,
+		},
 		Route{
 			"ws",
 			"GET",
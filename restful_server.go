@@ -116,7 +116,7 @@ func RESTGetOrderbook(w http.ResponseWriter, r *http.Request) {
 func GetAllActiveOrderbooks() []EnabledExchangeOrderbooks {
 	var orderbookData []EnabledExchangeOrderbooks
 
-	for _, individualBot := range bot.exchanges {
+	for _, individualBot := range bot.exchanges.GetExchanges() {
 		if individualBot != nil && individualBot.IsEnabled() {
 			var individualExchange EnabledExchangeOrderbooks
 			exchangeName := individualBot.GetName()
@@ -203,11 +203,50 @@ func RESTGetTicker(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetExchangeCapabilities returns the capabilities of a loaded exchange
+func GetExchangeCapabilities(exchName string) (exchange.CapabilityMatrixRow, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return exchange.CapabilityMatrixRow{}, ErrExchangeNotFound
+	}
+	return exchange.NewCapabilityMatrix([]exchange.IBotExchange{exch}).Exchanges[0], nil
+}
+
+// RESTGetCapabilities returns whether each wrapper method of an exchange is
+// supported, unsupported or not yet implemented so clients can hide features
+// the exchange doesn't offer
+func RESTGetCapabilities(w http.ResponseWriter, r *http.Request) {
+	exchName := mux.Vars(r)["exchangeName"]
+	response, err := GetExchangeCapabilities(exchName)
+	if err != nil {
+		log.Printf("Failed to fetch capabilities for %s: %s\n", exchName, err)
+		return
+	}
+	err = RESTfulJSONResponse(w, r, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetCapabilityMatrix returns the capability matrix of every supported
+// exchange, whether or not it is enabled
+func RESTGetCapabilityMatrix(w http.ResponseWriter, r *http.Request) {
+	response, err := exchange.GetCapabilityMatrix()
+	if err != nil {
+		log.Printf("Failed to build exchange capability matrix: %s\n", err)
+		return
+	}
+	err = RESTfulJSONResponse(w, r, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
 // GetAllActiveTickers returns all enabled exchange tickers
 func GetAllActiveTickers() []EnabledExchangeCurrencies {
 	var tickerData []EnabledExchangeCurrencies
 
-	for _, individualBot := range bot.exchanges {
+	for _, individualBot := range bot.exchanges.GetExchanges() {
 		if individualBot != nil && individualBot.IsEnabled() {
 			var individualExchange EnabledExchangeCurrencies
 			exchangeName := individualBot.GetName()
@@ -264,7 +303,7 @@ func RESTGetAllActiveTickers(w http.ResponseWriter, r *http.Request) {
 // GetAllEnabledExchangeAccountInfo returns all the current enabled exchanges
 func GetAllEnabledExchangeAccountInfo() AllEnabledExchangeAccounts {
 	var response AllEnabledExchangeAccounts
-	for _, individualBot := range bot.exchanges {
+	for _, individualBot := range bot.exchanges.GetExchanges() {
 		if individualBot != nil && individualBot.IsEnabled() {
 			if !individualBot.GetAuthenticatedAPISupport() {
 				log.Printf("GetAllEnabledExchangeAccountInfo: Skippping %s due to disabled authenticated API support.", individualBot.GetName())
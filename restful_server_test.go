@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
 )
 
 func loadConfig(t *testing.T) *config.Config {
@@ -50,3 +51,24 @@ func TestConfigAllJsonResponse(t *testing.T) {
 		t.Error("Test failed. Json not equal to config")
 	}
 }
+
+func TestGetExchangeCapabilities(t *testing.T) {
+	SetupTest(t)
+	defer CleanupTest(t)
+
+	response, err := GetExchangeCapabilities("bitfinex")
+	if err != nil {
+		t.Fatal("Test failed. GetExchangeCapabilities error", err)
+	}
+
+	if response.Exchange != "Bitfinex" || len(response.AssetTypes) == 0 ||
+		len(response.Capabilities) != len(exchange.CapabilityMethods) ||
+		response.Capabilities[exchange.MethodSubmitOrder] != exchange.CapabilitySupported {
+		t.Errorf("Test failed. GetExchangeCapabilities unexpected response %+v", response)
+	}
+
+	_, err = GetExchangeCapabilities("notanexchange")
+	if err != ErrExchangeNotFound {
+		t.Errorf("Test failed. GetExchangeCapabilities expected %s, received %v", ErrExchangeNotFound, err)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
@@ -182,22 +184,16 @@ func TickerUpdaterRoutine() {
 	log.Println("Starting ticker updater routine.")
 	var wg sync.WaitGroup
 	for {
-		wg.Add(len(bot.exchanges))
-		for x := range bot.exchanges {
+		exchanges := bot.exchanges.GetExchanges()
+		wg.Add(len(exchanges))
+		for x := range exchanges {
 			go func(x int, wg *sync.WaitGroup) {
 				defer wg.Done()
-				if bot.exchanges[x] == nil {
-					return
-				}
-				exchangeName := bot.exchanges[x].GetName()
-				enabledCurrencies := bot.exchanges[x].GetEnabledCurrencies()
-				supportsBatching := bot.exchanges[x].SupportsRESTTickerBatchUpdates()
-				assetTypes, err := exchange.GetExchangeAssetTypes(exchangeName)
-				if err != nil {
-					log.Printf("failed to get %s exchange asset types. Error: %s",
-						exchangeName, err)
+				if exchanges[x] == nil {
 					return
 				}
+				exchangeName := exchanges[x].GetName()
+				supportsBatching := exchanges[x].IsFeatureEnabled(exchange.FeatureRESTTickerBatching)
 
 				processTicker := func(exch exchange.IBotExchange, update bool, c pair.CurrencyPair, assetType string) {
 					var result ticker.Price
@@ -216,13 +212,13 @@ func TickerUpdaterRoutine() {
 					}
 				}
 
-				for y := range assetTypes {
+				for assetType, enabledCurrencies := range exchanges[x].GetAllEnabledPairs() {
 					for z := range enabledCurrencies {
 						if supportsBatching && z > 0 {
-							processTicker(bot.exchanges[x], false, enabledCurrencies[z], assetTypes[y])
+							processTicker(exchanges[x], false, enabledCurrencies[z], assetType)
 							continue
 						}
-						processTicker(bot.exchanges[x], true, enabledCurrencies[z], assetTypes[y])
+						processTicker(exchanges[x], true, enabledCurrencies[z], assetType)
 					}
 				}
 			}(x, &wg)
@@ -239,22 +235,16 @@ func OrderbookUpdaterRoutine() {
 	log.Println("Starting orderbook updater routine.")
 	var wg sync.WaitGroup
 	for {
-		wg.Add(len(bot.exchanges))
-		for x := range bot.exchanges {
+		exchanges := bot.exchanges.GetExchanges()
+		wg.Add(len(exchanges))
+		for x := range exchanges {
 			go func(x int, wg *sync.WaitGroup) {
 				defer wg.Done()
 
-				if bot.exchanges[x] == nil {
-					return
-				}
-				exchangeName := bot.exchanges[x].GetName()
-				enabledCurrencies := bot.exchanges[x].GetEnabledCurrencies()
-				assetTypes, err := exchange.GetExchangeAssetTypes(exchangeName)
-				if err != nil {
-					log.Printf("failed to get %s exchange asset types. Error: %s",
-						exchangeName, err)
+				if exchanges[x] == nil {
 					return
 				}
+				exchangeName := exchanges[x].GetName()
 
 				processOrderbook := func(exch exchange.IBotExchange, c pair.CurrencyPair, assetType string) {
 					result, err := exch.UpdateOrderbook(c, assetType)
@@ -267,10 +257,9 @@ func OrderbookUpdaterRoutine() {
 					}
 				}
 
-				for y := range assetTypes {
-					for z := range enabledCurrencies {
-						processOrderbook(bot.exchanges[x], enabledCurrencies[z], assetTypes[y])
-					}
+				assetPairs := exchanges[x].GetAllEnabledAssetPairs()
+				for y := range assetPairs {
+					processOrderbook(exchanges[x], assetPairs[y].Pair, assetPairs[y].AssetType)
 				}
 			}(x, &wg)
 		}
@@ -280,18 +269,92 @@ func OrderbookUpdaterRoutine() {
 	}
 }
 
+// SystemStatusRoutine polls the system status of each exchange which exposes
+// one so trading can be paused while the exchange is under maintenance
+func SystemStatusRoutine() {
+	log.Println("Starting system status routine.")
+	for {
+		exchanges := bot.exchanges.GetExchanges()
+		for x := range exchanges {
+			if exchanges[x] == nil {
+				continue
+			}
+
+			exchangeName := exchanges[x].GetName()
+			status, err := exchange.GetSystemStatus(exchanges[x])
+			if err == common.ErrFunctionNotSupported {
+				continue
+			}
+
+			if err != nil {
+				log.Printf("failed to get %s exchange system status. Error: %s",
+					exchangeName, err)
+				continue
+			}
+
+			if SetExchangeSystemStatus(exchangeName, status) {
+				log.Printf("%s exchange system status: %s (trading paused: %v)",
+					exchangeName, status, status.IsTradingPaused())
+			}
+		}
+		time.Sleep(time.Minute)
+	}
+}
+
+// configReloadInterval is how often the config file is checked for changes
+const configReloadInterval = time.Second * 10
+
+// ConfigReloadRoutine watches the config file and reloads the settings which
+// can change without a restart each time the file is modified
+func ConfigReloadRoutine() {
+	log.Println("Starting config reload routine.")
+	configPath, err := config.GetFilePath(bot.configFile)
+	if err != nil {
+		log.Printf("Config reload routine failed to start. Error: %s", err)
+		return
+	}
+
+	var lastModified time.Time
+	if info, err := os.Stat(configPath); err == nil {
+		lastModified = info.ModTime()
+	}
+
+	for {
+		time.Sleep(configReloadInterval)
+		info, err := os.Stat(configPath)
+		if err != nil {
+			log.Printf("Config reload: unable to stat config file %s. Error: %s",
+				configPath, err)
+			continue
+		}
+
+		if !info.ModTime().After(lastModified) {
+			continue
+		}
+		lastModified = info.ModTime()
+
+		log.Printf("Config file %s changed, reloading..", configPath)
+		err = ReloadConfig()
+		if err != nil {
+			log.Printf("Config reload failed. Error: %s", err)
+		}
+	}
+}
+
 // WebsocketRoutine Initial routine management system for websocket
 func WebsocketRoutine(verbose bool) {
 	log.Println("Connecting exchange websocket services...")
 
-	for i := range bot.exchanges {
+	exchanges := bot.exchanges.GetExchanges()
+
+	for i := range exchanges {
 		go func(i int) {
 			if verbose {
 				log.Printf("Establishing websocket connection for %s",
-					bot.exchanges[i].GetName())
+					exchanges[i].GetName())
 			}
 
-			ws, err := bot.exchanges[i].GetWebsocket()
+			ws, err := exchanges[i].GetWebsocket()
 			if err != nil {
 				return
 			}
@@ -299,6 +362,10 @@ func WebsocketRoutine(verbose bool) {
 			// Data handler routine
 			go WebsocketDataHandler(ws, verbose)
 
+			if wsRawLogHandle != nil {
+				ws.SetRawMessageWriter(wsRawLogHandle)
+			}
+
 			err = ws.Connect()
 			if err != nil {
 				switch err.Error() {